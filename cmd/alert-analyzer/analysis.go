@@ -6,18 +6,25 @@ import (
 	"os"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/rs/zerolog"
 
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/exclusion"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/patch"
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/reporter"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/routing"
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/storage"
+	"github.com/neogan/sre-toolkit/pkg/alertmanager"
 	toolkitmetrics "github.com/neogan/sre-toolkit/pkg/metrics"
 	"github.com/neogan/sre-toolkit/pkg/prometheus"
 )
 
 type analysisOptions struct {
+	inputFile            string
 	prometheusURLs       []string
+	sourcesFile          string
 	alertmanagerURL      string
 	lookbackStr          string
 	resolutionStr        string
@@ -30,6 +37,21 @@ type analysisOptions struct {
 	showTemporalPatterns bool
 	showRecommendations  bool
 	flappingThreshold    float64
+	includeRules         bool
+	showIncidents        bool
+	incidentWindow       time.Duration
+	showOnCallBurden     bool
+	alertmanagerConfig   string
+	oncallTimezone       string
+	emitPatches          string
+	compareToStr         string
+	groupBy              []string
+	showLabelAnalysis    bool
+	cardinalityThreshold int
+	showAnomalies        bool
+	anomalyThreshold     float64
+	excludeWindowsFile   string
+	excludeSilences      bool
 }
 
 type analysisResult struct {
@@ -39,49 +61,192 @@ type analysisResult struct {
 	flapping        []analyzer.FlappingResult
 	correlation     []analyzer.CorrelationResult
 	temporal        []analyzer.TemporalResult
+	labels          *analyzer.LabelReport
+	anomalies       *analyzer.AnomalyReport
+	incidents       *analyzer.IncidentReport
+	burden          *analyzer.BurdenReport
 	recommendations []analyzer.Recommendation
+	patches         *patch.Set
+	trends          *analyzer.TrendReport
+	topTrends       []analyzer.TrendResult
+	heatmap         [][]int
+	timelines       []analyzer.FlappingTimeline
 	history         *collector.AlertHistory
+	excluded        *exclusion.Report
 }
 
-func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResult, error) { //nolint:gocyclo // complex analysis function with many code paths
+func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResult, error) {
+	compareTo, err := parseCompareTo(opts.compareToStr)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions, err := loadExclusions(opts, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	history, rules, err := collectAnalysisData(opts, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *collector.AlertHistory
+	if compareTo > 0 {
+		history, previous, err = collectComparisonData(opts, history, compareTo, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	excluded := applyExclusions(exclusions, history, logger)
+	if exclusions != nil {
+		exclusions.Apply(previous)
+	}
+
+	result, err := analyzeHistory(history, rules, opts, logger)
+	if err != nil {
+		return nil, err
+	}
+	result.excluded = excluded
+
+	if previous != nil {
+		trends := analyzer.NewTrendAnalyzer(history, previous, opts.flappingThreshold).WithGroupBy(opts.groupBy).Analyze()
+		result.trends = &trends
+		result.topTrends = limitTrendResults(trends.Alerts, opts.topN)
+		logger.Info().
+			Int("firings_delta", trends.FiringDelta).
+			Int("new_alerts", len(trends.New)).
+			Int("disappeared_alerts", len(trends.Disappeared)).
+			Msg("Period comparison complete")
+	}
+
+	return result, nil
+}
+
+// collectAnalysisData loads alert history and rules either from an exported
+// history file or from the configured Prometheus sources.
+func collectAnalysisData(opts analysisOptions, logger zerolog.Logger) (*collector.AlertHistory, []collector.AlertRule, error) {
+	if opts.inputFile != "" {
+		return loadHistoryFile(opts.inputFile, logger)
+	}
+	return collectPrometheusData(opts, logger)
+}
+
+func loadHistoryFile(path string, logger zerolog.Logger) (*collector.AlertHistory, []collector.AlertRule, error) {
+	logger.Info().Str("input", path).Msg("Loading alert history from file")
+
+	file, err := collector.ReadHistoryFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if file.CountAlerts() == 0 && len(file.Rules) == 0 {
+		return nil, nil, fmt.Errorf("history file %s contains no alerts or rules", path)
+	}
+
+	logger.Info().
+		Int("alerts", file.CountAlerts()).
+		Int("rules", len(file.Rules)).
+		Time("start", file.StartTime).
+		Time("end", file.EndTime).
+		Msg("Alert history loaded")
+
+	history := file.AlertHistory
+	return &history, file.Rules, nil
+}
+
+// collectComparisonData splits the analysis into the current window and the
+// window of the same length that ends compareTo earlier. History files are split
+// locally; Prometheus sources are queried again for the earlier window.
+func collectComparisonData(opts analysisOptions, history *collector.AlertHistory, compareTo time.Duration, logger zerolog.Logger) (*collector.AlertHistory, *collector.AlertHistory, error) {
+	lookback, err := parseLookback(opts.lookbackStr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid lookback duration: %w", err)
+	}
+
+	end := history.EndTime
+	previousEnd := end.Add(-compareTo)
+	logger.Info().
+		Time("previous_start", previousEnd.Add(-lookback)).
+		Time("previous_end", previousEnd).
+		Msg("Collecting comparison window")
+
+	if opts.inputFile != "" {
+		current := history.Window(end.Add(-lookback), end)
+		previous := history.Window(previousEnd.Add(-lookback), previousEnd)
+		if previous.StartTime.Before(history.StartTime) {
+			logger.Warn().
+				Time("history_start", history.StartTime).
+				Time("previous_start", previous.StartTime).
+				Msg("Comparison window starts before the history file; previous counts may be incomplete")
+		}
+		return current, previous, nil
+	}
+
+	previous, _, err := collectPrometheusRange(opts, previousEnd, false, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	if previous == nil {
+		return nil, nil, fmt.Errorf("failed to collect the comparison window from any of the provided Prometheus sources")
+	}
+	return history, previous, nil
+}
+
+func collectPrometheusData(opts analysisOptions, logger zerolog.Logger) (*collector.AlertHistory, []collector.AlertRule, error) {
+	history, rules, err := collectPrometheusRange(opts, time.Now(), opts.needsRecommendations() || opts.includeRules, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if history == nil || (history.CountAlerts() == 0 && len(rules) == 0) {
+		return nil, nil, fmt.Errorf("failed to collect alert data from any of the provided Prometheus sources")
+	}
+
+	return history, rules, nil
+}
+
+// collectPrometheusRange collects the lookback window ending at end from every
+// Prometheus source. The history is nil when no source could be queried.
+func collectPrometheusRange(opts analysisOptions, end time.Time, collectRules bool, logger zerolog.Logger) (*collector.AlertHistory, []collector.AlertRule, error) { //nolint:gocyclo // collection loop with per-source error handling
 	if len(opts.prometheusURLs) == 0 {
-		return nil, fmt.Errorf("at least one prometheus-url is required")
+		return nil, nil, fmt.Errorf("at least one prometheus-url is required")
 	}
 
-	lookback, err := time.ParseDuration(opts.lookbackStr)
+	lookback, err := parseLookback(opts.lookbackStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid lookback duration: %w", err)
+		return nil, nil, fmt.Errorf("invalid lookback duration: %w", err)
 	}
 
 	resolution, err := time.ParseDuration(opts.resolutionStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid resolution duration: %w", err)
+		return nil, nil, fmt.Errorf("invalid resolution duration: %w", err)
 	}
 
 	timeout, err := time.ParseDuration(opts.timeoutStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid timeout duration: %w", err)
+		return nil, nil, fmt.Errorf("invalid timeout duration: %w", err)
 	}
 
-	store := storage.NewMemoryStorage()
-
 	logger.Info().
 		Dur("lookback", lookback).
 		Dur("resolution", resolution).
 		Msg("Collecting alert data")
 
+	sources, err := resolvePrometheusSources(opts.prometheusURLs, opts.sourcesFile, timeout, opts.insecure)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var aggregatedHistory *collector.AlertHistory
 	allRules := make([]collector.AlertRule, 0)
 
-	for _, urlInput := range opts.prometheusURLs {
-		clusterName, promURL := parsePrometheusURL(urlInput)
-		logger.Info().Str("cluster", clusterName).Str("url", promURL).Msg("Connecting to Prometheus")
+	for _, source := range sources {
+		clusterName := source.cluster
+		logger.Info().Str("cluster", clusterName).Str("url", source.config.URL).Msg("Connecting to Prometheus")
 
-		promClient, err := prometheus.NewClient(&prometheus.Config{
-			URL:      promURL,
-			Timeout:  timeout,
-			Insecure: opts.insecure,
-		}, &logger)
+		promClient, err := prometheus.NewClient(source.config, &logger)
 		if err != nil {
 			logger.Error().Err(err).Str("cluster", clusterName).Msg("Failed to create Prometheus client")
 			continue
@@ -94,7 +259,7 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 			continue
 		}
 
-		if opts.showRecommendations {
+		if collectRules {
 			ruleCollector := collector.NewRuleCollector(promClient, &logger)
 			rules, rulesErr := ruleCollector.CollectAlertRules(ctx, clusterName)
 			if rulesErr != nil {
@@ -105,7 +270,7 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 		}
 
 		promCollector := collector.NewPrometheusCollector(promClient, &logger)
-		history, err := promCollector.Collect(ctx, clusterName, lookback, resolution)
+		history, err := promCollector.CollectRange(ctx, clusterName, end.Add(-lookback), end, resolution)
 		cancel()
 		if err != nil {
 			logger.Error().Err(err).Str("cluster", clusterName).Msg("Failed to collect alert data")
@@ -119,18 +284,20 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 		}
 	}
 
-	if aggregatedHistory == nil && opts.showRecommendations && len(allRules) > 0 {
+	if aggregatedHistory == nil && len(allRules) > 0 {
 		aggregatedHistory = &collector.AlertHistory{
-			StartTime: time.Now().Add(-lookback),
-			EndTime:   time.Now(),
+			StartTime: end.Add(-lookback),
+			EndTime:   end,
 			Source:    "prometheus",
 		}
 	}
 
-	if aggregatedHistory == nil || (aggregatedHistory.CountAlerts() == 0 && (!opts.showRecommendations || len(allRules) == 0)) {
-		return nil, fmt.Errorf("failed to collect alert data from any of the provided Prometheus sources")
-	}
+	return aggregatedHistory, allRules, nil
+}
 
+// analyzeHistory runs the enabled analyzers over an alert history.
+func analyzeHistory(aggregatedHistory *collector.AlertHistory, allRules []collector.AlertRule, opts analysisOptions, logger zerolog.Logger) (*analysisResult, error) {
+	store := storage.NewMemoryStorage()
 	if err := store.Store(aggregatedHistory); err != nil {
 		return nil, fmt.Errorf("failed to store alert history: %w", err)
 	}
@@ -144,6 +311,11 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 	stats := frequencyAnalyzer.GetSummaryStats()
 	allFrequency := frequencyAnalyzer.Analyze()
 	topAlerts := limitFrequencyResults(allFrequency, opts.topN)
+	if len(opts.groupBy) > 0 {
+		// Recommendations keep working on per-alert results; only the report is split.
+		grouped := analyzer.NewFrequencyAnalyzer(aggregatedHistory).WithGroupBy(opts.groupBy).Analyze()
+		topAlerts = limitFrequencyResults(grouped, opts.topN)
+	}
 
 	logger.Info().
 		Int("total_firings", stats.TotalFirings).
@@ -152,7 +324,7 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 
 	allCorrelations := []analyzer.CorrelationResult{}
 	correlations := []analyzer.CorrelationResult{}
-	if opts.showCorrelation || opts.showRecommendations {
+	if opts.showCorrelation || opts.needsRecommendations() {
 		correlationAnalyzer := analyzer.NewCorrelationAnalyzer(aggregatedHistory)
 		allCorrelations = correlationAnalyzer.Analyze()
 		if opts.showCorrelation {
@@ -163,11 +335,15 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 
 	allFlapping := []analyzer.FlappingResult{}
 	flapping := []analyzer.FlappingResult{}
-	if opts.showFlapping || opts.showRecommendations {
+	if opts.showFlapping || opts.needsRecommendations() {
 		flappingAnalyzer := analyzer.NewFlappingAnalyzer(aggregatedHistory, opts.flappingThreshold)
 		allFlapping = flappingAnalyzer.Analyze()
 		if opts.showFlapping {
 			flapping = limitFlappingResults(allFlapping, opts.topN)
+			if len(opts.groupBy) > 0 {
+				grouped := analyzer.NewFlappingAnalyzer(aggregatedHistory, opts.flappingThreshold).WithGroupBy(opts.groupBy).Analyze()
+				flapping = limitFlappingResults(grouped, opts.topN)
+			}
 			flappingSummary := flappingAnalyzer.GetSummary()
 			logger.Info().
 				Int("flapping_alerts", flappingSummary.FlappingAlerts).
@@ -183,14 +359,78 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 		logger.Info().Int("temporal_patterns", len(temporal)).Msg("Temporal pattern analysis complete")
 	}
 
+	var labels *analyzer.LabelReport
+	if opts.showLabelAnalysis {
+		labelAnalyzer := analyzer.NewLabelAnalyzer(aggregatedHistory, analyzer.DefaultSkewThreshold, opts.cardinalityThreshold)
+		report := labelAnalyzer.AnalyzeTopN(opts.topN)
+		labels = &report
+		logger.Info().
+			Int("skewed_labels", len(report.Skew)).
+			Int("high_cardinality_labels", len(report.Cardinality)).
+			Msg("Label analysis complete")
+	}
+
+	var anomalies *analyzer.AnomalyReport
+	if opts.showAnomalies {
+		report := analyzer.NewAnomalyAnalyzer(aggregatedHistory, opts.anomalyThreshold).Analyze()
+		anomalies = &report
+		logger.Info().
+			Int("anomalous_windows", len(report.Windows)).
+			Int("scored_hours", report.ScoredHours).
+			Msg("Anomaly detection complete")
+		if report.ScoredHours == 0 {
+			logger.Warn().Msg("Lookback too short for an hour-of-week baseline; use at least 21d for anomaly detection")
+		}
+	}
+
+	var incidents *analyzer.IncidentReport
+	if opts.showIncidents {
+		incidentAnalyzer := analyzer.NewIncidentAnalyzer(aggregatedHistory, opts.incidentWindow)
+		report := incidentAnalyzer.AnalyzeTopN(opts.topN)
+		incidents = &report
+		logger.Info().
+			Int("incidents", len(report.Incidents)).
+			Int("suggestions", len(report.Suggestions)).
+			Msg("Incident clustering complete")
+	}
+
+	var burden *analyzer.BurdenReport
+	if opts.showOnCallBurden {
+		report, err := analyzeOnCallBurden(aggregatedHistory, opts, logger)
+		if err != nil {
+			return nil, err
+		}
+		burden = &report
+	}
+
 	recommendations := []analyzer.Recommendation{}
-	if opts.showRecommendations {
+	var patches *patch.Set
+	if opts.needsRecommendations() {
 		recommendationEngine := analyzer.NewRecommendationEngine()
-		recommendations = recommendationEngine.Generate(allFrequency, allFlapping, allCorrelations, allRules)
-		logger.Info().Int("recommendations", len(recommendations)).Msg("Recommendation analysis complete")
+		allRecommendations := recommendationEngine.Generate(allFrequency, allFlapping, allCorrelations, allRules)
+		logger.Info().Int("recommendations", len(allRecommendations)).Msg("Recommendation analysis complete")
+		if opts.showRecommendations {
+			recommendations = allRecommendations
+		}
+		if opts.emitPatches != "" {
+			patches = patch.NewGenerator(aggregatedHistory, allRules).Generate(allRecommendations)
+		}
+	}
+
+	var heatmap [][]int
+	var timelines []analyzer.FlappingTimeline
+	if opts.outputFormat == reporter.FormatHTML {
+		heatmap = analyzer.NewTemporalAnalyzer(aggregatedHistory).Heatmap()
+		timelines = analyzer.NewFlappingAnalyzer(aggregatedHistory, opts.flappingThreshold).
+			WithGroupBy(opts.groupBy).
+			Timelines(timelineAlertNames(topAlerts, flapping))
 	}
 
 	if opts.alertmanagerURL != "" {
+		timeout, err := time.ParseDuration(opts.timeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout duration: %w", err)
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 		if err := collectAlertmanagerData(ctx, opts.alertmanagerURL, timeout, opts.insecure, logger); err != nil {
@@ -205,21 +445,149 @@ func performAnalysis(opts analysisOptions, logger zerolog.Logger) (*analysisResu
 		flapping:        flapping,
 		correlation:     correlations,
 		temporal:        temporal,
+		labels:          labels,
+		anomalies:       anomalies,
+		incidents:       incidents,
+		burden:          burden,
 		recommendations: recommendations,
+		patches:         patches,
+		heatmap:         heatmap,
+		timelines:       timelines,
 		history:         aggregatedHistory,
 	}, nil
 }
 
+// needsRecommendations reports whether recommendations must be generated,
+// either for display or as the input for rule patches.
+func (o analysisOptions) needsRecommendations() bool {
+	return o.showRecommendations || o.emitPatches != ""
+}
+
+// writePatches stores proposed rule and inhibit rule changes for human review.
+func writePatches(result *analysisResult, dir string, logger zerolog.Logger) error {
+	if result.patches.IsEmpty() {
+		logger.Info().Str("dir", dir).Msg("No rule patches proposed")
+		return nil
+	}
+
+	written, err := result.patches.Write(dir)
+	if err != nil {
+		return fmt.Errorf("failed to write rule patches: %w", err)
+	}
+
+	logger.Info().
+		Str("dir", dir).
+		Strs("files", written).
+		Int("rules", len(result.patches.Rules)).
+		Int("inhibit_rules", len(result.patches.InhibitRules)).
+		Msg("Rule patches written")
+	return nil
+}
+
+func analyzeOnCallBurden(history *collector.AlertHistory, opts analysisOptions, logger zerolog.Logger) (analyzer.BurdenReport, error) {
+	location := time.Local
+	if opts.oncallTimezone != "" {
+		loc, err := time.LoadLocation(opts.oncallTimezone)
+		if err != nil {
+			return analyzer.BurdenReport{}, fmt.Errorf("invalid on-call timezone: %w", err)
+		}
+		location = loc
+	}
+
+	router, err := loadRouter(opts, logger)
+	if err != nil {
+		return analyzer.BurdenReport{}, err
+	}
+
+	report := analyzer.NewBurdenAnalyzer(history, router, location).AnalyzeTopN(opts.topN)
+	logger.Info().
+		Int("alerts", len(report.Alerts)).
+		Int("teams", len(report.Teams)).
+		Bool("routing", router != nil).
+		Msg("On-call burden analysis complete")
+	return report, nil
+}
+
+// loadRouter returns the Alertmanager routing tree from --alertmanager-config or,
+// failing that, from the running Alertmanager. A nil router means label-based ownership.
+func loadRouter(opts analysisOptions, logger zerolog.Logger) (analyzer.Router, error) {
+	if opts.alertmanagerConfig != "" {
+		tree, err := routing.LoadFile(opts.alertmanagerConfig)
+		if err != nil {
+			return nil, err
+		}
+		return tree, nil
+	}
+
+	if opts.alertmanagerURL == "" {
+		return nil, nil
+	}
+
+	timeout, err := time.ParseDuration(opts.timeoutStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout duration: %w", err)
+	}
+
+	amClient, err := alertmanager.NewClient(&alertmanager.Config{
+		URL:      opts.alertmanagerURL,
+		Timeout:  timeout,
+		Insecure: opts.insecure,
+	}, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Alertmanager client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	status, err := amClient.GetStatus(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to load Alertmanager routing, falling back to team labels")
+		return nil, nil
+	}
+
+	tree, err := routing.Parse([]byte(status.Config.Original))
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to parse Alertmanager routing, falling back to team labels")
+		return nil, nil
+	}
+	return tree, nil
+}
+
 func reportAnalysis(result *analysisResult, outputFormat string) error {
 	rep := reporter.NewReporter(outputFormat, os.Stdout)
-	return rep.ReportCompleteWithInsights(
-		result.stats,
-		result.topAlerts,
-		result.flapping,
-		result.correlation,
-		result.temporal,
-		result.recommendations,
-	)
+	return rep.ReportAnalysis(reporter.AnalysisReport{
+		Summary:         result.stats,
+		Frequency:       result.topAlerts,
+		Excluded:        result.excluded,
+		Trends:          result.trends,
+		Flapping:        result.flapping,
+		Correlation:     result.correlation,
+		Temporal:        result.temporal,
+		Labels:          result.labels,
+		Anomalies:       result.anomalies,
+		Incidents:       result.incidents,
+		OnCallBurden:    result.burden,
+		Recommendations: result.recommendations,
+		Heatmap:         result.heatmap,
+		Timelines:       result.timelines,
+	})
+}
+
+// timelineAlertNames picks the alerts drawn on the HTML flapping timeline: the
+// flapping results when flapping analysis ran, otherwise the top firing alerts.
+func timelineAlertNames(topAlerts []analyzer.FrequencyResult, flapping []analyzer.FlappingResult) []string {
+	names := make([]string, 0)
+	if len(flapping) > 0 {
+		for _, result := range flapping {
+			names = append(names, result.AlertName)
+		}
+		return names
+	}
+	for _, result := range topAlerts {
+		names = append(names, result.AlertName)
+	}
+	return names
 }
 
 func recordAnalysisMetrics(result *analysisResult, command string) {
@@ -237,6 +605,13 @@ func recordAnalysisMetrics(result *analysisResult, command string) {
 		result.temporal,
 		result.recommendations,
 	)
+	toolkitmetrics.SetAlertAnalyzerBreakdownMetrics(result.history)
+	if result.trends != nil {
+		toolkitmetrics.SetAlertAnalyzerTrendMetrics(*result.trends, result.topTrends)
+	}
+	if result.anomalies != nil {
+		toolkitmetrics.SetAlertAnalyzerAnomalyMetrics(*result.anomalies)
+	}
 }
 
 func limitFrequencyResults(results []analyzer.FrequencyResult, topN int) []analyzer.FrequencyResult {
@@ -259,3 +634,34 @@ func limitFlappingResults(results []analyzer.FlappingResult, topN int) []analyze
 	}
 	return results
 }
+
+func limitTrendResults(results []analyzer.TrendResult, topN int) []analyzer.TrendResult {
+	if topN > 0 && topN < len(results) {
+		return results[:topN]
+	}
+	return results
+}
+
+// parseCompareTo parses the --compare-to offset; an empty value disables the comparison.
+func parseCompareTo(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	compareTo, err := parseLookback(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid compare-to duration: %w", err)
+	}
+	if compareTo <= 0 {
+		return 0, fmt.Errorf("invalid compare-to duration: must be positive")
+	}
+	return compareTo, nil
+}
+
+// parseLookback parses a lookback window, accepting Prometheus-style units such as 7d or 2w.
+func parseLookback(value string) (time.Duration, error) {
+	d, err := model.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(d), nil
+}
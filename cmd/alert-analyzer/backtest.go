@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/backtest"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/reporter"
+	"github.com/neogan/sre-toolkit/pkg/config"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+	"github.com/neogan/sre-toolkit/pkg/prometheus"
+)
+
+type backtestOptions struct {
+	prometheusURL string
+	sourcesFile   string
+	expr          string
+	forDuration   time.Duration
+	alertName     string
+	lookbackStr   string
+	resolutionStr string
+	timeoutStr    string
+	insecure      bool
+	outputFormat  string
+}
+
+func newBacktestCmd() *cobra.Command {
+	opts := backtestOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "backtest",
+		Short: "Backtest a candidate alert expression against historical data",
+		Long: `Evaluate a candidate alerting expression over the lookback window with range
+queries, simulate the pending/firing state machine with the given 'for' duration,
+and compare the resulting firings with the existing rule's history from ALERTS.`,
+		Example: `  # How many pages would a higher threshold and longer for: produce?
+  alert-analyzer backtest --prometheus-url http://prom:9090 \
+    --expr 'rate(http_errors_total[5m]) > 0.2' --for 10m --lookback 14d \
+    --alert-name HighErrorRate
+
+  # Evaluate a new expression without comparison
+  alert-analyzer backtest --prometheus-url http://prom:9090 --expr 'up == 0' --for 5m`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBacktest(opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.prometheusURL, "prometheus-url", "", "Prometheus server URL in format [cluster=]url, or a source name from --prometheus-sources (required)")
+	cmd.Flags().StringVar(&opts.sourcesFile, "prometheus-sources", "", "YAML file with per-source auth, tenant and TLS settings")
+	cmd.Flags().StringVar(&opts.expr, "expr", "", "Candidate PromQL alert expression (required)")
+	cmd.Flags().DurationVar(&opts.forDuration, "for", 0, "Candidate for: duration (how long the expression must hold before firing)")
+	cmd.Flags().StringVar(&opts.alertName, "alert-name", "", "Existing alert to compare against via ALERTS history")
+	cmd.Flags().StringVar(&opts.lookbackStr, "lookback", "7d", "Time range to backtest (e.g., 7d, 24h, 14d)")
+	cmd.Flags().StringVar(&opts.resolutionStr, "resolution", "1m", "Evaluation step; should match the rule evaluation interval")
+	cmd.Flags().StringVar(&opts.timeoutStr, "timeout", "2m", "Timeout for the whole backtest")
+	cmd.Flags().BoolVar(&opts.insecure, "insecure", false, "Skip TLS verification")
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "table", "Output format: table, json, or markdown")
+
+	cmd.MarkFlagRequired("prometheus-url")
+	cmd.MarkFlagRequired("expr")
+
+	return cmd
+}
+
+func runBacktest(opts backtestOptions, stdout io.Writer) error {
+	if opts.outputFormat != reporter.FormatTable && opts.outputFormat != reporter.FormatJSON && opts.outputFormat != reporter.FormatMarkdown {
+		return fmt.Errorf("invalid output format: %s (must be table, json, or markdown)", opts.outputFormat)
+	}
+
+	lookback, err := parseLookback(opts.lookbackStr)
+	if err != nil {
+		return fmt.Errorf("invalid lookback duration: %w", err)
+	}
+
+	step, err := time.ParseDuration(opts.resolutionStr)
+	if err != nil {
+		return fmt.Errorf("invalid resolution duration: %w", err)
+	}
+
+	timeout, err := time.ParseDuration(opts.timeoutStr)
+	if err != nil {
+		return fmt.Errorf("invalid timeout duration: %w", err)
+	}
+
+	cfg := config.Default()
+	logging.Init(cfg.Logging)
+	logger := logging.GetLogger()
+
+	sources, err := resolvePrometheusSources([]string{opts.prometheusURL}, opts.sourcesFile, timeout, opts.insecure)
+	if err != nil {
+		return err
+	}
+	promClient, err := prometheus.NewClient(sources[0].config, &logger)
+	if err != nil {
+		return fmt.Errorf("failed to create Prometheus client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	end := time.Now().Truncate(step)
+	result, err := backtest.NewBacktester(promClient, &logger).Run(ctx, backtest.Config{
+		Expr:      opts.expr,
+		For:       opts.forDuration,
+		AlertName: opts.alertName,
+		Start:     end.Add(-lookback),
+		End:       end,
+		Step:      step,
+	})
+	if err != nil {
+		return err
+	}
+
+	return reporter.NewReporter(opts.outputFormat, stdout).ReportBacktest(result)
+}
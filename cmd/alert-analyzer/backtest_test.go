@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBacktestCmd_RequiresExpr(t *testing.T) {
+	cmd := newBacktestCmd()
+	cmd.SetArgs([]string{"--prometheus-url", "http://prometheus:9090"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `required flag(s) "expr" not set`)
+}
+
+func TestRunBacktestValidationErrors(t *testing.T) {
+	valid := backtestOptions{
+		prometheusURL: "http://prometheus:9090",
+		expr:          "up == 0",
+		lookbackStr:   "1d",
+		resolutionStr: "1m",
+		timeoutStr:    "30s",
+		outputFormat:  "table",
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*backtestOptions)
+		wantErr string
+	}{
+		{name: "Invalid Output", mutate: func(o *backtestOptions) { o.outputFormat = "xml" }, wantErr: "invalid output format"},
+		{name: "Invalid Lookback", mutate: func(o *backtestOptions) { o.lookbackStr = "bad" }, wantErr: "invalid lookback duration"},
+		{name: "Invalid Resolution", mutate: func(o *backtestOptions) { o.resolutionStr = "bad" }, wantErr: "invalid resolution duration"},
+		{name: "Invalid Timeout", mutate: func(o *backtestOptions) { o.timeoutStr = "bad" }, wantErr: "invalid timeout duration"},
+		{name: "Invalid Prometheus URL", mutate: func(o *backtestOptions) { o.prometheusURL = "" }, wantErr: "failed to create Prometheus client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.mutate(&opts)
+			assert.ErrorContains(t, runBacktest(opts, &bytes.Buffer{}), tt.wantErr)
+		})
+	}
+}
+
+func TestRunBacktest(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		queries = append(queries, r.Form.Get("query"))
+
+		// Every query returns one series that is present for the first 20 minutes of the window.
+		var start float64
+		_, err := fmt.Sscanf(r.Form.Get("start"), "%g", &start)
+		require.NoError(t, err)
+		values := make([][]interface{}, 0)
+		for i := 0; i < 20; i++ {
+			values = append(values, []interface{}{start + float64(i*60), "1"})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{
+				"resultType": "matrix",
+				"result": []map[string]interface{}{
+					{"metric": map[string]string{"job": "api"}, "values": values},
+				},
+			},
+		}))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	err := runBacktest(backtestOptions{
+		prometheusURL: server.URL,
+		expr:          "up == 0",
+		forDuration:   5 * time.Minute,
+		alertName:     "TargetDown",
+		lookbackStr:   "1h",
+		resolutionStr: "1m",
+		timeoutStr:    "30s",
+		outputFormat:  "json",
+	}, &stdout)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"up == 0", `ALERTS{alertname="TargetDown", alertstate="firing"}`}, queries)
+
+	var output struct {
+		Backtest struct {
+			Candidate struct {
+				Firings         int           `json:"firings"`
+				TotalFiringTime time.Duration `json:"total_firing_time"`
+			} `json:"candidate"`
+			Actual struct {
+				Firings         int           `json:"firings"`
+				TotalFiringTime time.Duration `json:"total_firing_time"`
+			} `json:"actual"`
+		} `json:"backtest"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, 1, output.Backtest.Candidate.Firings)
+	assert.Equal(t, 15*time.Minute, output.Backtest.Candidate.TotalFiringTime)
+	assert.Equal(t, 1, output.Backtest.Actual.Firings)
+	assert.Equal(t, 20*time.Minute, output.Backtest.Actual.TotalFiringTime)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neogan/sre-toolkit/pkg/metrics"
+)
+
+func newDashboardCmd() *cobra.Command {
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Print the Grafana dashboard for the monitor metrics",
+		Long: `Print the Grafana dashboard JSON generated from the alert-analyzer metric
+definitions. It has one panel per metric exported by 'monitor' and cluster and
+namespace variables for the per-cluster firing breakdown.`,
+		Example: `  # Regenerate the dashboard shipped with the docker-compose setup
+  alert-analyzer dashboard --output-file deployments/docker/alert-analyzer/grafana/dashboards/alert-analyzer-metrics.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDashboard(outputFile, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the dashboard to this file instead of stdout")
+
+	return cmd
+}
+
+func runDashboard(outputFile string, stdout io.Writer) error {
+	dashboard, err := metrics.AlertAnalyzerDashboard()
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "" {
+		_, err := stdout.Write(dashboard)
+		return err
+	}
+	if err := os.WriteFile(outputFile, dashboard, 0o600); err != nil {
+		return fmt.Errorf("failed to write dashboard: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDashboard(t *testing.T) {
+	var stdout bytes.Buffer
+	require.NoError(t, runDashboard("", &stdout))
+	assert.True(t, json.Valid(stdout.Bytes()))
+	assert.Contains(t, stdout.String(), "sre_toolkit_alert_analyzer_firings")
+
+	path := filepath.Join(t.TempDir(), "dashboard.json")
+	require.NoError(t, runDashboard(path, &bytes.Buffer{}))
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, stdout.Bytes(), written)
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/digest"
+	"github.com/neogan/sre-toolkit/pkg/config"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+)
+
+type digestOptions struct {
+	analysis         analysisOptions
+	slackWebhooks    []string
+	webhooks         []string
+	smtpServer       string
+	smtpFrom         string
+	smtpTo           []string
+	smtpUsername     string
+	smtpPasswordFile string
+	notifyTimeout    time.Duration
+	stateFile        string
+	templateFile     string
+	ownerLabels      []string
+	dryRun           bool
+}
+
+func newDigestCmd() *cobra.Command {
+	opts := digestOptions{analysis: analysisOptions{showRecommendations: true}}
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Send a digest of noisy alerts, new recommendations and trends",
+		Long: `Run the analysis and send a digest to Slack-compatible webhooks, generic JSON
+webhooks or e-mail. The digest lists the noisiest alerts per team (by owner label),
+recommendations that are new since the previous digest and period-over-period deltas.
+
+Recommendations already sent are tracked in --state-file; the state is only
+updated when every destination accepted the digest. The message is rendered with
+a Go text/template (see --template); a template may define a "subject" template.`,
+		Example: `  # Weekly digest to Slack, from cron
+  alert-analyzer digest --prometheus-url http://prom:9090 \
+    --slack-webhook https://hooks.slack.com/services/T000/B000/XXX \
+    --state-file /var/lib/alert-analyzer/digest-state.json
+
+  # E-mail digest with a custom template
+  alert-analyzer digest --prometheus-url http://prom:9090 --template digest.tmpl \
+    --smtp-server smtp.example.com:587 --smtp-from alerts@example.com --smtp-to sre@example.com \
+    --smtp-username alerts --smtp-password-file /etc/alert-analyzer/smtp-password
+
+  # Preview the digest of an exported history file
+  alert-analyzer digest --input history.json --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDigest(opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.analysis.inputFile, "input", "", "Read alert history and rules from a file written by 'alert-analyzer export'")
+	cmd.Flags().StringSliceVar(&opts.analysis.prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url, or source names from --prometheus-sources")
+	cmd.Flags().StringVar(&opts.analysis.sourcesFile, "prometheus-sources", "", "YAML file with per-source auth, tenant and TLS settings")
+	cmd.Flags().StringVar(&opts.analysis.lookbackStr, "lookback", "7d", "Time range covered by the digest (e.g., 7d, 24h)")
+	cmd.Flags().StringVar(&opts.analysis.excludeWindowsFile, "exclude-windows", "", "YAML file with maintenance windows whose alert activity is left out of the digest")
+	cmd.Flags().StringVar(&opts.analysis.resolutionStr, "resolution", "5m", "Query resolution (e.g., 1m, 5m, 15m)")
+	cmd.Flags().StringVar(&opts.analysis.timeoutStr, "timeout", "30s", "Request timeout")
+	cmd.Flags().BoolVar(&opts.analysis.insecure, "insecure", false, "Skip TLS verification")
+	cmd.Flags().Float64Var(&opts.analysis.flappingThreshold, "flapping-threshold", 3.0, "Flapping threshold (transitions per hour)")
+	cmd.Flags().StringVar(&opts.analysis.compareToStr, "compare-to", "7d", "Compare with the window of the same length ending this long ago; empty disables trend deltas")
+	cmd.Flags().IntVar(&opts.analysis.topN, "top-n", 5, "Number of alerts listed per team and of largest changes")
+	cmd.Flags().StringSliceVar(&opts.ownerLabels, "owner-label", digest.DefaultOwnerLabels, "Labels checked in order for the team owning an alert")
+	cmd.Flags().StringSliceVar(&opts.slackWebhooks, "slack-webhook", nil, "Slack-compatible incoming webhook URL (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.webhooks, "webhook", nil, "Generic webhook URL receiving the digest as JSON (repeatable)")
+	cmd.Flags().StringVar(&opts.smtpServer, "smtp-server", "", "SMTP server as host:port")
+	cmd.Flags().StringVar(&opts.smtpFrom, "smtp-from", "", "E-mail sender address")
+	cmd.Flags().StringSliceVar(&opts.smtpTo, "smtp-to", nil, "E-mail recipient addresses")
+	cmd.Flags().StringVar(&opts.smtpUsername, "smtp-username", "", "SMTP username for PLAIN authentication")
+	cmd.Flags().StringVar(&opts.smtpPasswordFile, "smtp-password-file", "", "File with the SMTP password")
+	cmd.Flags().DurationVar(&opts.notifyTimeout, "notify-timeout", 10*time.Second, "Timeout for each webhook request")
+	cmd.Flags().StringVar(&opts.stateFile, "state-file", "", "File tracking recommendations already sent; without it every recommendation is new")
+	cmd.Flags().StringVar(&opts.templateFile, "template", "", "Go text/template file for the digest (default: built-in template)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the digest instead of sending it; the state file is not updated")
+
+	cmd.MarkFlagsOneRequired("prometheus-url", "input")
+	cmd.MarkFlagsMutuallyExclusive("prometheus-url", "input")
+	cmd.MarkFlagsRequiredTogether("smtp-server", "smtp-from", "smtp-to")
+
+	return cmd
+}
+
+func runDigest(opts digestOptions, stdout io.Writer) error {
+	notifiers, err := opts.notifiers()
+	if err != nil {
+		return err
+	}
+	if len(notifiers) == 0 && !opts.dryRun {
+		return fmt.Errorf("no digest destination: set --slack-webhook, --webhook or --smtp-server, or use --dry-run")
+	}
+
+	tmpl, err := opts.template()
+	if err != nil {
+		return err
+	}
+
+	state := digest.NewState()
+	if opts.stateFile != "" {
+		if state, err = digest.LoadState(opts.stateFile); err != nil {
+			return err
+		}
+	}
+
+	cfg := config.Default()
+	logging.Init(cfg.Logging)
+	logger := logging.GetLogger()
+
+	result, err := performAnalysis(opts.analysis, logger)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := digest.NewBuilder(opts.ownerLabels, opts.analysis.topN).Build(result.history, result.recommendations, result.trends, state, now)
+	subject, body, err := tmpl.Render(report)
+	if err != nil {
+		return err
+	}
+	logger.Info().
+		Int("teams", len(report.Teams)).
+		Int("new_recommendations", len(report.NewRecommendations)).
+		Int("known_recommendations", report.KnownRecommendations).
+		Msg("Digest built")
+
+	if opts.dryRun {
+		fmt.Fprintf(stdout, "Subject: %s\n\n%s", subject, body)
+		return nil
+	}
+
+	message := digest.Message{Subject: subject, Body: body, Digest: report}
+	var errs []error
+	for _, notifier := range notifiers {
+		if err := notifier.Send(context.Background(), message); err != nil {
+			logger.Error().Err(err).Str("destination", notifier.Name()).Msg("Failed to send digest")
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), err))
+			continue
+		}
+		logger.Info().Str("destination", notifier.Name()).Msg("Digest sent")
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to send digest: %w", errors.Join(errs...))
+	}
+
+	if opts.stateFile != "" {
+		state.Record(result.recommendations, now)
+		if err := state.Save(opts.stateFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o digestOptions) notifiers() ([]digest.Notifier, error) {
+	notifiers := make([]digest.Notifier, 0, len(o.slackWebhooks)+len(o.webhooks)+1)
+	for _, url := range o.slackWebhooks {
+		notifiers = append(notifiers, digest.NewSlackNotifier(url, o.notifyTimeout))
+	}
+	for _, url := range o.webhooks {
+		notifiers = append(notifiers, digest.NewWebhookNotifier(url, o.notifyTimeout))
+	}
+
+	if o.smtpServer != "" {
+		smtpConfig := digest.SMTPConfig{
+			Address:  o.smtpServer,
+			From:     o.smtpFrom,
+			To:       o.smtpTo,
+			Username: o.smtpUsername,
+		}
+		if o.smtpPasswordFile != "" {
+			password, err := os.ReadFile(o.smtpPasswordFile) //nolint:gosec // path is provided by the operator
+			if err != nil {
+				return nil, fmt.Errorf("failed to read SMTP password file: %w", err)
+			}
+			smtpConfig.Password = strings.TrimSpace(string(password))
+		}
+		notifier, err := digest.NewSMTPNotifier(smtpConfig)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers, nil
+}
+
+func (o digestOptions) template() (*digest.Template, error) {
+	if o.templateFile != "" {
+		return digest.LoadTemplate(o.templateFile)
+	}
+	return digest.ParseTemplate(digest.DefaultTemplate)
+}
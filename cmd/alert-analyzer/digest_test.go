@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/digest"
+)
+
+func writeDigestHistory(t *testing.T) string {
+	t.Helper()
+
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	history := collector.HistoryFile{
+		AlertHistory: collector.AlertHistory{StartTime: start, EndTime: start.Add(14 * 24 * time.Hour)},
+	}
+	// DiskFull flaps in the second week only.
+	for i := 0; i < 40; i++ {
+		firedAt := start.Add(13*24*time.Hour + time.Duration(i)*10*time.Minute)
+		resolvedAt := firedAt.Add(2 * time.Minute)
+		history.Alerts = append(history.Alerts, collector.Alert{
+			Name:       "DiskFull",
+			Labels:     map[string]string{"severity": "warning", "team": "storage"},
+			State:      "firing",
+			FiredAt:    firedAt,
+			ResolvedAt: &resolvedAt,
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	data, err := json.Marshal(history)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestRunDigest(t *testing.T) {
+	var received []digest.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload digest.WebhookPayload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		received = append(received, payload)
+	}))
+	defer server.Close()
+
+	stateFile := filepath.Join(t.TempDir(), "digest-state.json")
+	opts := digestOptions{
+		analysis: analysisOptions{
+			inputFile:           writeDigestHistory(t),
+			lookbackStr:         "7d",
+			compareToStr:        "7d",
+			topN:                5,
+			flappingThreshold:   3,
+			showRecommendations: true,
+		},
+		webhooks:      []string{server.URL},
+		notifyTimeout: time.Second,
+		stateFile:     stateFile,
+	}
+
+	require.NoError(t, runDigest(opts, &bytes.Buffer{}))
+	require.Len(t, received, 1)
+	first := received[0]
+	require.NotEmpty(t, first.Digest.Teams)
+	assert.Equal(t, "storage", first.Digest.Teams[0].Team)
+	assert.Equal(t, 40, first.Digest.Teams[0].Firings)
+	require.NotNil(t, first.Digest.Trends)
+	assert.Equal(t, []string{"DiskFull"}, first.Digest.Trends.New)
+	require.NotEmpty(t, first.Digest.NewRecommendations)
+	assert.Contains(t, first.Text, "storage (40 firings")
+	assert.FileExists(t, stateFile)
+
+	// The second digest reports the same recommendations as known.
+	require.NoError(t, runDigest(opts, &bytes.Buffer{}))
+	require.Len(t, received, 2)
+	assert.Empty(t, received[1].Digest.NewRecommendations)
+	assert.Equal(t, len(first.Digest.NewRecommendations), received[1].Digest.KnownRecommendations)
+}
+
+func TestRunDigest_DryRun(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "digest-state.json")
+	var out bytes.Buffer
+	require.NoError(t, runDigest(digestOptions{
+		analysis: analysisOptions{
+			inputFile:           writeDigestHistory(t),
+			lookbackStr:         "7d",
+			topN:                5,
+			flappingThreshold:   3,
+			showRecommendations: true,
+		},
+		stateFile: stateFile,
+		dryRun:    true,
+	}, &out))
+
+	assert.Contains(t, out.String(), "Subject: Alert digest 2026-03-16")
+	assert.Contains(t, out.String(), "New recommendations")
+	assert.NoFileExists(t, stateFile, "dry runs do not update the state")
+}
+
+func TestRunDigest_Errors(t *testing.T) {
+	err := runDigest(digestOptions{analysis: analysisOptions{inputFile: "history.json"}}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "no digest destination")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	stateFile := filepath.Join(t.TempDir(), "digest-state.json")
+	err = runDigest(digestOptions{
+		analysis: analysisOptions{
+			inputFile:           writeDigestHistory(t),
+			lookbackStr:         "7d",
+			topN:                5,
+			flappingThreshold:   3,
+			showRecommendations: true,
+		},
+		slackWebhooks: []string{server.URL},
+		stateFile:     stateFile,
+	}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "slack: webhook returned status 500")
+	assert.NoFileExists(t, stateFile, "the state is kept when a destination fails")
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/exclusion"
+	"github.com/neogan/sre-toolkit/pkg/alertmanager"
+)
+
+// loadExclusions compiles the --exclude-windows file and, with --exclude-silences,
+// the silences known to Alertmanager. It returns nil when neither is configured.
+func loadExclusions(opts analysisOptions, logger zerolog.Logger) (*exclusion.Set, error) {
+	if opts.excludeWindowsFile == "" && !opts.excludeSilences {
+		return nil, nil
+	}
+
+	set := &exclusion.Set{}
+	if opts.excludeWindowsFile != "" {
+		loaded, err := exclusion.LoadFile(opts.excludeWindowsFile)
+		if err != nil {
+			return nil, err
+		}
+		set = loaded
+	}
+
+	if opts.excludeSilences {
+		if opts.alertmanagerURL == "" {
+			return nil, fmt.Errorf("--exclude-silences requires --alertmanager-url")
+		}
+		silences, err := fetchSilences(opts, logger)
+		if err != nil {
+			return nil, err
+		}
+		if err := set.AddSilences(silences, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info().Int("windows", set.Len()).Msg("Maintenance windows loaded")
+	return set, nil
+}
+
+func fetchSilences(opts analysisOptions, logger zerolog.Logger) ([]alertmanager.Silence, error) {
+	timeout, err := time.ParseDuration(opts.timeoutStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout duration: %w", err)
+	}
+
+	client, err := alertmanager.NewClient(&alertmanager.Config{
+		URL:      opts.alertmanagerURL,
+		Timeout:  timeout,
+		Insecure: opts.insecure,
+	}, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Alertmanager client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	silences, err := client.ListSilences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Alertmanager silences: %w", err)
+	}
+	return silences, nil
+}
+
+// applyExclusions removes the activity inside maintenance windows from history
+// and returns what was removed, or nil without exclusions.
+func applyExclusions(set *exclusion.Set, history *collector.AlertHistory, logger zerolog.Logger) *exclusion.Report {
+	if set == nil {
+		return nil
+	}
+	report := set.Apply(history)
+	logger.Info().
+		Int("excluded_firings", report.Firings).
+		Int("windows_with_activity", len(report.Windows)).
+		Msg("Maintenance activity excluded")
+	return &report
+}
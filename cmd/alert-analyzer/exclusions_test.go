@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/pkg/alertmanager"
+)
+
+func writeExclusionHistory(t *testing.T, dir string, start time.Time) string {
+	t.Helper()
+	history := collector.HistoryFile{AlertHistory: collector.AlertHistory{
+		StartTime: start,
+		EndTime:   start.Add(24 * time.Hour),
+	}}
+	for hour := 0; hour < 24; hour++ {
+		firedAt := start.Add(time.Duration(hour) * time.Hour)
+		resolvedAt := firedAt.Add(5 * time.Minute)
+		history.Alerts = append(history.Alerts, collector.Alert{
+			Name:       "NodeReboot",
+			Labels:     map[string]string{"severity": "warning", "alertname": "NodeReboot"},
+			FiredAt:    firedAt,
+			ResolvedAt: &resolvedAt,
+		})
+	}
+
+	path := filepath.Join(dir, "history.json")
+	data, err := json.Marshal(history)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestPerformAnalysisExcludeWindows(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, 3, 21, 0, 0, 0, 0, time.UTC)
+	historyPath := writeExclusionHistory(t, dir, start)
+
+	windowsPath := filepath.Join(dir, "windows.yaml")
+	require.NoError(t, os.WriteFile(windowsPath, []byte(`windows:
+  - name: nightly-reboots
+    cron: "0 2 * * *"
+    duration: 3h
+`), 0o600))
+
+	result, err := performAnalysis(analysisOptions{
+		inputFile:          historyPath,
+		topN:               10,
+		flappingThreshold:  3.0,
+		excludeWindowsFile: windowsPath,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+
+	assert.Equal(t, 21, result.stats.TotalFirings, "firings from 02:00 to 05:00 are excluded")
+	require.NotNil(t, result.excluded)
+	assert.Equal(t, 3, result.excluded.Firings)
+	require.Len(t, result.excluded.Windows, 1)
+	assert.Equal(t, "nightly-reboots", result.excluded.Windows[0].Name)
+}
+
+func TestLoadExclusionsSilences(t *testing.T) {
+	now := time.Now()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/silences", r.URL.Path)
+		require.NoError(t, json.NewEncoder(w).Encode([]alertmanager.Silence{{
+			ID:       "abc",
+			Comment:  "datacenter move",
+			StartsAt: now.Add(-2 * time.Hour),
+			EndsAt:   now.Add(-time.Hour),
+			Matchers: []alertmanager.SilenceMatcher{{Name: "alertname", Value: "NodeReboot"}},
+		}}))
+	}))
+	defer srv.Close()
+
+	set, err := loadExclusions(analysisOptions{
+		alertmanagerURL: srv.URL,
+		timeoutStr:      "5s",
+		excludeSilences: true,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, set.Len())
+
+	history := &collector.AlertHistory{Alerts: []collector.Alert{
+		{Name: "NodeReboot", FiredAt: now.Add(-90 * time.Minute)},
+		{Name: "NodeReboot", FiredAt: now.Add(-30 * time.Minute)},
+	}}
+	report := applyExclusions(set, history, zerolog.Nop())
+	require.NotNil(t, report)
+	assert.Equal(t, 1, report.Firings)
+	assert.Len(t, history.Alerts, 1)
+}
+
+func TestLoadExclusionsErrors(t *testing.T) {
+	set, err := loadExclusions(analysisOptions{}, zerolog.Nop())
+	require.NoError(t, err)
+	assert.Nil(t, set)
+	assert.Nil(t, applyExclusions(set, &collector.AlertHistory{}, zerolog.Nop()))
+
+	_, err = loadExclusions(analysisOptions{excludeSilences: true}, zerolog.Nop())
+	assert.ErrorContains(t, err, "--exclude-silences requires --alertmanager-url")
+
+	_, err = loadExclusions(analysisOptions{excludeWindowsFile: filepath.Join(t.TempDir(), "missing.yaml")}, zerolog.Nop())
+	assert.ErrorContains(t, err, "failed to read exclusion windows")
+}
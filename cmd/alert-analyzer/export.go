@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/pkg/config"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+)
+
+func newExportCmd() *cobra.Command {
+	var (
+		prometheusURLs []string
+		sourcesFile    string
+		lookback       string
+		resolution     string
+		timeout        string
+		insecure       bool
+		includeRules   bool
+		outputFile     string
+		format         string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export collected alert history to a file",
+		Long: `Collect alert history (and optionally alerting rules) from Prometheus and write it
+to a JSON or NDJSON file. The file can later be analyzed offline with
+'alert-analyzer analyze --input' and shared without Prometheus access.`,
+		Example: `  # Export the last 7 days of alert history with rules
+  alert-analyzer export --prometheus-url http://prom:9090 --output-file history.json
+
+  # Export as NDJSON for streaming tools
+  alert-analyzer export --prometheus-url prod=http://prom:9090 --output-file history.ndjson
+
+  # Analyze the exported data later
+  alert-analyzer analyze --input history.json --show-recommendations`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(analysisOptions{
+				prometheusURLs: prometheusURLs,
+				sourcesFile:    sourcesFile,
+				lookbackStr:    lookback,
+				resolutionStr:  resolution,
+				timeoutStr:     timeout,
+				insecure:       insecure,
+				includeRules:   includeRules,
+			}, outputFile, format, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url, or source names from --prometheus-sources (required)")
+	cmd.Flags().StringVar(&sourcesFile, "prometheus-sources", "", "YAML file with per-source auth, tenant and TLS settings")
+	cmd.Flags().StringVar(&lookback, "lookback", "7d", "Time range to export (e.g., 7d, 24h, 30d)")
+	cmd.Flags().StringVar(&resolution, "resolution", "5m", "Query resolution (e.g., 1m, 5m, 15m)")
+	cmd.Flags().StringVar(&timeout, "timeout", "30s", "Request timeout")
+	cmd.Flags().BoolVar(&insecure, "insecure", false, "Skip TLS verification")
+	cmd.Flags().BoolVar(&includeRules, "include-rules", true, "Include configured alerting rules in the export")
+	cmd.Flags().StringVarP(&outputFile, "output-file", "f", "", "File to write (default: stdout)")
+	cmd.Flags().StringVar(&format, "format", "", "Export format: json or ndjson (default: derived from --output-file extension)")
+
+	cmd.MarkFlagRequired("prometheus-url")
+
+	return cmd
+}
+
+func runExport(opts analysisOptions, outputFile, format string, stdout io.Writer) error {
+	cfg := config.Default()
+	logging.Init(cfg.Logging)
+	logger := logging.GetLogger()
+
+	if format == "" {
+		format = collector.DetectFileFormat(outputFile)
+	}
+	if format != collector.FileFormatJSON && format != collector.FileFormatNDJSON {
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	history, rules, err := collectPrometheusData(opts, logger)
+	if err != nil {
+		return err
+	}
+
+	file := &collector.HistoryFile{
+		AlertHistory: *history,
+		Rules:        rules,
+	}
+
+	if outputFile == "" {
+		return collector.EncodeHistory(stdout, format, file)
+	}
+
+	out, err := os.Create(outputFile) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := collector.EncodeHistory(out, format, file); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	logger.Info().
+		Str("file", outputFile).
+		Str("format", format).
+		Int("alerts", history.CountAlerts()).
+		Int("rules", len(rules)).
+		Msg("Alert history exported")
+	return nil
+}
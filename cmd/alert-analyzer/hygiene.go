@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/reporter"
+	"github.com/neogan/sre-toolkit/pkg/config"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+)
+
+type hygieneOptions struct {
+	analysis            analysisOptions
+	checkRunbooks       bool
+	runbookTimeout      time.Duration
+	allowedSeverities   []string
+	minScore            int
+	showRecommendations bool
+}
+
+func newHygieneCmd() *cobra.Command {
+	opts := hygieneOptions{analysis: analysisOptions{includeRules: true}}
+
+	cmd := &cobra.Command{
+		Use:   "hygiene",
+		Short: "Score alerting rules on runbooks, annotations and ownership",
+		Long: `Score every alerting rule from 0 to 100 on runbook links, summary and description
+annotation templates, team/owner and severity labels, and how actionable its firings
+are (average firing duration), and roll the scores up per team.
+
+Runbook URLs are resolved with an HTTP HEAD request; use --check-runbooks=false
+when running offline.`,
+		Example: `  # Score rules and check runbook links
+  alert-analyzer hygiene --prometheus-url http://prom:9090
+
+  # Score an exported history file offline and list rules to clean up
+  alert-analyzer hygiene --input history.json --check-runbooks=false --show-recommendations
+
+  # Use a custom severity scheme
+  alert-analyzer hygiene --prometheus-url http://prom:9090 --allowed-severities page,ticket`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHygiene(opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.analysis.inputFile, "input", "", "Read alert history and rules from a file written by 'alert-analyzer export'")
+	cmd.Flags().StringSliceVar(&opts.analysis.prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url, or source names from --prometheus-sources")
+	cmd.Flags().StringVar(&opts.analysis.sourcesFile, "prometheus-sources", "", "YAML file with per-source auth, tenant and TLS settings")
+	cmd.Flags().StringVar(&opts.analysis.lookbackStr, "lookback", "7d", "Time range used for the actionability check (e.g., 7d, 24h, 30d)")
+	cmd.Flags().StringVar(&opts.analysis.excludeWindowsFile, "exclude-windows", "", "YAML file with maintenance windows whose alert activity is left out of the analysis")
+	cmd.Flags().StringVar(&opts.analysis.resolutionStr, "resolution", "5m", "Query resolution (e.g., 1m, 5m, 15m)")
+	cmd.Flags().StringVar(&opts.analysis.timeoutStr, "timeout", "30s", "Request timeout")
+	cmd.Flags().BoolVar(&opts.analysis.insecure, "insecure", false, "Skip TLS verification for Prometheus and runbook checks")
+	cmd.Flags().BoolVar(&opts.checkRunbooks, "check-runbooks", true, "Resolve runbook URLs with an HTTP HEAD request")
+	cmd.Flags().DurationVar(&opts.runbookTimeout, "runbook-timeout", 5*time.Second, "Timeout for each runbook URL check")
+	cmd.Flags().StringSliceVar(&opts.allowedSeverities, "allowed-severities", analyzer.DefaultAllowedSeverities, "Accepted values of the severity label")
+	cmd.Flags().IntVar(&opts.minScore, "min-score", analyzer.DefaultHygieneMinScore, "Recommend cleanup for rules scoring below this")
+	cmd.Flags().BoolVar(&opts.showRecommendations, "show-recommendations", false, "Include cleanup recommendations for low-scoring rules")
+	cmd.Flags().StringVarP(&opts.analysis.outputFormat, "output", "o", "table", "Output format: table, json, or markdown")
+
+	cmd.MarkFlagsOneRequired("prometheus-url", "input")
+	cmd.MarkFlagsMutuallyExclusive("prometheus-url", "input")
+
+	return cmd
+}
+
+func runHygiene(opts hygieneOptions, stdout io.Writer) error {
+	format := opts.analysis.outputFormat
+	if format != reporter.FormatTable && format != reporter.FormatJSON && format != reporter.FormatMarkdown {
+		return fmt.Errorf("invalid output format: %s (must be table, json, or markdown)", format)
+	}
+
+	cfg := config.Default()
+	logging.Init(cfg.Logging)
+	logger := logging.GetLogger()
+
+	exclusions, err := loadExclusions(opts.analysis, logger)
+	if err != nil {
+		return err
+	}
+
+	history, rules, err := collectAnalysisData(opts.analysis, logger)
+	if err != nil {
+		return err
+	}
+	applyExclusions(exclusions, history, logger)
+	if len(rules) == 0 {
+		logger.Warn().Msg("No alerting rules collected; nothing to score")
+	}
+
+	var checker analyzer.RunbookChecker
+	if opts.checkRunbooks {
+		checker = newHTTPRunbookChecker(opts.runbookTimeout, opts.analysis.insecure)
+	}
+
+	report := analyzer.NewHygieneAnalyzer(rules, history, opts.allowedSeverities, checker).Analyze(context.Background())
+	logger.Info().
+		Int("rules", len(report.Rules)).
+		Int("teams", len(report.Teams)).
+		Float64("average_score", report.AverageScore).
+		Msg("Hygiene scoring complete")
+
+	var recommendations []analyzer.Recommendation
+	if opts.showRecommendations {
+		recommendations = analyzer.NewRecommendationEngine().GenerateHygiene(report, opts.minScore)
+	}
+
+	return reporter.NewReporter(format, stdout).ReportHygiene(report, recommendations)
+}
+
+// httpRunbookChecker resolves runbook URLs with HEAD requests.
+type httpRunbookChecker struct {
+	client *http.Client
+}
+
+func newHTTPRunbookChecker(timeout time.Duration, insecure bool) *httpRunbookChecker {
+	return &httpRunbookChecker{client: &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: insecure, // #nosec G402
+			},
+		},
+	}}
+}
+
+// Check reports an error when the URL cannot be fetched or answers with an error
+// status. 405 counts as reachable: the page exists but does not support HEAD.
+func (c *httpRunbookChecker) Check(ctx context.Context, runbookURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, runbookURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusMethodNotAllowed {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+func TestHTTPRunbookChecker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/head-not-allowed":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := newHTTPRunbookChecker(time.Second, false)
+	ctx := context.Background()
+
+	assert.NoError(t, checker.Check(ctx, server.URL+"/ok"))
+	assert.NoError(t, checker.Check(ctx, server.URL+"/head-not-allowed"))
+	assert.EqualError(t, checker.Check(ctx, server.URL+"/missing"), "HTTP 404")
+}
+
+func TestRunHygieneFromInputFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	start := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	history := collector.HistoryFile{
+		AlertHistory: collector.AlertHistory{StartTime: start, EndTime: start.Add(24 * time.Hour)},
+		Rules: []collector.AlertRule{
+			{
+				Name:   "HighLatency",
+				Labels: map[string]string{"severity": "critical", "team": "api"},
+				Annotations: map[string]string{
+					"runbook_url": server.URL + "/high-latency",
+					"summary":     "High latency on {{ $labels.instance }}",
+					"description": "p99 is {{ $value }}",
+				},
+			},
+			{Name: "Orphan"},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "history.json")
+	data, err := json.Marshal(history)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	var out bytes.Buffer
+	require.NoError(t, runHygiene(hygieneOptions{
+		analysis:            analysisOptions{inputFile: path, outputFormat: "markdown"},
+		checkRunbooks:       true,
+		runbookTimeout:      time.Second,
+		minScore:            70,
+		showRecommendations: true,
+	}, &out))
+
+	output := out.String()
+	assert.Contains(t, output, "| HighLatency | api | 🔴 critical | 100 | ok |")
+	assert.Contains(t, output, "| Orphan | unassigned |")
+	assert.Contains(t, output, "| unassigned | 1 | 15.0 | 15 | 1 | 0 | 2 | 1 | 0 |")
+	assert.Contains(t, output, "| HIGH | hygiene | Orphan |")
+}
+
+func TestRunHygieneInvalidFormat(t *testing.T) {
+	err := runHygiene(hygieneOptions{analysis: analysisOptions{outputFormat: "html"}}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid output format")
+}
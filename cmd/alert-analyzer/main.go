@@ -13,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
 	"github.com/neogan/sre-toolkit/pkg/alertmanager"
 	"github.com/neogan/sre-toolkit/pkg/cli"
@@ -40,6 +41,12 @@ to reduce alert fatigue and improve alerting effectiveness.`
 	// Add subcommands
 	rootCmd.AddCommand(newAnalyzeCmd())
 	rootCmd.AddCommand(newMonitorCmd())
+	rootCmd.AddCommand(newExportCmd())
+	rootCmd.AddCommand(newBacktestCmd())
+	rootCmd.AddCommand(newSLOAuditCmd())
+	rootCmd.AddCommand(newHygieneCmd())
+	rootCmd.AddCommand(newDigestCmd())
+	rootCmd.AddCommand(newDashboardCmd())
 	rootCmd.AddCommand(newVersionCmd())
 
 	// Execute
@@ -51,7 +58,9 @@ to reduce alert fatigue and improve alerting effectiveness.`
 
 func newAnalyzeCmd() *cobra.Command {
 	var (
+		inputFile            string
 		prometheusURLs       []string
+		sourcesFile          string
 		alertmanagerURL      string
 		lookback             string
 		resolution           string
@@ -63,6 +72,20 @@ func newAnalyzeCmd() *cobra.Command {
 		showCorrelation      bool
 		showTemporalPatterns bool
 		showRecommendations  bool
+		showIncidents        bool
+		incidentWindow       time.Duration
+		showOnCallBurden     bool
+		alertmanagerConfig   string
+		oncallTimezone       string
+		emitPatches          string
+		compareTo            string
+		groupBy              []string
+		showLabelAnalysis    bool
+		cardinalityThreshold int
+		showAnomalies        bool
+		anomalyThreshold     float64
+		excludeWindows       string
+		excludeSilences      bool
 		flappingThreshold    float64
 	)
 
@@ -72,7 +95,10 @@ func newAnalyzeCmd() *cobra.Command {
 		Long: `Analyze alert history from Prometheus to identify patterns and generate recommendations.
 
 This command connects to a Prometheus server, queries alert history over a specified
-time range, and performs frequency analysis to identify the most problematic alerts.`,
+time range, and performs frequency analysis to identify the most problematic alerts.
+
+Use --input to analyze a history file produced by 'alert-analyzer export' (JSON or
+NDJSON) without access to Prometheus.`,
 		Example: `  # Analyze last 7 days with default settings
   alert-analyzer analyze --prometheus-url http://localhost:9090
 
@@ -92,10 +118,41 @@ time range, and performs frequency analysis to identify the most problematic ale
   alert-analyzer analyze --prometheus-url http://prom:9090 --show-temporal-patterns
 
   # Generate actionable recommendations
-  alert-analyzer analyze --prometheus-url http://prom:9090 --show-recommendations`,
+  alert-analyzer analyze --prometheus-url http://prom:9090 --show-recommendations
+
+  # Group alert storms into incidents and suggest group_by/inhibit rules
+  alert-analyzer analyze --prometheus-url http://prom:9090 --show-incidents --incident-window 10m
+
+  # Rank alerts and teams by on-call burden using Alertmanager routing
+  alert-analyzer analyze --prometheus-url http://prom:9090 --show-oncall-burden \
+    --alertmanager-config alertmanager.yml --oncall-timezone Europe/Berlin
+
+  # Write proposed rule and inhibit rule changes for review
+  alert-analyzer analyze --prometheus-url http://prom:9090 --emit-patches patches/
+
+  # Compare this week with the previous week
+  alert-analyzer analyze --prometheus-url http://prom:9090 --lookback 7d --compare-to 7d
+
+  # Break firings down by namespace and flag skewed or exploding labels
+  alert-analyzer analyze --prometheus-url http://prom:9090 --group-by namespace,service --show-label-analysis
+
+  # Flag hours whose alert volume deviates from the usual for that hour of the week
+  alert-analyzer analyze --prometheus-url http://prom:9090 --lookback 28d --show-anomalies
+
+  # Leave planned maintenance and silenced periods out of the analysis
+  alert-analyzer analyze --prometheus-url http://prom:9090 --exclude-windows windows.yaml \
+    --alertmanager-url http://alertmanager:9093 --exclude-silences
+
+  # Query Mimir and Thanos with per-source tenants, tokens and client certificates
+  alert-analyzer analyze --prometheus-sources sources.yaml --prometheus-url mimir-prod,thanos-global
+
+  # Analyze an exported history file offline
+  alert-analyzer analyze --input history.json --show-flapping --show-recommendations`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAnalyze(analysisOptions{
+				inputFile:            inputFile,
 				prometheusURLs:       prometheusURLs,
+				sourcesFile:          sourcesFile,
 				alertmanagerURL:      alertmanagerURL,
 				lookbackStr:          lookback,
 				resolutionStr:        resolution,
@@ -107,17 +164,33 @@ time range, and performs frequency analysis to identify the most problematic ale
 				showCorrelation:      showCorrelation,
 				showTemporalPatterns: showTemporalPatterns,
 				showRecommendations:  showRecommendations,
+				showIncidents:        showIncidents,
+				incidentWindow:       incidentWindow,
+				showOnCallBurden:     showOnCallBurden,
+				alertmanagerConfig:   alertmanagerConfig,
+				oncallTimezone:       oncallTimezone,
+				emitPatches:          emitPatches,
+				compareToStr:         compareTo,
+				groupBy:              groupBy,
+				showLabelAnalysis:    showLabelAnalysis,
+				cardinalityThreshold: cardinalityThreshold,
+				showAnomalies:        showAnomalies,
+				anomalyThreshold:     anomalyThreshold,
+				excludeWindowsFile:   excludeWindows,
+				excludeSilences:      excludeSilences,
 				flappingThreshold:    flappingThreshold,
 			})
 		},
 	}
 
 	// Add flags
-	cmd.Flags().StringSliceVar(&prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url (required)")
+	cmd.Flags().StringVar(&inputFile, "input", "", "Analyze an exported alert history file (JSON or NDJSON) instead of querying Prometheus")
+	cmd.Flags().StringSliceVar(&prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url, or source names from --prometheus-sources")
+	cmd.Flags().StringVar(&sourcesFile, "prometheus-sources", "", "YAML file with per-source auth, tenant and TLS settings")
 	cmd.Flags().StringVar(&alertmanagerURL, "alertmanager-url", "", "Alertmanager server URL (optional)")
 	cmd.Flags().StringVar(&lookback, "lookback", "7d", "Time range to analyze (e.g., 7d, 24h, 30d)")
 	cmd.Flags().StringVar(&resolution, "resolution", "5m", "Query resolution (e.g., 1m, 5m, 15m)")
-	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json, or markdown")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json, markdown, or html")
 	cmd.Flags().IntVar(&topN, "top-n", 20, "Number of top alerts to show")
 	cmd.Flags().StringVar(&timeout, "timeout", "30s", "Request timeout")
 	cmd.Flags().BoolVar(&insecure, "insecure", false, "Skip TLS verification")
@@ -125,9 +198,24 @@ time range, and performs frequency analysis to identify the most problematic ale
 	cmd.Flags().BoolVar(&showCorrelation, "show-correlation", false, "Include alert correlation analysis")
 	cmd.Flags().BoolVar(&showTemporalPatterns, "show-temporal-patterns", false, "Include time-of-day and day-of-week alert patterns")
 	cmd.Flags().BoolVar(&showRecommendations, "show-recommendations", false, "Include actionable recommendations based on alert patterns")
+	cmd.Flags().BoolVar(&showIncidents, "show-incidents", false, "Cluster alert storms into incidents and suggest Alertmanager group_by/inhibit rules")
+	cmd.Flags().DurationVar(&incidentWindow, "incident-window", analyzer.DefaultIncidentWindow, "Maximum gap between firings that belong to the same incident")
+	cmd.Flags().BoolVar(&showOnCallBurden, "show-oncall-burden", false, "Include on-call burden per alert and team (top sleep disruptors)")
+	cmd.Flags().StringVar(&alertmanagerConfig, "alertmanager-config", "", "Alertmanager config file used to route alerts to teams (default: fetched from --alertmanager-url)")
+	cmd.Flags().StringVar(&oncallTimezone, "oncall-timezone", "", "IANA timezone for business/night hours in burden analysis (default: local time)")
+	cmd.Flags().StringVar(&emitPatches, "emit-patches", "", "Directory to write proposed Prometheus rule and Alertmanager inhibit rule patches to")
+	cmd.Flags().StringVar(&compareTo, "compare-to", "", "Compare with the window of the same length ending this long ago (e.g., 7d, 24h)")
+	cmd.Flags().StringSliceVar(&groupBy, "group-by", nil, "Split frequency and flapping results by these labels (e.g., namespace,service,instance)")
+	cmd.Flags().BoolVar(&showLabelAnalysis, "show-label-analysis", false, "Include label skew (dominating label values) and high-cardinality label analysis")
+	cmd.Flags().IntVar(&cardinalityThreshold, "cardinality-threshold", analyzer.DefaultCardinalityThreshold, "Distinct values of a label on one alert at which it is flagged as high cardinality")
+	cmd.Flags().BoolVar(&showAnomalies, "show-anomalies", false, "Flag hours whose alert volume deviates from the same hour of the week (needs a lookback of at least 21d)")
+	cmd.Flags().Float64Var(&anomalyThreshold, "anomaly-threshold", analyzer.DefaultAnomalyThreshold, "Robust z-score (median/MAD) above which an hour's alert volume is anomalous")
+	cmd.Flags().StringVar(&excludeWindows, "exclude-windows", "", "YAML file with recurring (cron) and absolute maintenance windows whose alert activity is left out of the analysis")
+	cmd.Flags().BoolVar(&excludeSilences, "exclude-silences", false, "Also exclude alert activity matched by Alertmanager silences (requires --alertmanager-url)")
 	cmd.Flags().Float64Var(&flappingThreshold, "flapping-threshold", 3.0, "Flapping threshold (transitions per hour)")
 
-	cmd.MarkFlagRequired("prometheus-url")
+	cmd.MarkFlagsOneRequired("prometheus-url", "input")
+	cmd.MarkFlagsMutuallyExclusive("prometheus-url", "input")
 
 	return cmd
 }
@@ -135,6 +223,7 @@ time range, and performs frequency analysis to identify the most problematic ale
 func newMonitorCmd() *cobra.Command {
 	var (
 		prometheusURLs       []string
+		sourcesFile          string
 		alertmanagerURL      string
 		lookback             string
 		resolution           string
@@ -146,9 +235,15 @@ func newMonitorCmd() *cobra.Command {
 		showTemporalPatterns bool
 		showRecommendations  bool
 		flappingThreshold    float64
+		compareTo            string
+		showAnomalies        bool
+		anomalyThreshold     float64
+		excludeWindows       string
+		excludeSilences      bool
 		interval             string
 		metricsAddress       string
 		metricsPath          string
+		remoteWrite          remoteWriteOptions
 	)
 
 	cmd := &cobra.Command{
@@ -164,10 +259,22 @@ for Prometheus and Grafana dashboards.`,
     --show-temporal-patterns \
     --show-recommendations \
     --interval 1m \
-    --metrics-address :8080`,
+    --metrics-address :8080
+
+  # Also export week-over-week delta gauges
+  alert-analyzer monitor --prometheus-url http://prom:9090 --compare-to 7d
+
+  # Export the alert volume anomaly score against a four-week baseline
+  alert-analyzer monitor --prometheus-url http://prom:9090 --lookback 28d --show-anomalies
+
+  # Push the metrics to a remote-write endpoint after every cycle
+  alert-analyzer monitor --prometheus-url http://prom:9090 \
+    --remote-write-url http://mimir:9009/api/v1/push \
+    --remote-write-header X-Scope-OrgID=sre --remote-write-label instance=alert-analyzer`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runMonitor(analysisOptions{
 				prometheusURLs:       prometheusURLs,
+				sourcesFile:          sourcesFile,
 				alertmanagerURL:      alertmanagerURL,
 				lookbackStr:          lookback,
 				resolutionStr:        resolution,
@@ -179,11 +286,17 @@ for Prometheus and Grafana dashboards.`,
 				showTemporalPatterns: showTemporalPatterns,
 				showRecommendations:  showRecommendations,
 				flappingThreshold:    flappingThreshold,
-			}, interval, metricsAddress, metricsPath)
+				compareToStr:         compareTo,
+				showAnomalies:        showAnomalies,
+				anomalyThreshold:     anomalyThreshold,
+				excludeWindowsFile:   excludeWindows,
+				excludeSilences:      excludeSilences,
+			}, interval, metricsAddress, metricsPath, remoteWrite)
 		},
 	}
 
-	cmd.Flags().StringSliceVar(&prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url (required)")
+	cmd.Flags().StringSliceVar(&prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url, or source names from --prometheus-sources (required)")
+	cmd.Flags().StringVar(&sourcesFile, "prometheus-sources", "", "YAML file with per-source auth, tenant and TLS settings")
 	cmd.Flags().StringVar(&alertmanagerURL, "alertmanager-url", "", "Alertmanager server URL (optional)")
 	cmd.Flags().StringVar(&lookback, "lookback", "7d", "Time range to analyze (e.g., 7d, 24h, 30d)")
 	cmd.Flags().StringVar(&resolution, "resolution", "5m", "Query resolution (e.g., 1m, 5m, 15m)")
@@ -195,9 +308,19 @@ for Prometheus and Grafana dashboards.`,
 	cmd.Flags().BoolVar(&showTemporalPatterns, "show-temporal-patterns", true, "Include time-of-day and day-of-week alert patterns")
 	cmd.Flags().BoolVar(&showRecommendations, "show-recommendations", true, "Include actionable recommendations based on alert patterns")
 	cmd.Flags().Float64Var(&flappingThreshold, "flapping-threshold", 3.0, "Flapping threshold (transitions per hour)")
+	cmd.Flags().StringVar(&compareTo, "compare-to", "", "Export delta gauges against the window of the same length ending this long ago (e.g., 7d)")
+	cmd.Flags().BoolVar(&showAnomalies, "show-anomalies", false, "Export anomaly gauges comparing alert volume with the same hour of the week (needs a lookback of at least 21d)")
+	cmd.Flags().Float64Var(&anomalyThreshold, "anomaly-threshold", analyzer.DefaultAnomalyThreshold, "Robust z-score (median/MAD) above which an hour's alert volume is anomalous")
+	cmd.Flags().StringVar(&excludeWindows, "exclude-windows", "", "YAML file with maintenance windows, re-read every cycle, whose alert activity is left out of the analysis")
+	cmd.Flags().BoolVar(&excludeSilences, "exclude-silences", false, "Also exclude alert activity matched by Alertmanager silences (requires --alertmanager-url)")
 	cmd.Flags().StringVar(&interval, "interval", "1m", "Analysis refresh interval")
 	cmd.Flags().StringVar(&metricsAddress, "metrics-address", ":8080", "Metrics listen address")
 	cmd.Flags().StringVar(&metricsPath, "metrics-path", "/metrics", "Metrics HTTP path")
+	cmd.Flags().StringVar(&remoteWrite.url, "remote-write-url", "", "Also push the metrics to this Prometheus remote-write endpoint after every cycle")
+	cmd.Flags().DurationVar(&remoteWrite.timeout, "remote-write-timeout", 30*time.Second, "Timeout for each remote-write request")
+	cmd.Flags().StringVar(&remoteWrite.bearerTokenFile, "remote-write-bearer-token-file", "", "File with the bearer token for remote write, re-read on every push")
+	cmd.Flags().StringToStringVar(&remoteWrite.headers, "remote-write-header", nil, "Extra HTTP header for remote write as name=value (repeatable)")
+	cmd.Flags().StringToStringVar(&remoteWrite.labels, "remote-write-label", nil, "Label added to every remote-written series as name=value (repeatable)")
 	cmd.MarkFlagRequired("prometheus-url")
 
 	return cmd
@@ -265,6 +388,12 @@ func runAnalyze(opts analysisOptions) error {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
 
+	if opts.emitPatches != "" {
+		if err := writePatches(result, opts.emitPatches, logger); err != nil {
+			return err
+		}
+	}
+
 	if cfg.Metrics.Enabled {
 		recordAnalysisMetrics(result, "analyze")
 	}
@@ -273,18 +402,24 @@ func runAnalyze(opts analysisOptions) error {
 	return nil
 }
 
-func runMonitor(opts analysisOptions, intervalStr, metricsAddress, metricsPath string) error {
+func runMonitor(opts analysisOptions, intervalStr, metricsAddress, metricsPath string, remoteWrite remoteWriteOptions) error {
 	interval, err := time.ParseDuration(intervalStr)
 	if err != nil {
 		return fmt.Errorf("invalid interval duration: %w", err)
 	}
 
+	writer, err := remoteWrite.newWriter(opts.insecure)
+	if err != nil {
+		return err
+	}
+
 	cfg := config.Default()
 	logging.Init(cfg.Logging)
 	logger := logging.GetLogger()
 	logger.Info().
 		Str("metrics_address", metricsAddress).
 		Str("metrics_path", metricsPath).
+		Str("remote_write_url", remoteWrite.url).
 		Dur("interval", interval).
 		Msg("Starting alert-analyzer monitor")
 
@@ -314,6 +449,12 @@ func runMonitor(opts analysisOptions, intervalStr, metricsAddress, metricsPath s
 		} else {
 			recordAnalysisMetrics(result, "monitor")
 			metrics.CommandDuration.WithLabelValues("monitor").Observe(time.Since(start).Seconds())
+			if writer != nil {
+				if err := writer.Write(ctx, time.Now()); err != nil {
+					logger.Error().Err(err).Msg("Remote write failed")
+					metrics.Errors.WithLabelValues("monitor", "remote_write").Inc()
+				}
+			}
 			logger.Info().
 				Int("alerts", result.history.CountAlerts()).
 				Int("recommendations", len(result.recommendations)).
@@ -2,9 +2,14 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,7 +59,239 @@ func TestNewAnalyzeCmd_RequiresPrometheusURL(t *testing.T) {
 
 	err := cmd.Execute()
 	require.Error(t, err)
-	assert.ErrorContains(t, err, `required flag(s) "prometheus-url" not set`)
+	assert.ErrorContains(t, err, "at least one of the flags in the group [prometheus-url input] is required")
+}
+
+func TestNewAnalyzeCmd_InputExcludesPrometheusURL(t *testing.T) {
+	cmd := newAnalyzeCmd()
+	cmd.SetArgs([]string{"--input", "history.json", "--prometheus-url", "http://prometheus:9090"})
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "none of the others can be")
+}
+
+func TestRunAnalyzeFromInputFile(t *testing.T) {
+	start := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+	resolved := start.Add(5 * time.Minute)
+	path := filepath.Join(t.TempDir(), "history.ndjson")
+	content := `{"history":{"start_time":"2026-03-20T10:00:00Z","end_time":"2026-03-20T12:00:00Z","source":"prometheus"}}
+{"alert":{"name":"HighCPU","labels":{"severity":"critical"},"state":"inactive","fired_at":"` + start.Format(time.RFC3339) + `","resolved_at":"` + resolved.Format(time.RFC3339) + `"}}
+{"rule":{"name":"DiskFull","labels":{"severity":"warning"}}}
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	history, rules, err := collectAnalysisData(analysisOptions{inputFile: path}, zerolog.Nop())
+	require.NoError(t, err)
+	require.Len(t, history.Alerts, 1)
+	require.Len(t, rules, 1)
+
+	result, err := analyzeHistory(history, rules, analysisOptions{
+		topN:                20,
+		showFlapping:        true,
+		showRecommendations: true,
+		flappingThreshold:   3.0,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.stats.TotalFirings)
+	assert.Equal(t, "HighCPU", result.topAlerts[0].AlertName)
+	assert.Equal(t, 5*time.Minute, result.topAlerts[0].TotalTime)
+
+	var deadRule bool
+	for _, rec := range result.recommendations {
+		if rec.Target == "DiskFull" {
+			deadRule = true
+		}
+	}
+	assert.True(t, deadRule, "rules loaded from file should feed dead-rule recommendations")
+}
+
+func TestAnalyzeHistoryEmitPatches(t *testing.T) {
+	start := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+	history := &collector.AlertHistory{StartTime: start, EndTime: start.Add(24 * time.Hour)}
+	for i := 0; i < 10; i++ {
+		firedAt := start.Add(time.Duration(i) * time.Hour)
+		resolvedAt := firedAt.Add(2 * time.Minute)
+		history.Alerts = append(history.Alerts, collector.Alert{
+			Name:       "NoisyLatency",
+			Labels:     map[string]string{"severity": "warning"},
+			FiredAt:    firedAt,
+			ResolvedAt: &resolvedAt,
+		})
+	}
+	rules := []collector.AlertRule{{Name: "NoisyLatency", Group: "api", File: "api.yml", Query: "latency > 1", Duration: time.Minute}}
+
+	dir := filepath.Join(t.TempDir(), "patches")
+	result, err := analyzeHistory(history, rules, analysisOptions{
+		topN:              20,
+		emitPatches:       dir,
+		flappingThreshold: 3.0,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	assert.Empty(t, result.recommendations, "recommendations are only reported with --show-recommendations")
+	require.NotNil(t, result.patches)
+	require.Len(t, result.patches.Rules, 1)
+	// Every firing lasted 2m, so `for:` must rise above that to suppress them.
+	assert.Equal(t, 4*time.Minute, result.patches.Rules[0].For)
+	require.NotEmpty(t, result.patches.Rules[0].Reasons)
+	assert.Contains(t, result.patches.Rules[0].Reasons[0], "10 of 10 resolved firings would not have paged")
+
+	require.NoError(t, writePatches(result, dir, zerolog.Nop()))
+	data, err := os.ReadFile(filepath.Join(dir, "api.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "for: 4m")
+}
+
+func TestPerformAnalysisCompareToFromInputFile(t *testing.T) {
+	end := time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC)
+	history := collector.HistoryFile{AlertHistory: collector.AlertHistory{
+		StartTime: end.Add(-14 * 24 * time.Hour),
+		EndTime:   end,
+		Source:    "prometheus",
+	}}
+	addFirings := func(name string, weekEnd time.Time, count int) {
+		for i := 0; i < count; i++ {
+			firedAt := weekEnd.Add(-time.Duration(i+1) * time.Hour)
+			resolvedAt := firedAt.Add(5 * time.Minute)
+			history.Alerts = append(history.Alerts, collector.Alert{
+				Name:       name,
+				Labels:     map[string]string{"severity": "warning"},
+				State:      "inactive",
+				FiredAt:    firedAt,
+				ResolvedAt: &resolvedAt,
+			})
+		}
+	}
+	previousEnd := end.Add(-7 * 24 * time.Hour)
+	addFirings("HighCPU", end, 6)
+	addFirings("HighCPU", previousEnd, 2)
+	addFirings("NewAlert", end, 1)
+	addFirings("OldAlert", previousEnd, 3)
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	data, err := json.Marshal(history)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	result, err := performAnalysis(analysisOptions{
+		inputFile:         path,
+		lookbackStr:       "7d",
+		compareToStr:      "7d",
+		topN:              1,
+		flappingThreshold: 3.0,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, result.stats.TotalFirings, "only the current window is analyzed")
+	require.NotNil(t, result.trends)
+	assert.Equal(t, previousEnd, result.trends.PreviousEnd)
+	assert.Equal(t, []string{"NewAlert"}, result.trends.New)
+	assert.Equal(t, []string{"OldAlert"}, result.trends.Disappeared)
+	require.Len(t, result.topTrends, 1)
+	assert.Equal(t, "HighCPU", result.topTrends[0].AlertName)
+	assert.Equal(t, 4, result.topTrends[0].FiringDelta)
+	assert.Len(t, result.trends.Alerts, 3, "the report keeps every compared alert")
+}
+
+func TestAnalyzeHistoryGroupByAndLabels(t *testing.T) {
+	start := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+	history := &collector.AlertHistory{StartTime: start, EndTime: start.Add(24 * time.Hour)}
+	for i := 0; i < 10; i++ {
+		namespace := "checkout"
+		if i == 0 {
+			namespace = "search"
+		}
+		firedAt := start.Add(time.Duration(i) * time.Hour)
+		resolvedAt := firedAt.Add(5 * time.Minute)
+		history.Alerts = append(history.Alerts, collector.Alert{
+			Name:       "HighLatency",
+			Labels:     map[string]string{"severity": "warning", "namespace": namespace},
+			State:      "firing",
+			FiredAt:    firedAt,
+			ResolvedAt: &resolvedAt,
+		})
+	}
+	rules := []collector.AlertRule{{Name: "HighLatency"}}
+
+	result, err := analyzeHistory(history, rules, analysisOptions{
+		topN:                 20,
+		groupBy:              []string{"namespace"},
+		showFlapping:         true,
+		showLabelAnalysis:    true,
+		showRecommendations:  true,
+		cardinalityThreshold: 20,
+		flappingThreshold:    3.0,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+
+	require.Len(t, result.topAlerts, 2)
+	assert.Equal(t, "HighLatency {namespace=checkout}", result.topAlerts[0].AlertName)
+	assert.Equal(t, 9, result.topAlerts[0].FiringCount)
+	require.Len(t, result.flapping, 2)
+	assert.Equal(t, 1, result.stats.UniqueAlerts, "summary statistics stay per alert")
+
+	require.NotNil(t, result.labels)
+	require.Len(t, result.labels.Skew, 1)
+	assert.Equal(t, "checkout", result.labels.Skew[0].Value)
+
+	for _, rec := range result.recommendations {
+		assert.NotEqual(t, analyzer.RecommendationCategoryDeadRule, rec.Category, "grouping must not hide the rule's firings")
+	}
+	assert.Nil(t, result.heatmap, "chart data is only collected for HTML output")
+}
+
+func TestAnalyzeHistoryHTMLChartData(t *testing.T) {
+	start := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC) // Monday
+	resolvedAt := start.Add(5 * time.Minute)
+	history := &collector.AlertHistory{
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Alerts: []collector.Alert{
+			{Name: "HighCPU", Labels: map[string]string{"severity": "critical"}, State: "firing", FiredAt: start, ResolvedAt: &resolvedAt},
+			{Name: "HighCPU", Labels: map[string]string{"severity": "critical"}, State: "firing", FiredAt: start.Add(30 * time.Minute)},
+		},
+	}
+
+	result, err := analyzeHistory(history, nil, analysisOptions{
+		topN:              20,
+		outputFormat:      "html",
+		flappingThreshold: 3.0,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+
+	require.Len(t, result.heatmap, 7)
+	assert.Equal(t, 2, result.heatmap[int(time.Monday)][10])
+	require.Len(t, result.timelines, 1)
+	assert.Equal(t, "HighCPU", result.timelines[0].AlertName)
+	require.Len(t, result.timelines[0].Intervals, 2)
+	assert.Equal(t, history.EndTime, result.timelines[0].Intervals[1].End)
+}
+
+func TestParseCompareTo(t *testing.T) {
+	compareTo, err := parseCompareTo("")
+	require.NoError(t, err)
+	assert.Zero(t, compareTo)
+
+	compareTo, err = parseCompareTo("7d")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, compareTo)
+
+	_, err = parseCompareTo("0s")
+	assert.ErrorContains(t, err, "must be positive")
+
+	_, err = parseCompareTo("later")
+	assert.ErrorContains(t, err, "invalid compare-to duration")
+}
+
+func TestRunAnalyzeFromMissingInputFile(t *testing.T) {
+	err := runAnalyze(analysisOptions{
+		inputFile:    filepath.Join(t.TempDir(), "missing.json"),
+		outputFormat: "table",
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "failed to open history file")
 }
 
 func TestNewVersionCmd(t *testing.T) {
@@ -146,3 +383,16 @@ func TestCollectAlertmanagerData_InvalidURL(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "failed to create Alertmanager client")
 }
+
+func TestParseLookback(t *testing.T) {
+	d, err := parseLookback("7d")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, d)
+
+	d, err = parseLookback("36h")
+	require.NoError(t, err)
+	assert.Equal(t, 36*time.Hour, d)
+
+	_, err = parseLookback("soon")
+	assert.Error(t, err)
+}
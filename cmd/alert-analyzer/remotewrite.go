@@ -0,0 +1,32 @@
+package main
+
+import (
+	"time"
+
+	"github.com/neogan/sre-toolkit/pkg/metrics"
+)
+
+// remoteWriteOptions holds the monitor --remote-write-* flags.
+type remoteWriteOptions struct {
+	url             string
+	timeout         time.Duration
+	bearerTokenFile string
+	headers         map[string]string
+	labels          map[string]string
+}
+
+// newWriter returns nil when remote write is not configured.
+func (o remoteWriteOptions) newWriter(insecure bool) (*metrics.RemoteWriter, error) {
+	if o.url == "" {
+		return nil, nil
+	}
+	return metrics.NewRemoteWriter(&metrics.RemoteWriteConfig{
+		URL:             o.url,
+		Timeout:         o.timeout,
+		BearerTokenFile: o.bearerTokenFile,
+		Headers:         o.headers,
+		ExternalLabels:  o.labels,
+		MetricPrefix:    metrics.DefaultRemoteWritePrefix,
+		Insecure:        insecure,
+	}, nil)
+}
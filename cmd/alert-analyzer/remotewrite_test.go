@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteWriteOptions_NewWriter(t *testing.T) {
+	writer, err := remoteWriteOptions{}.newWriter(false)
+	require.NoError(t, err)
+	assert.Nil(t, writer, "remote write is disabled without a URL")
+
+	writer, err = remoteWriteOptions{url: "http://mimir:9009/api/v1/push"}.newWriter(false)
+	require.NoError(t, err)
+	assert.NotNil(t, writer)
+
+	_, err = remoteWriteOptions{url: "mimir"}.newWriter(false)
+	assert.ErrorContains(t, err, "invalid remote write URL")
+}
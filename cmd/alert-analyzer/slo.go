@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/backtest"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/reporter"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/slo"
+	"github.com/neogan/sre-toolkit/pkg/config"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+	"github.com/neogan/sre-toolkit/pkg/prometheus"
+)
+
+type sloAuditOptions struct {
+	analysis  analysisOptions
+	sloFile   string
+	estimate  bool
+	emitRules string
+}
+
+func newSLOAuditCmd() *cobra.Command {
+	opts := sloAuditOptions{analysis: analysisOptions{includeRules: true}}
+
+	cmd := &cobra.Command{
+		Use:   "slo-audit",
+		Short: "Check SLOs for multi-window, multi-burn-rate alert coverage",
+		Long: `Load SLO definitions from YAML and check whether the collected alerting rules
+include multi-window, multi-burn-rate alerts for each of them. With Prometheus
+access, the recommended burn-rate alerts are replayed over the lookback window and
+compared with the firings of the existing symptom and cause alerts. Rule groups
+can be generated for SLOs that have no burn-rate alerting at all.`,
+		Example: `  # Audit SLO alerting coverage and estimate burn-rate alert firings
+  alert-analyzer slo-audit --prometheus-url http://prom:9090 --slo-file slos.yaml --lookback 30d
+
+  # Generate rule groups for uncovered SLOs
+  alert-analyzer slo-audit --prometheus-url http://prom:9090 --slo-file slos.yaml --emit-rules ./slo-rules
+
+  # Audit offline against an exported history file (no estimates)
+  alert-analyzer slo-audit --input history.json --slo-file slos.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSLOAudit(opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.analysis.inputFile, "input", "", "Read alert history and rules from a file written by 'alert-analyzer export'")
+	cmd.Flags().StringSliceVar(&opts.analysis.prometheusURLs, "prometheus-url", nil, "Prometheus server URL(s) in format [cluster=]url, or source names from --prometheus-sources")
+	cmd.Flags().StringVar(&opts.analysis.sourcesFile, "prometheus-sources", "", "YAML file with per-source auth, tenant and TLS settings")
+	cmd.Flags().StringVar(&opts.sloFile, "slo-file", "", "YAML file with SLO definitions (required)")
+	cmd.Flags().StringVar(&opts.analysis.lookbackStr, "lookback", "7d", "Time range to analyze (e.g., 7d, 24h, 30d)")
+	cmd.Flags().StringVar(&opts.analysis.excludeWindowsFile, "exclude-windows", "", "YAML file with maintenance windows whose alert activity is left out of the analysis")
+	cmd.Flags().StringVar(&opts.analysis.resolutionStr, "resolution", "5m", "Query resolution for history and burn-rate estimates")
+	cmd.Flags().StringVar(&opts.analysis.timeoutStr, "timeout", "2m", "Request timeout")
+	cmd.Flags().BoolVar(&opts.analysis.insecure, "insecure", false, "Skip TLS verification")
+	cmd.Flags().BoolVar(&opts.estimate, "estimate", true, "Replay the recommended burn-rate alerts over the lookback window (requires --prometheus-url)")
+	cmd.Flags().StringVar(&opts.emitRules, "emit-rules", "", "Directory to write recommended burn-rate rule groups for SLOs without coverage")
+	cmd.Flags().StringVarP(&opts.analysis.outputFormat, "output", "o", "table", "Output format: table, json, or markdown")
+
+	cmd.MarkFlagRequired("slo-file")
+	cmd.MarkFlagsOneRequired("prometheus-url", "input")
+	cmd.MarkFlagsMutuallyExclusive("prometheus-url", "input")
+
+	return cmd
+}
+
+func runSLOAudit(opts sloAuditOptions, stdout io.Writer) error {
+	format := opts.analysis.outputFormat
+	if format != reporter.FormatTable && format != reporter.FormatJSON && format != reporter.FormatMarkdown {
+		return fmt.Errorf("invalid output format: %s (must be table, json, or markdown)", format)
+	}
+
+	cfg := config.Default()
+	logging.Init(cfg.Logging)
+	logger := logging.GetLogger()
+
+	slos, err := slo.LoadFile(opts.sloFile)
+	if err != nil {
+		return err
+	}
+
+	exclusions, err := loadExclusions(opts.analysis, logger)
+	if err != nil {
+		return err
+	}
+
+	history, rules, err := collectAnalysisData(opts.analysis, logger)
+	if err != nil {
+		return err
+	}
+	applyExclusions(exclusions, history, logger)
+	if len(rules) == 0 {
+		logger.Warn().Msg("No alerting rules collected; every SLO will be reported as missing coverage")
+	}
+
+	results := slo.NewAuditor(rules, history).Audit(slos)
+	if opts.estimate && len(opts.analysis.prometheusURLs) > 0 {
+		if err := estimateBurnRateFirings(opts.analysis, slos, results, history, logger); err != nil {
+			return err
+		}
+	}
+
+	if err := reporter.NewReporter(format, stdout).ReportSLOCoverage(results); err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+
+	if opts.emitRules != "" {
+		return writeSLORules(opts.emitRules, slos, results, logger)
+	}
+	return nil
+}
+
+// estimateBurnRateFirings replays the recommended alerts of every SLO against the
+// first Prometheus source. SLOs whose SLI cannot be evaluated are skipped.
+func estimateBurnRateFirings(opts analysisOptions, slos []slo.SLO, results []slo.Coverage, history *collector.AlertHistory, logger zerolog.Logger) error {
+	step, err := time.ParseDuration(opts.resolutionStr)
+	if err != nil {
+		return fmt.Errorf("invalid resolution duration: %w", err)
+	}
+	timeout, err := time.ParseDuration(opts.timeoutStr)
+	if err != nil {
+		return fmt.Errorf("invalid timeout duration: %w", err)
+	}
+
+	sources, err := resolvePrometheusSources(opts.prometheusURLs[:1], opts.sourcesFile, timeout, opts.insecure)
+	if err != nil {
+		return err
+	}
+	if len(opts.prometheusURLs) > 1 {
+		logger.Info().Str("cluster", sources[0].cluster).Msg("Estimating burn-rate alerts against the first Prometheus source only")
+	}
+	promClient, err := prometheus.NewClient(sources[0].config, &logger)
+	if err != nil {
+		return fmt.Errorf("failed to create Prometheus client: %w", err)
+	}
+
+	backtester := backtest.NewBacktester(promClient, &logger)
+	start, end := history.StartTime, history.EndTime
+	for i, s := range slos {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		estimates, err := slo.EstimateFirings(ctx, backtester, s, start, end, step)
+		cancel()
+		if err != nil {
+			logger.Warn().Err(err).Str("slo", s.Name).Msg("Failed to estimate burn-rate alert firings")
+			continue
+		}
+		results[i].Estimates = estimates
+	}
+	return nil
+}
+
+// writeSLORules writes the recommended rule groups for SLOs without any burn-rate alerting.
+func writeSLORules(dir string, slos []slo.SLO, results []slo.Coverage, logger zerolog.Logger) error {
+	missing := make([]slo.SLO, 0)
+	for i, result := range results {
+		if result.Status == slo.StatusMissing {
+			missing = append(missing, slos[i])
+		}
+	}
+	if len(missing) == 0 {
+		logger.Info().Msg("Every SLO has burn-rate alerting; no rule groups generated")
+		return nil
+	}
+
+	paths, err := slo.WriteRuleGroups(dir, missing)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		logger.Info().Str("file", path).Msg("Burn-rate rule group written")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+const sloAuditTestFile = `
+slos:
+  - name: checkout-availability
+    objective: 99.9
+    sli:
+      good: sum(rate(http_requests_total{job="checkout", code!~"5.."}[{{.window}}]))
+      total: sum(rate(http_requests_total{job="checkout"}[{{.window}}]))
+  - name: search-latency
+    objective: 99
+    sli:
+      good: sum(rate(search_duration_seconds_bucket{le="0.5"}[{{.window}}]))
+      total: sum(rate(search_duration_seconds_count[{{.window}}]))
+`
+
+func TestNewSLOAuditCmd_RequiresSLOFile(t *testing.T) {
+	cmd := newSLOAuditCmd()
+	cmd.SetArgs([]string{"--input", "history.json"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `required flag(s) "slo-file" not set`)
+}
+
+func TestRunSLOAuditFromInputFile(t *testing.T) {
+	dir := t.TempDir()
+	sloPath := filepath.Join(dir, "slos.yaml")
+	require.NoError(t, os.WriteFile(sloPath, []byte(sloAuditTestFile), 0o600))
+
+	start := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	resolved := start.Add(10 * time.Minute)
+	history := collector.HistoryFile{
+		AlertHistory: collector.AlertHistory{
+			StartTime: start,
+			EndTime:   start.Add(7 * 24 * time.Hour),
+			Alerts: []collector.Alert{
+				{Name: "CheckoutHighErrorRate", Labels: map[string]string{"severity": "critical"}, FiredAt: start, ResolvedAt: &resolved},
+			},
+		},
+		Rules: []collector.AlertRule{
+			{Name: "CheckoutHighErrorRate", Query: `sum(rate(http_requests_total{job="checkout",code=~"5.."}[5m])) > 1`},
+			{Name: "SearchBudgetBurn", Labels: map[string]string{"slo": "search-latency"}, Query: `burnrate1h > 14.4 and burnrate5m > 14.4`},
+		},
+	}
+	historyPath := filepath.Join(dir, "history.json")
+	data, err := json.Marshal(history)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(historyPath, data, 0o600))
+
+	rulesDir := filepath.Join(dir, "rules")
+	var out bytes.Buffer
+	require.NoError(t, runSLOAudit(sloAuditOptions{
+		analysis: analysisOptions{
+			inputFile:    historyPath,
+			outputFormat: "markdown",
+		},
+		sloFile:   sloPath,
+		estimate:  true,
+		emitRules: rulesDir,
+	}, &out))
+
+	output := out.String()
+	assert.Contains(t, output, "| checkout-availability | 99.9% / 30d | ❌ missing |")
+	assert.Contains(t, output, "| search-latency | 99% / 30d | ⚠️ partial | SearchBudgetBurn |")
+	assert.Contains(t, output, "| checkout-availability | CheckoutHighErrorRate | sli_metric | 1 | 10m 0s |")
+
+	entries, err := os.ReadDir(rulesDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "rules are only generated for SLOs without coverage")
+	assert.Equal(t, "slo-checkout-availability.yaml", entries[0].Name())
+}
+
+func TestRunSLOAuditInvalidFormat(t *testing.T) {
+	err := runSLOAudit(sloAuditOptions{analysis: analysisOptions{outputFormat: "html"}}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid output format")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neogan/sre-toolkit/pkg/prometheus"
+)
+
+// prometheusSource is a resolved --prometheus-url entry.
+type prometheusSource struct {
+	cluster string
+	config  *prometheus.Config
+}
+
+// resolvePrometheusSources builds the client configuration of every --prometheus-url
+// entry. An entry that is the name of a source from the sources file uses that
+// source; a cluster=url entry whose cluster names a source uses its settings
+// with the given URL. Other entries are plain URLs.
+func resolvePrometheusSources(entries []string, sourcesFile string, timeout time.Duration, insecure bool) ([]prometheusSource, error) {
+	sources := make(map[string]prometheus.Source)
+	if sourcesFile != "" {
+		loaded, err := prometheus.LoadSources(sourcesFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range loaded {
+			sources[source.Name] = source
+		}
+	}
+
+	defaults := prometheus.Config{Timeout: timeout, Insecure: insecure}
+	resolved := make([]prometheusSource, 0, len(entries))
+	for _, entry := range entries {
+		if source, ok := sources[entry]; ok && !strings.Contains(entry, "=") {
+			cfg, err := source.Config(defaults)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, prometheusSource{cluster: source.Name, config: cfg})
+			continue
+		}
+
+		clusterName, promURL := parsePrometheusURL(entry)
+		source, ok := sources[clusterName]
+		if !ok {
+			if sourcesFile != "" && !strings.Contains(promURL, "://") {
+				return nil, fmt.Errorf("unknown Prometheus source %q (not defined in %s)", entry, sourcesFile)
+			}
+			resolved = append(resolved, prometheusSource{
+				cluster: clusterName,
+				config:  &prometheus.Config{URL: promURL, Timeout: timeout, Insecure: insecure},
+			})
+			continue
+		}
+
+		source.URL = promURL
+		cfg, err := source.Config(defaults)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, prometheusSource{cluster: clusterName, config: cfg})
+	}
+	return resolved, nil
+}
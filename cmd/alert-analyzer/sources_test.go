@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrometheusSources = `
+sources:
+  - name: mimir-prod
+    url: https://mimir.example.com
+    flavor: mimir
+    tenant_id: team-a
+    bearer_token_file: /var/run/secrets/token
+  - name: thanos
+    flavor: thanos
+    partial_response: false
+    timeout: 1m
+`
+
+func TestResolvePrometheusSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sources.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testPrometheusSources), 0o600))
+
+	sources, err := resolvePrometheusSources([]string{
+		"mimir-prod",
+		"thanos=https://thanos.example.com",
+		"dev=http://prom-dev:9090",
+	}, path, 30*time.Second, true)
+	require.NoError(t, err)
+	require.Len(t, sources, 3)
+
+	assert.Equal(t, "mimir-prod", sources[0].cluster)
+	assert.Equal(t, "https://mimir.example.com/prometheus", sources[0].config.URL)
+	assert.Equal(t, "team-a", sources[0].config.TenantID)
+	assert.Equal(t, 30*time.Second, sources[0].config.Timeout)
+	assert.True(t, sources[0].config.Insecure)
+
+	assert.Equal(t, "thanos", sources[1].cluster)
+	assert.Equal(t, "https://thanos.example.com", sources[1].config.URL, "cluster=url entries set the URL of a named source")
+	require.NotNil(t, sources[1].config.PartialResponse)
+	assert.Equal(t, time.Minute, sources[1].config.Timeout)
+
+	assert.Equal(t, "dev", sources[2].cluster)
+	assert.Equal(t, "http://prom-dev:9090", sources[2].config.URL)
+	assert.Empty(t, sources[2].config.TenantID)
+
+	t.Run("unknown source name", func(t *testing.T) {
+		_, err := resolvePrometheusSources([]string{"mimir-staging"}, path, time.Second, false)
+		assert.ErrorContains(t, err, `unknown Prometheus source "mimir-staging"`)
+	})
+
+	t.Run("source without url", func(t *testing.T) {
+		_, err := resolvePrometheusSources([]string{"thanos"}, path, time.Second, false)
+		assert.ErrorContains(t, err, "url is required")
+	})
+
+	t.Run("without sources file", func(t *testing.T) {
+		sources, err := resolvePrometheusSources([]string{"http://prom:9090"}, "", time.Second, false)
+		require.NoError(t, err)
+		assert.Equal(t, "prom:9090", sources[0].cluster)
+		assert.Equal(t, "http://prom:9090", sources[0].config.URL)
+	})
+}
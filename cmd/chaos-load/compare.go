@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/output"
+	"github.com/spf13/cobra"
+)
+
+func newCompareCmd() *cobra.Command {
+	var tolerance string
+
+	cmd := &cobra.Command{
+		Use:   "compare <base.json> <current.json>",
+		Short: "Compare two load test results",
+		Long: `Compares two results files written by --out json and highlights regressions:
+latencies that grew or a request rate that fell by more than --tolerance, and
+error or failed-check rates that grew by more than 0.1 percentage points.
+
+Exits non-zero when the current run regressed.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			limit, err := parseTolerance(tolerance)
+			if err != nil {
+				return err
+			}
+			base, err := output.ReadResults(args[0])
+			if err != nil {
+				return err
+			}
+			current, err := output.ReadResults(args[1])
+			if err != nil {
+				return err
+			}
+
+			comparisons := output.Compare(base, current, limit)
+			output.FprintComparisons(os.Stdout, base, current, comparisons)
+			if regressions := output.Regressions(comparisons); regressions > 0 {
+				return fmt.Errorf("%d of %d metrics regressed", regressions, len(comparisons))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tolerance, "tolerance", "10%", "Relative change in latency or request rate tolerated before it counts as a regression")
+
+	return cmd
+}
+
+// parseTolerance parses a percentage such as "10%" or a fraction such as 0.1.
+func parseTolerance(value string) (float64, error) {
+	percent, isPercent := strings.CutSuffix(value, "%")
+	tolerance, err := strconv.ParseFloat(percent, 64)
+	if err != nil || tolerance < 0 {
+		return 0, fmt.Errorf("invalid tolerance %q: expected a percentage such as 10%%", value)
+	}
+	if isPercent {
+		tolerance /= 100
+	}
+	return tolerance, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/experiment"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/http"
+	"github.com/neogan/sre-toolkit/pkg/k8s"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+func newExperimentCmd() *cobra.Command {
+	var (
+		kubeconfig string
+		dryRun     bool
+		uiEnabled  bool
+		outputs    []string
+		client     http.ClientConfig
+		headers    []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "experiment <experiment.yaml>",
+		Short: "Run load and inject Kubernetes faults on a schedule",
+		Long: `Runs the load profile of an experiment file and injects its faults (pod kills,
+network partitions, node drains) at scheduled offsets from the start of the load.
+
+Results are broken down into phases: the baseline before the first fault, the
+window of each fault and the recovery after it. The report shows error rate and
+latency per phase and how long the target took to recover from each fault.
+
+Steady-state probes (PromQL queries or HTTP health checks) run before, during
+and after the load. A probe breaching its hard limit aborts the experiment and
+rolls back its faults.
+
+Exits non-zero when a threshold fails, a fault cannot be injected, the target
+does not recover from a fault or the steady state is not met.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := logging.GetLogger()
+
+			exp, err := experiment.LoadFile(args[0])
+			if err != nil {
+				return err
+			}
+			logger.Info().Str("experiment", exp.Name).Int("faults", len(exp.Faults)).Msg("Starting experiment")
+
+			cfg, err := exp.PoolConfig()
+			if err != nil {
+				return err
+			}
+			cfg.UI = uiEnabled
+			cfg.Client = client
+			if cfg.Name == "" {
+				cfg.Name = strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+			}
+			if err := applyOutputFlags(&cfg.Config, outputs); err != nil {
+				return err
+			}
+			parsed, err := parseHeaders(headers)
+			if err != nil {
+				return err
+			}
+			// Headers from flags override those of the experiment file.
+			for name, value := range parsed {
+				if cfg.Headers == nil {
+					cfg.Headers = make(map[string]string)
+				}
+				cfg.Headers[name] = value
+			}
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+
+			k8sClient, err := k8s.NewClient(&k8s.Config{Kubeconfig: kubeconfig})
+			if err != nil {
+				return err
+			}
+			pool, err := http.NewPool(cfg)
+			if err != nil {
+				return err
+			}
+
+			// An interrupt stops the experiment and rolls back its faults; a
+			// second one exits at once.
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			context.AfterFunc(ctx, stop)
+			return experiment.New(exp, pool, k8sClient.Clientset(), dryRun).Run(ctx)
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Generate the load but only log the faults that would be injected")
+	cmd.Flags().BoolVar(&uiEnabled, "ui", false, "Enable real-time dashboard UI")
+	addOutputFlag(cmd, &outputs)
+	addClientFlags(cmd, &client, &headers)
+
+	return cmd
+}
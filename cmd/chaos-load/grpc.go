@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/grpc"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+func newGRPCCmd() *cobra.Command {
+	var (
+		cfg         grpc.PoolConfig
+		rateSpec    string
+		stagesSpec  string
+		thresholds  []string
+		abortOnFail bool
+		abortDelay  time.Duration
+		outputs     []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "grpc",
+		Short: "Run gRPC load test",
+		Long: `Generates load against a unary gRPC method. Request messages are written as
+protobuf JSON and encoded using descriptors from server reflection, or from
+the --proto files if given. Exits non-zero when a --threshold fails.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := logging.GetLogger()
+			logger.Info().Str("target", cfg.Target).Str("method", cfg.Method).Msg("Starting gRPC load test")
+
+			cfg.AbortOnFail = abortOnFail
+			cfg.AbortDelay = abortDelay
+			cfg.Name = cfg.Method
+			if err := applyRateFlags(&cfg.Config, rateSpec, stagesSpec); err != nil {
+				return err
+			}
+			if err := applyThresholdFlags(&cfg.Config, thresholds); err != nil {
+				return err
+			}
+			if err := applyOutputFlags(&cfg.Config, outputs); err != nil {
+				return err
+			}
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			pool, err := grpc.NewPool(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			return pool.RunContext(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.Target, "target", "", "Server address, host:port")
+	cmd.Flags().StringVar(&cfg.Method, "method", "", "Unary method to call, e.g. helloworld.Greeter/SayHello")
+	cmd.Flags().StringVar(&cfg.Data, "data", "", "Request message as protobuf JSON, e.g. '{\"name\": \"load\"}'")
+	cmd.Flags().StringToStringVar(&cfg.Metadata, "metadata", nil, "Metadata sent with every call, e.g. x-tenant=load-test")
+	cmd.Flags().StringSliceVar(&cfg.ProtoFiles, "proto", nil, "Proto files describing the service (default: use server reflection)")
+	cmd.Flags().StringSliceVar(&cfg.ImportPaths, "import-path", nil, "Directories searched for --proto files and their imports")
+	cmd.Flags().BoolVar(&cfg.Plaintext, "plaintext", false, "Connect without TLS")
+	cmd.Flags().BoolVar(&cfg.Insecure, "insecure", false, "Skip TLS certificate verification")
+	cmd.Flags().IntVar(&cfg.Connections, "connections", 1, "Number of connections the calls are spread over")
+	cmd.Flags().DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "Deadline of each call; 0 disables it")
+	cmd.Flags().IntVar(&cfg.Concurrency, "concurrency", 10, "Number of concurrent workers (maximum calls in flight with --rate or --stages)")
+	cmd.Flags().DurationVar(&cfg.Duration, "duration", 30*time.Second, "Duration of the test")
+	cmd.Flags().IntVar(&cfg.Requests, "requests", 0, "Total number of calls (0 for unlimited)")
+	cmd.Flags().BoolVar(&cfg.UI, "ui", false, "Enable real-time dashboard UI")
+	cmd.Flags().StringVar(&rateSpec, "rate", "", "Send calls at a constant rate for --duration, e.g. 500/s or 30/m")
+	cmd.Flags().StringVar(&stagesSpec, "stages", "", "Ramp the call rate through duration:rate stages, e.g. 30s:100,2m:500,30s:0")
+	addThresholdFlags(cmd, &thresholds, &abortOnFail, &abortDelay)
+	addOutputFlag(cmd, &outputs)
+
+	cmd.MarkFlagRequired("target")
+	cmd.MarkFlagRequired("method")
+
+	return cmd
+}
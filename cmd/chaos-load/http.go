@@ -2,9 +2,15 @@
 package main
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
 	"github.com/neogan/sre-toolkit/internal/chaos-load/http"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/output"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/rate"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
 	"github.com/neogan/sre-toolkit/pkg/logging"
 	"github.com/spf13/cobra"
 )
@@ -21,34 +27,76 @@ func newHTTPCmd() *cobra.Command {
 		duration      time.Duration
 		requests      int
 		uiEnabled     bool
+		rateSpec      string
+		stagesSpec    string
+		thresholds    []string
+		abortOnFail   bool
+		abortDelay    time.Duration
+		checks        check.Checks
+		checkJSONPath string
+		outputs       []string
+		client        http.ClientConfig
+		headers       []string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "http",
 		Short: "Run HTTP load test",
-		Long:  "Generates HTTP load against a target URL",
+		Long:  "Generates HTTP load against a target URL. Exits non-zero when a --threshold fails.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := logging.GetLogger()
 			logger.Info().Str("url", url).Msg("Starting HTTP load test")
 
 			// Initialize worker pool
 			cfg := http.PoolConfig{
+				Config: runner.Config{
+					Concurrency: concurrency,
+					Duration:    duration,
+					Requests:    requests,
+					UI:          uiEnabled,
+					AbortOnFail: abortOnFail,
+					AbortDelay:  abortDelay,
+					Name:        url,
+				},
 				TargetURL:     url,
 				Method:        method,
 				Body:          body,
 				BearerToken:   bearerToken,
 				BasicUsername: basicUsername,
 				BasicPassword: basicPassword,
-				Concurrency:   concurrency,
-				Duration:      duration,
-				Requests:      requests,
-				UI:            uiEnabled,
+				Client:        client,
+			}
+			if err := applyRateFlags(&cfg.Config, rateSpec, stagesSpec); err != nil {
+				return err
+			}
+			if err := applyThresholdFlags(&cfg.Config, thresholds); err != nil {
+				return err
+			}
+			if err := applyOutputFlags(&cfg.Config, outputs); err != nil {
+				return err
+			}
+			parsed, err := parseHeaders(headers)
+			if err != nil {
+				return err
+			}
+			cfg.Headers = parsed
+			if checkJSONPath != "" {
+				checks.ParseJSONPathCheck(checkJSONPath)
+			}
+			if !checks.Empty() {
+				if err := checks.Compile(); err != nil {
+					return err
+				}
+				cfg.Checks = &checks
 			}
 			if err := cfg.Validate(); err != nil {
 				return err
 			}
 
-			pool := http.NewPool(cfg)
+			pool, err := http.NewPool(cfg)
+			if err != nil {
+				return err
+			}
 
 			// Run load test
 			if err := pool.Run(); err != nil {
@@ -65,12 +113,105 @@ func newHTTPCmd() *cobra.Command {
 	cmd.Flags().StringVar(&bearerToken, "bearer-token", "", "Bearer token for Authorization header")
 	cmd.Flags().StringVar(&basicUsername, "basic-username", "", "Username for HTTP Basic authentication")
 	cmd.Flags().StringVar(&basicPassword, "basic-password", "", "Password for HTTP Basic authentication")
-	cmd.Flags().IntVar(&concurrency, "concurrency", 10, "Number of concurrent workers")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 10, "Number of concurrent workers (maximum requests in flight with --rate or --stages)")
 	cmd.Flags().DurationVar(&duration, "duration", 30*time.Second, "Duration of the test")
 	cmd.Flags().IntVar(&requests, "requests", 0, "Total number of requests (0 for unlimited)")
 	cmd.Flags().BoolVar(&uiEnabled, "ui", false, "Enable real-time dashboard UI")
+	cmd.Flags().StringVar(&rateSpec, "rate", "", "Send requests at a constant rate for --duration, e.g. 500/s or 30/m")
+	cmd.Flags().StringVar(&stagesSpec, "stages", "", "Ramp the request rate through duration:rate stages, e.g. 30s:100,2m:500,30s:0")
+	cmd.Flags().IntSliceVar(&checks.Status, "check-status", nil, "Accepted response status codes, e.g. 200,204")
+	cmd.Flags().StringVar(&checks.BodyContains, "check-body-contains", "", "Text every response body must contain")
+	cmd.Flags().StringVar(&checkJSONPath, "check-jsonpath", "", "JSONPath every response must match, optionally with a value, e.g. '$.status == ok'")
+	cmd.Flags().StringSliceVar(&checks.Headers, "check-header", nil, "Response headers that must be present")
+	cmd.Flags().DurationVar(&checks.MaxLatency, "check-latency", 0, "Slowest acceptable response, e.g. 500ms")
+	addThresholdFlags(cmd, &thresholds, &abortOnFail, &abortDelay)
+	addOutputFlag(cmd, &outputs)
+	addClientFlags(cmd, &client, &headers)
 
 	cmd.MarkFlagRequired("url")
 
 	return cmd
 }
+
+// applyRateFlags parses the --rate and --stages flags into the pool configuration.
+func applyRateFlags(cfg *runner.Config, rateSpec, stagesSpec string) error {
+	if rateSpec != "" {
+		r, err := rate.Parse(rateSpec)
+		if err != nil {
+			return err
+		}
+		cfg.Rate = r
+	}
+	if stagesSpec != "" {
+		stages, err := rate.ParseStages(stagesSpec)
+		if err != nil {
+			return err
+		}
+		cfg.Stages = stages
+	}
+	return nil
+}
+
+// addThresholdFlags registers the flags that decide whether a load test passes.
+func addThresholdFlags(cmd *cobra.Command, thresholds *[]string, abortOnFail *bool, abortDelay *time.Duration) {
+	cmd.Flags().StringArrayVar(thresholds, "threshold", nil, "Pass/fail condition, e.g. 'p99 < 300ms', 'error_rate < 1%', 'rps > 400' (repeatable)")
+	cmd.Flags().BoolVar(abortOnFail, "abort-on-fail", false, "Stop the test as soon as an upper-bound threshold (< or <=) fails")
+	cmd.Flags().DurationVar(abortDelay, "abort-delay", 10*time.Second, "Time before --abort-on-fail starts evaluating thresholds")
+}
+
+// applyThresholdFlags parses the --threshold flags into the pool configuration.
+func applyThresholdFlags(cfg *runner.Config, exprs []string) error {
+	thresholds, err := check.ParseThresholds(exprs)
+	if err != nil {
+		return err
+	}
+	cfg.Thresholds = append(cfg.Thresholds, thresholds...)
+	return nil
+}
+
+// addOutputFlag registers the --out flag that exports the results.
+func addOutputFlag(cmd *cobra.Command, outputs *[]string) {
+	cmd.Flags().StringArrayVar(outputs, "out", nil, "Export results as json=<file>, csv=<file> or prometheus=<pushgateway or remote-write URL> (repeatable)")
+}
+
+// applyOutputFlags parses the --out flags into the pool configuration.
+func applyOutputFlags(cfg *runner.Config, values []string) error {
+	outputs, err := output.NewAll(values, cfg.Name)
+	if err != nil {
+		return err
+	}
+	cfg.Outputs = outputs
+	return nil
+}
+
+// addClientFlags registers the flags that control how requests are sent.
+func addClientFlags(cmd *cobra.Command, client *http.ClientConfig, headers *[]string) {
+	cmd.Flags().StringArrayVarP(headers, "header", "H", nil, "Header sent with every request, e.g. 'X-Tenant: load-test' (repeatable)")
+	cmd.Flags().DurationVar(&client.Timeout, "timeout", 10*time.Second, "Timeout of each request, including reading the response; 0 disables it")
+	cmd.Flags().BoolVar(&client.HTTP2, "http2", false, "Negotiate HTTP/2 over TLS (default HTTP/1.1)")
+	cmd.Flags().BoolVar(&client.H2C, "h2c", false, "Use HTTP/2 without TLS for http:// targets")
+	cmd.Flags().BoolVar(&client.Insecure, "insecure", false, "Skip TLS certificate verification")
+	cmd.Flags().StringVar(&client.CACert, "ca-cert", "", "CA bundle used to verify the target")
+	cmd.Flags().StringVar(&client.ClientCert, "client-cert", "", "Client certificate for mTLS (PEM)")
+	cmd.Flags().StringVar(&client.ClientKey, "client-key", "", "Client private key for mTLS (PEM)")
+	cmd.Flags().BoolVar(&client.DisableKeepAlive, "disable-keepalive", false, "Open a new connection for every request")
+	cmd.Flags().IntVar(&client.MaxConnsPerHost, "max-conns-per-host", 0, "Maximum connections to the target (0 for unlimited)")
+	cmd.Flags().StringVar(&client.Proxy, "proxy", "", "HTTP proxy URL, e.g. http://proxy:3128")
+}
+
+// parseHeaders parses "Name: value" header flags.
+func parseHeaders(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	headers := make(map[string]string, len(values))
+	for _, value := range values {
+		name, v, found := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid header %q: expected 'Name: value'", value)
+		}
+		headers[name] = strings.TrimSpace(v)
+	}
+	return headers, nil
+}
@@ -37,3 +37,124 @@ func TestHTTPCmdRejectsBasicPasswordWithoutUsername(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestRunCmdRequiresScenarioFile(t *testing.T) {
+	cmd := newRunCmd()
+	cmd.SetArgs([]string{"does-not-exist.yaml"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected command to fail for a missing scenario file")
+	}
+	if !strings.Contains(err.Error(), "failed to read scenario") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPCmdRejectsInvalidThreshold(t *testing.T) {
+	cmd := newHTTPCmd()
+	cmd.SetArgs([]string{
+		"--url", "https://example.com",
+		"--threshold", "p99 below 300ms",
+	})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected command to reject an invalid threshold")
+	}
+	if !strings.Contains(err.Error(), "invalid threshold") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPCmdRejectsInvalidOutput(t *testing.T) {
+	cmd := newHTTPCmd()
+	cmd.SetArgs([]string{
+		"--url", "https://example.com",
+		"--out", "xml=results.xml",
+	})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected command to reject an invalid output")
+	}
+	if !strings.Contains(err.Error(), "unknown kind") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCompareCmdRequiresResultsFiles(t *testing.T) {
+	cmd := newCompareCmd()
+	cmd.SetArgs([]string{"base.json", "current.json"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected command to fail for missing results files")
+	}
+	if !strings.Contains(err.Error(), "failed to read results") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseTolerance(t *testing.T) {
+	for value, want := range map[string]float64{"10%": 0.1, "0.25": 0.25, "0": 0} {
+		got, err := parseTolerance(value)
+		if err != nil || got != want {
+			t.Errorf("parseTolerance(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := parseTolerance("-5%"); err == nil {
+		t.Fatal("expected a negative tolerance to be rejected")
+	}
+}
+
+func TestGRPCCmdRejectsConflictingTLSFlags(t *testing.T) {
+	cmd := newGRPCCmd()
+	cmd.SetArgs([]string{
+		"--target", "localhost:50051",
+		"--method", "helloworld.Greeter/SayHello",
+		"--plaintext",
+		"--insecure",
+	})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected command to reject --plaintext with --insecure")
+	}
+	if !strings.Contains(err.Error(), "cannot be used together") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPCmdRejectsConflictingHTTP2Flags(t *testing.T) {
+	cmd := newHTTPCmd()
+	cmd.SetArgs([]string{
+		"--url", "https://example.com",
+		"--http2",
+		"--h2c",
+	})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected command to reject --http2 with --h2c")
+	}
+	if !strings.Contains(err.Error(), "cannot be used together") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := parseHeaders([]string{"X-Tenant: load-test", "Host:api.example.com", "X-Empty:"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if headers["X-Tenant"] != "load-test" || headers["Host"] != "api.example.com" || headers["X-Empty"] != "" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+
+	for _, value := range []string{"X-Tenant", ": value"} {
+		if _, err := parseHeaders([]string{value}); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	k8schaos "github.com/neogan/sre-toolkit/internal/chaos-load/k8s"
@@ -118,17 +122,33 @@ func newNodeDrainCmd() *cobra.Command {
 		ignoreDaemonSets   bool
 		deleteEmptyDirData bool
 		dryRun             bool
+		duration           time.Duration
+		concurrency        int
 	)
 
 	cmd := &cobra.Command{
 		Use:   "node-drain",
 		Short: "Cordon and drain a Kubernetes node",
 		Long: `Marks a node as unschedulable (cordon) then evicts all eligible pods.
-Mirrors the behavior of kubectl drain with configurable safety options.`,
+Mirrors the behavior of kubectl drain with configurable safety options.
+
+Evictions blocked by a PodDisruptionBudget are retried with backoff until the
+timeout, and a pod that cannot be evicted does not stop the others. Once the
+pods are evicted, the command waits for their replacements and reports where
+they were rescheduled and how long it took.
+
+With --duration the node is uncordoned once the duration has passed, or
+earlier on interrupt.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if nodeName == "" {
 				return cmd.Usage()
 			}
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			if duration < 0 {
+				return fmt.Errorf("--duration must not be negative")
+			}
 
 			client, err := k8s.NewClient(&k8s.Config{Kubeconfig: kubeconfig})
 			if err != nil {
@@ -142,9 +162,19 @@ Mirrors the behavior of kubectl drain with configurable safety options.`,
 				IgnoreDaemonSets:   ignoreDaemonSets,
 				DeleteEmptyDirData: deleteEmptyDirData,
 				DryRun:             dryRun,
+				Duration:           duration,
+				Concurrency:        concurrency,
 			})
 
-			return drainer.Run(cmd.Context())
+			// Canceling the context uncordons the node early
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			err = drainer.Run(ctx)
+			if report, ok := drainer.Report(); ok {
+				report.Fprint(os.Stdout)
+			}
+			return err
 		},
 	}
 
@@ -155,6 +185,8 @@ Mirrors the behavior of kubectl drain with configurable safety options.`,
 	cmd.Flags().BoolVar(&ignoreDaemonSets, "ignore-daemonsets", true, "Ignore DaemonSet-managed pods")
 	cmd.Flags().BoolVar(&deleteEmptyDirData, "delete-emptydir-data", false, "Delete local data in emptyDir volumes")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print what would be drained without taking action")
+	cmd.Flags().DurationVarP(&duration, "duration", "d", 0, "Uncordon the node after this long (0 leaves it cordoned)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 1, "Number of pods to evict in parallel")
 
 	cmd.MarkFlagRequired("node")
 
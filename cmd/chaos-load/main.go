@@ -34,6 +34,10 @@ in comprehensive tests. It helps verify system resilience and capability.`
 
 	// Add subcommands
 	rootCmd.AddCommand(newHTTPCmd())
+	rootCmd.AddCommand(newGRPCCmd())
+	rootCmd.AddCommand(newRunCmd())
+	rootCmd.AddCommand(newExperimentCmd())
+	rootCmd.AddCommand(newCompareCmd())
 	rootCmd.AddCommand(newMockCmd())
 	rootCmd.AddCommand(newK8sCmd())
 
@@ -15,12 +15,17 @@ func newMockCmd() *cobra.Command {
 	var timeoutDuration time.Duration
 	var latency time.Duration
 	var jitter time.Duration
+	var grpcMode bool
+	var grpcErrorCode string
 
 	cmd := &cobra.Command{
 		Use:   "mock",
 		Short: "Run a chaos mock server",
 		Long: `Starts an HTTP server that simulates chaos scenarios.
-Useful for testing observability tools and verify client resilience.`,
+Useful for testing observability tools and verify client resilience.
+
+With --grpc it serves the gRPC method chaosload.mock.v1.MockService/Echo
+instead, with server reflection enabled.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := mock.ServerConfig{
 				Port:                  port,
@@ -30,6 +35,8 @@ Useful for testing observability tools and verify client resilience.`,
 				TimeoutDuration:       timeoutDuration,
 				Latency:               latency,
 				Jitter:                jitter,
+				GRPC:                  grpcMode,
+				GRPCErrorCode:         grpcErrorCode,
 			}
 			if err := cfg.Validate(); err != nil {
 				return err
@@ -47,6 +54,8 @@ Useful for testing observability tools and verify client resilience.`,
 	cmd.Flags().DurationVar(&timeoutDuration, "timeout-duration", 0, "Duration to wait before returning HTTP 504 for timed-out requests")
 	cmd.Flags().DurationVar(&latency, "latency", 0, "Artificial latency to inject (e.g. 100ms)")
 	cmd.Flags().DurationVar(&jitter, "jitter", 0, "Random latency variation (+/- duration)")
+	cmd.Flags().BoolVar(&grpcMode, "grpc", false, "Serve the gRPC MockService instead of HTTP")
+	cmd.Flags().StringVar(&grpcErrorCode, "grpc-error-code", "UNAVAILABLE", "gRPC status code returned for --error-rate failures in --grpc mode")
 
 	return cmd
 }
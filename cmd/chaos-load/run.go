@@ -0,0 +1,103 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/http"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/scenario"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+func newRunCmd() *cobra.Command {
+	var (
+		concurrency int
+		duration    time.Duration
+		iterations  int
+		uiEnabled   bool
+		rateSpec    string
+		stagesSpec  string
+		thresholds  []string
+		abortOnFail bool
+		abortDelay  time.Duration
+		outputs     []string
+		client      http.ClientConfig
+		headers     []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run <scenario.yaml>",
+		Short: "Run a multi-step scenario load test",
+		Long: `Generates load from a scenario file: weighted flows of request templates
+with variables from CSV/JSON data files, values extracted from earlier
+responses and think times between steps. Results are broken down per step.
+
+Exits non-zero when a threshold from the scenario or --threshold fails.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := logging.GetLogger()
+
+			sc, err := scenario.LoadFile(args[0])
+			if err != nil {
+				return err
+			}
+			logger.Info().Str("scenario", sc.Name).Int("flows", len(sc.Flows)).Msg("Starting scenario load test")
+
+			cfg := http.PoolConfig{
+				Config: runner.Config{
+					Concurrency: concurrency,
+					Duration:    duration,
+					Requests:    iterations,
+					UI:          uiEnabled,
+					AbortOnFail: abortOnFail,
+					AbortDelay:  abortDelay,
+					Name:        sc.Name,
+				},
+				Scenario: sc,
+				Client:   client,
+			}
+			if cfg.Name == "" {
+				cfg.Name = strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+			}
+			if err := applyRateFlags(&cfg.Config, rateSpec, stagesSpec); err != nil {
+				return err
+			}
+			// Thresholds from the scenario file come first, then those from flags.
+			if err := applyThresholdFlags(&cfg.Config, append(sc.Thresholds, thresholds...)); err != nil {
+				return err
+			}
+			if err := applyOutputFlags(&cfg.Config, outputs); err != nil {
+				return err
+			}
+			parsed, err := parseHeaders(headers)
+			if err != nil {
+				return err
+			}
+			cfg.Headers = parsed
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+
+			pool, err := http.NewPool(cfg)
+			if err != nil {
+				return err
+			}
+			return pool.Run()
+		},
+	}
+
+	cmd.Flags().IntVar(&concurrency, "concurrency", 10, "Number of concurrent workers (maximum iterations in flight with --rate or --stages)")
+	cmd.Flags().DurationVar(&duration, "duration", 30*time.Second, "Duration of the test")
+	cmd.Flags().IntVar(&iterations, "iterations", 0, "Total number of flow iterations (0 for unlimited)")
+	cmd.Flags().BoolVar(&uiEnabled, "ui", false, "Enable real-time dashboard UI")
+	cmd.Flags().StringVar(&rateSpec, "rate", "", "Start iterations at a constant rate for --duration, e.g. 50/s")
+	cmd.Flags().StringVar(&stagesSpec, "stages", "", "Ramp the iteration rate through duration:rate stages, e.g. 30s:10,2m:50,30s:0")
+	addThresholdFlags(cmd, &thresholds, &abortOnFail, &abortDelay)
+	addOutputFlag(cmd, &outputs)
+	addClientFlags(cmd, &client, &headers)
+
+	return cmd
+}
@@ -0,0 +1,261 @@
+// Package analyzer provides frequency and pattern analysis for Prometheus alerts.
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+const (
+	// DefaultAnomalyThreshold is the robust z-score (deviation from the seasonal
+	// median in scaled MADs) above which an hour's alert volume is anomalous.
+	DefaultAnomalyThreshold = 3.5
+
+	// AnomalySpike marks a window with more firings than its baseline.
+	AnomalySpike = "spike"
+	// AnomalyDrop marks a window with fewer firings than its baseline.
+	AnomalyDrop = "drop"
+
+	hoursPerWeek = 7 * 24
+
+	// minAnomalyBaseline is the number of other weeks needed for an hour of the
+	// week before its volume is scored, so at least three weeks of history.
+	minAnomalyBaseline = 2
+
+	// madScale makes the median absolute deviation comparable to a standard
+	// deviation for normally distributed counts.
+	madScale = 1.4826
+
+	// minAnomalySpread keeps hours that always see the same number of firings
+	// from being flagged for a single extra firing.
+	minAnomalySpread = 1.0
+
+	// maxAnomalyContributors limits the alerts listed per anomalous window.
+	maxAnomalyContributors = 5
+)
+
+// AnomalyContributor is an alert that explains part of an anomalous window.
+type AnomalyContributor struct {
+	AlertName string  `json:"alert_name"`
+	Severity  string  `json:"severity"`
+	Firings   int     `json:"firings"`
+	Expected  float64 `json:"expected"`
+	Excess    float64 `json:"excess"`
+}
+
+// AnomalyWindow is a run of consecutive hours whose alert volume deviates from
+// the seasonal baseline in the same direction.
+type AnomalyWindow struct {
+	Start        time.Time            `json:"start"`
+	End          time.Time            `json:"end"`
+	Direction    string               `json:"direction"`
+	Firings      int                  `json:"firings"`
+	Expected     float64              `json:"expected"`
+	Score        float64              `json:"score"`
+	Contributors []AnomalyContributor `json:"contributors"`
+}
+
+// AnomalyReport lists the anomalous windows of alert volume.
+type AnomalyReport struct {
+	Threshold float64 `json:"threshold"`
+	// Hours is the number of complete hours analyzed, ScoredHours those with
+	// enough history for a baseline.
+	Hours       int `json:"hours"`
+	ScoredHours int `json:"scored_hours"`
+	// LatestScore is the score of the most recent complete hour, zero without a baseline.
+	LatestScore float64         `json:"latest_score"`
+	Windows     []AnomalyWindow `json:"windows"`
+}
+
+// AnomalyAnalyzer compares hourly alert volume with a seasonal baseline built
+// from the same hour of the week in the other weeks of the history.
+type AnomalyAnalyzer struct {
+	history   *collector.AlertHistory
+	threshold float64
+}
+
+type hourScore struct {
+	expected float64
+	score    float64
+	scored   bool
+}
+
+// NewAnomalyAnalyzer creates an anomaly analyzer. A non-positive threshold falls back to the default.
+func NewAnomalyAnalyzer(history *collector.AlertHistory, threshold float64) *AnomalyAnalyzer {
+	if threshold <= 0 {
+		threshold = DefaultAnomalyThreshold
+	}
+	return &AnomalyAnalyzer{history: history, threshold: threshold}
+}
+
+// Analyze scores every hour against the median and MAD of the same hour of the
+// week and returns the anomalous windows, the largest deviation first.
+func (a *AnomalyAnalyzer) Analyze() AnomalyReport {
+	report := AnomalyReport{Threshold: a.threshold, Windows: []AnomalyWindow{}}
+
+	start, counts := NewTemporalAnalyzer(a.history).HourlyCounts()
+	report.Hours = len(counts)
+	if len(counts) == 0 {
+		return report
+	}
+
+	scores := make([]hourScore, len(counts))
+	for i := range counts {
+		baseline := seasonalBaseline(counts, i)
+		if len(baseline) < minAnomalyBaseline {
+			continue
+		}
+		expected, spread := medianMAD(baseline)
+		scores[i] = hourScore{
+			expected: expected,
+			score:    (float64(counts[i]) - expected) / math.Max(madScale*spread, minAnomalySpread),
+			scored:   true,
+		}
+		report.ScoredHours++
+	}
+	report.LatestScore = scores[len(scores)-1].score
+
+	report.Windows = a.windows(start, counts, scores)
+	if len(report.Windows) == 0 {
+		return report
+	}
+	a.addContributors(start, len(counts), report.Windows)
+
+	sort.Slice(report.Windows, func(i, j int) bool {
+		si, sj := math.Abs(report.Windows[i].Score), math.Abs(report.Windows[j].Score)
+		if si != sj {
+			return si > sj
+		}
+		return report.Windows[i].Start.Before(report.Windows[j].Start)
+	})
+	return report
+}
+
+// windows merges consecutive anomalous hours of the same direction.
+func (a *AnomalyAnalyzer) windows(start time.Time, counts []int, scores []hourScore) []AnomalyWindow {
+	var windows []AnomalyWindow
+	var current *AnomalyWindow
+	for i, hour := range scores {
+		direction := ""
+		if hour.scored && hour.score >= a.threshold {
+			direction = AnomalySpike
+		} else if hour.scored && hour.score <= -a.threshold {
+			direction = AnomalyDrop
+		}
+
+		if direction == "" || current == nil || current.Direction != direction {
+			if current != nil {
+				windows = append(windows, *current)
+				current = nil
+			}
+			if direction == "" {
+				continue
+			}
+			current = &AnomalyWindow{Start: start.Add(time.Duration(i) * time.Hour), Direction: direction}
+		}
+
+		current.End = start.Add(time.Duration(i+1) * time.Hour)
+		current.Firings += counts[i]
+		current.Expected += hour.expected
+		if math.Abs(hour.score) > math.Abs(current.Score) {
+			current.Score = hour.score
+		}
+	}
+	if current != nil {
+		windows = append(windows, *current)
+	}
+	return windows
+}
+
+// addContributors lists the alerts whose own volume deviates the most from
+// their seasonal baseline in the direction of each window.
+func (a *AnomalyAnalyzer) addContributors(start time.Time, buckets int, windows []AnomalyWindow) {
+	type alertCounts struct {
+		name     string
+		severity string
+		counts   []int
+	}
+
+	grouped := collector.GroupAlertsByName(a.history.Alerts)
+	alerts := make([]alertCounts, 0, len(grouped))
+	for name, group := range grouped {
+		alerts = append(alerts, alertCounts{
+			name:     name,
+			severity: group[0].GetSeverity(),
+			counts:   hourlyCounts(group, start, buckets),
+		})
+	}
+
+	for w := range windows {
+		window := &windows[w]
+		first := int(window.Start.Sub(start) / time.Hour)
+		last := int(window.End.Sub(start) / time.Hour)
+
+		contributors := make([]AnomalyContributor, 0)
+		for _, alert := range alerts {
+			contributor := AnomalyContributor{AlertName: alert.name, Severity: alert.severity}
+			for i := first; i < last; i++ {
+				contributor.Firings += alert.counts[i]
+				if baseline := seasonalBaseline(alert.counts, i); len(baseline) > 0 {
+					expected, _ := medianMAD(baseline)
+					contributor.Expected += expected
+				}
+			}
+			contributor.Excess = float64(contributor.Firings) - contributor.Expected
+			if window.Direction == AnomalySpike && contributor.Excess > 0 ||
+				window.Direction == AnomalyDrop && contributor.Excess < 0 {
+				contributors = append(contributors, contributor)
+			}
+		}
+
+		sort.Slice(contributors, func(i, j int) bool {
+			ei, ej := math.Abs(contributors[i].Excess), math.Abs(contributors[j].Excess)
+			if ei != ej {
+				return ei > ej
+			}
+			return contributors[i].AlertName < contributors[j].AlertName
+		})
+		if len(contributors) > maxAnomalyContributors {
+			contributors = contributors[:maxAnomalyContributors]
+		}
+		window.Contributors = contributors
+	}
+}
+
+// seasonalBaseline returns the counts of the same hour of the week in every
+// other week, leaving the scored hour out so a spike does not hide itself.
+func seasonalBaseline(counts []int, index int) []float64 {
+	var baseline []float64
+	for i := index % hoursPerWeek; i < len(counts); i += hoursPerWeek {
+		if i != index {
+			baseline = append(baseline, float64(counts[i]))
+		}
+	}
+	return baseline
+}
+
+// medianMAD returns the median of values and their median absolute deviation.
+func medianMAD(values []float64) (median, mad float64) {
+	median = medianOf(values)
+	deviations := make([]float64, len(values))
+	for i, value := range values {
+		deviations[i] = math.Abs(value - median)
+	}
+	return median, medianOf(deviations)
+}
+
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
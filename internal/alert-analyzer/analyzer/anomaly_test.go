@@ -0,0 +1,124 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+var anomalyBase = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // Monday
+
+// anomalyHistory has four weeks with five "Background" firings every hour.
+func anomalyHistory() *collector.AlertHistory {
+	history := &collector.AlertHistory{
+		StartTime: anomalyBase,
+		EndTime:   anomalyBase.Add(4 * hoursPerWeek * time.Hour),
+	}
+	for hour := 0; hour < 4*hoursPerWeek; hour++ {
+		for i := 0; i < 5; i++ {
+			history.Alerts = append(history.Alerts, collector.Alert{
+				Name:    "Background",
+				Labels:  map[string]string{"severity": "info"},
+				FiredAt: anomalyBase.Add(time.Duration(hour)*time.Hour + time.Duration(i)*time.Minute),
+			})
+		}
+	}
+	return history
+}
+
+func addAnomalyFirings(history *collector.AlertHistory, name string, at time.Time, count int) {
+	for i := 0; i < count; i++ {
+		history.Alerts = append(history.Alerts, collector.Alert{
+			Name:    name,
+			Labels:  map[string]string{"severity": "critical"},
+			FiredAt: at.Add(time.Duration(i) * time.Second),
+		})
+	}
+}
+
+func TestAnomalyAnalyzer_Spike(t *testing.T) {
+	history := anomalyHistory()
+	storm := anomalyBase.Add(3*hoursPerWeek*time.Hour + 2*24*time.Hour + 10*time.Hour) // Wednesday 10:00 of week 4
+	addAnomalyFirings(history, "NodeDown", storm, 30)
+	addAnomalyFirings(history, "NodeDown", storm.Add(time.Hour), 20)
+	addAnomalyFirings(history, "DiskFull", storm.Add(time.Hour), 4)
+
+	report := NewAnomalyAnalyzer(history, 0).Analyze()
+
+	assert.Equal(t, DefaultAnomalyThreshold, report.Threshold)
+	assert.Equal(t, 4*hoursPerWeek, report.Hours)
+	assert.Equal(t, 4*hoursPerWeek, report.ScoredHours)
+	assert.Zero(t, report.LatestScore)
+
+	require.Len(t, report.Windows, 1, "consecutive anomalous hours form one window")
+	window := report.Windows[0]
+	assert.Equal(t, AnomalySpike, window.Direction)
+	assert.Equal(t, storm, window.Start)
+	assert.Equal(t, storm.Add(2*time.Hour), window.End)
+	assert.Equal(t, 64, window.Firings)
+	assert.InDelta(t, 10.0, window.Expected, 0.001)
+	assert.InDelta(t, 30.0, window.Score, 0.001)
+
+	require.Len(t, window.Contributors, 2)
+	assert.Equal(t, "NodeDown", window.Contributors[0].AlertName)
+	assert.Equal(t, "critical", window.Contributors[0].Severity)
+	assert.Equal(t, 50, window.Contributors[0].Firings)
+	assert.InDelta(t, 50.0, window.Contributors[0].Excess, 0.001)
+	assert.Equal(t, "DiskFull", window.Contributors[1].AlertName)
+}
+
+func TestAnomalyAnalyzer_Drop(t *testing.T) {
+	history := anomalyHistory()
+	outage := anomalyBase.Add(2*hoursPerWeek*time.Hour + 24*time.Hour + 3*time.Hour) // Tuesday 03:00 of week 3
+	alerts := history.Alerts[:0]
+	for _, alert := range history.Alerts {
+		if alert.FiredAt.Truncate(time.Hour).Equal(outage) {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	history.Alerts = alerts
+
+	report := NewAnomalyAnalyzer(history, 3).Analyze()
+
+	require.Len(t, report.Windows, 1)
+	window := report.Windows[0]
+	assert.Equal(t, AnomalyDrop, window.Direction)
+	assert.Equal(t, outage, window.Start)
+	assert.Equal(t, 0, window.Firings)
+	assert.InDelta(t, -5.0, window.Score, 0.001)
+	require.Len(t, window.Contributors, 1)
+	assert.Equal(t, "Background", window.Contributors[0].AlertName)
+	assert.InDelta(t, -5.0, window.Contributors[0].Excess, 0.001)
+}
+
+func TestAnomalyAnalyzer_ShortHistory(t *testing.T) {
+	history := &collector.AlertHistory{
+		StartTime: anomalyBase,
+		EndTime:   anomalyBase.Add(2 * hoursPerWeek * time.Hour),
+	}
+	addAnomalyFirings(history, "NodeDown", anomalyBase.Add(time.Hour), 100)
+
+	report := NewAnomalyAnalyzer(history, 0).Analyze()
+	assert.Equal(t, 2*hoursPerWeek, report.Hours)
+	assert.Zero(t, report.ScoredHours, "two weeks leave a single week as baseline")
+	assert.Empty(t, report.Windows)
+
+	empty := NewAnomalyAnalyzer(nil, 0).Analyze()
+	assert.Zero(t, empty.Hours)
+	assert.Empty(t, empty.Windows)
+}
+
+func TestMedianMAD(t *testing.T) {
+	median, mad := medianMAD([]float64{1, 1, 2, 2, 4, 6, 9})
+	assert.Equal(t, 2.0, median)
+	assert.Equal(t, 1.0, mad)
+
+	median, mad = medianMAD([]float64{3, 1})
+	assert.Equal(t, 2.0, median)
+	assert.Equal(t, 1.0, mad)
+}
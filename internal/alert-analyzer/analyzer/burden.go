@@ -0,0 +1,233 @@
+// Package analyzer provides frequency and pattern analysis for Prometheus alerts.
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+const (
+	nightStartHour = 22
+	nightEndHour   = 7
+
+	// nightPageWeight makes a page that wakes someone up count three times
+	// as much as an evening or weekend-daytime page.
+	nightPageWeight = 3.0
+
+	// UnassignedTeam is used when neither routing nor labels identify an owner.
+	UnassignedTeam = "unassigned"
+)
+
+// Router resolves the Alertmanager receivers an alert is delivered to.
+type Router interface {
+	Receivers(labels map[string]string) []string
+}
+
+// BurdenResult describes how much on-call pain a single alert causes.
+type BurdenResult struct {
+	AlertName            string        `json:"alert_name"`
+	Team                 string        `json:"team"`
+	Severity             string        `json:"severity"`
+	Pages                int           `json:"pages"`
+	OffHoursPages        int           `json:"off_hours_pages"`
+	NightPages           int           `json:"night_pages"`
+	WeekendPages         int           `json:"weekend_pages"`
+	MeanTimeToResolve    time.Duration `json:"mean_time_to_resolve"`
+	CumulativeFiringTime time.Duration `json:"cumulative_firing_time"`
+	SleepDisruptionScore float64       `json:"sleep_disruption_score"`
+}
+
+// TeamBurden aggregates on-call burden for every alert routed to a team.
+type TeamBurden struct {
+	Team                 string        `json:"team"`
+	Alerts               int           `json:"alerts"`
+	Pages                int           `json:"pages"`
+	OffHoursPages        int           `json:"off_hours_pages"`
+	NightPages           int           `json:"night_pages"`
+	WeekendPages         int           `json:"weekend_pages"`
+	MeanTimeToResolve    time.Duration `json:"mean_time_to_resolve"`
+	CumulativeFiringTime time.Duration `json:"cumulative_firing_time"`
+	SleepDisruptionScore float64       `json:"sleep_disruption_score"`
+}
+
+// BurdenReport holds per-alert and per-team on-call burden, ranked by sleep disruption.
+type BurdenReport struct {
+	Alerts []BurdenResult `json:"alerts"`
+	Teams  []TeamBurden   `json:"teams"`
+}
+
+// BurdenAnalyzer computes paging cost per alert and per team.
+type BurdenAnalyzer struct {
+	history  *collector.AlertHistory
+	router   Router
+	location *time.Location
+}
+
+// NewBurdenAnalyzer creates a burden analyzer. router may be nil, in which case
+// the team/owner labels decide ownership. Hours are evaluated in location
+// (the timestamps' own zone when nil).
+func NewBurdenAnalyzer(history *collector.AlertHistory, router Router, location *time.Location) *BurdenAnalyzer {
+	return &BurdenAnalyzer{
+		history:  history,
+		router:   router,
+		location: location,
+	}
+}
+
+type burdenAccumulator struct {
+	result       BurdenResult
+	alerts       []collector.Alert
+	resolved     int
+	resolveTotal time.Duration
+}
+
+type teamAccumulator struct {
+	result       TeamBurden
+	alerts       map[string]struct{}
+	resolved     int
+	resolveTotal time.Duration
+}
+
+// Analyze returns on-call burden for all alerts and teams, most disruptive first.
+func (a *BurdenAnalyzer) Analyze() BurdenReport {
+	if a.history == nil || len(a.history.Alerts) == 0 {
+		return BurdenReport{Alerts: []BurdenResult{}, Teams: []TeamBurden{}}
+	}
+
+	byAlert := make(map[string]*burdenAccumulator)
+	for _, alert := range a.history.Alerts {
+		team := a.resolveTeam(alert)
+		key := alert.GetGroupingKey() + "\x00" + team
+
+		acc, ok := byAlert[key]
+		if !ok {
+			acc = &burdenAccumulator{result: BurdenResult{
+				AlertName: alert.GetGroupingKey(),
+				Team:      team,
+				Severity:  alert.GetSeverity(),
+			}}
+			byAlert[key] = acc
+		}
+		a.addPage(acc, alert)
+	}
+
+	alerts := make([]BurdenResult, 0, len(byAlert))
+	teams := make(map[string]*teamAccumulator)
+	for _, acc := range byAlert {
+		// The paging shares come from the same weekday/hour buckets as the
+		// temporal analysis, so the two reports agree.
+		heatmap := NewTemporalAnalyzer(&collector.AlertHistory{Alerts: acc.alerts}).heatmapIn(a.location)
+		acc.result.OffHoursPages, acc.result.NightPages, acc.result.WeekendPages = pagingShares(heatmap)
+
+		result := acc.result
+		if acc.resolved > 0 {
+			result.MeanTimeToResolve = acc.resolveTotal / time.Duration(acc.resolved)
+		}
+		result.SleepDisruptionScore = sleepDisruptionScore(result.NightPages, result.OffHoursPages)
+		alerts = append(alerts, result)
+
+		team, ok := teams[result.Team]
+		if !ok {
+			team = &teamAccumulator{
+				result: TeamBurden{Team: result.Team},
+				alerts: make(map[string]struct{}),
+			}
+			teams[result.Team] = team
+		}
+		team.alerts[result.AlertName] = struct{}{}
+		team.result.Pages += result.Pages
+		team.result.OffHoursPages += result.OffHoursPages
+		team.result.NightPages += result.NightPages
+		team.result.WeekendPages += result.WeekendPages
+		team.result.CumulativeFiringTime += result.CumulativeFiringTime
+		team.resolved += acc.resolved
+		team.resolveTotal += acc.resolveTotal
+	}
+
+	teamResults := make([]TeamBurden, 0, len(teams))
+	for _, acc := range teams {
+		team := acc.result
+		team.Alerts = len(acc.alerts)
+		team.SleepDisruptionScore = sleepDisruptionScore(team.NightPages, team.OffHoursPages)
+		if acc.resolved > 0 {
+			team.MeanTimeToResolve = acc.resolveTotal / time.Duration(acc.resolved)
+		}
+		teamResults = append(teamResults, team)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].SleepDisruptionScore != alerts[j].SleepDisruptionScore {
+			return alerts[i].SleepDisruptionScore > alerts[j].SleepDisruptionScore
+		}
+		if alerts[i].Pages != alerts[j].Pages {
+			return alerts[i].Pages > alerts[j].Pages
+		}
+		if alerts[i].AlertName != alerts[j].AlertName {
+			return alerts[i].AlertName < alerts[j].AlertName
+		}
+		return alerts[i].Team < alerts[j].Team
+	})
+
+	sort.Slice(teamResults, func(i, j int) bool {
+		if teamResults[i].SleepDisruptionScore != teamResults[j].SleepDisruptionScore {
+			return teamResults[i].SleepDisruptionScore > teamResults[j].SleepDisruptionScore
+		}
+		if teamResults[i].Pages != teamResults[j].Pages {
+			return teamResults[i].Pages > teamResults[j].Pages
+		}
+		return teamResults[i].Team < teamResults[j].Team
+	})
+
+	return BurdenReport{Alerts: alerts, Teams: teamResults}
+}
+
+// AnalyzeTopN returns the N most disruptive alerts together with all team totals.
+func (a *BurdenAnalyzer) AnalyzeTopN(n int) BurdenReport {
+	report := a.Analyze()
+	if n > 0 && n < len(report.Alerts) {
+		report.Alerts = report.Alerts[:n]
+	}
+	return report
+}
+
+func (a *BurdenAnalyzer) addPage(acc *burdenAccumulator, alert collector.Alert) {
+	acc.result.Pages++
+	acc.alerts = append(acc.alerts, alert)
+	acc.result.CumulativeFiringTime += alert.DurationUntil(a.history.EndTime)
+	if alert.ResolvedAt != nil {
+		acc.resolved++
+		acc.resolveTotal += alert.ResolvedAt.Sub(alert.FiredAt)
+	}
+}
+
+// resolveTeam prefers the first Alertmanager receiver and falls back to ownership labels.
+func (a *BurdenAnalyzer) resolveTeam(alert collector.Alert) string {
+	if a.router != nil {
+		labels := make(map[string]string, len(alert.Labels)+1)
+		for k, v := range alert.Labels {
+			labels[k] = v
+		}
+		labels["alertname"] = alert.Name
+		if receivers := a.router.Receivers(labels); len(receivers) > 0 {
+			return receivers[0]
+		}
+	}
+
+	return teamFromLabels(alert.Labels)
+}
+
+// teamFromLabels returns the owning team from the team or owner label.
+func teamFromLabels(labels map[string]string) string {
+	for _, key := range []string{"team", "owner"} {
+		if value := labels[key]; value != "" {
+			return value
+		}
+	}
+	return UnassignedTeam
+}
+
+func sleepDisruptionScore(nightPages, offHoursPages int) float64 {
+	return nightPageWeight*float64(nightPages) + float64(offHoursPages-nightPages)
+}
@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRouter map[string]string
+
+func (f fakeRouter) Receivers(labels map[string]string) []string {
+	if receiver, ok := f[labels["alertname"]]; ok {
+		return []string{receiver}
+	}
+	return nil
+}
+
+func resolvedAt(ts time.Time, d time.Duration) *time.Time {
+	end := ts.Add(d)
+	return &end
+}
+
+func burdenTestHistory() *collector.AlertHistory {
+	monday10 := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)   // business hours
+	monday20 := time.Date(2026, 3, 16, 20, 0, 0, 0, time.UTC)   // evening
+	tuesday03 := time.Date(2026, 3, 17, 3, 0, 0, 0, time.UTC)   // night
+	saturday14 := time.Date(2026, 3, 21, 14, 0, 0, 0, time.UTC) // weekend daytime
+	saturday23 := time.Date(2026, 3, 21, 23, 0, 0, 0, time.UTC) // weekend night
+
+	return &collector.AlertHistory{
+		StartTime: monday10.Add(-time.Hour),
+		EndTime:   time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC),
+		Alerts: []collector.Alert{
+			{Name: "DiskFull", Labels: map[string]string{"severity": "warning", "team": "storage"}, FiredAt: monday10, ResolvedAt: resolvedAt(monday10, 10*time.Minute)},
+			{Name: "DiskFull", Labels: map[string]string{"severity": "warning", "team": "storage"}, FiredAt: monday20, ResolvedAt: resolvedAt(monday20, 30*time.Minute)},
+			{Name: "NodeDown", Labels: map[string]string{"severity": "critical", "owner": "platform"}, FiredAt: tuesday03, ResolvedAt: resolvedAt(tuesday03, 20*time.Minute)},
+			{Name: "NodeDown", Labels: map[string]string{"severity": "critical", "owner": "platform"}, FiredAt: saturday23, ResolvedAt: resolvedAt(saturday23, 40*time.Minute)},
+			{Name: "Heartbeat", Labels: map[string]string{"severity": "info"}, FiredAt: saturday14},
+		},
+	}
+}
+
+func TestBurdenAnalyzer_Analyze(t *testing.T) {
+	report := NewBurdenAnalyzer(burdenTestHistory(), nil, time.UTC).Analyze()
+
+	require.Len(t, report.Alerts, 3)
+
+	nodeDown := report.Alerts[0]
+	assert.Equal(t, "NodeDown", nodeDown.AlertName)
+	assert.Equal(t, "platform", nodeDown.Team)
+	assert.Equal(t, 2, nodeDown.Pages)
+	assert.Equal(t, 2, nodeDown.NightPages)
+	assert.Equal(t, 1, nodeDown.WeekendPages)
+	assert.Equal(t, 2, nodeDown.OffHoursPages)
+	assert.Equal(t, 30*time.Minute, nodeDown.MeanTimeToResolve)
+	assert.Equal(t, time.Hour, nodeDown.CumulativeFiringTime)
+	assert.InDelta(t, 6.0, nodeDown.SleepDisruptionScore, 0.001)
+
+	diskFull := report.Alerts[1]
+	assert.Equal(t, "DiskFull", diskFull.AlertName)
+	assert.Equal(t, "storage", diskFull.Team)
+	assert.Equal(t, 1, diskFull.OffHoursPages)
+	assert.Equal(t, 0, diskFull.NightPages)
+	assert.Equal(t, 20*time.Minute, diskFull.MeanTimeToResolve)
+	assert.InDelta(t, 1.0, diskFull.SleepDisruptionScore, 0.001)
+
+	heartbeat := report.Alerts[2]
+	assert.Equal(t, UnassignedTeam, heartbeat.Team)
+	assert.Equal(t, 1, heartbeat.WeekendPages)
+	assert.Equal(t, time.Duration(0), heartbeat.MeanTimeToResolve)
+	// Unresolved alerts are capped at the end of the analysis window.
+	assert.Equal(t, 10*time.Hour, heartbeat.CumulativeFiringTime)
+
+	require.Len(t, report.Teams, 3)
+	assert.Equal(t, "platform", report.Teams[0].Team)
+	assert.Equal(t, 1, report.Teams[0].Alerts)
+	assert.Equal(t, 2, report.Teams[0].Pages)
+}
+
+func TestBurdenAnalyzer_Router(t *testing.T) {
+	router := fakeRouter{"NodeDown": "infra-pager", "DiskFull": "infra-pager"}
+	report := NewBurdenAnalyzer(burdenTestHistory(), router, time.UTC).Analyze()
+
+	require.Len(t, report.Teams, 2)
+	assert.Equal(t, "infra-pager", report.Teams[0].Team)
+	assert.Equal(t, 2, report.Teams[0].Alerts)
+	assert.Equal(t, 4, report.Teams[0].Pages)
+	assert.Equal(t, 3, report.Teams[0].OffHoursPages)
+	assert.Equal(t, 25*time.Minute, report.Teams[0].MeanTimeToResolve)
+
+	// Alerts without a matching route fall back to ownership labels.
+	assert.Equal(t, UnassignedTeam, report.Teams[1].Team)
+}
+
+func TestBurdenAnalyzer_Timezone(t *testing.T) {
+	// 07:30 UTC on a Monday is business hours in Tokyo (16:30) but night in Los Angeles.
+	firedAt := time.Date(2026, 3, 16, 7, 30, 0, 0, time.UTC)
+	history := &collector.AlertHistory{
+		Alerts: []collector.Alert{{Name: "A", FiredAt: firedAt, ResolvedAt: resolvedAt(firedAt, time.Minute)}},
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, NewBurdenAnalyzer(history, nil, tokyo).Analyze().Alerts[0].OffHoursPages)
+	assert.Equal(t, 1, NewBurdenAnalyzer(history, nil, losAngeles).Analyze().Alerts[0].NightPages)
+}
+
+func TestBurdenAnalyzer_EmptyAndTopN(t *testing.T) {
+	empty := NewBurdenAnalyzer(&collector.AlertHistory{}, nil, nil).Analyze()
+	assert.Empty(t, empty.Alerts)
+	assert.Empty(t, empty.Teams)
+
+	top := NewBurdenAnalyzer(burdenTestHistory(), nil, time.UTC).AnalyzeTopN(1)
+	require.Len(t, top.Alerts, 1)
+	assert.Len(t, top.Teams, 3)
+}
+
+func TestBurdenAnalyzer_SharesMatchTemporalBuckets(t *testing.T) {
+	history := burdenTestHistory()
+	report := NewBurdenAnalyzer(history, nil, nil).Analyze()
+
+	offHours, night, weekend := pagingShares(NewTemporalAnalyzer(history).Heatmap())
+	total := TeamBurden{}
+	for _, team := range report.Teams {
+		total.OffHoursPages += team.OffHoursPages
+		total.NightPages += team.NightPages
+		total.WeekendPages += team.WeekendPages
+	}
+	assert.Equal(t, offHours, total.OffHoursPages)
+	assert.Equal(t, night, total.NightPages)
+	assert.Equal(t, weekend, total.WeekendPages)
+}
@@ -27,10 +27,23 @@ type FlappingResult struct {
 	Severity         string        `json:"severity"`
 }
 
+// FiringInterval is a single period during which an alert was firing.
+type FiringInterval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// FlappingTimeline lists the firing intervals of one alert in chronological order.
+type FlappingTimeline struct {
+	AlertName string           `json:"alert_name"`
+	Intervals []FiringInterval `json:"intervals"`
+}
+
 // FlappingAnalyzer detects alerts that constantly switch between firing and resolved states.
 type FlappingAnalyzer struct {
 	history   *collector.AlertHistory
 	threshold float64 // transitions per hour to be considered flapping
+	groupBy   []string
 }
 
 // DefaultFlappingThreshold is the default threshold for flapping detection (3 transitions/hour).
@@ -47,14 +60,20 @@ func NewFlappingAnalyzer(history *collector.AlertHistory, threshold float64) *Fl
 	}
 }
 
+// WithGroupBy splits results by the values of the given labels in addition to alert name.
+func (a *FlappingAnalyzer) WithGroupBy(labels []string) *FlappingAnalyzer {
+	a.groupBy = labels
+	return a
+}
+
 // Analyze performs flapping analysis and returns results for all alerts.
 func (a *FlappingAnalyzer) Analyze() []FlappingResult {
 	if a.history == nil || len(a.history.Alerts) == 0 {
 		return []FlappingResult{}
 	}
 
-	// Group alerts by name
-	grouped := collector.GroupAlertsByName(a.history.Alerts)
+	// Group alerts by name (and the --group-by labels, if any)
+	grouped := collector.GroupAlertsByLabels(a.history.Alerts, a.groupBy)
 
 	// Calculate the analysis period duration
 	analysisPeriod := a.history.EndTime.Sub(a.history.StartTime)
@@ -88,6 +107,33 @@ func (a *FlappingAnalyzer) AnalyzeTopN(n int) []FlappingResult {
 	return allResults[:n]
 }
 
+// Timelines returns the firing intervals for the given alerts, in the order given.
+// Alerts still firing at the end of the history are closed at its end time.
+func (a *FlappingAnalyzer) Timelines(alertNames []string) []FlappingTimeline {
+	timelines := make([]FlappingTimeline, 0, len(alertNames))
+	if a.history == nil {
+		return timelines
+	}
+
+	grouped := collector.GroupAlertsByLabels(a.history.Alerts, a.groupBy)
+	for _, alertName := range alertNames {
+		alerts := grouped[alertName]
+		intervals := make([]FiringInterval, 0, len(alerts))
+		for _, alert := range alerts {
+			end := a.history.EndTime
+			if alert.ResolvedAt != nil {
+				end = *alert.ResolvedAt
+			}
+			intervals = append(intervals, FiringInterval{Start: alert.FiredAt, End: end})
+		}
+		sort.Slice(intervals, func(i, j int) bool {
+			return intervals[i].Start.Before(intervals[j].Start)
+		})
+		timelines = append(timelines, FlappingTimeline{AlertName: alertName, Intervals: intervals})
+	}
+	return timelines
+}
+
 // GetFlappingAlerts returns only alerts that are considered flapping (above threshold).
 func (a *FlappingAnalyzer) GetFlappingAlerts() []FlappingResult {
 	allResults := a.Analyze()
@@ -191,7 +237,7 @@ func (a *FlappingAnalyzer) calculateDurations(alerts []collector.Alert, transiti
 		if len(alerts) > 0 {
 			var totalDuration time.Duration
 			for _, alert := range alerts {
-				totalDuration += alert.Duration()
+				totalDuration += alert.DurationUntil(a.history.EndTime)
 			}
 			avg = totalDuration / time.Duration(len(alerts))
 		}
@@ -178,6 +178,60 @@ func TestFlappingAnalyzer_AnalyzeTopN(t *testing.T) {
 	}
 }
 
+func TestFlappingAnalyzer_WithGroupBy(t *testing.T) {
+	now := time.Now()
+	flappy := makeTestAlertWithResolved("Alert1", now.Add(-50*time.Minute), now.Add(-45*time.Minute))
+	flappy.Labels = map[string]string{"severity": "warning", "instance": "a"}
+	flappyAgain := makeTestAlertWithResolved("Alert1", now.Add(-40*time.Minute), now.Add(-35*time.Minute))
+	flappyAgain.Labels = flappy.Labels
+	stable := makeTestAlertWithResolved("Alert1", now.Add(-50*time.Minute), now.Add(-10*time.Minute))
+	stable.Labels = map[string]string{"severity": "warning", "instance": "b"}
+
+	history := &collector.AlertHistory{
+		Alerts:    []collector.Alert{flappy, flappyAgain, stable},
+		StartTime: now.Add(-time.Hour),
+		EndTime:   now,
+	}
+
+	results := NewFlappingAnalyzer(history, 3.0).WithGroupBy([]string{"instance"}).Analyze()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].AlertName != "Alert1 {instance=a}" || !results[0].IsFlapping {
+		t.Errorf("expected instance a to flap first, got %+v", results[0])
+	}
+	if results[1].AlertName != "Alert1 {instance=b}" || results[1].IsFlapping {
+		t.Errorf("expected instance b to be stable, got %+v", results[1])
+	}
+}
+
+func TestFlappingAnalyzer_Timelines(t *testing.T) {
+	now := time.Now()
+	second := makeTestAlertWithResolved("Alert1", now.Add(-30*time.Minute), now.Add(-20*time.Minute))
+	first := makeTestAlertWithResolved("Alert1", now.Add(-50*time.Minute), now.Add(-45*time.Minute))
+	ongoing := makeTestAlert(now.Add(-10 * time.Minute))
+
+	history := &collector.AlertHistory{
+		Alerts:    []collector.Alert{second, first, ongoing},
+		StartTime: now.Add(-time.Hour),
+		EndTime:   now,
+	}
+
+	timelines := NewFlappingAnalyzer(history, 3.0).Timelines([]string{"StableAlert", "Alert1", "Missing"})
+	if len(timelines) != 3 {
+		t.Fatalf("expected 3 timelines, got %d", len(timelines))
+	}
+	if got := timelines[0].Intervals; len(got) != 1 || !got[0].End.Equal(now) {
+		t.Errorf("expected unresolved alert to end at history end, got %+v", got)
+	}
+	if got := timelines[1].Intervals; len(got) != 2 || !got[0].Start.Equal(first.FiredAt) || !got[1].End.Equal(*second.ResolvedAt) {
+		t.Errorf("expected Alert1 intervals in chronological order, got %+v", got)
+	}
+	if len(timelines[2].Intervals) != 0 {
+		t.Errorf("expected no intervals for unknown alert, got %+v", timelines[2].Intervals)
+	}
+}
+
 func TestFlappingAnalyzer_GetFlappingAlerts(t *testing.T) {
 	now := time.Now()
 
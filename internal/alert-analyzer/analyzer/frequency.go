@@ -21,6 +21,7 @@ type FrequencyResult struct {
 // FrequencyAnalyzer analyzes alert firing frequency
 type FrequencyAnalyzer struct {
 	history *collector.AlertHistory
+	groupBy []string
 }
 
 // NewFrequencyAnalyzer creates a new frequency analyzer
@@ -30,10 +31,16 @@ func NewFrequencyAnalyzer(history *collector.AlertHistory) *FrequencyAnalyzer {
 	}
 }
 
+// WithGroupBy splits results by the values of the given labels in addition to alert name.
+func (a *FrequencyAnalyzer) WithGroupBy(labels []string) *FrequencyAnalyzer {
+	a.groupBy = labels
+	return a
+}
+
 // Analyze performs frequency analysis and returns results for all alerts
 func (a *FrequencyAnalyzer) Analyze() []FrequencyResult {
-	// Group alerts by name
-	grouped := collector.GroupAlertsByName(a.history.Alerts)
+	// Group alerts by name (and the --group-by labels, if any)
+	grouped := collector.GroupAlertsByLabels(a.history.Alerts, a.groupBy)
 
 	results := make([]FrequencyResult, 0, len(grouped))
 
@@ -73,7 +80,7 @@ func (a *FrequencyAnalyzer) analyzeAlertGroup(alertName string, alerts []collect
 		firingCount++
 
 		// Accumulate total time in firing state
-		totalTime += alert.Duration()
+		totalTime += alert.DurationUntil(a.history.EndTime)
 
 		// Track the most recent firing time
 		if alert.FiredAt.After(lastFired) {
@@ -48,6 +48,25 @@ func TestFrequencyAnalyzer_Analyze(t *testing.T) {
 	assert.Equal(t, 1, results[1].FiringCount)
 }
 
+func TestFrequencyAnalyzer_UnresolvedAlertEndsWithHistory(t *testing.T) {
+	// A saved history whose last alert was still firing when it was exported.
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolvedAt := start.Add(10 * time.Minute)
+	history := &collector.AlertHistory{
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Alerts: []collector.Alert{
+			{Name: "DiskFull", FiredAt: start, ResolvedAt: &resolvedAt},
+			{Name: "DiskFull", FiredAt: start.Add(30 * time.Minute), State: "firing"},
+		},
+	}
+
+	results := NewFrequencyAnalyzer(history).Analyze()
+	assert.Len(t, results, 1)
+	assert.Equal(t, 40*time.Minute, results[0].TotalTime, "the firing alert counts until the end of the history, not until now")
+	assert.Equal(t, 20*time.Minute, results[0].AvgDuration)
+}
+
 func TestFrequencyAnalyzer_AnalyzeTopN(t *testing.T) {
 	alerts := []collector.Alert{
 		{Name: "AlertA"}, {Name: "AlertA"},
@@ -62,6 +81,22 @@ func TestFrequencyAnalyzer_AnalyzeTopN(t *testing.T) {
 	assert.Equal(t, "AlertA", results[0].AlertName)
 }
 
+func TestFrequencyAnalyzer_WithGroupBy(t *testing.T) {
+	alerts := []collector.Alert{
+		{Name: "AlertA", Labels: map[string]string{"namespace": "api"}},
+		{Name: "AlertA", Labels: map[string]string{"namespace": "api"}},
+		{Name: "AlertA", Labels: map[string]string{"namespace": "api"}},
+		{Name: "AlertA", Labels: map[string]string{"namespace": "db"}},
+	}
+	history := &collector.AlertHistory{Alerts: alerts}
+
+	results := NewFrequencyAnalyzer(history).WithGroupBy([]string{"namespace"}).Analyze()
+	assert.Len(t, results, 2)
+	assert.Equal(t, "AlertA {namespace=api}", results[0].AlertName)
+	assert.Equal(t, 3, results[0].FiringCount)
+	assert.Equal(t, "AlertA {namespace=db}", results[1].AlertName)
+}
+
 func TestFrequencyAnalyzer_GetNoisyAlerts(t *testing.T) {
 	now := time.Now()
 	// AlertA: 3 firings, short duration (noisy)
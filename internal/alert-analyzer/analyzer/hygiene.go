@@ -0,0 +1,395 @@
+// Package analyzer provides frequency and pattern analysis for Prometheus alerts.
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+// Hygiene check weights; a rule passing every check scores 100.
+const (
+	hygieneRunbookWeight       = 25
+	hygieneSummaryWeight       = 15
+	hygieneDescriptionWeight   = 10
+	hygieneOwnerWeight         = 20
+	hygieneSeverityWeight      = 15
+	hygieneActionabilityWeight = 15
+
+	// DefaultHygieneMinScore is the score below which a rule is recommended for cleanup.
+	DefaultHygieneMinScore = 70
+)
+
+// Runbook check outcomes.
+const (
+	RunbookOK          = "ok"
+	RunbookMissing     = "missing"
+	RunbookInvalid     = "invalid"
+	RunbookUnreachable = "unreachable"
+	RunbookUnchecked   = "unchecked"
+	RunbookTemplated   = "templated"
+)
+
+// Hygiene checks an issue can be raised by.
+const (
+	HygieneCheckRunbook       = "runbook"
+	HygieneCheckAnnotations   = "annotations"
+	HygieneCheckOwner         = "owner"
+	HygieneCheckSeverity      = "severity"
+	HygieneCheckActionability = "actionability"
+)
+
+// DefaultAllowedSeverities are the severities accepted when none are configured.
+var DefaultAllowedSeverities = []string{"critical", "warning", "info"}
+
+// alertTemplateVars matches template references to the labels or value of a
+// firing alert, which are not known from the rule alone.
+var alertTemplateVars = regexp.MustCompile(`\$(labels|value)\b|\.(Labels|Value)\b`)
+
+// RunbookChecker verifies that a runbook URL resolves.
+type RunbookChecker interface {
+	Check(ctx context.Context, runbookURL string) error
+}
+
+// HygieneResult scores the annotations, labels and observed behavior of one alerting rule.
+type HygieneResult struct {
+	AlertName     string         `json:"alert_name"`
+	Team          string         `json:"team"`
+	Severity      string         `json:"severity"`
+	Score         int            `json:"score"`
+	RunbookURL    string         `json:"runbook_url,omitempty"`
+	RunbookStatus string         `json:"runbook_status"`
+	Firings       int            `json:"firings"`
+	AvgDuration   time.Duration  `json:"avg_duration"`
+	Issues        []HygieneIssue `json:"issues"`
+}
+
+// HygieneIssue is one failed or partially failed hygiene check.
+type HygieneIssue struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// TeamHygiene rolls up rule hygiene for every rule owned by a team.
+type TeamHygiene struct {
+	Team             string  `json:"team"`
+	Rules            int     `json:"rules"`
+	AverageScore     float64 `json:"average_score"`
+	MinScore         int     `json:"min_score"`
+	MissingRunbooks  int     `json:"missing_runbooks"`
+	BrokenRunbooks   int     `json:"broken_runbooks"`
+	AnnotationIssues int     `json:"annotation_issues"`
+	BadSeverities    int     `json:"bad_severities"`
+	ShortLived       int     `json:"short_lived"`
+}
+
+// HygieneReport holds per-rule and per-team hygiene, worst first.
+type HygieneReport struct {
+	AverageScore float64         `json:"average_score"`
+	Rules        []HygieneResult `json:"rules"`
+	Teams        []TeamHygiene   `json:"teams"`
+}
+
+// HygieneAnalyzer scores alerting rules on runbooks, annotation templates,
+// ownership and severity labels, and how actionable their firings look.
+type HygieneAnalyzer struct {
+	rules             []collector.AlertRule
+	history           *collector.AlertHistory
+	allowedSeverities map[string]bool
+	checker           RunbookChecker
+}
+
+// NewHygieneAnalyzer creates a hygiene analyzer. history may be nil, in which case
+// actionability is not scored. checker may be nil to skip runbook URL resolution,
+// e.g. when running offline; allowedSeverities defaults to DefaultAllowedSeverities.
+func NewHygieneAnalyzer(rules []collector.AlertRule, history *collector.AlertHistory, allowedSeverities []string, checker RunbookChecker) *HygieneAnalyzer {
+	if len(allowedSeverities) == 0 {
+		allowedSeverities = DefaultAllowedSeverities
+	}
+	allowed := make(map[string]bool, len(allowedSeverities))
+	for _, severity := range allowedSeverities {
+		allowed[severity] = true
+	}
+
+	return &HygieneAnalyzer{
+		rules:             rules,
+		history:           history,
+		allowedSeverities: allowed,
+		checker:           checker,
+	}
+}
+
+// Analyze scores every rule and rolls the scores up per team.
+func (a *HygieneAnalyzer) Analyze(ctx context.Context) HygieneReport {
+	frequency := make(map[string]FrequencyResult)
+	if a.history != nil {
+		for _, result := range NewFrequencyAnalyzer(a.history).Analyze() {
+			frequency[result.AlertName] = result
+		}
+	}
+
+	// Rules are often duplicated across clusters; check each runbook only once.
+	runbooks := make(map[string]error)
+	results := make([]HygieneResult, 0, len(a.rules))
+	for _, rule := range a.rules {
+		results = append(results, a.score(ctx, rule, frequency[rule.GetGroupingKey()], runbooks))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score < results[j].Score
+		}
+		return results[i].AlertName < results[j].AlertName
+	})
+
+	report := HygieneReport{Rules: results, Teams: rollUpHygiene(results)}
+	if len(results) > 0 {
+		total := 0
+		for _, result := range results {
+			total += result.Score
+		}
+		report.AverageScore = float64(total) / float64(len(results))
+	}
+	return report
+}
+
+func (a *HygieneAnalyzer) score(ctx context.Context, rule collector.AlertRule, frequency FrequencyResult, runbooks map[string]error) HygieneResult { //nolint:gocyclo // one branch per hygiene check
+	result := HygieneResult{
+		AlertName:   rule.GetGroupingKey(),
+		Team:        teamFromLabels(rule.Labels),
+		Severity:    rule.GetSeverity(),
+		Firings:     frequency.FiringCount,
+		AvgDuration: frequency.AvgDuration,
+		Issues:      make([]HygieneIssue, 0),
+	}
+	addIssue := func(check, format string, args ...interface{}) {
+		result.Issues = append(result.Issues, HygieneIssue{Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+
+	result.RunbookURL, result.RunbookStatus = a.checkRunbook(ctx, rule, runbooks)
+	switch result.RunbookStatus {
+	case RunbookOK, RunbookUnchecked, RunbookTemplated:
+		result.Score += hygieneRunbookWeight
+	case RunbookMissing:
+		addIssue(HygieneCheckRunbook, "missing runbook_url annotation")
+	case RunbookInvalid:
+		addIssue(HygieneCheckRunbook, "runbook_url is not an absolute http(s) URL")
+	case RunbookUnreachable:
+		// A dead link still tells the responder where the runbook used to live.
+		result.Score += hygieneRunbookWeight / 5
+		addIssue(HygieneCheckRunbook, "runbook_url does not resolve: %v", runbooks[result.RunbookURL])
+	}
+
+	for _, annotation := range []struct {
+		name   string
+		weight int
+	}{
+		{"summary", hygieneSummaryWeight},
+		{"description", hygieneDescriptionWeight},
+	} {
+		text := strings.TrimSpace(rule.Annotations[annotation.name])
+		if text == "" {
+			addIssue(HygieneCheckAnnotations, "missing %s annotation", annotation.name)
+			continue
+		}
+		if err := checkAnnotationTemplate(text, rule.Labels); err != nil {
+			addIssue(HygieneCheckAnnotations, "%s template error: %v", annotation.name, err)
+			continue
+		}
+		result.Score += annotation.weight
+	}
+
+	if result.Team != UnassignedTeam {
+		result.Score += hygieneOwnerWeight
+	} else {
+		addIssue(HygieneCheckOwner, "missing team or owner label")
+	}
+
+	if a.allowedSeverities[rule.Labels["severity"]] {
+		result.Score += hygieneSeverityWeight
+	} else if rule.Labels["severity"] == "" {
+		addIssue(HygieneCheckSeverity, "missing severity label")
+	} else {
+		addIssue(HygieneCheckSeverity, "severity %q is not one of %s", rule.Labels["severity"], strings.Join(a.sortedSeverities(), ", "))
+	}
+
+	// Alerts that resolve within minutes rarely leave time for anyone to act.
+	switch {
+	case result.Firings == 0 || result.AvgDuration > defaultMediumSignalAvgDuration:
+		result.Score += hygieneActionabilityWeight
+	case result.AvgDuration > defaultLowSignalAvgDuration:
+		result.Score += hygieneActionabilityWeight / 2
+		addIssue(HygieneCheckActionability, "fires for %s on average, which is borderline actionable", formatCompactDuration(result.AvgDuration))
+	default:
+		addIssue(HygieneCheckActionability, "fires for %s on average, too short to act on", formatCompactDuration(result.AvgDuration))
+	}
+
+	return result
+}
+
+// checkRunbook resolves the runbook_url annotation, rendering templates first.
+// A URL that depends on the labels or value of the firing alert is not
+// resolved, since the rule labels alone would render a different URL.
+// Check errors are cached by URL in runbooks.
+func (a *HygieneAnalyzer) checkRunbook(ctx context.Context, rule collector.AlertRule, runbooks map[string]error) (string, string) {
+	runbookURL := strings.TrimSpace(rule.Annotations["runbook_url"])
+	if runbookURL == "" {
+		return "", RunbookMissing
+	}
+	if strings.Contains(runbookURL, "{{") {
+		rendered, err := renderAnnotationTemplate(runbookURL, rule.Labels)
+		if err != nil {
+			return runbookURL, RunbookInvalid
+		}
+		if alertTemplateVars.MatchString(runbookURL) {
+			if !strings.HasPrefix(rendered, "http://") && !strings.HasPrefix(rendered, "https://") {
+				return runbookURL, RunbookInvalid
+			}
+			return runbookURL, RunbookTemplated
+		}
+		runbookURL = rendered
+	}
+
+	parsed, err := url.Parse(runbookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return runbookURL, RunbookInvalid
+	}
+	if a.checker == nil {
+		return runbookURL, RunbookUnchecked
+	}
+
+	checkErr, ok := runbooks[runbookURL]
+	if !ok {
+		checkErr = a.checker.Check(ctx, runbookURL)
+		runbooks[runbookURL] = checkErr
+	}
+	if checkErr != nil {
+		return runbookURL, RunbookUnreachable
+	}
+	return runbookURL, RunbookOK
+}
+
+func (a *HygieneAnalyzer) sortedSeverities() []string {
+	severities := make([]string, 0, len(a.allowedSeverities))
+	for severity := range a.allowedSeverities {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+	return severities
+}
+
+func rollUpHygiene(results []HygieneResult) []TeamHygiene {
+	byTeam := make(map[string]*TeamHygiene)
+	totals := make(map[string]int)
+	for _, result := range results {
+		team, ok := byTeam[result.Team]
+		if !ok {
+			team = &TeamHygiene{Team: result.Team, MinScore: result.Score}
+			byTeam[result.Team] = team
+		}
+		team.Rules++
+		totals[result.Team] += result.Score
+		if result.Score < team.MinScore {
+			team.MinScore = result.Score
+		}
+		switch result.RunbookStatus {
+		case RunbookMissing:
+			team.MissingRunbooks++
+		case RunbookInvalid, RunbookUnreachable:
+			team.BrokenRunbooks++
+		}
+		for _, issue := range result.Issues {
+			switch issue.Check {
+			case HygieneCheckAnnotations:
+				team.AnnotationIssues++
+			case HygieneCheckSeverity:
+				team.BadSeverities++
+			case HygieneCheckActionability:
+				team.ShortLived++
+			}
+		}
+	}
+
+	teams := make([]TeamHygiene, 0, len(byTeam))
+	for name, team := range byTeam {
+		team.AverageScore = float64(totals[name]) / float64(team.Rules)
+		teams = append(teams, *team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].AverageScore != teams[j].AverageScore {
+			return teams[i].AverageScore < teams[j].AverageScore
+		}
+		return teams[i].Team < teams[j].Team
+	})
+	return teams
+}
+
+// annotationTemplateHeader defines the variables Prometheus makes available
+// to annotation templates.
+const annotationTemplateHeader = "{{$labels := .Labels}}{{$externalLabels := .ExternalLabels}}{{$externalURL := .ExternalURL}}{{$value := .Value}}"
+
+// annotationTemplateData mirrors the data Prometheus passes to annotation templates.
+type annotationTemplateData struct {
+	Labels         map[string]string
+	ExternalLabels map[string]string
+	ExternalURL    string
+	Value          float64
+}
+
+// annotationTemplateFuncs stubs the template functions Prometheus provides, so
+// templates using them parse and execute without a Prometheus to query.
+var annotationTemplateFuncs = func() template.FuncMap {
+	stub := func(...interface{}) (interface{}, error) { return "", nil }
+	funcs := template.FuncMap{
+		"query": func(string) ([]interface{}, error) { return []interface{}{}, nil },
+		"first": func(interface{}) (interface{}, error) { return nil, nil },
+	}
+	for _, name := range []string{
+		"label", "value", "strvalue", "args", "reReplaceAll", "safeHtml", "match",
+		"title", "toUpper", "toLower", "graphLink", "tableLink", "sortByLabel",
+		"humanize", "humanize1024", "humanizeDuration", "humanizePercentage",
+		"humanizeTimestamp", "toTime", "toDuration", "pathPrefix", "externalURL",
+		"parseDuration", "stripPort", "stripDomain", "now", "urlUnescape",
+	} {
+		funcs[name] = stub
+	}
+	return funcs
+}()
+
+// checkAnnotationTemplate parses and executes an annotation the way Prometheus
+// does, catching syntax errors and references to fields that do not exist,
+// such as {{ .labels.instance }}.
+func checkAnnotationTemplate(text string, labels map[string]string) error {
+	_, err := renderAnnotationTemplate(text, labels)
+	return err
+}
+
+func renderAnnotationTemplate(text string, labels map[string]string) (string, error) {
+	tmpl, err := template.New("annotation").Funcs(annotationTemplateFuncs).Parse(annotationTemplateHeader + text)
+	if err != nil {
+		return "", cleanTemplateError(err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, annotationTemplateData{Labels: labels}); err != nil {
+		return "", cleanTemplateError(err)
+	}
+	return rendered.String(), nil
+}
+
+// cleanTemplateError strips the "template: annotation:1:N:" prefix, whose column
+// is offset by the variable header anyway.
+func cleanTemplateError(err error) error {
+	message := err.Error()
+	if idx := strings.LastIndex(message, ": "); idx >= 0 && strings.HasPrefix(message, "template: ") {
+		message = message[idx+2:]
+	}
+	return fmt.Errorf("%s", message)
+}
@@ -0,0 +1,205 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunbookChecker struct {
+	broken map[string]bool
+	calls  map[string]int
+}
+
+func (f *fakeRunbookChecker) Check(_ context.Context, runbookURL string) error {
+	f.calls[runbookURL]++
+	if f.broken[runbookURL] {
+		return errors.New("HTTP 404")
+	}
+	return nil
+}
+
+func hygieneTestRules() []collector.AlertRule {
+	return []collector.AlertRule{
+		{
+			Name:   "HighLatency",
+			Labels: map[string]string{"severity": "critical", "team": "api"},
+			Annotations: map[string]string{
+				"runbook_url": "https://runbooks.example.com/{{ $labels.team }}/high-latency",
+				"summary":     "High latency on {{ $labels.instance }}",
+				"description": "p99 latency is {{ $value | humanizeDuration }}",
+			},
+		},
+		{
+			Name:   "DiskFull",
+			Labels: map[string]string{"severity": "page", "team": "storage"},
+			Annotations: map[string]string{
+				"runbook_url": "https://runbooks.example.com/disk-full",
+				"summary":     "Disk full on {{ .labels.instance }}",
+			},
+		},
+		{
+			Name:   "CPUSpike",
+			Labels: map[string]string{"severity": "warning", "owner": "api"},
+			Annotations: map[string]string{
+				"runbook_url": "https://runbooks.example.com/broken",
+				"summary":     "CPU spike {{ humanise $value }}",
+				"description": "CPU usage is high",
+			},
+		},
+		{
+			Name: "Orphan",
+		},
+	}
+}
+
+func hygieneTestHistory() *collector.AlertHistory {
+	start := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	alerts := make([]collector.Alert, 0)
+	for i := 0; i < 4; i++ {
+		firedAt := start.Add(time.Duration(i) * time.Hour)
+		alerts = append(alerts,
+			collector.Alert{Name: "CPUSpike", FiredAt: firedAt, ResolvedAt: resolvedAt(firedAt, 2*time.Minute)},
+			collector.Alert{Name: "HighLatency", FiredAt: firedAt, ResolvedAt: resolvedAt(firedAt, 30*time.Minute)},
+		)
+	}
+	return &collector.AlertHistory{StartTime: start, EndTime: start.Add(24 * time.Hour), Alerts: alerts}
+}
+
+func hygieneResultByName(t *testing.T, report HygieneReport, name string) HygieneResult {
+	t.Helper()
+	for _, result := range report.Rules {
+		if result.AlertName == name {
+			return result
+		}
+	}
+	require.Failf(t, "missing hygiene result", "no result for %s", name)
+	return HygieneResult{}
+}
+
+func issueChecks(result HygieneResult) []string {
+	checks := make([]string, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		checks = append(checks, issue.Check)
+	}
+	return checks
+}
+
+func TestHygieneAnalyzer_Analyze(t *testing.T) {
+	checker := &fakeRunbookChecker{
+		broken: map[string]bool{"https://runbooks.example.com/broken": true},
+		calls:  make(map[string]int),
+	}
+	report := NewHygieneAnalyzer(hygieneTestRules(), hygieneTestHistory(), nil, checker).Analyze(context.Background())
+
+	require.Len(t, report.Rules, 4)
+	assert.Equal(t, "Orphan", report.Rules[0].AlertName, "worst rule first")
+
+	latency := hygieneResultByName(t, report, "HighLatency")
+	assert.Equal(t, 100, latency.Score)
+	assert.Equal(t, RunbookTemplated, latency.RunbookStatus, "runbooks that depend on alert labels are not resolved")
+	assert.Equal(t, "https://runbooks.example.com/{{ $labels.team }}/high-latency", latency.RunbookURL)
+	assert.Empty(t, latency.Issues)
+	assert.Equal(t, 4, latency.Firings)
+
+	disk := hygieneResultByName(t, report, "DiskFull")
+	assert.Equal(t, hygieneRunbookWeight+hygieneOwnerWeight+hygieneActionabilityWeight, disk.Score)
+	assert.Equal(t, []string{HygieneCheckAnnotations, HygieneCheckAnnotations, HygieneCheckSeverity}, issueChecks(disk))
+	assert.Contains(t, disk.Issues[0].Message, "summary template error")
+	assert.Contains(t, disk.Issues[0].Message, "can't evaluate field labels")
+	assert.Equal(t, "missing description annotation", disk.Issues[1].Message)
+	assert.Contains(t, disk.Issues[2].Message, `severity "page" is not one of critical, info, warning`)
+
+	cpu := hygieneResultByName(t, report, "CPUSpike")
+	assert.Equal(t, RunbookUnreachable, cpu.RunbookStatus)
+	assert.Equal(t, "api", cpu.Team, "owner label is used when team is missing")
+	assert.Equal(t, []string{HygieneCheckRunbook, HygieneCheckAnnotations, HygieneCheckActionability}, issueChecks(cpu))
+	assert.Contains(t, cpu.Issues[1].Message, `function "humanise" not defined`)
+	assert.Equal(t, hygieneRunbookWeight/5+hygieneDescriptionWeight+hygieneOwnerWeight+hygieneSeverityWeight, cpu.Score)
+
+	orphan := hygieneResultByName(t, report, "Orphan")
+	assert.Equal(t, hygieneActionabilityWeight, orphan.Score)
+	assert.Equal(t, RunbookMissing, orphan.RunbookStatus)
+	assert.Equal(t, UnassignedTeam, orphan.Team)
+
+	require.Len(t, report.Teams, 3)
+	assert.Equal(t, UnassignedTeam, report.Teams[0].Team)
+	api := report.Teams[2]
+	assert.Equal(t, "api", api.Team)
+	assert.Equal(t, 2, api.Rules)
+	assert.Equal(t, cpu.Score, api.MinScore)
+	assert.InDelta(t, float64(latency.Score+cpu.Score)/2, api.AverageScore, 0.001)
+	assert.Equal(t, 1, api.BrokenRunbooks)
+	assert.Equal(t, 1, api.AnnotationIssues)
+	assert.Equal(t, 1, api.ShortLived)
+	assert.Equal(t, 1, report.Teams[1].BadSeverities)
+
+	assert.InDelta(t, float64(latency.Score+disk.Score+cpu.Score+orphan.Score)/4, report.AverageScore, 0.001)
+}
+
+func TestHygieneAnalyzer_RunbookChecks(t *testing.T) {
+	rules := []collector.AlertRule{
+		{Name: "A", Cluster: "prod", Annotations: map[string]string{"runbook_url": "https://runbooks.example.com/a"}},
+		{Name: "A", Cluster: "staging", Annotations: map[string]string{"runbook_url": "https://runbooks.example.com/a"}},
+		{Name: "B", Annotations: map[string]string{"runbook_url": "wiki/b"}},
+	}
+
+	t.Run("each URL is checked once", func(t *testing.T) {
+		checker := &fakeRunbookChecker{calls: make(map[string]int)}
+		report := NewHygieneAnalyzer(rules, nil, nil, checker).Analyze(context.Background())
+
+		assert.Equal(t, map[string]int{"https://runbooks.example.com/a": 1}, checker.calls)
+		assert.Equal(t, RunbookInvalid, hygieneResultByName(t, report, "B").RunbookStatus)
+		assert.Equal(t, RunbookOK, hygieneResultByName(t, report, "A [prod]").RunbookStatus)
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		templated := []collector.AlertRule{
+			{Name: "Labels", Labels: map[string]string{"team": "api"}, Annotations: map[string]string{"runbook_url": "https://runbooks.example.com/{{ $labels.namespace }}/labels"}},
+			{Name: "Value", Annotations: map[string]string{"runbook_url": "https://runbooks.example.com/value?v={{ $value }}"}},
+			{Name: "Dot", Annotations: map[string]string{"runbook_url": "https://runbooks.example.com/{{ .Labels.namespace }}"}},
+			{Name: "External", Annotations: map[string]string{"runbook_url": "https://runbooks.example.com/{{ if true }}external{{ end }}"}},
+			{Name: "Relative", Annotations: map[string]string{"runbook_url": "{{ $labels.namespace }}/relative"}},
+		}
+		checker := &fakeRunbookChecker{calls: make(map[string]int)}
+		report := NewHygieneAnalyzer(templated, nil, nil, checker).Analyze(context.Background())
+
+		assert.Equal(t, map[string]int{"https://runbooks.example.com/external": 1}, checker.calls, "only URLs known from the rule are requested")
+		for _, name := range []string{"Labels", "Value", "Dot"} {
+			result := hygieneResultByName(t, report, name)
+			assert.Equal(t, RunbookTemplated, result.RunbookStatus, name)
+			assert.NotContains(t, issueChecks(result), HygieneCheckRunbook, name)
+		}
+		assert.Equal(t, RunbookOK, hygieneResultByName(t, report, "External").RunbookStatus)
+		assert.Equal(t, RunbookInvalid, hygieneResultByName(t, report, "Relative").RunbookStatus)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		report := NewHygieneAnalyzer(rules, nil, nil, nil).Analyze(context.Background())
+
+		result := hygieneResultByName(t, report, "A [staging]")
+		assert.Equal(t, RunbookUnchecked, result.RunbookStatus)
+		assert.NotContains(t, issueChecks(result), HygieneCheckRunbook)
+	})
+}
+
+func TestHygieneAnalyzer_AllowedSeverities(t *testing.T) {
+	rules := []collector.AlertRule{{Name: "A", Labels: map[string]string{"severity": "page"}}}
+
+	report := NewHygieneAnalyzer(rules, nil, []string{"page", "ticket"}, nil).Analyze(context.Background())
+
+	assert.NotContains(t, issueChecks(report.Rules[0]), HygieneCheckSeverity)
+}
+
+func TestHygieneAnalyzer_Empty(t *testing.T) {
+	report := NewHygieneAnalyzer(nil, nil, nil, nil).Analyze(context.Background())
+
+	assert.Empty(t, report.Rules)
+	assert.Empty(t, report.Teams)
+	assert.Zero(t, report.AverageScore)
+}
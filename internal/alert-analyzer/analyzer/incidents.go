@@ -0,0 +1,439 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+const (
+	// DefaultIncidentWindow is the maximum gap between firings of the same incident.
+	DefaultIncidentWindow = 5 * time.Minute
+
+	// minIncidentAlerts is the number of distinct alerts that turns a burst into an incident.
+	minIncidentAlerts = 2
+
+	// minInhibitOccurrences is how often a root must precede a member before an
+	// inhibit rule is suggested for the pair.
+	minInhibitOccurrences = 2
+)
+
+// Incident suggestion types.
+const (
+	SuggestionGroupBy     = "group_by"
+	SuggestionInhibitRule = "inhibit_rule"
+)
+
+// incidentLabels are the labels that scope an incident; alerts only join an
+// incident when they share at least one of these label values with it.
+var incidentLabels = []string{"cluster", "namespace", "service"}
+
+// rootSeverityRank orders alerts that fired at the same time, the most
+// severe first as the likelier root cause. Other severities come last.
+var rootSeverityRank = map[string]int{"critical": 0, "warning": 1, "info": 2}
+
+// Incident is a storm of distinct alerts that fired close together in the
+// same scope. Alerts are told apart by name throughout: the root candidate,
+// the alert list and the inhibit rules all use alert names.
+type Incident struct {
+	ID            int               `json:"id"`
+	Start         time.Time         `json:"start"`
+	End           time.Time         `json:"end"`
+	Duration      time.Duration     `json:"duration"`
+	RootCandidate string            `json:"root_candidate"`
+	Alerts        []string          `json:"alerts"`
+	Firings       int               `json:"firings"`
+	SharedLabels  map[string]string `json:"shared_labels,omitempty"`
+}
+
+// IncidentSuggestion is an Alertmanager configuration change that would have
+// collapsed observed incidents into fewer notifications.
+type IncidentSuggestion struct {
+	Type           string   `json:"type"`
+	GroupBy        []string `json:"group_by,omitempty"`
+	SourceMatchers []string `json:"source_matchers,omitempty"`
+	TargetMatchers []string `json:"target_matchers,omitempty"`
+	Equal          []string `json:"equal,omitempty"`
+	Incidents      int      `json:"incidents"`
+	Description    string   `json:"description"`
+}
+
+// IncidentReport contains detected incidents and the routing suggestions derived from them.
+type IncidentReport struct {
+	Incidents   []Incident           `json:"incidents"`
+	Suggestions []IncidentSuggestion `json:"suggestions"`
+}
+
+// IncidentAnalyzer clusters alerts into incidents by time proximity and shared labels.
+type IncidentAnalyzer struct {
+	history *collector.AlertHistory
+	window  time.Duration
+}
+
+// NewIncidentAnalyzer creates a new incident analyzer. A non-positive window
+// falls back to DefaultIncidentWindow.
+func NewIncidentAnalyzer(history *collector.AlertHistory, window time.Duration) *IncidentAnalyzer {
+	if window <= 0 {
+		window = DefaultIncidentWindow
+	}
+	return &IncidentAnalyzer{history: history, window: window}
+}
+
+// incidentCluster accumulates alerts while an incident is still open.
+type incidentCluster struct {
+	alerts    []collector.Alert
+	scope     map[string]map[string]bool
+	lastFired time.Time
+}
+
+// Analyze groups alerts into incidents and suggests group_by and inhibit rules.
+func (a *IncidentAnalyzer) Analyze() IncidentReport {
+	if a.history == nil || len(a.history.Alerts) == 0 {
+		return IncidentReport{Incidents: []Incident{}, Suggestions: []IncidentSuggestion{}}
+	}
+
+	alerts := make([]collector.Alert, len(a.history.Alerts))
+	copy(alerts, a.history.Alerts)
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return firedBefore(alerts[i], alerts[j])
+	})
+
+	var open, closed []*incidentCluster
+	for _, alert := range alerts {
+		// Close clusters that have been quiet for longer than the window.
+		stillOpen := open[:0]
+		for _, cluster := range open {
+			if alert.FiredAt.Sub(cluster.lastFired) > a.window {
+				closed = append(closed, cluster)
+				continue
+			}
+			stillOpen = append(stillOpen, cluster)
+		}
+		open = stillOpen
+
+		scope := alertScope(alert)
+		var target *incidentCluster
+		for _, cluster := range open {
+			if cluster.matches(scope) {
+				target = cluster
+				break
+			}
+		}
+		if target == nil {
+			target = &incidentCluster{scope: make(map[string]map[string]bool)}
+			open = append(open, target)
+		}
+		target.add(alert, scope)
+	}
+	closed = append(closed, open...)
+
+	sort.SliceStable(closed, func(i, j int) bool {
+		return firedBefore(closed[i].alerts[0], closed[j].alerts[0])
+	})
+
+	incidents := make([]Incident, 0)
+	members := make([][]collector.Alert, 0)
+	for _, cluster := range closed {
+		if countDistinctAlerts(cluster.alerts) < minIncidentAlerts {
+			continue
+		}
+		incident := a.buildIncident(cluster)
+		incident.ID = len(incidents) + 1
+		incidents = append(incidents, incident)
+		members = append(members, cluster.alerts)
+	}
+
+	suggestions := suggestGroupBy(incidents)
+	suggestions = append(suggestions, suggestInhibitRules(incidents, members)...)
+
+	sort.SliceStable(incidents, func(i, j int) bool {
+		if len(incidents[i].Alerts) == len(incidents[j].Alerts) {
+			if incidents[i].Firings == incidents[j].Firings {
+				return incidents[i].Start.Before(incidents[j].Start)
+			}
+			return incidents[i].Firings > incidents[j].Firings
+		}
+		return len(incidents[i].Alerts) > len(incidents[j].Alerts)
+	})
+
+	return IncidentReport{Incidents: incidents, Suggestions: suggestions}
+}
+
+// AnalyzeTopN returns the N largest incidents along with all suggestions;
+// n <= 0 returns every incident.
+func (a *IncidentAnalyzer) AnalyzeTopN(n int) IncidentReport {
+	report := a.Analyze()
+	if n > 0 && n < len(report.Incidents) {
+		report.Incidents = report.Incidents[:n]
+	}
+	return report
+}
+
+func (c *incidentCluster) matches(scope map[string]string) bool {
+	// Alerts without scoping labels are clustered on time alone.
+	if len(scope) == 0 || len(c.scope) == 0 {
+		return true
+	}
+	for label, value := range scope {
+		if c.scope[label][value] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *incidentCluster) add(alert collector.Alert, scope map[string]string) {
+	c.alerts = append(c.alerts, alert)
+	if alert.FiredAt.After(c.lastFired) {
+		c.lastFired = alert.FiredAt
+	}
+	for label, value := range scope {
+		if c.scope[label] == nil {
+			c.scope[label] = make(map[string]bool)
+		}
+		c.scope[label][value] = true
+	}
+}
+
+func (a *IncidentAnalyzer) buildIncident(cluster *incidentCluster) Incident {
+	start := cluster.alerts[0].FiredAt
+	end := start
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+
+	for _, alert := range cluster.alerts {
+		if alertEnd := a.alertEnd(alert); alertEnd.After(end) {
+			end = alertEnd
+		}
+		if !seen[alert.Name] {
+			seen[alert.Name] = true
+			names = append(names, alert.Name)
+		}
+	}
+
+	return Incident{
+		Start:         start,
+		End:           end,
+		Duration:      end.Sub(start),
+		RootCandidate: cluster.alerts[0].Name,
+		Alerts:        names,
+		Firings:       len(cluster.alerts),
+		SharedLabels:  sharedScope(cluster.alerts),
+	}
+}
+
+func (a *IncidentAnalyzer) alertEnd(alert collector.Alert) time.Time {
+	if alert.ResolvedAt != nil {
+		return *alert.ResolvedAt
+	}
+	if !a.history.EndTime.IsZero() {
+		return a.history.EndTime
+	}
+	return alert.FiredAt
+}
+
+// suggestGroupBy proposes grouping on the labels that stayed constant during
+// incidents, so one notification is sent per storm instead of one per alert.
+func suggestGroupBy(incidents []Incident) []IncidentSuggestion {
+	counts := make(map[string]int)
+	notifications := make(map[string]int)
+	for _, incident := range incidents {
+		keys := sortedKeys(incident.SharedLabels)
+		if len(keys) == 0 {
+			continue
+		}
+		signature := strings.Join(keys, ",")
+		counts[signature]++
+		notifications[signature] += len(incident.Alerts)
+	}
+
+	signatures := make([]string, 0, len(counts))
+	for signature := range counts {
+		signatures = append(signatures, signature)
+	}
+	sort.Slice(signatures, func(i, j int) bool {
+		if counts[signatures[i]] == counts[signatures[j]] {
+			return signatures[i] < signatures[j]
+		}
+		return counts[signatures[i]] > counts[signatures[j]]
+	})
+
+	suggestions := make([]IncidentSuggestion, 0, len(signatures))
+	for _, signature := range signatures {
+		groupBy := strings.Split(signature, ",")
+		suggestions = append(suggestions, IncidentSuggestion{
+			Type:      SuggestionGroupBy,
+			GroupBy:   groupBy,
+			Incidents: counts[signature],
+			Description: fmt.Sprintf("Grouping by [%s] instead of alertname would have sent %d notifications instead of %d",
+				strings.Join(groupBy, ", "), counts[signature], notifications[signature]),
+		})
+	}
+	return suggestions
+}
+
+// suggestInhibitRules proposes inhibiting alerts that repeatedly follow the same root candidate.
+func suggestInhibitRules(incidents []Incident, members [][]collector.Alert) []IncidentSuggestion {
+	type rootStats struct {
+		incidents int
+		targets   map[string]int
+		equal     map[string]bool
+	}
+
+	roots := make(map[string]*rootStats)
+	for i, incident := range incidents {
+		rootName := members[i][0].Name
+		stats, ok := roots[rootName]
+		if !ok {
+			stats = &rootStats{targets: make(map[string]int), equal: make(map[string]bool)}
+			for label := range incident.SharedLabels {
+				stats.equal[label] = true
+			}
+			roots[rootName] = stats
+		} else {
+			for label := range stats.equal {
+				if _, shared := incident.SharedLabels[label]; !shared {
+					delete(stats.equal, label)
+				}
+			}
+		}
+		stats.incidents++
+
+		seen := map[string]bool{rootName: true}
+		for _, alert := range members[i] {
+			if seen[alert.Name] {
+				continue
+			}
+			seen[alert.Name] = true
+			stats.targets[alert.Name]++
+		}
+	}
+
+	rootNames := make([]string, 0, len(roots))
+	for name := range roots {
+		rootNames = append(rootNames, name)
+	}
+	sort.Strings(rootNames)
+
+	suggestions := make([]IncidentSuggestion, 0)
+	for _, rootName := range rootNames {
+		stats := roots[rootName]
+		if stats.incidents < minInhibitOccurrences {
+			continue
+		}
+
+		targets := make([]string, 0)
+		for name, count := range stats.targets {
+			if count >= minInhibitOccurrences {
+				targets = append(targets, name)
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		sort.Strings(targets)
+
+		target := fmt.Sprintf("alertname=%q", targets[0])
+		if len(targets) > 1 {
+			target = fmt.Sprintf("alertname=~%q", strings.Join(targets, "|"))
+		}
+
+		suggestions = append(suggestions, IncidentSuggestion{
+			Type:           SuggestionInhibitRule,
+			SourceMatchers: []string{fmt.Sprintf("alertname=%q", rootName)},
+			TargetMatchers: []string{target},
+			Equal:          sortedKeys(stats.equal),
+			Incidents:      stats.incidents,
+			Description: fmt.Sprintf("%s started %d incidents; inhibiting %s while it fires would have suppressed the follow-up pages",
+				rootName, stats.incidents, strings.Join(targets, ", ")),
+		})
+	}
+	return suggestions
+}
+
+// alertScope returns the scoping label values of an alert.
+func alertScope(alert collector.Alert) map[string]string {
+	scope := make(map[string]string)
+	for _, label := range incidentLabels {
+		if value := alert.Labels[label]; value != "" {
+			scope[label] = value
+		}
+	}
+	if _, ok := scope["cluster"]; !ok && alert.Cluster != "" {
+		scope["cluster"] = alert.Cluster
+	}
+	return scope
+}
+
+// sharedScope returns the scoping labels that have the same value on every alert.
+func sharedScope(alerts []collector.Alert) map[string]string {
+	shared := alertScope(alerts[0])
+	for _, alert := range alerts[1:] {
+		scope := alertScope(alert)
+		for label, value := range shared {
+			if scope[label] != value {
+				delete(shared, label)
+			}
+		}
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+	return shared
+}
+
+func countDistinctAlerts(alerts []collector.Alert) int {
+	seen := make(map[string]bool)
+	for _, alert := range alerts {
+		seen[alert.Name] = true
+	}
+	return len(seen)
+}
+
+// firedBefore orders alerts by firing time. Alerts that fired at the same
+// time are ordered by severity, name, cluster and label fingerprint, so that
+// the root candidate does not depend on the order of the input.
+func firedBefore(a, b collector.Alert) bool {
+	if !a.FiredAt.Equal(b.FiredAt) {
+		return a.FiredAt.Before(b.FiredAt)
+	}
+	if rankA, rankB := severityRank(a), severityRank(b); rankA != rankB {
+		return rankA < rankB
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	if a.Cluster != b.Cluster {
+		return a.Cluster < b.Cluster
+	}
+	return labelFingerprint(a) < labelFingerprint(b)
+}
+
+func severityRank(alert collector.Alert) int {
+	if rank, ok := rootSeverityRank[alert.GetSeverity()]; ok {
+		return rank
+	}
+	return len(rootSeverityRank)
+}
+
+func labelFingerprint(alert collector.Alert) model.Fingerprint {
+	labels := make(model.LabelSet, len(alert.Labels))
+	for name, value := range alert.Labels {
+		labels[model.LabelName(name)] = model.LabelValue(value)
+	}
+	return labels.Fingerprint()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
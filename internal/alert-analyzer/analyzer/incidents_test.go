@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stormAlerts(start time.Time, cluster string) []collector.Alert {
+	return []collector.Alert{
+		{Name: "NodeDown", Cluster: cluster, Labels: map[string]string{"namespace": "kube-system"}, FiredAt: start, ResolvedAt: resolvedAt(start, 20*time.Minute)},
+		{Name: "PodCrashLooping", Cluster: cluster, Labels: map[string]string{"namespace": "payments"}, FiredAt: start.Add(time.Minute), ResolvedAt: resolvedAt(start, 15*time.Minute)},
+		{Name: "TargetDown", Cluster: cluster, Labels: map[string]string{"namespace": "payments"}, FiredAt: start.Add(3 * time.Minute), ResolvedAt: resolvedAt(start, 25*time.Minute)},
+		{Name: "PodCrashLooping", Cluster: cluster, Labels: map[string]string{"namespace": "checkout"}, FiredAt: start.Add(6 * time.Minute), ResolvedAt: resolvedAt(start, 18*time.Minute)},
+	}
+}
+
+func TestIncidentAnalyzer_Analyze(t *testing.T) {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+
+	alerts := stormAlerts(base, "prod")
+	alerts = append(alerts, stormAlerts(base.Add(24*time.Hour), "prod")...)
+	// Same time, different cluster: must not be merged into the prod storm.
+	alerts = append(alerts,
+		collector.Alert{Name: "HighLatency", Cluster: "staging", Labels: map[string]string{}, FiredAt: base.Add(2 * time.Minute), ResolvedAt: resolvedAt(base, 5*time.Minute)},
+		// Isolated single alert: not an incident.
+		collector.Alert{Name: "DiskFull", Cluster: "prod", Labels: map[string]string{}, FiredAt: base.Add(6 * time.Hour), ResolvedAt: resolvedAt(base, 7*time.Hour)},
+	)
+
+	report := NewIncidentAnalyzer(&collector.AlertHistory{Alerts: alerts}, 5*time.Minute).Analyze()
+
+	require.Len(t, report.Incidents, 2)
+	first := report.Incidents[0]
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, "NodeDown", first.RootCandidate)
+	assert.Equal(t, []string{"NodeDown", "PodCrashLooping", "TargetDown"}, first.Alerts)
+	assert.Equal(t, 4, first.Firings)
+	assert.Equal(t, base, first.Start)
+	assert.Equal(t, base.Add(25*time.Minute), first.End)
+	assert.Equal(t, map[string]string{"cluster": "prod"}, first.SharedLabels)
+
+	require.Len(t, report.Suggestions, 2)
+	groupBy := report.Suggestions[0]
+	assert.Equal(t, SuggestionGroupBy, groupBy.Type)
+	assert.Equal(t, []string{"cluster"}, groupBy.GroupBy)
+	assert.Equal(t, 2, groupBy.Incidents)
+
+	inhibit := report.Suggestions[1]
+	assert.Equal(t, SuggestionInhibitRule, inhibit.Type)
+	assert.Equal(t, []string{`alertname="NodeDown"`}, inhibit.SourceMatchers)
+	assert.Equal(t, []string{`alertname=~"PodCrashLooping|TargetDown"`}, inhibit.TargetMatchers)
+	assert.Equal(t, []string{"cluster"}, inhibit.Equal)
+}
+
+func TestIncidentAnalyzer_WindowSplitsStorms(t *testing.T) {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	alerts := []collector.Alert{
+		{Name: "A", Labels: map[string]string{"service": "api"}, FiredAt: base},
+		{Name: "B", Labels: map[string]string{"service": "api"}, FiredAt: base.Add(4 * time.Minute)},
+		{Name: "C", Labels: map[string]string{"service": "api"}, FiredAt: base.Add(20 * time.Minute)},
+	}
+
+	report := NewIncidentAnalyzer(&collector.AlertHistory{Alerts: alerts}, 5*time.Minute).Analyze()
+	require.Len(t, report.Incidents, 1)
+	assert.Equal(t, []string{"A", "B"}, report.Incidents[0].Alerts)
+	// An alert seen only once as root is not enough for an inhibit rule.
+	require.Len(t, report.Suggestions, 1)
+	assert.Equal(t, SuggestionGroupBy, report.Suggestions[0].Type)
+
+	wide := NewIncidentAnalyzer(&collector.AlertHistory{Alerts: alerts}, 30*time.Minute).Analyze()
+	require.Len(t, wide.Incidents, 1)
+	assert.Len(t, wide.Incidents[0].Alerts, 3)
+}
+
+func TestIncidentAnalyzer_SimultaneousRootIsDeterministic(t *testing.T) {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	storm := func(start time.Time) []collector.Alert {
+		return []collector.Alert{
+			{Name: "TargetDown", Labels: map[string]string{"service": "api", "severity": "warning"}, FiredAt: start},
+			{Name: "NodeDown", Labels: map[string]string{"service": "api", "severity": "critical"}, FiredAt: start},
+			{Name: "APIErrors", Labels: map[string]string{"service": "api", "severity": "warning"}, FiredAt: start},
+		}
+	}
+	alerts := append(storm(base), storm(base.Add(24*time.Hour))...)
+
+	reversed := make([]collector.Alert, len(alerts))
+	for i, alert := range alerts {
+		reversed[len(alerts)-1-i] = alert
+	}
+
+	for _, input := range [][]collector.Alert{alerts, reversed} {
+		report := NewIncidentAnalyzer(&collector.AlertHistory{Alerts: input}, 5*time.Minute).Analyze()
+		require.Len(t, report.Incidents, 2)
+		for _, incident := range report.Incidents {
+			assert.Equal(t, "NodeDown", incident.RootCandidate, "the most severe alert is the root")
+			assert.Equal(t, []string{"NodeDown", "APIErrors", "TargetDown"}, incident.Alerts)
+		}
+		require.Len(t, report.Suggestions, 2)
+		assert.Equal(t, []string{`alertname="NodeDown"`}, report.Suggestions[1].SourceMatchers)
+		assert.Equal(t, []string{`alertname=~"APIErrors|TargetDown"`}, report.Suggestions[1].TargetMatchers)
+	}
+}
+
+func TestIncidentAnalyzer_EmptyAndTopN(t *testing.T) {
+	empty := NewIncidentAnalyzer(&collector.AlertHistory{}, 0).Analyze()
+	assert.Empty(t, empty.Incidents)
+	assert.Empty(t, empty.Suggestions)
+
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	alerts := stormAlerts(base, "prod")
+	alerts = append(alerts, stormAlerts(base.Add(time.Hour), "dev")...)
+
+	top := NewIncidentAnalyzer(&collector.AlertHistory{Alerts: alerts}, 0).AnalyzeTopN(1)
+	assert.Len(t, top.Incidents, 1)
+	assert.NotEmpty(t, top.Suggestions)
+
+	all := NewIncidentAnalyzer(&collector.AlertHistory{Alerts: alerts}, 0).AnalyzeTopN(0)
+	assert.Len(t, all.Incidents, 2, "n = 0 returns every incident")
+}
@@ -0,0 +1,200 @@
+// Package analyzer provides frequency and pattern analysis for Prometheus alerts.
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+const (
+	// DefaultSkewThreshold is the share of firings a single label value must
+	// account for before it is reported as dominating the alert.
+	DefaultSkewThreshold = 0.5
+
+	// DefaultCardinalityThreshold is the number of distinct values of a label on
+	// one alert at which the label is considered to explode.
+	DefaultCardinalityThreshold = 20
+
+	// minSkewFirings keeps rarely firing alerts out of the skew report.
+	minSkewFirings = 5
+
+	// maxCardinalityExamples limits how many sample values are reported per label.
+	maxCardinalityExamples = 3
+)
+
+// LabelSkewResult shows that one value of a label causes most firings of an alert.
+type LabelSkewResult struct {
+	AlertName      string  `json:"alert_name"`
+	Severity       string  `json:"severity"`
+	Label          string  `json:"label"`
+	Value          string  `json:"value"`
+	Firings        int     `json:"firings"`
+	ValueFirings   int     `json:"value_firings"`
+	Share          float64 `json:"share"`
+	DistinctValues int     `json:"distinct_values"`
+}
+
+// CardinalityResult flags a label whose distinct values explode for one alert,
+// e.g. pod names, which break Alertmanager grouping and deduplication.
+type CardinalityResult struct {
+	AlertName      string   `json:"alert_name"`
+	Severity       string   `json:"severity"`
+	Label          string   `json:"label"`
+	DistinctValues int      `json:"distinct_values"`
+	Firings        int      `json:"firings"`
+	Series         int      `json:"series"`
+	Examples       []string `json:"examples"`
+}
+
+// LabelReport holds the label skew and cardinality findings.
+type LabelReport struct {
+	Skew        []LabelSkewResult   `json:"skew"`
+	Cardinality []CardinalityResult `json:"cardinality"`
+}
+
+// LabelAnalyzer breaks alert firings down by label values.
+type LabelAnalyzer struct {
+	history              *collector.AlertHistory
+	skewThreshold        float64
+	cardinalityThreshold int
+}
+
+// NewLabelAnalyzer creates a label analyzer. Non-positive thresholds fall back to the defaults.
+func NewLabelAnalyzer(history *collector.AlertHistory, skewThreshold float64, cardinalityThreshold int) *LabelAnalyzer {
+	if skewThreshold <= 0 || skewThreshold > 1 {
+		skewThreshold = DefaultSkewThreshold
+	}
+	if cardinalityThreshold <= 0 {
+		cardinalityThreshold = DefaultCardinalityThreshold
+	}
+	return &LabelAnalyzer{
+		history:              history,
+		skewThreshold:        skewThreshold,
+		cardinalityThreshold: cardinalityThreshold,
+	}
+}
+
+// Analyze returns label skew ordered by the firings of the dominating value and
+// high-cardinality labels ordered by their number of distinct values.
+func (a *LabelAnalyzer) Analyze() LabelReport {
+	report := LabelReport{
+		Skew:        make([]LabelSkewResult, 0),
+		Cardinality: make([]CardinalityResult, 0),
+	}
+	if a.history == nil {
+		return report
+	}
+
+	grouped := collector.GroupAlertsByName(a.history.Alerts)
+	for _, alertName := range sortedKeys(grouped) {
+		alerts := grouped[alertName]
+		values := labelValueCounts(alerts)
+		severity := alerts[0].GetSeverity()
+
+		for _, label := range sortedKeys(values) {
+			counts := values[label]
+			if len(counts) >= a.cardinalityThreshold {
+				report.Cardinality = append(report.Cardinality, CardinalityResult{
+					AlertName:      alertName,
+					Severity:       severity,
+					Label:          label,
+					DistinctValues: len(counts),
+					Firings:        len(alerts),
+					Series:         countSeries(alerts),
+					Examples:       topValues(counts, maxCardinalityExamples),
+				})
+				// Exploding labels are reported as cardinality, not as skew.
+				continue
+			}
+
+			if skew, ok := a.skew(alertName, severity, label, counts, len(alerts)); ok {
+				report.Skew = append(report.Skew, skew)
+			}
+		}
+	}
+
+	sort.SliceStable(report.Skew, func(i, j int) bool {
+		return report.Skew[i].ValueFirings > report.Skew[j].ValueFirings
+	})
+	sort.SliceStable(report.Cardinality, func(i, j int) bool {
+		return report.Cardinality[i].DistinctValues > report.Cardinality[j].DistinctValues
+	})
+
+	return report
+}
+
+// AnalyzeTopN returns the label report with each section limited to n entries.
+func (a *LabelAnalyzer) AnalyzeTopN(n int) LabelReport {
+	report := a.Analyze()
+	if n > 0 && n < len(report.Skew) {
+		report.Skew = report.Skew[:n]
+	}
+	if n > 0 && n < len(report.Cardinality) {
+		report.Cardinality = report.Cardinality[:n]
+	}
+	return report
+}
+
+// skew reports the dominating value of a label. Labels with a single value
+// (constant for the alert) and alerts with few firings are skipped.
+func (a *LabelAnalyzer) skew(alertName, severity, label string, counts map[string]int, firings int) (LabelSkewResult, bool) {
+	if firings < minSkewFirings || len(counts) < 2 {
+		return LabelSkewResult{}, false
+	}
+
+	top := topValues(counts, 1)[0]
+	share := float64(counts[top]) / float64(firings)
+	if share < a.skewThreshold {
+		return LabelSkewResult{}, false
+	}
+
+	return LabelSkewResult{
+		AlertName:      alertName,
+		Severity:       severity,
+		Label:          label,
+		Value:          top,
+		Firings:        firings,
+		ValueFirings:   counts[top],
+		Share:          share,
+		DistinctValues: len(counts),
+	}, true
+}
+
+// labelValueCounts counts firings per label value. Severity is skipped, since
+// it is part of the alert definition rather than of what fired.
+func labelValueCounts(alerts []collector.Alert) map[string]map[string]int {
+	values := make(map[string]map[string]int)
+	for _, alert := range alerts {
+		for label, value := range alert.Labels {
+			if label == "severity" {
+				continue
+			}
+			if values[label] == nil {
+				values[label] = make(map[string]int)
+			}
+			values[label][value]++
+		}
+	}
+	return values
+}
+
+// topValues returns up to n values ordered by count, ties broken alphabetically.
+func topValues(counts map[string]int, n int) []string {
+	values := sortedKeys(counts)
+	sort.SliceStable(values, func(i, j int) bool {
+		return counts[values[i]] > counts[values[j]]
+	})
+	if n < len(values) {
+		values = values[:n]
+	}
+	return values
+}
+
+func countSeries(alerts []collector.Alert) int {
+	series := make(map[string]bool)
+	for _, alert := range alerts {
+		series[alert.GetGroupingKeyBy(sortedKeys(alert.Labels))] = true
+	}
+	return len(series)
+}
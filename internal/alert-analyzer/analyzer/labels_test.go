@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func labelTestHistory() *collector.AlertHistory {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	history := &collector.AlertHistory{StartTime: base, EndTime: base.Add(24 * time.Hour)}
+
+	add := func(name string, labels map[string]string) {
+		history.Alerts = append(history.Alerts, collector.Alert{
+			Name:    name,
+			Labels:  labels,
+			FiredAt: base.Add(time.Duration(len(history.Alerts)) * time.Minute),
+		})
+	}
+
+	// HighLatency: namespace=checkout causes 9 of 10 firings.
+	for i := 0; i < 9; i++ {
+		add("HighLatency", map[string]string{"severity": "warning", "namespace": "checkout", "service": "api"})
+	}
+	add("HighLatency", map[string]string{"severity": "warning", "namespace": "search", "service": "api"})
+
+	// PodCrashLooping: a new pod name on every firing.
+	for i := 0; i < 25; i++ {
+		add("PodCrashLooping", map[string]string{"severity": "critical", "namespace": "jobs", "pod": fmt.Sprintf("worker-%02d", i)})
+	}
+
+	// DiskFull: evenly spread, nothing dominates.
+	for i := 0; i < 6; i++ {
+		add("DiskFull", map[string]string{"severity": "warning", "instance": fmt.Sprintf("node-%d", i%3)})
+	}
+
+	return history
+}
+
+func TestLabelAnalyzer_Analyze(t *testing.T) {
+	report := NewLabelAnalyzer(labelTestHistory(), DefaultSkewThreshold, DefaultCardinalityThreshold).Analyze()
+
+	require.Len(t, report.Skew, 1)
+	skew := report.Skew[0]
+	assert.Equal(t, "HighLatency", skew.AlertName)
+	assert.Equal(t, "namespace", skew.Label)
+	assert.Equal(t, "checkout", skew.Value)
+	assert.Equal(t, 9, skew.ValueFirings)
+	assert.Equal(t, 10, skew.Firings)
+	assert.InDelta(t, 0.9, skew.Share, 0.001)
+	assert.Equal(t, 2, skew.DistinctValues)
+
+	require.Len(t, report.Cardinality, 1)
+	cardinality := report.Cardinality[0]
+	assert.Equal(t, "PodCrashLooping", cardinality.AlertName)
+	assert.Equal(t, "critical", cardinality.Severity)
+	assert.Equal(t, "pod", cardinality.Label)
+	assert.Equal(t, 25, cardinality.DistinctValues)
+	assert.Equal(t, 25, cardinality.Series)
+	assert.Equal(t, []string{"worker-00", "worker-01", "worker-02"}, cardinality.Examples)
+}
+
+func TestLabelAnalyzer_Thresholds(t *testing.T) {
+	report := NewLabelAnalyzer(labelTestHistory(), 0.3, 3).Analyze()
+
+	// DiskFull's 3 instances now reach the cardinality threshold, so its
+	// instance label is reported there instead of as a 33% skew.
+	labels := make([]string, 0, len(report.Cardinality))
+	for _, result := range report.Cardinality {
+		labels = append(labels, result.AlertName+"/"+result.Label)
+	}
+	assert.Equal(t, []string{"PodCrashLooping/pod", "DiskFull/instance"}, labels)
+	require.Len(t, report.Skew, 1)
+	assert.Equal(t, "HighLatency", report.Skew[0].AlertName)
+
+	limited := NewLabelAnalyzer(labelTestHistory(), 0.3, 3).AnalyzeTopN(1)
+	assert.Len(t, limited.Cardinality, 1)
+}
+
+func TestLabelAnalyzer_Empty(t *testing.T) {
+	report := NewLabelAnalyzer(nil, 0, 0).Analyze()
+	assert.Empty(t, report.Skew)
+	assert.Empty(t, report.Cardinality)
+}
@@ -22,6 +22,7 @@ const (
 	RecommendationCategoryDeduplication = "deduplication"
 	RecommendationCategoryReview        = "review"
 	RecommendationCategoryDeadRule      = "dead_rule"
+	RecommendationCategoryHygiene       = "hygiene"
 
 	SignalToNoiseLow    = "low"
 	SignalToNoiseMedium = "medium"
@@ -148,6 +149,67 @@ func (e *RecommendationEngine) Generate(frequency []FrequencyResult, flapping []
 	return recommendations
 }
 
+// GenerateHygiene recommends cleaning up rules whose hygiene score is below minScore.
+func (e *RecommendationEngine) GenerateHygiene(report HygieneReport, minScore int) []Recommendation {
+	recommendations := make([]Recommendation, 0)
+	for _, result := range report.Rules {
+		if result.Score >= minScore {
+			continue
+		}
+
+		priority := RecommendationPriorityMedium
+		if result.Score < minScore/2 {
+			priority = RecommendationPriorityHigh
+		}
+
+		problems := make([]string, 0, len(result.Issues))
+		for _, issue := range result.Issues {
+			problems = append(problems, issue.Message)
+		}
+
+		recommendations = append(recommendations, Recommendation{
+			Category: RecommendationCategoryHygiene,
+			Priority: recommendationPriorityForSeverity(result.Severity, priority),
+			Target:   result.AlertName,
+			Summary:  fmt.Sprintf("%s scores %d/100 on rule hygiene: %s.", result.AlertName, result.Score, strings.Join(problems, "; ")),
+			Action:   hygieneAction(result.Issues),
+		})
+	}
+
+	sortRecommendations(recommendations)
+	return recommendations
+}
+
+// hygieneAction turns the failed checks of a rule into follow-up steps.
+func hygieneAction(issues []HygieneIssue) string {
+	actions := make([]string, 0, len(issues))
+	seen := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		if seen[issue.Check] {
+			continue
+		}
+		seen[issue.Check] = true
+
+		switch issue.Check {
+		case HygieneCheckRunbook:
+			actions = append(actions, "link a reachable runbook via the `runbook_url` annotation")
+		case HygieneCheckAnnotations:
+			actions = append(actions, "fix the `summary`/`description` annotation templates")
+		case HygieneCheckOwner:
+			actions = append(actions, "add a `team` or `owner` label")
+		case HygieneCheckSeverity:
+			actions = append(actions, "use one of the allowed `severity` values")
+		case HygieneCheckActionability:
+			actions = append(actions, "increase `for:` or the threshold so the alert only fires when someone can act")
+		}
+	}
+	if len(actions) == 0 {
+		return "Review the rule's annotations and labels."
+	}
+	action := strings.Join(actions, ", ")
+	return strings.ToUpper(action[:1]) + action[1:] + "."
+}
+
 func assessSignalToNoise(result FrequencyResult) string {
 	switch {
 	case result.FiringCount >= defaultNoisyAlertMinFirings && result.AvgDuration <= defaultLowSignalAvgDuration:
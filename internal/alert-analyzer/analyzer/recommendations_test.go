@@ -89,6 +89,40 @@ func TestRecommendationEngine_Generate(t *testing.T) {
 	assert.Equal(t, []string{"DatabaseConnectionFlap", "APILatencyHigh"}, recommendations[5].RelatedAlerts)
 }
 
+func TestRecommendationEngine_GenerateHygiene(t *testing.T) {
+	report := HygieneReport{Rules: []HygieneResult{
+		{
+			AlertName: "Orphan",
+			Severity:  "unknown",
+			Score:     15,
+			Issues: []HygieneIssue{
+				{Check: HygieneCheckRunbook, Message: "missing runbook_url annotation"},
+				{Check: HygieneCheckAnnotations, Message: "missing summary annotation"},
+				{Check: HygieneCheckAnnotations, Message: "missing description annotation"},
+				{Check: HygieneCheckOwner, Message: "missing team or owner label"},
+			},
+		},
+		{
+			AlertName: "CPUSpike",
+			Severity:  "warning",
+			Score:     60,
+			Issues:    []HygieneIssue{{Check: HygieneCheckActionability, Message: "fires for 2m0s on average, too short to act on"}},
+		},
+		{AlertName: "HighLatency", Severity: "critical", Score: 100, Issues: []HygieneIssue{}},
+	}}
+
+	recommendations := NewRecommendationEngine().GenerateHygiene(report, DefaultHygieneMinScore)
+
+	require.Len(t, recommendations, 2)
+	assert.Equal(t, RecommendationCategoryHygiene, recommendations[0].Category)
+	assert.Equal(t, RecommendationPriorityHigh, recommendations[0].Priority)
+	assert.Equal(t, "Orphan", recommendations[0].Target)
+	assert.Contains(t, recommendations[0].Summary, "scores 15/100")
+	assert.Equal(t, "Link a reachable runbook via the `runbook_url` annotation, fix the `summary`/`description` annotation templates, add a `team` or `owner` label.", recommendations[0].Action)
+	assert.Equal(t, RecommendationPriorityMedium, recommendations[1].Priority)
+	assert.Contains(t, recommendations[1].Action, "Increase `for:`")
+}
+
 func TestAssessSignalToNoise(t *testing.T) {
 	tests := []struct {
 		name     string
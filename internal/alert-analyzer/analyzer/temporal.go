@@ -75,6 +75,79 @@ func (a *TemporalAnalyzer) AnalyzeTopN(n int) []TemporalResult {
 	return all[:n]
 }
 
+// Heatmap counts firings of all alerts per weekday (rows, Sunday first) and hour of day (columns).
+func (a *TemporalAnalyzer) Heatmap() [][]int {
+	return a.heatmapIn(nil)
+}
+
+// heatmapIn is Heatmap with hours evaluated in location (the timestamps' own
+// zone when nil).
+func (a *TemporalAnalyzer) heatmapIn(location *time.Location) [][]int {
+	heatmap := make([][]int, len(weekdayNames))
+	for day := range heatmap {
+		heatmap[day] = make([]int, 24)
+	}
+	if a.history == nil {
+		return heatmap
+	}
+
+	for _, alert := range a.history.Alerts {
+		firedAt := alert.FiredAt
+		if location != nil {
+			firedAt = firedAt.In(location)
+		}
+		heatmap[int(firedAt.Weekday())][firedAt.Hour()]++
+	}
+	return heatmap
+}
+
+// pagingShares counts the firings of a weekday/hour heatmap that fall outside
+// business hours, at night and at weekends.
+func pagingShares(heatmap [][]int) (offHours, night, weekend int) {
+	for day, hours := range heatmap {
+		for hour, count := range hours {
+			if !businessHour(time.Weekday(day), hour) {
+				offHours += count
+			}
+			if nightHour(hour) {
+				night += count
+			}
+			if weekendDay(time.Weekday(day)) {
+				weekend += count
+			}
+		}
+	}
+	return offHours, night, weekend
+}
+
+// HourlyCounts counts firings of all alerts per complete hour of the history
+// window. The first bucket starts at the returned time, the first full hour
+// of the window; partial hours at either end are not counted.
+func (a *TemporalAnalyzer) HourlyCounts() (time.Time, []int) {
+	if a.history == nil || !a.history.EndTime.After(a.history.StartTime) {
+		return time.Time{}, nil
+	}
+	start := a.history.StartTime.Truncate(time.Hour)
+	if start.Before(a.history.StartTime) {
+		start = start.Add(time.Hour)
+	}
+	buckets := max(int(a.history.EndTime.Truncate(time.Hour).Sub(start)/time.Hour), 0)
+	return start, hourlyCounts(a.history.Alerts, start, buckets)
+}
+
+func hourlyCounts(alerts []collector.Alert, start time.Time, buckets int) []int {
+	counts := make([]int, buckets)
+	for _, alert := range alerts {
+		if alert.FiredAt.Before(start) {
+			continue
+		}
+		if bucket := int(alert.FiredAt.Sub(start) / time.Hour); bucket < buckets {
+			counts[bucket]++
+		}
+	}
+	return counts
+}
+
 func (a *TemporalAnalyzer) analyzeAlertGroup(alertName string, alerts []collector.Alert) TemporalResult {
 	hours := make([]int, 24)
 	weekdays := make([]int, 7)
@@ -90,7 +163,7 @@ func (a *TemporalAnalyzer) analyzeAlertGroup(alertName string, alerts []collecto
 		if isBusinessHour(firedAt) {
 			businessHours++
 		}
-		if firedAt.Weekday() == time.Saturday || firedAt.Weekday() == time.Sunday {
+		if weekendDay(firedAt.Weekday()) {
 			weekend++
 		}
 		if severity == "unknown" {
@@ -135,9 +208,17 @@ func maxBucket(values []int) (index, count int) {
 }
 
 func isBusinessHour(ts time.Time) bool {
-	if ts.Weekday() == time.Saturday || ts.Weekday() == time.Sunday {
-		return false
-	}
-	hour := ts.Hour()
-	return hour >= 9 && hour < 18
+	return businessHour(ts.Weekday(), ts.Hour())
+}
+
+func businessHour(day time.Weekday, hour int) bool {
+	return !weekendDay(day) && hour >= 9 && hour < 18
+}
+
+func nightHour(hour int) bool {
+	return hour >= nightStartHour || hour < nightEndHour
+}
+
+func weekendDay(day time.Weekday) bool {
+	return day == time.Saturday || day == time.Sunday
 }
@@ -70,6 +70,55 @@ func TestTemporalAnalyzer_AnalyzeTopN(t *testing.T) {
 	assert.Equal(t, "A", results[0].AlertName)
 }
 
+func TestTemporalAnalyzer_Heatmap(t *testing.T) {
+	history := &collector.AlertHistory{
+		Alerts: []collector.Alert{
+			{Name: "A", FiredAt: time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)},  // Monday
+			{Name: "B", FiredAt: time.Date(2026, 3, 16, 10, 45, 0, 0, time.UTC)}, // Monday
+			{Name: "A", FiredAt: time.Date(2026, 3, 22, 3, 0, 0, 0, time.UTC)},   // Sunday
+		},
+	}
+
+	heatmap := NewTemporalAnalyzer(history).Heatmap()
+	require.Len(t, heatmap, 7)
+	require.Len(t, heatmap[0], 24)
+	assert.Equal(t, 2, heatmap[int(time.Monday)][10])
+	assert.Equal(t, 1, heatmap[int(time.Sunday)][3])
+
+	empty := NewTemporalAnalyzer(nil).Heatmap()
+	require.Len(t, empty, 7)
+	assert.Equal(t, 0, empty[0][0])
+}
+
+func TestTemporalAnalyzer_HourlyCounts(t *testing.T) {
+	history := &collector.AlertHistory{
+		StartTime: time.Date(2026, 3, 16, 9, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 3, 16, 12, 15, 0, 0, time.UTC),
+		Alerts: []collector.Alert{
+			{Name: "A", FiredAt: time.Date(2026, 3, 16, 9, 40, 0, 0, time.UTC)}, // incomplete hour
+			{Name: "B", FiredAt: time.Date(2026, 3, 16, 11, 0, 0, 0, time.UTC)},
+			{Name: "A", FiredAt: time.Date(2026, 3, 16, 11, 59, 0, 0, time.UTC)},
+			{Name: "A", FiredAt: time.Date(2026, 3, 16, 12, 5, 0, 0, time.UTC)}, // incomplete hour
+		},
+	}
+
+	start, counts := NewTemporalAnalyzer(history).HourlyCounts()
+	assert.Equal(t, time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC), start, "the partial first hour is skipped")
+	assert.Equal(t, []int{0, 2}, counts)
+
+	history.StartTime = time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	start, counts = NewTemporalAnalyzer(history).HourlyCounts()
+	assert.Equal(t, history.StartTime, start)
+	assert.Equal(t, []int{1, 0, 2}, counts)
+
+	history.StartTime = time.Date(2026, 3, 16, 12, 5, 0, 0, time.UTC)
+	_, counts = NewTemporalAnalyzer(history).HourlyCounts()
+	assert.Empty(t, counts, "no complete hour")
+
+	_, counts = NewTemporalAnalyzer(nil).HourlyCounts()
+	assert.Empty(t, counts)
+}
+
 func TestTemporalAnalyzer_AnalyzeEmpty(t *testing.T) {
 	assert.Empty(t, NewTemporalAnalyzer(&collector.AlertHistory{}).Analyze())
 	assert.Empty(t, NewTemporalAnalyzer(nil).Analyze())
@@ -0,0 +1,194 @@
+// Package analyzer provides frequency and pattern analysis for Prometheus alerts.
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+// Trend statuses describe how an alert changed between the two windows.
+const (
+	TrendStatusNew         = "new"
+	TrendStatusDisappeared = "disappeared"
+	TrendStatusExisting    = "existing"
+)
+
+// TrendResult compares a single alert between the current and the previous window.
+type TrendResult struct {
+	AlertName             string        `json:"alert_name"`
+	Severity              string        `json:"severity"`
+	Status                string        `json:"status"`
+	FiringCount           int           `json:"firing_count"`
+	PreviousFiringCount   int           `json:"previous_firing_count"`
+	FiringDelta           int           `json:"firing_delta"`
+	TotalTime             time.Duration `json:"total_time"`
+	PreviousTotalTime     time.Duration `json:"previous_total_time"`
+	TotalTimeDelta        time.Duration `json:"total_time_delta"`
+	FlappingScore         float64       `json:"flapping_score"`
+	PreviousFlappingScore float64       `json:"previous_flapping_score"`
+	FlappingScoreDelta    float64       `json:"flapping_score_delta"`
+}
+
+// TrendReport is a period-over-period comparison of two alert histories.
+type TrendReport struct {
+	CurrentStart    time.Time     `json:"current_start"`
+	CurrentEnd      time.Time     `json:"current_end"`
+	PreviousStart   time.Time     `json:"previous_start"`
+	PreviousEnd     time.Time     `json:"previous_end"`
+	FiringDelta     int           `json:"firing_delta"`
+	FiringTimeDelta time.Duration `json:"firing_time_delta"`
+	Alerts          []TrendResult `json:"alerts"`
+	New             []string      `json:"new"`
+	Disappeared     []string      `json:"disappeared"`
+}
+
+// Lookup returns the trend for an alert grouping key, if the alert fired in either window.
+func (r *TrendReport) Lookup(alertName string) (TrendResult, bool) {
+	if r == nil {
+		return TrendResult{}, false
+	}
+	for _, result := range r.Alerts {
+		if result.AlertName == alertName {
+			return result, true
+		}
+	}
+	return TrendResult{}, false
+}
+
+// TrendAnalyzer runs frequency and flapping analysis on two windows and diffs them.
+type TrendAnalyzer struct {
+	current           *collector.AlertHistory
+	previous          *collector.AlertHistory
+	flappingThreshold float64
+	groupBy           []string
+}
+
+// NewTrendAnalyzer creates a trend analyzer comparing current against previous.
+func NewTrendAnalyzer(current, previous *collector.AlertHistory, flappingThreshold float64) *TrendAnalyzer {
+	return &TrendAnalyzer{
+		current:           current,
+		previous:          previous,
+		flappingThreshold: flappingThreshold,
+	}
+}
+
+// WithGroupBy compares alerts split by the values of the given labels, matching
+// the keys of a frequency analysis run with the same labels.
+func (a *TrendAnalyzer) WithGroupBy(labels []string) *TrendAnalyzer {
+	a.groupBy = labels
+	return a
+}
+
+// Analyze compares every alert that fired in either window, ordered by the
+// absolute change in firing count.
+func (a *TrendAnalyzer) Analyze() TrendReport {
+	current := a.summarize(a.current)
+	previous := a.summarize(a.previous)
+
+	report := TrendReport{
+		CurrentStart:  a.current.StartTime,
+		CurrentEnd:    a.current.EndTime,
+		PreviousStart: a.previous.StartTime,
+		PreviousEnd:   a.previous.EndTime,
+		Alerts:        make([]TrendResult, 0, len(current)),
+		New:           make([]string, 0),
+		Disappeared:   make([]string, 0),
+	}
+
+	names := make(map[string]bool, len(current)+len(previous))
+	for name := range current {
+		names[name] = true
+	}
+	for name := range previous {
+		names[name] = true
+	}
+
+	for _, name := range sortedKeys(names) {
+		now, inCurrent := current[name]
+		before, inPrevious := previous[name]
+
+		result := TrendResult{
+			AlertName:             name,
+			Severity:              now.severity,
+			Status:                TrendStatusExisting,
+			FiringCount:           now.firings,
+			PreviousFiringCount:   before.firings,
+			FiringDelta:           now.firings - before.firings,
+			TotalTime:             now.totalTime,
+			PreviousTotalTime:     before.totalTime,
+			TotalTimeDelta:        now.totalTime - before.totalTime,
+			FlappingScore:         now.flappingScore,
+			PreviousFlappingScore: before.flappingScore,
+			FlappingScoreDelta:    now.flappingScore - before.flappingScore,
+		}
+
+		switch {
+		case !inPrevious:
+			result.Status = TrendStatusNew
+			report.New = append(report.New, name)
+		case !inCurrent:
+			result.Status = TrendStatusDisappeared
+			result.Severity = before.severity
+			report.Disappeared = append(report.Disappeared, name)
+		}
+
+		report.FiringDelta += result.FiringDelta
+		report.FiringTimeDelta += result.TotalTimeDelta
+		report.Alerts = append(report.Alerts, result)
+	}
+
+	sort.SliceStable(report.Alerts, func(i, j int) bool {
+		return absInt(report.Alerts[i].FiringDelta) > absInt(report.Alerts[j].FiringDelta)
+	})
+
+	return report
+}
+
+// AnalyzeTopN returns the comparison limited to the n alerts that changed most.
+// The new and disappeared lists are never truncated.
+func (a *TrendAnalyzer) AnalyzeTopN(n int) TrendReport {
+	report := a.Analyze()
+	if n > 0 && n < len(report.Alerts) {
+		report.Alerts = report.Alerts[:n]
+	}
+	return report
+}
+
+type trendWindow struct {
+	severity      string
+	firings       int
+	totalTime     time.Duration
+	flappingScore float64
+}
+
+func (a *TrendAnalyzer) summarize(history *collector.AlertHistory) map[string]trendWindow {
+	windows := make(map[string]trendWindow)
+	if history == nil {
+		return windows
+	}
+
+	for _, result := range NewFrequencyAnalyzer(history).WithGroupBy(a.groupBy).Analyze() {
+		windows[result.AlertName] = trendWindow{
+			severity:  result.Severity,
+			firings:   result.FiringCount,
+			totalTime: result.TotalTime,
+		}
+	}
+
+	for _, result := range NewFlappingAnalyzer(history, a.flappingThreshold).WithGroupBy(a.groupBy).Analyze() {
+		window := windows[result.AlertName]
+		window.flappingScore = result.FlappingScore
+		windows[result.AlertName] = window
+	}
+
+	return windows
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trendHistory(start time.Time, firings map[string]int) *collector.AlertHistory {
+	history := &collector.AlertHistory{StartTime: start, EndTime: start.Add(10 * time.Hour)}
+	for _, name := range sortedKeys(firings) {
+		for i := 0; i < firings[name]; i++ {
+			firedAt := start.Add(time.Duration(i) * time.Hour)
+			history.Alerts = append(history.Alerts, collector.Alert{
+				Name:       name,
+				Labels:     map[string]string{"severity": "warning"},
+				State:      "firing",
+				FiredAt:    firedAt,
+				ResolvedAt: resolvedAt(firedAt, 10*time.Minute),
+			})
+		}
+	}
+	return history
+}
+
+func TestTrendAnalyzer_Analyze(t *testing.T) {
+	previousStart := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	currentStart := previousStart.Add(7 * 24 * time.Hour)
+
+	previous := trendHistory(previousStart, map[string]int{"HighCPU": 2, "DiskFull": 4, "OldAlert": 1})
+	current := trendHistory(currentStart, map[string]int{"HighCPU": 7, "DiskFull": 3, "NewAlert": 2})
+
+	report := NewTrendAnalyzer(current, previous, DefaultFlappingThreshold).Analyze()
+
+	require.Len(t, report.Alerts, 4)
+	assert.Equal(t, currentStart, report.CurrentStart)
+	assert.Equal(t, previousStart, report.PreviousStart)
+	assert.Equal(t, []string{"NewAlert"}, report.New)
+	assert.Equal(t, []string{"OldAlert"}, report.Disappeared)
+	assert.Equal(t, 5, report.FiringDelta)
+	assert.Equal(t, 50*time.Minute, report.FiringTimeDelta)
+
+	highCPU := report.Alerts[0]
+	assert.Equal(t, "HighCPU", highCPU.AlertName, "largest absolute change comes first")
+	assert.Equal(t, TrendStatusExisting, highCPU.Status)
+	assert.Equal(t, 7, highCPU.FiringCount)
+	assert.Equal(t, 2, highCPU.PreviousFiringCount)
+	assert.Equal(t, 5, highCPU.FiringDelta)
+	assert.Equal(t, 50*time.Minute, highCPU.TotalTimeDelta)
+	assert.InDelta(t, 1.0, highCPU.FlappingScoreDelta, 0.001)
+
+	old, ok := report.Lookup("OldAlert")
+	require.True(t, ok)
+	assert.Equal(t, TrendStatusDisappeared, old.Status)
+	assert.Equal(t, "warning", old.Severity)
+	assert.Equal(t, -1, old.FiringDelta)
+
+	_, ok = report.Lookup("Unknown")
+	assert.False(t, ok)
+}
+
+func TestTrendAnalyzer_AnalyzeTopN(t *testing.T) {
+	start := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	previous := trendHistory(start.Add(-7*24*time.Hour), map[string]int{"A": 1, "B": 1})
+	current := trendHistory(start, map[string]int{"C": 3, "D": 1})
+
+	report := NewTrendAnalyzer(current, previous, DefaultFlappingThreshold).AnalyzeTopN(1)
+
+	require.Len(t, report.Alerts, 1)
+	assert.Equal(t, "C", report.Alerts[0].AlertName)
+	assert.Equal(t, []string{"C", "D"}, report.New)
+	assert.Equal(t, []string{"A", "B"}, report.Disappeared)
+}
+
+func TestTrendAnalyzer_EmptyHistories(t *testing.T) {
+	report := NewTrendAnalyzer(&collector.AlertHistory{}, &collector.AlertHistory{}, DefaultFlappingThreshold).Analyze()
+	assert.Empty(t, report.Alerts)
+	assert.Empty(t, report.New)
+	assert.Empty(t, report.Disappeared)
+}
+
+func TestTrendAnalyzer_AlertSpanningWindows(t *testing.T) {
+	base := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	history := &collector.AlertHistory{
+		Alerts: []collector.Alert{
+			{Name: "Outage", FiredAt: base.Add(30 * time.Minute), ResolvedAt: resolvedAt(base, 80*time.Minute)},
+			{Name: "Stuck", FiredAt: base.Add(90 * time.Minute), State: "firing"},
+		},
+		StartTime: base,
+		EndTime:   base.Add(5 * time.Hour),
+	}
+
+	previous := history.Window(base, base.Add(time.Hour))
+	current := history.Window(base.Add(time.Hour), base.Add(2*time.Hour))
+	report := NewTrendAnalyzer(current, previous, DefaultFlappingThreshold).Analyze()
+
+	outage, ok := report.Lookup("Outage")
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Minute, outage.PreviousTotalTime, "firing time is clipped at the end of the previous window")
+	assert.Equal(t, 20*time.Minute, outage.TotalTime, "firing time after the previous window counts in the current one")
+
+	stuck, ok := report.Lookup("Stuck")
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Minute, stuck.TotalTime, "an unresolved alert fires until the end of the window")
+	assert.Equal(t, time.Duration(0), stuck.PreviousTotalTime)
+}
@@ -0,0 +1,295 @@
+// Package backtest replays a candidate alerting expression over historical data
+// and compares the simulated firings with what the existing rule actually did.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/rs/zerolog"
+)
+
+// maxPointsPerQuery keeps range queries below Prometheus' 11,000 points per series limit.
+const maxPointsPerQuery = 10000
+
+type prometheusAPI interface {
+	QueryRange(ctx context.Context, query string, r v1.Range) (model.Value, error)
+}
+
+// Config describes a single backtest run.
+type Config struct {
+	Expr      string
+	For       time.Duration
+	AlertName string
+	Start     time.Time
+	End       time.Time
+	Step      time.Duration
+}
+
+// Episode is one firing of one series, from the moment it started firing until it resolved.
+type Episode struct {
+	Labels     map[string]string `json:"labels"`
+	ActiveAt   time.Time         `json:"active_at"`
+	FiredAt    time.Time         `json:"fired_at"`
+	ResolvedAt *time.Time        `json:"resolved_at,omitempty"`
+	Duration   time.Duration     `json:"duration"`
+}
+
+// Summary aggregates the firing episodes of one side of the comparison.
+type Summary struct {
+	Firings         int           `json:"firings"`
+	Series          int           `json:"series"`
+	TotalFiringTime time.Duration `json:"total_firing_time"`
+	AvgDuration     time.Duration `json:"avg_duration"`
+	MedianDuration  time.Duration `json:"median_duration"`
+	MaxDuration     time.Duration `json:"max_duration"`
+}
+
+// Result is the outcome of a backtest.
+type Result struct {
+	Expr               string        `json:"expr"`
+	For                time.Duration `json:"for"`
+	AlertName          string        `json:"alert_name,omitempty"`
+	Start              time.Time     `json:"start"`
+	End                time.Time     `json:"end"`
+	Step               time.Duration `json:"step"`
+	Candidate          Summary       `json:"candidate"`
+	Actual             *Summary      `json:"actual,omitempty"`
+	FiringDelta        int           `json:"firing_delta"`
+	FiringDeltaPercent float64       `json:"firing_delta_percent"`
+	FiringTimeDelta    time.Duration `json:"firing_time_delta"`
+	CandidateEpisodes  []Episode     `json:"candidate_episodes,omitempty"`
+	SuppressedByFor    int           `json:"suppressed_by_for"`
+	PendingOnlySeries  int           `json:"pending_only_series"`
+}
+
+// Series is a sampled time series: the timestamps at which the expression returned a value.
+type Series struct {
+	Labels     map[string]string
+	Timestamps []time.Time
+}
+
+// Backtester evaluates candidate expressions through Prometheus range queries.
+type Backtester struct {
+	client prometheusAPI
+	logger *zerolog.Logger
+}
+
+// NewBacktester creates a new backtester.
+func NewBacktester(client prometheusAPI, logger *zerolog.Logger) *Backtester {
+	return &Backtester{client: client, logger: logger}
+}
+
+// Run evaluates cfg.Expr over the configured window, simulates the pending/firing
+// state machine and, when cfg.AlertName is set, compares against ALERTS history.
+func (b *Backtester) Run(ctx context.Context, cfg Config) (*Result, error) {
+	if strings.TrimSpace(cfg.Expr) == "" {
+		return nil, fmt.Errorf("expression is required")
+	}
+	if cfg.Step <= 0 {
+		return nil, fmt.Errorf("step must be positive")
+	}
+	if !cfg.End.After(cfg.Start) {
+		return nil, fmt.Errorf("end time must be after start time")
+	}
+	if cfg.For < 0 {
+		return nil, fmt.Errorf("for duration must not be negative")
+	}
+
+	b.logger.Info().
+		Str("expr", cfg.Expr).
+		Dur("for", cfg.For).
+		Time("start", cfg.Start).
+		Time("end", cfg.End).
+		Dur("step", cfg.Step).
+		Msg("Evaluating candidate expression")
+
+	candidateSeries, err := b.querySeries(ctx, cfg.Expr, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate candidate expression: %w", err)
+	}
+
+	candidate, stats := Simulate(candidateSeries, cfg.For, cfg.End, cfg.Step)
+	result := &Result{
+		Expr:              cfg.Expr,
+		For:               cfg.For,
+		AlertName:         cfg.AlertName,
+		Start:             cfg.Start,
+		End:               cfg.End,
+		Step:              cfg.Step,
+		Candidate:         Summarize(candidate),
+		CandidateEpisodes: candidate,
+		SuppressedByFor:   stats.SuppressedByFor,
+		PendingOnlySeries: stats.PendingOnlySeries,
+	}
+
+	if cfg.AlertName == "" {
+		return result, nil
+	}
+
+	query := fmt.Sprintf("ALERTS{alertname=%q, alertstate=\"firing\"}", cfg.AlertName)
+	actualSeries, err := b.querySeries(ctx, query, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alert history for %s: %w", cfg.AlertName, err)
+	}
+
+	// ALERTS only has samples while the alert is firing, so no `for` is applied.
+	actual, _ := Simulate(actualSeries, 0, cfg.End, cfg.Step)
+	actualSummary := Summarize(actual)
+	result.Actual = &actualSummary
+	result.FiringDelta = result.Candidate.Firings - actualSummary.Firings
+	result.FiringTimeDelta = result.Candidate.TotalFiringTime - actualSummary.TotalFiringTime
+	if actualSummary.Firings > 0 {
+		result.FiringDeltaPercent = float64(result.FiringDelta) / float64(actualSummary.Firings) * 100
+	}
+
+	b.logger.Info().
+		Int("candidate_firings", result.Candidate.Firings).
+		Int("actual_firings", actualSummary.Firings).
+		Msg("Backtest complete")
+
+	return result, nil
+}
+
+// querySeries runs a range query, split into chunks that stay under the
+// per-query point limit, and merges the results per series.
+func (b *Backtester) querySeries(ctx context.Context, query string, cfg Config) ([]Series, error) {
+	chunk := cfg.Step * maxPointsPerQuery
+	bySeries := make(map[model.Fingerprint]*Series)
+
+	for chunkStart := cfg.Start; !chunkStart.After(cfg.End); chunkStart = chunkStart.Add(chunk + cfg.Step) {
+		chunkEnd := chunkStart.Add(chunk)
+		if chunkEnd.After(cfg.End) {
+			chunkEnd = cfg.End
+		}
+
+		value, err := b.client.QueryRange(ctx, query, v1.Range{Start: chunkStart, End: chunkEnd, Step: cfg.Step})
+		if err != nil {
+			return nil, err
+		}
+
+		matrix, ok := value.(model.Matrix)
+		if !ok {
+			return nil, fmt.Errorf("unexpected result type: %s", value.Type())
+		}
+
+		for _, stream := range matrix {
+			fingerprint := stream.Metric.Fingerprint()
+			series, exists := bySeries[fingerprint]
+			if !exists {
+				series = &Series{Labels: seriesLabels(stream.Metric)}
+				bySeries[fingerprint] = series
+			}
+			for _, sample := range stream.Values {
+				series.Timestamps = append(series.Timestamps, sample.Timestamp.Time())
+			}
+		}
+	}
+
+	result := make([]Series, 0, len(bySeries))
+	for _, series := range bySeries {
+		result = append(result, *series)
+	}
+	return result, nil
+}
+
+// SimulationStats counts activity that never reached the firing state.
+type SimulationStats struct {
+	// SuppressedByFor is the number of pending runs that resolved before `for` elapsed.
+	SuppressedByFor int
+	// PendingOnlySeries is the number of series that were pending but never fired.
+	PendingOnlySeries int
+}
+
+// Simulate replays the Prometheus alerting state machine for each series: a
+// series becomes pending when the expression first returns it, fires once it has
+// been continuously present for forDuration, and resolves at the first step
+// without a sample. Episodes still firing at end are left unresolved.
+func Simulate(series []Series, forDuration time.Duration, end time.Time, step time.Duration) ([]Episode, SimulationStats) {
+	episodes := make([]Episode, 0)
+	stats := SimulationStats{}
+
+	for _, s := range series {
+		timestamps := append([]time.Time(nil), s.Timestamps...)
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+		fired := false
+		for i := 0; i < len(timestamps); {
+			// Collect a run of consecutive samples.
+			runStart := timestamps[i]
+			j := i
+			for j+1 < len(timestamps) && timestamps[j+1].Sub(timestamps[j]) <= step {
+				j++
+			}
+			runEnd := timestamps[j]
+			i = j + 1
+
+			firedAt := runStart.Add(forDuration)
+			if firedAt.After(runEnd) {
+				stats.SuppressedByFor++
+				continue
+			}
+			fired = true
+
+			episode := Episode{Labels: s.Labels, ActiveAt: runStart, FiredAt: firedAt}
+			resolvedAt := runEnd.Add(step)
+			if resolvedAt.After(end) {
+				episode.Duration = end.Sub(firedAt)
+			} else {
+				episode.ResolvedAt = &resolvedAt
+				episode.Duration = resolvedAt.Sub(firedAt)
+			}
+			episodes = append(episodes, episode)
+		}
+
+		if !fired && len(timestamps) > 0 {
+			stats.PendingOnlySeries++
+		}
+	}
+
+	sort.Slice(episodes, func(i, j int) bool {
+		return episodes[i].FiredAt.Before(episodes[j].FiredAt)
+	})
+	return episodes, stats
+}
+
+// Summarize aggregates episodes into firing counts and duration statistics.
+func Summarize(episodes []Episode) Summary {
+	summary := Summary{Firings: len(episodes)}
+	if len(episodes) == 0 {
+		return summary
+	}
+
+	series := make(map[uint64]bool)
+	durations := make([]time.Duration, 0, len(episodes))
+	for _, episode := range episodes {
+		series[model.LabelsToSignature(episode.Labels)] = true
+		durations = append(durations, episode.Duration)
+		summary.TotalFiringTime += episode.Duration
+		if episode.Duration > summary.MaxDuration {
+			summary.MaxDuration = episode.Duration
+		}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	summary.Series = len(series)
+	summary.AvgDuration = summary.TotalFiringTime / time.Duration(len(episodes))
+	summary.MedianDuration = durations[len(durations)/2]
+	return summary
+}
+
+func seriesLabels(metric model.Metric) map[string]string {
+	labels := make(map[string]string, len(metric))
+	for name, value := range metric {
+		if name == model.MetricNameLabel || name == "alertstate" {
+			continue
+		}
+		labels[string(name)] = string(value)
+	}
+	return labels
+}
@@ -0,0 +1,209 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePrometheus struct {
+	series  map[string]model.Matrix
+	ranges  []v1.Range
+	queries []string
+	err     error
+}
+
+func (f *fakePrometheus) QueryRange(_ context.Context, query string, r v1.Range) (model.Value, error) {
+	f.queries = append(f.queries, query)
+	f.ranges = append(f.ranges, r)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	// Return only the samples inside the requested range, like Prometheus does.
+	result := model.Matrix{}
+	for _, stream := range f.series[query] {
+		filtered := &model.SampleStream{Metric: stream.Metric}
+		for _, sample := range stream.Values {
+			ts := sample.Timestamp.Time()
+			if !ts.Before(r.Start) && !ts.After(r.End) {
+				filtered.Values = append(filtered.Values, sample)
+			}
+		}
+		if len(filtered.Values) > 0 {
+			result = append(result, filtered)
+		}
+	}
+	return result, nil
+}
+
+// runs builds a sample stream present for each [startMinute, endMinute] run (inclusive).
+func runs(base time.Time, metric model.Metric, minuteRuns ...[2]int) *model.SampleStream {
+	stream := &model.SampleStream{Metric: metric}
+	for _, run := range minuteRuns {
+		for minute := run[0]; minute <= run[1]; minute++ {
+			stream.Values = append(stream.Values, model.SamplePair{
+				Timestamp: model.TimeFromUnixNano(base.Add(time.Duration(minute) * time.Minute).UnixNano()),
+				Value:     1,
+			})
+		}
+	}
+	return stream
+}
+
+func TestSimulate(t *testing.T) {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	at := func(minute int) time.Time { return base.Add(time.Duration(minute) * time.Minute) }
+
+	series := []Series{
+		{
+			Labels: map[string]string{"instance": "a"},
+			// Runs of 3m, 12m and one still active at the end of the window.
+			Timestamps: []time.Time{at(0), at(1), at(2), at(10), at(11), at(12), at(13), at(14), at(15), at(16), at(17), at(18), at(19), at(20), at(21), at(55), at(56), at(57), at(58), at(59), at(60)},
+		},
+		{
+			Labels:     map[string]string{"instance": "b"},
+			Timestamps: []time.Time{at(30), at(31)},
+		},
+	}
+
+	t.Run("No For", func(t *testing.T) {
+		episodes, stats := Simulate(series, 0, at(60), time.Minute)
+		require.Len(t, episodes, 4)
+		assert.Equal(t, at(0), episodes[0].FiredAt)
+		require.NotNil(t, episodes[0].ResolvedAt)
+		assert.Equal(t, at(3), *episodes[0].ResolvedAt)
+		assert.Equal(t, 3*time.Minute, episodes[0].Duration)
+		assert.Equal(t, "b", episodes[2].Labels["instance"])
+		assert.Nil(t, episodes[3].ResolvedAt, "episode active at the end of the window stays open")
+		assert.Equal(t, 5*time.Minute, episodes[3].Duration)
+		assert.Zero(t, stats.SuppressedByFor)
+	})
+
+	t.Run("With For", func(t *testing.T) {
+		episodes, stats := Simulate(series, 5*time.Minute, at(60), time.Minute)
+		require.Len(t, episodes, 2)
+		assert.Equal(t, at(10), episodes[0].ActiveAt)
+		assert.Equal(t, at(15), episodes[0].FiredAt)
+		assert.Equal(t, 7*time.Minute, episodes[0].Duration)
+		assert.Equal(t, at(60), episodes[1].FiredAt)
+		assert.Equal(t, 2, stats.SuppressedByFor)
+		assert.Equal(t, 1, stats.PendingOnlySeries)
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	assert.Equal(t, Summary{}, Summarize(nil))
+
+	summary := Summarize([]Episode{
+		{Labels: map[string]string{"instance": "a"}, Duration: time.Minute},
+		{Labels: map[string]string{"instance": "a"}, Duration: 3 * time.Minute},
+		{Labels: map[string]string{"instance": "b"}, Duration: 8 * time.Minute},
+	})
+	assert.Equal(t, 3, summary.Firings)
+	assert.Equal(t, 2, summary.Series)
+	assert.Equal(t, 12*time.Minute, summary.TotalFiringTime)
+	assert.Equal(t, 4*time.Minute, summary.AvgDuration)
+	assert.Equal(t, 3*time.Minute, summary.MedianDuration)
+	assert.Equal(t, 8*time.Minute, summary.MaxDuration)
+}
+
+func TestBacktester_Run(t *testing.T) {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	expr := `rate(http_errors_total[5m]) > 0.1`
+	alerts := `ALERTS{alertname="HighErrorRate", alertstate="firing"}`
+
+	client := &fakePrometheus{series: map[string]model.Matrix{
+		expr: {
+			runs(base, model.Metric{"service": "api"}, [2]int{0, 2}, [2]int{20, 40}, [2]int{50, 51}),
+		},
+		alerts: {
+			runs(base, model.Metric{"__name__": "ALERTS", "alertname": "HighErrorRate", "alertstate": "firing", "service": "api"},
+				[2]int{1, 2}, [2]int{21, 40}, [2]int{51, 51}),
+		},
+	}}
+
+	logger := zerolog.Nop()
+	result, err := NewBacktester(client, &logger).Run(context.Background(), Config{
+		Expr:      expr,
+		For:       10 * time.Minute,
+		AlertName: "HighErrorRate",
+		Start:     base,
+		End:       base.Add(time.Hour),
+		Step:      time.Minute,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{expr, alerts}, client.queries)
+	assert.Equal(t, 1, result.Candidate.Firings)
+	assert.Equal(t, 11*time.Minute, result.Candidate.TotalFiringTime)
+	assert.Equal(t, 2, result.SuppressedByFor)
+
+	require.NotNil(t, result.Actual)
+	assert.Equal(t, 3, result.Actual.Firings)
+	assert.Equal(t, map[string]string{"service": "api"}, result.CandidateEpisodes[0].Labels)
+	assert.Equal(t, -2, result.FiringDelta)
+	assert.InDelta(t, -66.67, result.FiringDeltaPercent, 0.01)
+	assert.Equal(t, 11*time.Minute-23*time.Minute, result.FiringTimeDelta)
+}
+
+func TestBacktester_ChunksLongRanges(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expr := "up == 0"
+	// A single outage that spans the boundary between the first and second chunk.
+	boundary := maxPointsPerQuery
+	client := &fakePrometheus{series: map[string]model.Matrix{
+		expr: {runs(base, model.Metric{"job": "node"}, [2]int{boundary - 5, boundary + 5})},
+	}}
+
+	logger := zerolog.Nop()
+	result, err := NewBacktester(client, &logger).Run(context.Background(), Config{
+		Expr:  expr,
+		Start: base,
+		End:   base.Add(14 * 24 * time.Hour),
+		Step:  time.Minute,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, client.ranges, 3)
+	assert.Equal(t, base.Add(maxPointsPerQuery*time.Minute), client.ranges[0].End)
+	assert.Equal(t, client.ranges[0].End.Add(time.Minute), client.ranges[1].Start)
+	assert.Equal(t, 1, result.Candidate.Firings, "runs split across chunks are merged")
+	assert.Equal(t, 11*time.Minute, result.Candidate.TotalFiringTime)
+	assert.Nil(t, result.Actual)
+}
+
+func TestBacktester_Errors(t *testing.T) {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	logger := zerolog.Nop()
+	valid := Config{Expr: "up == 0", Start: base, End: base.Add(time.Hour), Step: time.Minute}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		err     error
+		wantErr string
+	}{
+		{name: "Missing Expr", mutate: func(c *Config) { c.Expr = " " }, wantErr: "expression is required"},
+		{name: "Invalid Step", mutate: func(c *Config) { c.Step = 0 }, wantErr: "step must be positive"},
+		{name: "Invalid Window", mutate: func(c *Config) { c.End = c.Start }, wantErr: "end time must be after start time"},
+		{name: "Negative For", mutate: func(c *Config) { c.For = -time.Minute }, wantErr: "must not be negative"},
+		{name: "Query Failure", mutate: func(*Config) {}, err: errors.New("boom"), wantErr: "failed to evaluate candidate expression: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.mutate(&cfg)
+			_, err := NewBacktester(&fakePrometheus{err: tt.err}, &logger).Run(context.Background(), cfg)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package collector
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// File format constants for exported alert history.
+const (
+	FileFormatJSON   = "json"
+	FileFormatNDJSON = "ndjson"
+)
+
+// HistoryFile is the on-disk representation of collected alert data.
+// A plain AlertHistory document is a valid HistoryFile without rules.
+type HistoryFile struct {
+	AlertHistory
+	Rules []AlertRule `json:"rules,omitempty"`
+}
+
+// historyRecord is a single NDJSON line. Lines that carry none of the
+// envelope keys are decoded as a bare Alert.
+type historyRecord struct {
+	History *AlertHistory `json:"history,omitempty"`
+	Alert   *Alert        `json:"alert,omitempty"`
+	Rule    *AlertRule    `json:"rule,omitempty"`
+}
+
+// DetectFileFormat guesses the history file format from the file extension.
+func DetectFileFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return FileFormatNDJSON
+	default:
+		return FileFormatJSON
+	}
+}
+
+// ReadHistoryFile loads alert history and optional rules from a JSON or NDJSON file.
+func ReadHistoryFile(path string) (*HistoryFile, error) {
+	f, err := os.Open(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	file, err := DecodeHistory(f, DetectFileFormat(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// DecodeHistory reads a HistoryFile in the given format.
+func DecodeHistory(r io.Reader, format string) (*HistoryFile, error) {
+	switch format {
+	case FileFormatJSON:
+		var file HistoryFile
+		if err := json.NewDecoder(r).Decode(&file); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON: %w", err)
+		}
+		file.fillWindow()
+		return &file, nil
+	case FileFormatNDJSON:
+		return decodeNDJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported history format: %s", format)
+	}
+}
+
+// EncodeHistory writes a HistoryFile in the given format.
+func EncodeHistory(w io.Writer, format string, file *HistoryFile) error {
+	if file == nil {
+		return errors.New("cannot encode nil history")
+	}
+
+	switch format {
+	case FileFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(file)
+	case FileFormatNDJSON:
+		encoder := json.NewEncoder(w)
+		header := AlertHistory{
+			StartTime: file.StartTime,
+			EndTime:   file.EndTime,
+			Source:    file.Source,
+		}
+		if err := encoder.Encode(historyRecord{History: &header}); err != nil {
+			return err
+		}
+		for i := range file.Alerts {
+			if err := encoder.Encode(historyRecord{Alert: &file.Alerts[i]}); err != nil {
+				return err
+			}
+		}
+		for i := range file.Rules {
+			if err := encoder.Encode(historyRecord{Rule: &file.Rules[i]}); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported history format: %s", format)
+	}
+}
+
+func decodeNDJSON(r io.Reader) (*HistoryFile, error) {
+	file := &HistoryFile{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var record historyRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch {
+		case record.History != nil:
+			file.StartTime = record.History.StartTime
+			file.EndTime = record.History.EndTime
+			file.Source = record.History.Source
+			file.Alerts = append(file.Alerts, record.History.Alerts...)
+		case record.Alert != nil:
+			file.Alerts = append(file.Alerts, *record.Alert)
+		case record.Rule != nil:
+			file.Rules = append(file.Rules, *record.Rule)
+		default:
+			var alert Alert
+			if err := json.Unmarshal(raw, &alert); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if alert.Name == "" {
+				return nil, fmt.Errorf("line %d: record is neither an alert nor a rule", line)
+			}
+			file.Alerts = append(file.Alerts, alert)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NDJSON: %w", err)
+	}
+
+	file.fillWindow()
+	return file, nil
+}
+
+// fillWindow derives the analysis window and source from the alerts when the file omits them.
+func (f *HistoryFile) fillWindow() {
+	if f.Source == "" {
+		f.Source = "file"
+	}
+	if !f.StartTime.IsZero() && !f.EndTime.IsZero() {
+		return
+	}
+
+	var start, end time.Time
+	for _, alert := range f.Alerts {
+		if start.IsZero() || alert.FiredAt.Before(start) {
+			start = alert.FiredAt
+		}
+		last := alert.FiredAt
+		if alert.ResolvedAt != nil {
+			last = *alert.ResolvedAt
+		}
+		if last.After(end) {
+			end = last
+		}
+	}
+
+	if f.StartTime.IsZero() {
+		f.StartTime = start
+	}
+	if f.EndTime.IsZero() {
+		f.EndTime = end
+	}
+}
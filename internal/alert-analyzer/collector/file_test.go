@@ -0,0 +1,146 @@
+package collector
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleHistoryFile() *HistoryFile {
+	start := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+	resolved := start.Add(10 * time.Minute)
+
+	return &HistoryFile{
+		AlertHistory: AlertHistory{
+			Alerts: []Alert{
+				{
+					Name:       "HighCPU",
+					Cluster:    "prod",
+					Labels:     map[string]string{"severity": "critical"},
+					State:      "inactive",
+					FiredAt:    start,
+					ActiveAt:   start,
+					ResolvedAt: &resolved,
+				},
+				{
+					Name:     "DiskFull",
+					Labels:   map[string]string{"severity": "warning"},
+					State:    "firing",
+					FiredAt:  start.Add(time.Hour),
+					ActiveAt: start.Add(time.Hour),
+				},
+			},
+			StartTime: start.Add(-time.Hour),
+			EndTime:   start.Add(2 * time.Hour),
+			Source:    "prometheus",
+		},
+		Rules: []AlertRule{
+			{Name: "HighCPU", Cluster: "prod", Duration: 5 * time.Minute},
+		},
+	}
+}
+
+func TestDetectFileFormat(t *testing.T) {
+	assert.Equal(t, FileFormatJSON, DetectFileFormat("history.json"))
+	assert.Equal(t, FileFormatNDJSON, DetectFileFormat("history.ndjson"))
+	assert.Equal(t, FileFormatNDJSON, DetectFileFormat("history.JSONL"))
+	assert.Equal(t, FileFormatJSON, DetectFileFormat(""))
+}
+
+func TestEncodeDecodeHistory_RoundTrip(t *testing.T) {
+	for _, format := range []string{FileFormatJSON, FileFormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			original := sampleHistoryFile()
+
+			var buf bytes.Buffer
+			require.NoError(t, EncodeHistory(&buf, format, original))
+
+			decoded, err := DecodeHistory(&buf, format)
+			require.NoError(t, err)
+
+			assert.Equal(t, original.StartTime, decoded.StartTime)
+			assert.Equal(t, original.EndTime, decoded.EndTime)
+			assert.Equal(t, "prometheus", decoded.Source)
+			require.Len(t, decoded.Alerts, 2)
+			assert.Equal(t, "HighCPU", decoded.Alerts[0].Name)
+			assert.Equal(t, 10*time.Minute, decoded.Alerts[0].Duration())
+			assert.Nil(t, decoded.Alerts[1].ResolvedAt)
+			require.Len(t, decoded.Rules, 1)
+			assert.Equal(t, 5*time.Minute, decoded.Rules[0].Duration)
+		})
+	}
+}
+
+func TestDecodeHistory_PlainAlertHistory(t *testing.T) {
+	input := `{"alerts":[{"name":"HighCPU","state":"firing","fired_at":"2026-03-20T10:00:00Z","resolved_at":"2026-03-20T10:30:00Z"}]}`
+
+	decoded, err := DecodeHistory(strings.NewReader(input), FileFormatJSON)
+	require.NoError(t, err)
+
+	require.Len(t, decoded.Alerts, 1)
+	assert.Empty(t, decoded.Rules)
+	assert.Equal(t, "file", decoded.Source)
+	assert.Equal(t, time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC), decoded.StartTime)
+	assert.Equal(t, time.Date(2026, 3, 20, 10, 30, 0, 0, time.UTC), decoded.EndTime)
+}
+
+func TestDecodeHistory_NDJSONBareAlerts(t *testing.T) {
+	input := `{"name":"HighCPU","state":"firing","fired_at":"2026-03-20T10:00:00Z"}
+
+{"name":"DiskFull","state":"firing","fired_at":"2026-03-20T11:00:00Z"}
+`
+
+	decoded, err := DecodeHistory(strings.NewReader(input), FileFormatNDJSON)
+	require.NoError(t, err)
+
+	require.Len(t, decoded.Alerts, 2)
+	assert.Equal(t, 2, decoded.CountUniqueAlerts())
+	assert.Equal(t, time.Date(2026, 3, 20, 11, 0, 0, 0, time.UTC), decoded.EndTime)
+}
+
+func TestDecodeHistory_Errors(t *testing.T) {
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := DecodeHistory(strings.NewReader("{"), FileFormatJSON)
+		assert.ErrorContains(t, err, "failed to decode JSON")
+	})
+
+	t.Run("Invalid NDJSON Line", func(t *testing.T) {
+		_, err := DecodeHistory(strings.NewReader("{\"name\":\"A\"}\nnot-json\n"), FileFormatNDJSON)
+		assert.ErrorContains(t, err, "line 2")
+	})
+
+	t.Run("Unknown NDJSON Record", func(t *testing.T) {
+		_, err := DecodeHistory(strings.NewReader(`{"foo":"bar"}`), FileFormatNDJSON)
+		assert.ErrorContains(t, err, "neither an alert nor a rule")
+	})
+
+	t.Run("Unsupported Format", func(t *testing.T) {
+		_, err := DecodeHistory(strings.NewReader("{}"), "yaml")
+		assert.ErrorContains(t, err, "unsupported history format")
+
+		err = EncodeHistory(new(bytes.Buffer), "yaml", sampleHistoryFile())
+		assert.ErrorContains(t, err, "unsupported history format")
+	})
+}
+
+func TestReadHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.ndjson")
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeHistory(&buf, FileFormatNDJSON, sampleHistoryFile()))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	file, err := ReadHistoryFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, file.CountAlerts())
+	assert.Len(t, file.Rules, 1)
+
+	_, err = ReadHistoryFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to open history file")
+}
@@ -33,12 +33,15 @@ func NewPrometheusCollector(client prometheusAPI, logger *zerolog.Logger) *Prome
 // Collect fetches alert history from Prometheus for the specified time range
 func (c *PrometheusCollector) Collect(ctx context.Context, clusterName string, lookback, resolution time.Duration) (*AlertHistory, error) {
 	endTime := time.Now()
-	startTime := endTime.Add(-lookback)
+	return c.CollectRange(ctx, clusterName, endTime.Add(-lookback), endTime, resolution)
+}
 
+// CollectRange fetches alert history from Prometheus between startTime and endTime.
+func (c *PrometheusCollector) CollectRange(ctx context.Context, clusterName string, startTime, endTime time.Time, resolution time.Duration) (*AlertHistory, error) {
 	c.logger.Info().
 		Time("start", startTime).
 		Time("end", endTime).
-		Dur("lookback", lookback).
+		Dur("lookback", endTime.Sub(startTime)).
 		Dur("resolution", resolution).
 		Msg("Collecting alert data from Prometheus")
 
@@ -122,6 +122,26 @@ func TestPrometheusCollector_Collect(t *testing.T) {
 	})
 }
 
+func TestPrometheusCollector_CollectRange(t *testing.T) {
+	logger := zerolog.Nop()
+	end := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-7 * 24 * time.Hour)
+
+	client := &fakePrometheusClient{
+		queryRangeFn: func(_ context.Context, _ string, r v1.Range) (model.Value, error) {
+			assert.Equal(t, start, r.Start)
+			assert.Equal(t, end, r.End)
+			return model.Matrix{}, nil
+		},
+	}
+
+	history, err := NewPrometheusCollector(client, &logger).CollectRange(context.Background(), "prod", start, end, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, start, history.StartTime)
+	assert.Equal(t, end, history.EndTime)
+	assert.Empty(t, history.Alerts)
+}
+
 func TestPrometheusCollector_ParseAlerts(t *testing.T) {
 	logger := zerolog.Nop()
 	collector := NewPrometheusCollector(&fakePrometheusClient{}, &logger)
@@ -1,6 +1,9 @@
 package collector
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Alert represents a single alert instance
 type Alert struct {
@@ -52,6 +55,22 @@ func (a *Alert) GetGroupingKey() string {
 	return a.Name
 }
 
+// GetGroupingKeyBy extends the grouping key with the values of the given labels,
+// e.g. "HighCPU [prod] {namespace=api, instance=a}". Labels the alert lacks are skipped.
+func (a *Alert) GetGroupingKeyBy(labels []string) string {
+	key := a.GetGroupingKey()
+	parts := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if value, ok := a.Labels[label]; ok {
+			parts = append(parts, label+"="+value)
+		}
+	}
+	if len(parts) == 0 {
+		return key
+	}
+	return key + " {" + strings.Join(parts, ", ") + "}"
+}
+
 // GetGroupingKey returns a unique key for grouping a rule by name + cluster.
 func (r *AlertRule) GetGroupingKey() string {
 	if r.Cluster != "" {
@@ -101,6 +120,19 @@ func (a *Alert) Duration() time.Duration {
 	return time.Since(a.FiredAt)
 }
 
+// DurationUntil returns the duration the alert was in firing state, counting
+// an alert that has not resolved as firing until end, such as the end of its
+// history. With a zero end it is Duration.
+func (a *Alert) DurationUntil(end time.Time) time.Duration {
+	if a.ResolvedAt != nil || end.IsZero() {
+		return a.Duration()
+	}
+	if end.Before(a.FiredAt) {
+		return 0
+	}
+	return end.Sub(a.FiredAt)
+}
+
 // IsResolved returns true if the alert has been resolved
 func (a *Alert) IsResolved() bool {
 	return a.ResolvedAt != nil
@@ -127,6 +159,17 @@ func GroupAlertsByName(alerts []Alert) map[string][]Alert {
 	return groups
 }
 
+// GroupAlertsByLabels groups alerts by name and cluster, split further by the
+// values of the given labels. Without labels it matches GroupAlertsByName.
+func GroupAlertsByLabels(alerts []Alert, labels []string) map[string][]Alert {
+	groups := make(map[string][]Alert)
+	for _, alert := range alerts {
+		key := alert.GetGroupingKeyBy(labels)
+		groups[key] = append(groups[key], alert)
+	}
+	return groups
+}
+
 // CountAlerts returns the total number of alerts in the history
 func (h *AlertHistory) CountAlerts() int {
 	return len(h.Alerts)
@@ -151,6 +194,36 @@ func (h *AlertHistory) Merge(other *AlertHistory) {
 	}
 }
 
+// Window returns the alerts that were firing within [start, end), with the
+// history bounds set to the window. Each alert is clipped to the window the
+// way a Prometheus query over the window reports it: an alert already firing
+// at start fires from start, and one that resolves after end is still firing.
+func (h *AlertHistory) Window(start, end time.Time) *AlertHistory {
+	window := &AlertHistory{
+		Alerts:    make([]Alert, 0),
+		StartTime: start,
+		EndTime:   end,
+		Source:    h.Source,
+	}
+	for _, alert := range h.Alerts {
+		if !alert.FiredAt.Before(end) || (alert.ResolvedAt != nil && !alert.ResolvedAt.After(start)) {
+			continue
+		}
+		if alert.FiredAt.Before(start) {
+			alert.FiredAt = start
+			if alert.ActiveAt.Before(start) {
+				alert.ActiveAt = start
+			}
+		}
+		if alert.ResolvedAt != nil && alert.ResolvedAt.After(end) {
+			alert.ResolvedAt = nil
+			alert.State = "firing"
+		}
+		window.Alerts = append(window.Alerts, alert)
+	}
+	return window
+}
+
 // CountUniqueAlerts returns the number of unique alert names
 func (h *AlertHistory) CountUniqueAlerts() int {
 	unique := make(map[string]bool)
@@ -93,6 +93,25 @@ func TestGroupAlertsByName(t *testing.T) {
 	assert.Len(t, groups["Alert2"], 1)
 }
 
+func TestGroupAlertsByLabels(t *testing.T) {
+	alerts := []Alert{
+		{Name: "Alert1", Cluster: "prod", Labels: map[string]string{"namespace": "api", "instance": "1"}},
+		{Name: "Alert1", Cluster: "prod", Labels: map[string]string{"namespace": "api", "instance": "2"}},
+		{Name: "Alert1", Cluster: "prod", Labels: map[string]string{"namespace": "db", "instance": "1"}},
+		{Name: "Alert2", Labels: map[string]string{"instance": "1"}},
+	}
+
+	groups := GroupAlertsByLabels(alerts, []string{"namespace"})
+
+	assert.Len(t, groups, 3)
+	assert.Len(t, groups["Alert1 [prod] {namespace=api}"], 2)
+	assert.Len(t, groups["Alert1 [prod] {namespace=db}"], 1)
+	assert.Len(t, groups["Alert2"], 1, "alerts without the label keep the plain key")
+
+	assert.Equal(t, "Alert1 [prod] {namespace=api, instance=2}", alerts[1].GetGroupingKeyBy([]string{"namespace", "instance"}))
+	assert.Equal(t, GroupAlertsByName(alerts), GroupAlertsByLabels(alerts, nil))
+}
+
 func TestAlertRule_GetGroupingKey(t *testing.T) {
 	t.Run("with cluster", func(t *testing.T) {
 		rule := AlertRule{Name: "HighCPU", Cluster: "prod"}
@@ -141,3 +160,61 @@ func TestAlertHistory_Merge(t *testing.T) {
 	assert.Equal(t, history2.EndTime, history1.EndTime)
 	assert.Equal(t, "prometheus1, prometheus2", history1.Source)
 }
+
+func TestAlertHistory_Window(t *testing.T) {
+	base := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	resolvedAt := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+	history := &AlertHistory{
+		Alerts: []Alert{
+			{Name: "Before", FiredAt: base.Add(-time.Hour), ResolvedAt: resolvedAt(0)},
+			{Name: "Carried", FiredAt: base.Add(-time.Hour), ResolvedAt: resolvedAt(10 * time.Minute)},
+			{Name: "Start", FiredAt: base},
+			{Name: "Inside", FiredAt: base.Add(time.Hour), ResolvedAt: resolvedAt(3 * time.Hour), State: "inactive"},
+			{Name: "End", FiredAt: base.Add(2 * time.Hour)},
+		},
+		StartTime: base.Add(-time.Hour),
+		EndTime:   base.Add(3 * time.Hour),
+		Source:    "file",
+	}
+
+	window := history.Window(base, base.Add(2*time.Hour))
+
+	names := make([]string, 0, len(window.Alerts))
+	for _, alert := range window.Alerts {
+		names = append(names, alert.Name)
+	}
+	assert.Equal(t, []string{"Carried", "Start", "Inside"}, names)
+	assert.Equal(t, base, window.StartTime)
+	assert.Equal(t, base.Add(2*time.Hour), window.EndTime)
+	assert.Equal(t, "file", window.Source)
+
+	carried := window.Alerts[0]
+	assert.Equal(t, base, carried.FiredAt, "an alert firing at the start fires from the start")
+	assert.Equal(t, 10*time.Minute, carried.DurationUntil(window.EndTime))
+
+	inside := window.Alerts[2]
+	assert.Nil(t, inside.ResolvedAt, "an alert resolving after the end is still firing")
+	assert.Equal(t, "firing", inside.State)
+	assert.Equal(t, time.Hour, inside.DurationUntil(window.EndTime))
+
+	assert.Len(t, history.Alerts, 5, "the original history is left untouched")
+	assert.Equal(t, base.Add(-time.Hour), history.Alerts[1].FiredAt)
+	assert.NotNil(t, history.Alerts[3].ResolvedAt)
+}
+
+func TestAlert_DurationUntil(t *testing.T) {
+	firedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	resolvedAt := firedAt.Add(5 * time.Minute)
+	end := firedAt.Add(time.Hour)
+
+	resolved := Alert{FiredAt: firedAt, ResolvedAt: &resolvedAt}
+	assert.Equal(t, 5*time.Minute, resolved.DurationUntil(end))
+
+	firing := Alert{FiredAt: firedAt, State: "firing"}
+	assert.Equal(t, time.Hour, firing.DurationUntil(end), "an unresolved alert fires until the end of the history")
+	assert.Equal(t, time.Duration(0), firing.DurationUntil(firedAt.Add(-time.Minute)))
+	assert.Greater(t, firing.DurationUntil(time.Time{}), time.Hour, "without an end it fires until now")
+}
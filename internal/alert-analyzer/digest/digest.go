@@ -0,0 +1,130 @@
+// Package digest builds the periodic alert digest sent to team channels:
+// top noisy alerts per team, recommendations that are new since the previous
+// digest and period-over-period deltas.
+package digest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+// DefaultOwnerLabels are checked in order to find the team owning an alert.
+var DefaultOwnerLabels = []string{"team", "owner"}
+
+// Digest is the data passed to the digest templates.
+type Digest struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Start       time.Time             `json:"start"`
+	End         time.Time             `json:"end"`
+	Stats       analyzer.SummaryStats `json:"stats"`
+	Teams       []TeamDigest          `json:"teams"`
+	// NewRecommendations were not part of the previous digest.
+	NewRecommendations []analyzer.Recommendation `json:"new_recommendations"`
+	// KnownRecommendations counts recommendations already sent in an earlier digest.
+	KnownRecommendations int                    `json:"known_recommendations"`
+	Trends               *analyzer.TrendReport  `json:"trends,omitempty"`
+	TopChanges           []analyzer.TrendResult `json:"top_changes,omitempty"`
+	PreviousDigest       time.Time              `json:"previous_digest,omitempty"`
+}
+
+// TeamDigest lists the noisiest alerts of one team.
+type TeamDigest struct {
+	Team       string                     `json:"team"`
+	Firings    int                        `json:"firings"`
+	FiringTime time.Duration              `json:"firing_time"`
+	TopAlerts  []analyzer.FrequencyResult `json:"top_alerts"`
+}
+
+// Builder assembles digests from analysis results.
+type Builder struct {
+	ownerLabels []string
+	topN        int
+}
+
+// NewBuilder creates a digest builder. ownerLabels are checked in order for
+// the owning team; topN limits the alerts listed per team and the trend changes.
+func NewBuilder(ownerLabels []string, topN int) *Builder {
+	if len(ownerLabels) == 0 {
+		ownerLabels = DefaultOwnerLabels
+	}
+	return &Builder{ownerLabels: ownerLabels, topN: topN}
+}
+
+// Build creates the digest and splits recommendations into new and known
+// ones using the state of the previous digest. trends may be nil.
+func (b *Builder) Build(history *collector.AlertHistory, recommendations []analyzer.Recommendation, trends *analyzer.TrendReport, state *State, now time.Time) Digest {
+	digest := Digest{
+		GeneratedAt:        now,
+		Start:              history.StartTime,
+		End:                history.EndTime,
+		Stats:              analyzer.NewFrequencyAnalyzer(history).GetSummaryStats(),
+		Teams:              b.teams(history),
+		NewRecommendations: make([]analyzer.Recommendation, 0),
+		Trends:             trends,
+	}
+
+	for _, recommendation := range recommendations {
+		if state.Known(recommendation) {
+			digest.KnownRecommendations++
+			continue
+		}
+		digest.NewRecommendations = append(digest.NewRecommendations, recommendation)
+	}
+	if state != nil {
+		digest.PreviousDigest = state.LastDigest
+	}
+
+	if trends != nil {
+		digest.TopChanges = trends.Alerts
+		if b.topN > 0 && b.topN < len(digest.TopChanges) {
+			digest.TopChanges = digest.TopChanges[:b.topN]
+		}
+	}
+
+	return digest
+}
+
+// teams groups the history by owning team, noisiest team first.
+func (b *Builder) teams(history *collector.AlertHistory) []TeamDigest {
+	byTeam := make(map[string][]collector.Alert)
+	for _, alert := range history.Alerts {
+		team := b.owner(alert.Labels)
+		byTeam[team] = append(byTeam[team], alert)
+	}
+
+	teams := make([]TeamDigest, 0, len(byTeam))
+	for team, alerts := range byTeam {
+		teamHistory := &collector.AlertHistory{Alerts: alerts, StartTime: history.StartTime, EndTime: history.EndTime}
+		frequency := analyzer.NewFrequencyAnalyzer(teamHistory).Analyze()
+
+		digest := TeamDigest{Team: team, TopAlerts: frequency}
+		for _, result := range frequency {
+			digest.Firings += result.FiringCount
+			digest.FiringTime += result.TotalTime
+		}
+		if b.topN > 0 && b.topN < len(frequency) {
+			digest.TopAlerts = frequency[:b.topN]
+		}
+		teams = append(teams, digest)
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].Firings != teams[j].Firings {
+			return teams[i].Firings > teams[j].Firings
+		}
+		return teams[i].Team < teams[j].Team
+	})
+	return teams
+}
+
+func (b *Builder) owner(labels map[string]string) string {
+	for _, label := range b.ownerLabels {
+		if value := labels[label]; value != "" {
+			return value
+		}
+	}
+	return analyzer.UnassignedTeam
+}
@@ -0,0 +1,95 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+var digestBase = time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+func digestAlert(name, team string, offset, duration time.Duration) collector.Alert {
+	labels := map[string]string{"severity": "warning"}
+	if team != "" {
+		labels["team"] = team
+	}
+	firedAt := digestBase.Add(offset)
+	resolvedAt := firedAt.Add(duration)
+	return collector.Alert{Name: name, Labels: labels, State: "firing", FiredAt: firedAt, ResolvedAt: &resolvedAt}
+}
+
+func digestHistory() *collector.AlertHistory {
+	return &collector.AlertHistory{
+		StartTime: digestBase,
+		EndTime:   digestBase.Add(7 * 24 * time.Hour),
+		Alerts: []collector.Alert{
+			digestAlert("DiskFull", "storage", 0, time.Hour),
+			digestAlert("DiskFull", "storage", time.Hour*2, time.Hour),
+			digestAlert("DiskFull", "storage", time.Hour*4, time.Hour),
+			digestAlert("SlowIO", "storage", 0, 10*time.Minute),
+			digestAlert("HighLatency", "payments", 0, 5*time.Minute),
+			digestAlert("HighLatency", "payments", time.Hour, 5*time.Minute),
+			digestAlert("Orphan", "", 0, time.Minute),
+		},
+	}
+}
+
+func TestBuilder_Build(t *testing.T) {
+	recommendations := []analyzer.Recommendation{
+		{Category: "tuning", Priority: "high", Target: "DiskFull", Summary: "DiskFull fired 3 times"},
+		{Category: "flapping", Priority: "medium", Target: "HighLatency", Summary: "HighLatency flaps"},
+	}
+	state := NewState()
+	state.Record(recommendations[:1], digestBase)
+
+	trends := &analyzer.TrendReport{
+		FiringDelta: 4,
+		Alerts: []analyzer.TrendResult{
+			{AlertName: "DiskFull", Status: analyzer.TrendStatusExisting, FiringDelta: 2},
+			{AlertName: "HighLatency", Status: analyzer.TrendStatusNew, FiringDelta: 2},
+		},
+	}
+
+	now := digestBase.Add(8 * 24 * time.Hour)
+	digest := NewBuilder(nil, 1).Build(digestHistory(), recommendations, trends, state, now)
+
+	assert.Equal(t, now, digest.GeneratedAt)
+	assert.Equal(t, digestBase, digest.PreviousDigest)
+	assert.Equal(t, 7, digest.Stats.TotalFirings)
+
+	require.Len(t, digest.Teams, 3)
+	assert.Equal(t, "storage", digest.Teams[0].Team)
+	assert.Equal(t, 4, digest.Teams[0].Firings)
+	assert.Equal(t, 3*time.Hour+10*time.Minute, digest.Teams[0].FiringTime)
+	require.Len(t, digest.Teams[0].TopAlerts, 1, "top alerts are limited to topN")
+	assert.Equal(t, "DiskFull", digest.Teams[0].TopAlerts[0].AlertName)
+	assert.Equal(t, "payments", digest.Teams[1].Team)
+	assert.Equal(t, analyzer.UnassignedTeam, digest.Teams[2].Team)
+
+	require.Len(t, digest.NewRecommendations, 1)
+	assert.Equal(t, "HighLatency", digest.NewRecommendations[0].Target)
+	assert.Equal(t, 1, digest.KnownRecommendations)
+
+	require.Len(t, digest.TopChanges, 1)
+	assert.Equal(t, "DiskFull", digest.TopChanges[0].AlertName)
+}
+
+func TestBuilder_OwnerLabels(t *testing.T) {
+	history := &collector.AlertHistory{Alerts: []collector.Alert{
+		{Name: "A", Labels: map[string]string{"squad": "core", "team": "platform"}},
+		{Name: "B", Labels: map[string]string{"team": "platform"}},
+	}}
+
+	digest := NewBuilder([]string{"squad", "team"}, 0).Build(history, nil, nil, nil, digestBase)
+
+	require.Len(t, digest.Teams, 2)
+	assert.Equal(t, "core", digest.Teams[0].Team)
+	assert.Equal(t, "platform", digest.Teams[1].Team)
+	assert.Empty(t, digest.NewRecommendations)
+	assert.Zero(t, digest.KnownRecommendations)
+}
@@ -0,0 +1,178 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Message is a rendered digest.
+type Message struct {
+	Subject string
+	Body    string
+	Digest  Digest
+}
+
+// Notifier delivers a digest to one destination.
+type Notifier interface {
+	// Name identifies the destination in logs and errors.
+	Name() string
+	Send(ctx context.Context, message Message) error
+}
+
+// SlackNotifier posts the digest to a Slack-compatible incoming webhook
+// (Slack, Mattermost, Rocket.Chat).
+type SlackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackNotifier creates a notifier for a Slack-compatible incoming webhook.
+func NewSlackNotifier(url string, timeout time.Duration) *SlackNotifier {
+	return &SlackNotifier{url: url, client: newHTTPClient(timeout)}
+}
+
+// Name implements Notifier.
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// Send implements Notifier.
+func (n *SlackNotifier) Send(ctx context.Context, message Message) error {
+	return postJSON(ctx, n.client, n.url, map[string]string{
+		"text": "*" + message.Subject + "*\n" + message.Body,
+	})
+}
+
+// WebhookPayload is the JSON body sent to generic webhooks.
+type WebhookPayload struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	Digest  Digest `json:"digest"`
+}
+
+// WebhookNotifier posts the rendered digest and its data as JSON.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier for a generic JSON webhook.
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: newHTTPClient(timeout)}
+}
+
+// Name implements Notifier.
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// Send implements Notifier.
+func (n *WebhookNotifier) Send(ctx context.Context, message Message) error {
+	return postJSON(ctx, n.client, n.url, WebhookPayload{
+		Subject: message.Subject,
+		Text:    message.Body,
+		Digest:  message.Digest,
+	})
+}
+
+// SMTPConfig holds e-mail delivery settings.
+type SMTPConfig struct {
+	// Address is the server as host:port.
+	Address  string
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+// SMTPNotifier sends the digest as a plain-text e-mail.
+type SMTPNotifier struct {
+	config   SMTPConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPNotifier creates an e-mail notifier. The connection is upgraded
+// with STARTTLS when the server offers it.
+func NewSMTPNotifier(cfg SMTPConfig) (*SMTPNotifier, error) {
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		return nil, fmt.Errorf("invalid SMTP server %q: %w", cfg.Address, err)
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("SMTP sender and at least one recipient are required")
+	}
+	return &SMTPNotifier{config: cfg, sendMail: smtp.SendMail}, nil
+}
+
+// Name implements Notifier.
+func (n *SMTPNotifier) Name() string {
+	return "smtp"
+}
+
+// Send implements Notifier.
+func (n *SMTPNotifier) Send(_ context.Context, message Message) error {
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		host, _, err := net.SplitHostPort(n.config.Address)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, host)
+	}
+
+	if err := n.sendMail(n.config.Address, auth, n.config.From, n.config.To, n.message(message)); err != nil {
+		return fmt.Errorf("sending e-mail: %w", err)
+	}
+	return nil
+}
+
+func (n *SMTPNotifier) message(message Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	buf.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return buf.Bytes()
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshalling payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req) //nolint:gosec // webhook URL is user-configured, SSRF is acceptable
+	if err != nil {
+		return fmt.Errorf("sending webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
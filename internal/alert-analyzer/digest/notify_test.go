@@ -0,0 +1,103 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage() Message {
+	return Message{Subject: "Alert digest", Body: "line one\nline two\n", Digest: testDigest()}
+}
+
+func TestSlackNotifier_Send(t *testing.T) {
+	var received map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, NewSlackNotifier(srv.URL, time.Second).Send(context.Background(), testMessage()))
+	assert.Equal(t, "*Alert digest*\nline one\nline two\n", received["text"])
+}
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var received WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, NewWebhookNotifier(srv.URL, time.Second).Send(context.Background(), testMessage()))
+	assert.Equal(t, "Alert digest", received.Subject)
+	assert.Equal(t, "line one\nline two\n", received.Text)
+	require.Len(t, received.Digest.Teams, 1)
+	assert.Equal(t, "storage", received.Digest.Teams[0].Team)
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := NewWebhookNotifier(srv.URL, time.Second).Send(context.Background(), testMessage())
+	assert.ErrorContains(t, err, "webhook returned status 403")
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	n, err := NewSMTPNotifier(SMTPConfig{
+		Address:  "smtp.example.com:587",
+		From:     "alerts@example.com",
+		To:       []string{"sre@example.com", "oncall@example.com"},
+		Username: "alerts",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+
+	var (
+		gotAddr string
+		gotAuth smtp.Auth
+		gotTo   []string
+		gotMsg  string
+	)
+	n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotTo, gotMsg = addr, a, to, string(msg)
+		assert.Equal(t, "alerts@example.com", from)
+		return nil
+	}
+
+	message := testMessage()
+	message.Subject = "Alert digest – week 12"
+	require.NoError(t, n.Send(context.Background(), message))
+
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.NotNil(t, gotAuth)
+	assert.Equal(t, []string{"sre@example.com", "oncall@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "To: sre@example.com, oncall@example.com\r\n")
+	assert.Contains(t, gotMsg, "Subject: =?utf-8?q?")
+	assert.Contains(t, gotMsg, "Content-Type: text/plain; charset=utf-8\r\n")
+	assert.True(t, strings.HasSuffix(gotMsg, "\r\n\r\nline one\r\nline two\r\n"))
+}
+
+func TestNewSMTPNotifier_Invalid(t *testing.T) {
+	_, err := NewSMTPNotifier(SMTPConfig{Address: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}})
+	assert.ErrorContains(t, err, "invalid SMTP server")
+
+	_, err = NewSMTPNotifier(SMTPConfig{Address: "smtp.example.com:25"})
+	assert.ErrorContains(t, err, "sender and at least one recipient")
+}
@@ -0,0 +1,112 @@
+package digest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// State records what the previous digest contained, so the next one only
+// lists recommendations that are new since then.
+type State struct {
+	LastDigest time.Time `json:"last_digest"`
+	// Recommendations maps recommendation keys to when they were first sent.
+	Recommendations map[string]time.Time `json:"recommendations"`
+}
+
+// NewState returns an empty state.
+func NewState() *State {
+	return &State{Recommendations: make(map[string]time.Time)}
+}
+
+// LoadState reads a state file. A missing file yields an empty state, as for
+// the first digest.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if errors.Is(err, os.ErrNotExist) {
+		return NewState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read digest state: %w", err)
+	}
+
+	state := NewState()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse digest state %s: %w", path, err)
+	}
+	if state.Recommendations == nil {
+		state.Recommendations = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the state atomically, so an interrupted run keeps the previous state.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode digest state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write digest state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write digest state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write digest state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write digest state: %w", err)
+	}
+	return nil
+}
+
+// Known reports whether the recommendation was part of an earlier digest.
+func (s *State) Known(recommendation analyzer.Recommendation) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.Recommendations[RecommendationKey(recommendation)]
+	return ok
+}
+
+// Record replaces the tracked recommendations with the current ones, keeping
+// when each was first sent. Recommendations that no longer apply are dropped,
+// so they count as new again if they come back.
+func (s *State) Record(recommendations []analyzer.Recommendation, now time.Time) {
+	current := make(map[string]time.Time, len(recommendations))
+	for _, recommendation := range recommendations {
+		key := RecommendationKey(recommendation)
+		if firstSent, ok := s.Recommendations[key]; ok {
+			current[key] = firstSent
+			continue
+		}
+		current[key] = now
+	}
+	s.Recommendations = current
+	s.LastDigest = now
+}
+
+// RecommendationKey identifies a recommendation across digests. The summary
+// is left out because it contains counts that change from run to run.
+func RecommendationKey(recommendation analyzer.Recommendation) string {
+	related := append([]string(nil), recommendation.RelatedAlerts...)
+	sort.Strings(related)
+	return strings.Join([]string{
+		recommendation.Category,
+		recommendation.Target,
+		strings.Join(related, ","),
+	}, "|")
+}
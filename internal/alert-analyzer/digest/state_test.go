@@ -0,0 +1,61 @@
+package digest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+func TestState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digest-state.json")
+
+	state, err := LoadState(path)
+	require.NoError(t, err, "a missing state file is the first digest")
+	assert.Empty(t, state.Recommendations)
+	assert.True(t, state.LastDigest.IsZero())
+
+	first := digestBase
+	state.Record([]analyzer.Recommendation{
+		{Category: "tuning", Target: "DiskFull"},
+		{Category: "correlation", Target: "A + B", RelatedAlerts: []string{"B", "A"}},
+	}, first)
+	require.NoError(t, state.Save(path))
+
+	loaded, err := LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, first.Equal(loaded.LastDigest))
+	assert.True(t, loaded.Known(analyzer.Recommendation{Category: "tuning", Target: "DiskFull", Summary: "fired 12 times"}),
+		"the summary does not identify a recommendation")
+	assert.True(t, loaded.Known(analyzer.Recommendation{Category: "correlation", Target: "A + B", RelatedAlerts: []string{"A", "B"}}))
+
+	second := first.Add(7 * 24 * time.Hour)
+	loaded.Record([]analyzer.Recommendation{
+		{Category: "tuning", Target: "DiskFull"},
+		{Category: "flapping", Target: "HighLatency"},
+	}, second)
+
+	assert.True(t, first.Equal(loaded.Recommendations[RecommendationKey(analyzer.Recommendation{Category: "tuning", Target: "DiskFull"})]),
+		"recommendations keep when they were first sent")
+	assert.False(t, loaded.Known(analyzer.Recommendation{Category: "correlation", Target: "A + B", RelatedAlerts: []string{"A", "B"}}),
+		"resolved recommendations are dropped")
+	assert.True(t, second.Equal(loaded.LastDigest))
+}
+
+func TestLoadState_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digest-state.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := LoadState(path)
+	assert.ErrorContains(t, err, "failed to parse digest state")
+}
+
+func TestState_KnownNil(t *testing.T) {
+	var state *State
+	assert.False(t, state.Known(analyzer.Recommendation{Category: "tuning"}))
+}
@@ -0,0 +1,130 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// subjectTemplate is the name of the optional template that renders the
+// message subject; the body is rendered by the root template.
+const subjectTemplate = "subject"
+
+const defaultSubject = `Alert digest {{ .End.Format "2006-01-02" }}: {{ .Stats.TotalFirings }} firings, {{ len .NewRecommendations }} new recommendations`
+
+// DefaultTemplate is the built-in digest body. It is plain text that reads
+// well in Slack and e-mail.
+const DefaultTemplate = `{{ define "subject" }}` + defaultSubject + `{{ end -}}
+Alert digest for {{ .Start.Format "2006-01-02" }} – {{ .End.Format "2006-01-02" }}
+{{ .Stats.TotalFirings }} firings of {{ .Stats.UniqueAlerts }} alerts, {{ duration .Stats.TotalFiringTime }} firing in total.
+{{- with .Trends }}
+Versus the previous window: {{ signed .FiringDelta }} firings, {{ signedDuration .FiringTimeDelta }} firing time
+{{- if .New }}, new: {{ join .New ", " }}{{ end }}
+{{- if .Disappeared }}, gone: {{ join .Disappeared ", " }}{{ end }}.
+{{- end }}
+
+Top noisy alerts per team
+{{- range .Teams }}
+• {{ .Team }} ({{ .Firings }} firings, {{ duration .FiringTime }})
+{{- range .TopAlerts }}
+    – {{ .AlertName }} [{{ .Severity }}]: {{ .FiringCount }} firings, avg {{ duration .AvgDuration }}
+{{- end }}
+{{- end }}
+{{- if .TopChanges }}
+
+Largest changes
+{{- range .TopChanges }}
+• {{ .AlertName }} ({{ .Status }}): {{ signed .FiringDelta }} firings, {{ signedDuration .TotalTimeDelta }}
+{{- end }}
+{{- end }}
+
+{{ if .NewRecommendations -}}
+New recommendations
+{{- range .NewRecommendations }}
+• [{{ .Priority }}] {{ .Summary }}
+    {{ .Action }}
+{{- end }}
+{{- else -}}
+No new recommendations.
+{{- end }}
+{{- if .KnownRecommendations }}
+({{ .KnownRecommendations }} recommendations from earlier digests are still open.)
+{{- end }}
+`
+
+// Template renders digests into a subject and body.
+type Template struct {
+	tmpl *template.Template
+}
+
+// ParseTemplate parses a digest template. The template may define a "subject"
+// template; otherwise the default subject is used.
+func ParseTemplate(text string) (*Template, error) {
+	tmpl, err := template.New("digest").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse digest template: %w", err)
+	}
+	if tmpl.Lookup(subjectTemplate) == nil {
+		if _, err := tmpl.New(subjectTemplate).Parse(defaultSubject); err != nil {
+			return nil, fmt.Errorf("failed to parse digest template: %w", err)
+		}
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// LoadTemplate parses the digest template in path.
+func LoadTemplate(path string) (*Template, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read digest template: %w", err)
+	}
+	return ParseTemplate(string(data))
+}
+
+// Render executes the template for digest.
+func (t *Template) Render(digest Digest) (subject, body string, err error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.ExecuteTemplate(&buf, subjectTemplate, digest); err != nil {
+		return "", "", fmt.Errorf("failed to render digest subject: %w", err)
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := t.tmpl.Execute(&buf, digest); err != nil {
+		return "", "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return subject, strings.TrimSpace(buf.String()) + "\n", nil
+}
+
+var templateFuncs = template.FuncMap{
+	"duration":       formatDuration,
+	"signed":         func(value int) string { return fmt.Sprintf("%+d", value) },
+	"signedDuration": formatSignedDuration,
+	"join":           strings.Join,
+	"upper":          strings.ToUpper,
+}
+
+// formatDuration rounds to a readable precision, e.g. "3d 4h", "2h 5m", "45s".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+func formatSignedDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDuration(-d)
+	}
+	return "+" + formatDuration(d)
+}
@@ -0,0 +1,103 @@
+package digest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+func testDigest() Digest {
+	return Digest{
+		Start: digestBase,
+		End:   digestBase.Add(7 * 24 * time.Hour),
+		Stats: analyzer.SummaryStats{TotalFirings: 7, UniqueAlerts: 3, TotalFiringTime: 3*time.Hour + 20*time.Minute},
+		Teams: []TeamDigest{
+			{Team: "storage", Firings: 4, FiringTime: 3 * time.Hour, TopAlerts: []analyzer.FrequencyResult{
+				{AlertName: "DiskFull", Severity: "warning", FiringCount: 3, AvgDuration: time.Hour},
+			}},
+		},
+		NewRecommendations: []analyzer.Recommendation{
+			{Priority: "high", Summary: "HighLatency flaps", Action: "Add a for: duration"},
+		},
+		KnownRecommendations: 2,
+		Trends: &analyzer.TrendReport{
+			FiringDelta:     -3,
+			FiringTimeDelta: 90 * time.Minute,
+			New:             []string{"HighLatency"},
+		},
+		TopChanges: []analyzer.TrendResult{
+			{AlertName: "DiskFull", Status: analyzer.TrendStatusExisting, FiringDelta: 2, TotalTimeDelta: -30 * time.Minute},
+		},
+	}
+}
+
+func TestDefaultTemplate_Render(t *testing.T) {
+	tmpl, err := ParseTemplate(DefaultTemplate)
+	require.NoError(t, err)
+
+	subject, body, err := tmpl.Render(testDigest())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Alert digest 2026-03-23: 7 firings, 1 new recommendations", subject)
+	assert.Contains(t, body, "Alert digest for 2026-03-16 – 2026-03-23")
+	assert.Contains(t, body, "7 firings of 3 alerts, 3h 20m firing in total.")
+	assert.Contains(t, body, "Versus the previous window: -3 firings, +1h 30m firing time, new: HighLatency.")
+	assert.Contains(t, body, "• storage (4 firings, 3h 0m)")
+	assert.Contains(t, body, "– DiskFull [warning]: 3 firings, avg 1h 0m")
+	assert.Contains(t, body, "• DiskFull (existing): +2 firings, -30m 0s")
+	assert.Contains(t, body, "• [high] HighLatency flaps\n    Add a for: duration")
+	assert.Contains(t, body, "(2 recommendations from earlier digests are still open.)")
+	assert.NotContains(t, body, "subject")
+}
+
+func TestDefaultTemplate_NoRecommendations(t *testing.T) {
+	tmpl, err := ParseTemplate(DefaultTemplate)
+	require.NoError(t, err)
+
+	digest := testDigest()
+	digest.NewRecommendations = nil
+	digest.KnownRecommendations = 0
+	digest.Trends = nil
+	digest.TopChanges = nil
+
+	_, body, err := tmpl.Render(digest)
+	require.NoError(t, err)
+	assert.Contains(t, body, "No new recommendations.")
+	assert.NotContains(t, body, "Versus the previous window")
+	assert.NotContains(t, body, "Largest changes")
+}
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	custom := filepath.Join(dir, "custom.tmpl")
+	require.NoError(t, os.WriteFile(custom, []byte(`{{ define "subject" }}[{{ upper (index .Teams 0).Team }}] weekly alerts{{ end }}{{ range .Teams }}{{ .Team }}={{ .Firings }}{{ end }}`), 0o600))
+	tmpl, err := LoadTemplate(custom)
+	require.NoError(t, err)
+	subject, body, err := tmpl.Render(testDigest())
+	require.NoError(t, err)
+	assert.Equal(t, "[STORAGE] weekly alerts", subject)
+	assert.Equal(t, "storage=4\n", body)
+
+	bodyOnly := filepath.Join(dir, "body.tmpl")
+	require.NoError(t, os.WriteFile(bodyOnly, []byte(`{{ len .NewRecommendations }} new`), 0o600))
+	tmpl, err = LoadTemplate(bodyOnly)
+	require.NoError(t, err)
+	subject, _, err = tmpl.Render(testDigest())
+	require.NoError(t, err)
+	assert.Contains(t, subject, "Alert digest 2026-03-23", "templates without a subject use the default one")
+
+	broken := filepath.Join(dir, "broken.tmpl")
+	require.NoError(t, os.WriteFile(broken, []byte(`{{ .Teams`), 0o600))
+	_, err = LoadTemplate(broken)
+	assert.ErrorContains(t, err, "failed to parse digest template")
+
+	_, err = LoadTemplate(filepath.Join(dir, "missing.tmpl"))
+	assert.ErrorContains(t, err, "failed to read digest template")
+}
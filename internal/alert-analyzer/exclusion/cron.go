@@ -0,0 +1,135 @@
+package exclusion
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule is a compiled five-field cron expression: minute, hour, day of
+// month, month and day of week (0-7, Sunday is 0 or 7).
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny record a day field starting with "*", such as "*" or
+	// "*/2"; as in cron, a time matches either restricted day field when both
+	// are restricted.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// parseCron compiles expressions such as "0 2 * * 6" or "*/30 22-23 1,15 * *".
+func parseCron(expr string) (*schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		value, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		bits[i] = value
+	}
+
+	// Sunday may be written as 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses a comma-separated list of "*", values and ranges,
+// each with an optional "/step".
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		base, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			base = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", spec.name, part)
+			}
+			step = n
+		}
+
+		first, last := spec.min, spec.max
+		switch {
+		case base == "*":
+		case strings.Contains(base, "-"):
+			bounds := strings.SplitN(base, "-", 2)
+			var err error
+			if first, err = cronValue(bounds[0], spec); err != nil {
+				return 0, err
+			}
+			if last, err = cronValue(bounds[1], spec); err != nil {
+				return 0, err
+			}
+			if first > last {
+				return 0, fmt.Errorf("invalid range in %s field %q", spec.name, part)
+			}
+		default:
+			value, err := cronValue(base, spec)
+			if err != nil {
+				return 0, err
+			}
+			first = value
+			if step == 1 {
+				last = value
+			}
+		}
+
+		for value := first; value <= last; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(value string, spec cronField) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < spec.min || n > spec.max {
+		return 0, fmt.Errorf("%s value %q must be between %d and %d", spec.name, value, spec.min, spec.max)
+	}
+	return n, nil
+}
+
+// matches reports whether the schedule fires at the minute of t.
+func (s *schedule) matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
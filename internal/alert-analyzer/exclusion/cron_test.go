@@ -0,0 +1,87 @@
+package exclusion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		matches []time.Time
+		misses  []time.Time
+	}{
+		{
+			name:    "Weekly",
+			expr:    "0 2 * * 6",
+			matches: []time.Time{time.Date(2026, 3, 21, 2, 0, 0, 0, time.UTC)},                                               // Saturday
+			misses:  []time.Time{time.Date(2026, 3, 21, 2, 1, 0, 0, time.UTC), time.Date(2026, 3, 22, 2, 0, 0, 0, time.UTC)}, // Sunday
+		},
+		{
+			name:    "Steps, Ranges And Lists",
+			expr:    "*/30 22-23 1,15 * *",
+			matches: []time.Time{time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC), time.Date(2026, 3, 15, 23, 30, 0, 0, time.UTC)},
+			misses:  []time.Time{time.Date(2026, 3, 15, 23, 15, 0, 0, time.UTC), time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "Sunday As 7",
+			expr:    "0 0 * * 7",
+			matches: []time.Time{time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "Day Of Month Or Weekday",
+			expr:    "0 0 1 * 1",
+			matches: []time.Time{time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)}, // 1st, Monday
+			misses:  []time.Time{time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "Day Of Month Step And Weekday",
+			expr:    "0 0 */2 * 1",
+			matches: []time.Time{time.Date(2026, 3, 23, 0, 0, 0, 0, time.UTC)},                                               // odd Monday
+			misses:  []time.Time{time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)}, // even Monday, odd Tuesday
+		},
+		{
+			name:    "Day Of Month And Weekday Step",
+			expr:    "0 0 1 * */2",
+			matches: []time.Time{time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},                                               // 1st on Sunday
+			misses:  []time.Time{time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)}, // 1st on Wednesday, Tuesday
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := parseCron(tt.expr)
+			require.NoError(t, err)
+			for _, ts := range tt.matches {
+				assert.True(t, sched.matches(ts), "expected %s to match", ts)
+			}
+			for _, ts := range tt.misses {
+				assert.False(t, sched.matches(ts), "expected %s not to match", ts)
+			}
+		})
+	}
+}
+
+func TestParseCron_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "0 2 * *", wantErr: "expected 5 fields"},
+		{expr: "60 2 * * *", wantErr: "minute value \"60\" must be between 0 and 59"},
+		{expr: "0 5-2 * * *", wantErr: "invalid range in hour field"},
+		{expr: "*/0 * * * *", wantErr: "invalid step in minute field"},
+		{expr: "0 0 * * MON", wantErr: "day of week value \"MON\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := parseCron(tt.expr)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
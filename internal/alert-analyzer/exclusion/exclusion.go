@@ -0,0 +1,347 @@
+// Package exclusion removes alert activity during planned maintenance windows
+// and Alertmanager silences from the analyzed history.
+package exclusion
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/routing"
+	"github.com/neogan/sre-toolkit/pkg/alertmanager"
+)
+
+// Window sources.
+const (
+	SourceFile    = "file"
+	SourceSilence = "silence"
+)
+
+// maxReportedAlerts limits the alert names listed per excluded window.
+const maxReportedAlerts = 5
+
+// Window is a maintenance window. It either recurs on a cron schedule for
+// Duration, or covers the absolute range from Start to End. Matchers restrict
+// the window to alerts with matching labels; without them it covers every alert.
+type Window struct {
+	Name     string        `yaml:"name"`
+	Cron     string        `yaml:"cron"`
+	Duration time.Duration `yaml:"duration"`
+	// Timezone is the IANA timezone the cron schedule is evaluated in (default UTC).
+	Timezone string    `yaml:"timezone"`
+	Start    time.Time `yaml:"start"`
+	End      time.Time `yaml:"end"`
+	Matchers []string  `yaml:"matchers"`
+	Source   string    `yaml:"-"`
+
+	schedule *schedule
+	location *time.Location
+	matchers routing.Matchers
+}
+
+type file struct {
+	Windows []Window `yaml:"windows"`
+}
+
+// Set is a compiled list of maintenance windows.
+type Set struct {
+	windows []*Window
+}
+
+// WindowSummary counts the alert activity excluded by one window.
+type WindowSummary struct {
+	Name       string        `json:"name"`
+	Source     string        `json:"source"`
+	Firings    int           `json:"firings"`
+	FiringTime time.Duration `json:"firing_time"`
+	Alerts     []string      `json:"alerts"`
+}
+
+// Report summarizes the alert activity removed from a history.
+type Report struct {
+	Firings    int             `json:"firings"`
+	FiringTime time.Duration   `json:"firing_time"`
+	Windows    []WindowSummary `json:"windows"`
+}
+
+type interval struct {
+	start, end time.Time
+}
+
+// LoadFile reads and compiles a maintenance window file.
+func LoadFile(path string) (*Set, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exclusion windows: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse compiles maintenance windows from a YAML document with a top-level
+// windows list.
+func Parse(data []byte) (*Set, error) {
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse exclusion windows: %w", err)
+	}
+
+	set := &Set{}
+	for i := range f.Windows {
+		window := f.Windows[i]
+		window.Source = SourceFile
+		if window.Name == "" {
+			window.Name = "window-" + strconv.Itoa(i+1)
+		}
+		if err := set.Add(window); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// Add compiles a window and adds it to the set.
+func (s *Set) Add(window Window) error {
+	switch {
+	case window.Cron != "":
+		if !window.Start.IsZero() || !window.End.IsZero() {
+			return fmt.Errorf("window %s: cron and start/end are mutually exclusive", window.Name)
+		}
+		if window.Duration <= 0 {
+			return fmt.Errorf("window %s: a cron window needs a positive duration", window.Name)
+		}
+		sched, err := parseCron(window.Cron)
+		if err != nil {
+			return fmt.Errorf("window %s: %w", window.Name, err)
+		}
+		window.schedule = sched
+
+		window.location = time.UTC
+		if window.Timezone != "" {
+			location, err := time.LoadLocation(window.Timezone)
+			if err != nil {
+				return fmt.Errorf("window %s: invalid timezone: %w", window.Name, err)
+			}
+			window.location = location
+		}
+	case !window.Start.IsZero() && !window.End.IsZero():
+		if !window.End.After(window.Start) {
+			return fmt.Errorf("window %s: end must be after start", window.Name)
+		}
+	default:
+		return fmt.Errorf("window %s: set either cron and duration, or start and end", window.Name)
+	}
+
+	matchers, err := routing.ParseMatchers(window.Matchers)
+	if err != nil {
+		return fmt.Errorf("window %s: %w", window.Name, err)
+	}
+	window.matchers = matchers
+
+	s.windows = append(s.windows, &window)
+	return nil
+}
+
+// AddSilences adds Alertmanager silences as absolute windows. Silences that
+// have not started yet are skipped.
+func (s *Set) AddSilences(silences []alertmanager.Silence, now time.Time) error {
+	for _, silence := range silences {
+		if !silence.StartsAt.Before(now) {
+			continue
+		}
+
+		matchers := make([]string, 0, len(silence.Matchers))
+		for _, m := range silence.Matchers {
+			matchers = append(matchers, silenceMatcher(m))
+		}
+
+		name := silence.ID
+		if silence.Comment != "" {
+			name = silence.Comment + " (" + silence.ID + ")"
+		}
+		end := silence.EndsAt
+		if end.After(now) {
+			end = now
+		}
+		if !end.After(silence.StartsAt) {
+			continue
+		}
+
+		if err := s.Add(Window{
+			Name:     name,
+			Start:    silence.StartsAt,
+			End:      end,
+			Matchers: matchers,
+			Source:   SourceSilence,
+		}); err != nil {
+			return fmt.Errorf("silence %s: %w", silence.ID, err)
+		}
+	}
+	return nil
+}
+
+func silenceMatcher(m alertmanager.SilenceMatcher) string {
+	op := "="
+	if m.IsRegex {
+		op = "=~"
+	}
+	if m.IsEqual != nil && !*m.IsEqual {
+		if m.IsRegex {
+			op = "!~"
+		} else {
+			op = "!="
+		}
+	}
+	return m.Name + op + strconv.Quote(m.Value)
+}
+
+// Len returns the number of windows in the set.
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.windows)
+}
+
+// Apply removes firings that start inside a matching window from the history
+// and returns what was removed. A firing is attributed to the first window in
+// the set that covers it.
+func (s *Set) Apply(history *collector.AlertHistory) Report {
+	report := Report{Windows: []WindowSummary{}}
+	if s.Len() == 0 || history == nil || len(history.Alerts) == 0 {
+		return report
+	}
+
+	from, to := firingRange(history.Alerts)
+	intervals := make([][]interval, len(s.windows))
+	for i, window := range s.windows {
+		intervals[i] = window.intervals(from, to)
+	}
+
+	summaries := make([]WindowSummary, len(s.windows))
+	alertCounts := make([]map[string]int, len(s.windows))
+	kept := make([]collector.Alert, 0, len(history.Alerts))
+	for _, alert := range history.Alerts {
+		index := s.match(alert, intervals)
+		if index < 0 {
+			kept = append(kept, alert)
+			continue
+		}
+
+		duration := alert.DurationUntil(history.EndTime)
+		summaries[index].Firings++
+		summaries[index].FiringTime += duration
+		if alertCounts[index] == nil {
+			alertCounts[index] = make(map[string]int)
+		}
+		alertCounts[index][alert.Name]++
+		report.Firings++
+		report.FiringTime += duration
+	}
+	history.Alerts = kept
+
+	for i, summary := range summaries {
+		if summary.Firings == 0 {
+			continue
+		}
+		summary.Name = s.windows[i].Name
+		summary.Source = s.windows[i].Source
+		summary.Alerts = topAlerts(alertCounts[i])
+		report.Windows = append(report.Windows, summary)
+	}
+	sort.SliceStable(report.Windows, func(i, j int) bool {
+		return report.Windows[i].Firings > report.Windows[j].Firings
+	})
+	return report
+}
+
+// match returns the index of the first window covering the alert, or -1.
+func (s *Set) match(alert collector.Alert, intervals [][]interval) int {
+	var labels map[string]string
+	for i, window := range s.windows {
+		if !covers(intervals[i], alert.FiredAt) {
+			continue
+		}
+		if labels == nil {
+			labels = alertLabels(alert)
+		}
+		if window.matchers.Matches(labels) {
+			return i
+		}
+	}
+	return -1
+}
+
+// intervals returns the occurrences of the window that may cover firings
+// between from and to, ordered by start.
+func (w *Window) intervals(from, to time.Time) []interval {
+	if w.schedule == nil {
+		return []interval{{start: w.Start, end: w.End}}
+	}
+
+	var result []interval
+	for t := from.Add(-w.Duration).Truncate(time.Minute); !t.After(to); t = t.Add(time.Minute) {
+		if w.schedule.matches(t.In(w.location)) {
+			result = append(result, interval{start: t, end: t.Add(w.Duration)})
+		}
+	}
+	return result
+}
+
+// covers reports whether t falls into one of the intervals. All intervals of a
+// window have the same length, so the latest one starting at or before t is
+// the only candidate.
+func covers(intervals []interval, t time.Time) bool {
+	i := sort.Search(len(intervals), func(i int) bool { return intervals[i].start.After(t) })
+	return i > 0 && t.Before(intervals[i-1].end)
+}
+
+func firingRange(alerts []collector.Alert) (from, to time.Time) {
+	from, to = alerts[0].FiredAt, alerts[0].FiredAt
+	for _, alert := range alerts[1:] {
+		if alert.FiredAt.Before(from) {
+			from = alert.FiredAt
+		}
+		if alert.FiredAt.After(to) {
+			to = alert.FiredAt
+		}
+	}
+	return from, to
+}
+
+// alertLabels returns the alert labels with alertname and cluster filled in,
+// so window matchers can use them like Alertmanager silences do.
+func alertLabels(alert collector.Alert) map[string]string {
+	labels := make(map[string]string, len(alert.Labels)+2)
+	for name, value := range alert.Labels {
+		labels[name] = value
+	}
+	if _, ok := labels["alertname"]; !ok {
+		labels["alertname"] = alert.Name
+	}
+	if _, ok := labels["cluster"]; !ok && alert.Cluster != "" {
+		labels["cluster"] = alert.Cluster
+	}
+	return labels
+}
+
+func topAlerts(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > maxReportedAlerts {
+		names = names[:maxReportedAlerts]
+	}
+	return names
+}
@@ -0,0 +1,167 @@
+package exclusion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+	"github.com/neogan/sre-toolkit/pkg/alertmanager"
+)
+
+const testWindows = `
+windows:
+  - name: weekly-patching
+    cron: "0 2 * * 6"
+    duration: 4h
+    timezone: Europe/Berlin
+    matchers:
+      - cluster="prod"
+  - name: dc-migration
+    start: 2026-03-18T08:00:00Z
+    end: 2026-03-18T20:00:00Z
+`
+
+func exclusionAlert(name, cluster string, firedAt time.Time) collector.Alert {
+	resolvedAt := firedAt.Add(10 * time.Minute)
+	return collector.Alert{
+		Name:       name,
+		Cluster:    cluster,
+		Labels:     map[string]string{"severity": "warning"},
+		FiredAt:    firedAt,
+		ResolvedAt: &resolvedAt,
+	}
+}
+
+func TestSet_Apply(t *testing.T) {
+	set, err := Parse([]byte(testWindows))
+	require.NoError(t, err)
+	require.Equal(t, 2, set.Len())
+
+	patching := time.Date(2026, 3, 21, 1, 0, 0, 0, time.UTC) // Saturday 02:00 in Berlin
+	history := &collector.AlertHistory{Alerts: []collector.Alert{
+		exclusionAlert("NodeReboot", "prod", patching.Add(30*time.Minute)),
+		exclusionAlert("NodeReboot", "prod", patching.Add(3*time.Hour)),
+		exclusionAlert("NodeReboot", "prod", patching.Add(4*time.Hour)),                         // after the window
+		exclusionAlert("NodeReboot", "dev", patching.Add(time.Hour)),                            // other cluster
+		exclusionAlert("HighLatency", "dev", time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)),     // migration
+		exclusionAlert("HighLatency", "dev", time.Date(2026, 3, 18, 7, 59, 0, 0, time.UTC)),     // before the migration
+		exclusionAlert("DiskFull", "prod", time.Date(2026, 3, 18, 19, 0, 0, 0, time.UTC)),       // migration
+		exclusionAlert("DiskFull", "prod", time.Date(2026, 3, 14, 1, 30, 0, 0, time.UTC)),       // previous Saturday
+		exclusionAlert("DiskFull", "prod", time.Date(2026, 3, 14, 5, 30, 0, 0, time.UTC)),       // after it
+		exclusionAlert("DiskFull", "prod", time.Date(2026, 3, 21, 0, 59, 0, 0, time.UTC)),       // one minute early
+		exclusionAlert("Unrelated", "prod", time.Date(2026, 3, 19, 12, 0, 0, 0, time.UTC)),      // no window
+		exclusionAlert("Unrelated", "staging", time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)),   // no window
+		exclusionAlert("Unrelated", "staging", time.Date(2026, 3, 21, 12, 0, 0, 0, time.UTC)),   // no window
+		exclusionAlert("Unrelated", "staging", time.Date(2026, 3, 21, 1, 30, 0, 0, time.UTC)),   // no matching cluster
+		exclusionAlert("DiskFull", "prod", time.Date(2026, 3, 21, 4, 59, 0, 0, time.UTC)),       // last minute
+		exclusionAlert("HighLatency", "staging", time.Date(2026, 3, 18, 20, 0, 0, 0, time.UTC)), // migration ended
+	}}
+
+	report := set.Apply(history)
+
+	assert.Equal(t, 6, report.Firings)
+	assert.Equal(t, 60*time.Minute, report.FiringTime)
+	require.Len(t, report.Windows, 2)
+	assert.Equal(t, WindowSummary{
+		Name:       "weekly-patching",
+		Source:     SourceFile,
+		Firings:    4,
+		FiringTime: 40 * time.Minute,
+		Alerts:     []string{"DiskFull", "NodeReboot"},
+	}, report.Windows[0])
+	assert.Equal(t, "dc-migration", report.Windows[1].Name)
+	assert.Equal(t, []string{"DiskFull", "HighLatency"}, report.Windows[1].Alerts)
+
+	assert.Len(t, history.Alerts, 10)
+	for _, alert := range history.Alerts {
+		assert.False(t, alert.Cluster == "prod" && alert.FiredAt.Equal(patching.Add(30*time.Minute)))
+	}
+}
+
+func TestSet_ApplyEmpty(t *testing.T) {
+	var set *Set
+	history := &collector.AlertHistory{Alerts: []collector.Alert{exclusionAlert("A", "", time.Now())}}
+	report := set.Apply(history)
+	assert.Zero(t, report.Firings)
+	assert.Empty(t, report.Windows)
+	assert.Len(t, history.Alerts, 1)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{name: "Invalid YAML", config: "windows: [", wantErr: "failed to parse exclusion windows"},
+		{name: "No Schedule", config: "windows:\n  - name: a", wantErr: "window a: set either cron and duration, or start and end"},
+		{name: "Cron Without Duration", config: "windows:\n  - cron: \"0 2 * * *\"", wantErr: "window window-1: a cron window needs a positive duration"},
+		{name: "Cron And Range", config: "windows:\n  - name: a\n    cron: \"0 2 * * *\"\n    duration: 1h\n    start: 2026-03-18T08:00:00Z", wantErr: "mutually exclusive"},
+		{name: "Reversed Range", config: "windows:\n  - name: a\n    start: 2026-03-18T08:00:00Z\n    end: 2026-03-18T07:00:00Z", wantErr: "end must be after start"},
+		{name: "Invalid Cron", config: "windows:\n  - name: a\n    cron: \"0 2 * *\"\n    duration: 1h", wantErr: "expected 5 fields"},
+		{name: "Invalid Timezone", config: "windows:\n  - name: a\n    cron: \"0 2 * * *\"\n    duration: 1h\n    timezone: Mars/Olympus", wantErr: "invalid timezone"},
+		{name: "Invalid Matcher", config: "windows:\n  - name: a\n    cron: \"0 2 * * *\"\n    duration: 1h\n    matchers: [cluster]", wantErr: "missing operator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.config))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "windows.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testWindows), 0o600))
+
+	set, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, set.Len())
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read exclusion windows")
+}
+
+func TestSet_AddSilences(t *testing.T) {
+	now := time.Date(2026, 3, 21, 12, 0, 0, 0, time.UTC)
+	notEqual := false
+	silences := []alertmanager.Silence{
+		{
+			ID:       "expired",
+			Comment:  "kernel upgrade",
+			StartsAt: now.Add(-48 * time.Hour),
+			EndsAt:   now.Add(-46 * time.Hour),
+			Matchers: []alertmanager.SilenceMatcher{
+				{Name: "alertname", Value: "Node.*", IsRegex: true},
+				{Name: "severity", Value: "info", IsEqual: &notEqual},
+			},
+		},
+		{ID: "active", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), Matchers: []alertmanager.SilenceMatcher{{Name: "alertname", Value: "DiskFull"}}},
+		{ID: "pending", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+	}
+
+	set := &Set{}
+	require.NoError(t, set.AddSilences(silences, now))
+	require.Equal(t, 2, set.Len(), "pending silences are skipped")
+
+	history := &collector.AlertHistory{Alerts: []collector.Alert{
+		exclusionAlert("NodeDown", "", now.Add(-47*time.Hour)),
+		{Name: "NodeDown", Labels: map[string]string{"severity": "info"}, FiredAt: now.Add(-47 * time.Hour)},
+		exclusionAlert("DiskFull", "", now.Add(-30*time.Minute)),
+		exclusionAlert("DiskFull", "", now.Add(-2*time.Hour)),
+	}}
+	report := set.Apply(history)
+
+	require.Len(t, report.Windows, 2)
+	assert.Equal(t, "kernel upgrade (expired)", report.Windows[0].Name)
+	assert.Equal(t, SourceSilence, report.Windows[0].Source)
+	assert.Equal(t, 1, report.Windows[0].Firings, "negative matchers are kept")
+	assert.Equal(t, "active", report.Windows[1].Name)
+	assert.Len(t, history.Alerts, 2)
+}
@@ -0,0 +1,619 @@
+// Package patch turns alert-analyzer recommendations into reviewable
+// Prometheus rule and Alertmanager inhibit rule changes.
+package patch
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+const (
+	// forQuantile selects the firing duration that a new `for:` should absorb:
+	// firings up to the median would no longer page.
+	forQuantile = 0.5
+
+	// keepFiringQuantile selects the resolve-to-refire gap bridged by keep_firing_for.
+	keepFiringQuantile = 0.9
+
+	// maxSuggestedDuration caps generated `for:` increments and keep_firing_for values.
+	maxSuggestedDuration = time.Hour
+
+	// InhibitRulesFile is the file name used for proposed Alertmanager inhibit rules.
+	InhibitRulesFile = "alertmanager-inhibit-rules.yaml"
+
+	defaultRuleFile = "rules"
+	fileHeader      = "Proposed by alert-analyzer from observed alert history. Review before applying."
+	groupComment    = "Complete group of collected alerting rules; recording rules are not collected, keep them when replacing the group."
+)
+
+// equalLabels are the labels considered for an inhibit rule's `equal` list.
+var equalLabels = []string{"cluster", "namespace", "service"}
+
+// RuleChange is a proposed modification of a single alerting rule.
+type RuleChange struct {
+	Alert         string
+	Cluster       string
+	File          string
+	Group         string
+	Expr          string
+	Labels        map[string]string
+	Annotations   map[string]string
+	PreviousFor   time.Duration
+	For           time.Duration
+	KeepFiringFor time.Duration
+	Reasons       []string
+	RuleFound     bool
+
+	// position is the index of the rule among the collected rules, used to
+	// keep the order of rules within a group.
+	position int
+}
+
+// InhibitRule is a proposed Alertmanager inhibit rule.
+type InhibitRule struct {
+	SourceMatchers []string `yaml:"source_matchers"`
+	TargetMatchers []string `yaml:"target_matchers"`
+	Equal          []string `yaml:"equal,omitempty"`
+	Reason         string   `yaml:"-"`
+}
+
+// Set contains every change proposed for one analysis run.
+type Set struct {
+	Rules        []RuleChange
+	InhibitRules []InhibitRule
+	// Unchanged are the other collected rules of the groups that Rules
+	// modify. They are written unmodified next to the changed rules, so
+	// that each patched group is complete.
+	Unchanged []RuleChange
+}
+
+// Generator derives rule patches from recommendations and observed alert history.
+type Generator struct {
+	history *collector.AlertHistory
+	grouped map[string][]collector.Alert
+	rules   []collector.AlertRule
+	byKey   map[string]int
+}
+
+// NewGenerator creates a new patch generator.
+func NewGenerator(history *collector.AlertHistory, rules []collector.AlertRule) *Generator {
+	g := &Generator{
+		history: history,
+		grouped: make(map[string][]collector.Alert),
+		rules:   rules,
+		byKey:   make(map[string]int, len(rules)),
+	}
+	if history != nil {
+		g.grouped = collector.GroupAlertsByName(history.Alerts)
+	}
+	for i, rule := range rules {
+		g.byKey[rule.GetGroupingKey()] = i
+	}
+	return g
+}
+
+// Generate builds patches for tuning, stability and deduplication recommendations.
+// Other categories need human judgement and are skipped.
+func (g *Generator) Generate(recommendations []analyzer.Recommendation) *Set {
+	changes := make(map[string]*RuleChange)
+	order := make([]string, 0)
+	inhibitSeen := make(map[string]bool)
+	set := &Set{}
+
+	change := func(target string) *RuleChange {
+		if existing, ok := changes[target]; ok {
+			return existing
+		}
+		created := g.newRuleChange(target)
+		changes[target] = created
+		order = append(order, target)
+		return created
+	}
+
+	for _, rec := range recommendations {
+		switch rec.Category {
+		case analyzer.RecommendationCategoryTuning:
+			g.applyTuning(rec.Target, change)
+		case analyzer.RecommendationCategoryStability:
+			g.applyStability(rec.Target, change)
+		case analyzer.RecommendationCategoryDeduplication:
+			if len(rec.RelatedAlerts) != 2 {
+				continue
+			}
+			rule, ok := g.inhibitRule(rec.RelatedAlerts[0], rec.RelatedAlerts[1])
+			if !ok {
+				continue
+			}
+			key := strings.Join(rule.SourceMatchers, ",") + "->" + strings.Join(rule.TargetMatchers, ",")
+			if inhibitSeen[key] {
+				continue
+			}
+			inhibitSeen[key] = true
+			set.InhibitRules = append(set.InhibitRules, rule)
+		}
+	}
+
+	for _, target := range order {
+		if c := changes[target]; len(c.Reasons) > 0 {
+			set.Rules = append(set.Rules, *c)
+		}
+	}
+	set.Unchanged = g.unchangedRules(set.Rules)
+	return set
+}
+
+// IsEmpty reports whether the set contains no changes.
+func (s *Set) IsEmpty() bool {
+	return s == nil || (len(s.Rules) == 0 && len(s.InhibitRules) == 0)
+}
+
+func (g *Generator) newRuleChange(target string) *RuleChange {
+	c := &RuleChange{Alert: target}
+	if alerts := g.grouped[target]; len(alerts) > 0 {
+		c.Alert = alerts[0].Name
+		c.Cluster = alerts[0].Cluster
+	}
+
+	position, ok := g.byKey[target]
+	if !ok {
+		return c
+	}
+	found := ruleChange(g.rules[position], position)
+	return &found
+}
+
+// unchangedRules returns the collected rules that share a group with a changed
+// rule without being changed themselves, in their collected order.
+func (g *Generator) unchangedRules(changed []RuleChange) []RuleChange {
+	groups := make(map[string]bool)
+	positions := make(map[int]bool)
+	for _, c := range changed {
+		if c.RuleFound {
+			groups[groupKey(c.Cluster, c.File, c.Group)] = true
+			positions[c.position] = true
+		}
+	}
+
+	unchanged := make([]RuleChange, 0)
+	for i, rule := range g.rules {
+		if positions[i] || !groups[groupKey(rule.Cluster, rule.File, rule.Group)] {
+			continue
+		}
+		unchanged = append(unchanged, ruleChange(rule, i))
+	}
+	return unchanged
+}
+
+// ruleChange returns a change that leaves the collected rule as it is.
+func ruleChange(rule collector.AlertRule, position int) RuleChange {
+	return RuleChange{
+		Alert:       rule.Name,
+		Cluster:     rule.Cluster,
+		File:        rule.File,
+		Group:       rule.Group,
+		Expr:        rule.Query,
+		Labels:      rule.Labels,
+		Annotations: rule.Annotations,
+		PreviousFor: rule.Duration,
+		For:         rule.Duration,
+		RuleFound:   true,
+		position:    position,
+	}
+}
+
+func groupKey(cluster, file, group string) string {
+	return cluster + "\x00" + file + "\x00" + group
+}
+
+// applyTuning raises `for:` to the first whole minute above the median firing
+// duration, so that firings up to the median resolve while still pending.
+func (g *Generator) applyTuning(target string, change func(string) *RuleChange) {
+	durations := make([]time.Duration, 0)
+	for _, alert := range g.grouped[target] {
+		if alert.IsResolved() {
+			durations = append(durations, alert.Duration())
+		}
+	}
+	if len(durations) == 0 {
+		return
+	}
+
+	increment := minuteAbove(quantile(durations, forQuantile))
+	suppressed := 0
+	for _, d := range durations {
+		if d < increment {
+			suppressed++
+		}
+	}
+
+	c := change(target)
+	c.For = c.PreviousFor + increment
+	forChange := fmt.Sprintf("for: %s -> %s", formatDuration(c.PreviousFor), formatDuration(c.For))
+	if !c.RuleFound {
+		forChange = fmt.Sprintf("for: +%s over the current value", formatDuration(increment))
+	}
+	c.Reasons = append(c.Reasons, fmt.Sprintf("%s; median firing lasted %s, %d of %d resolved firings would not have paged",
+		forChange, formatDuration(quantile(durations, forQuantile)), suppressed, len(durations)))
+}
+
+// applyStability adds keep_firing_for so brief resolves do not re-notify.
+func (g *Generator) applyStability(target string, change func(string) *RuleChange) {
+	gaps := refireGaps(g.grouped[target])
+	if len(gaps) == 0 {
+		return
+	}
+
+	keepFiringFor := roundUpToMinute(quantile(gaps, keepFiringQuantile))
+	bridged := 0
+	for _, gap := range gaps {
+		if gap <= keepFiringFor {
+			bridged++
+		}
+	}
+
+	c := change(target)
+	c.KeepFiringFor = keepFiringFor
+	c.Reasons = append(c.Reasons, fmt.Sprintf("keep_firing_for: %s; %d of %d re-firings happened within that gap after resolving",
+		formatDuration(keepFiringFor), bridged, len(gaps)))
+}
+
+// inhibitRule proposes inhibiting the alert that usually fires second while the
+// one that usually fires first is active.
+func (g *Generator) inhibitRule(keyA, keyB string) (InhibitRule, bool) {
+	alertsA, alertsB := g.grouped[keyA], g.grouped[keyB]
+	if len(alertsA) == 0 || len(alertsB) == 0 {
+		return InhibitRule{}, false
+	}
+
+	leadsA, leadsB := 0, 0
+	for _, left := range alertsA {
+		for _, right := range alertsB {
+			if !g.overlaps(left, right) {
+				continue
+			}
+			if right.FiredAt.Before(left.FiredAt) {
+				leadsB++
+			} else {
+				leadsA++
+			}
+		}
+	}
+
+	source, target := alertsA, alertsB
+	leads, total := leadsA, leadsA+leadsB
+	if leadsB > leadsA {
+		source, target = alertsB, alertsA
+		leads = leadsB
+	}
+	if source[0].Name == target[0].Name {
+		return InhibitRule{}, false
+	}
+
+	return InhibitRule{
+		SourceMatchers: []string{fmt.Sprintf("alertname=%q", source[0].Name)},
+		TargetMatchers: []string{fmt.Sprintf("alertname=%q", target[0].Name)},
+		Equal:          commonLabels(append(append([]collector.Alert{}, source...), target...)),
+		Reason: fmt.Sprintf("%s fired first in %d of %d overlaps with %s",
+			source[0].Name, leads, total, target[0].Name),
+	}, true
+}
+
+func (g *Generator) overlaps(left, right collector.Alert) bool {
+	return left.FiredAt.Before(g.alertEnd(right)) && right.FiredAt.Before(g.alertEnd(left))
+}
+
+func (g *Generator) alertEnd(alert collector.Alert) time.Time {
+	if alert.ResolvedAt != nil {
+		return *alert.ResolvedAt
+	}
+	if g.history != nil && !g.history.EndTime.IsZero() {
+		return g.history.EndTime
+	}
+	return alert.FiredAt
+}
+
+// refireGaps returns the time between a series resolving and firing again.
+func refireGaps(alerts []collector.Alert) []time.Duration {
+	series := make(map[string][]collector.Alert)
+	for _, alert := range alerts {
+		key := seriesKey(alert.Labels)
+		series[key] = append(series[key], alert)
+	}
+
+	gaps := make([]time.Duration, 0)
+	for _, firings := range series {
+		sort.Slice(firings, func(i, j int) bool {
+			return firings[i].FiredAt.Before(firings[j].FiredAt)
+		})
+		for i := 1; i < len(firings); i++ {
+			prev := firings[i-1]
+			if prev.ResolvedAt == nil {
+				continue
+			}
+			if gap := firings[i].FiredAt.Sub(*prev.ResolvedAt); gap > 0 {
+				gaps = append(gaps, gap)
+			}
+		}
+	}
+	return gaps
+}
+
+// commonLabels returns the equalLabels that are present on every alert.
+func commonLabels(alerts []collector.Alert) []string {
+	common := make([]string, 0)
+	for _, label := range equalLabels {
+		present := true
+		for _, alert := range alerts {
+			if alert.Labels[label] == "" {
+				present = false
+				break
+			}
+		}
+		if present {
+			common = append(common, label)
+		}
+	}
+	return common
+}
+
+func seriesKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func quantile(values []time.Duration, q float64) time.Duration {
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+func roundUpToMinute(d time.Duration) time.Duration {
+	if d > maxSuggestedDuration {
+		return maxSuggestedDuration
+	}
+	rounded := d.Truncate(time.Minute)
+	if rounded < d || rounded == 0 {
+		rounded += time.Minute
+	}
+	return rounded
+}
+
+// minuteAbove returns the first whole minute strictly above d, capped at
+// maxSuggestedDuration. A firing must outlast a raised `for:` to page, so
+// firings as long as d no longer do.
+func minuteAbove(d time.Duration) time.Duration {
+	return min(d.Truncate(time.Minute)+time.Minute, maxSuggestedDuration)
+}
+
+func formatDuration(d time.Duration) string {
+	return model.Duration(d).String()
+}
+
+// Write stores the proposed changes in dir: one Prometheus rule file per source
+// rule file and an Alertmanager inhibit rules file. It returns the written paths.
+func (s *Set) Write(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create patch directory: %w", err)
+	}
+
+	written := make([]string, 0)
+	files, names, err := s.ruleFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := writeYAML(path, files[name]); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	if len(s.InhibitRules) > 0 {
+		doc, err := inhibitRulesDocument(s.InhibitRules)
+		if err != nil {
+			return written, err
+		}
+		path := filepath.Join(dir, InhibitRulesFile)
+		if err := writeYAML(path, doc); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+type ruleYAML struct {
+	Alert         string            `yaml:"alert"`
+	Expr          string            `yaml:"expr,omitempty"`
+	For           string            `yaml:"for,omitempty"`
+	KeepFiringFor string            `yaml:"keep_firing_for,omitempty"`
+	Labels        map[string]string `yaml:"labels,omitempty"`
+	Annotations   map[string]string `yaml:"annotations,omitempty"`
+}
+
+// ruleFiles renders rule changes grouped by output file and rule group.
+// Groups with a collected rule also list the unchanged rules of the group in
+// their collected order, so that a patched group can replace the original.
+// Documents are assembled node by node because yaml.v3 drops comments on
+// nested nodes when encoding them as values.
+func (s *Set) ruleFiles() (map[string]*yaml.Node, []string, error) {
+	type fileGroups struct {
+		groups   map[string][]RuleChange
+		complete map[string]bool
+		order    []string
+	}
+
+	byFile := make(map[string]*fileGroups)
+	add := func(change RuleChange, create bool) {
+		name := ruleFileName(change)
+		file, ok := byFile[name]
+		if !ok {
+			if !create {
+				return
+			}
+			file = &fileGroups{groups: make(map[string][]RuleChange), complete: make(map[string]bool)}
+			byFile[name] = file
+		}
+
+		group := change.Group
+		if group == "" {
+			group = "alert-analyzer"
+		}
+		if _, ok := file.groups[group]; !ok {
+			if !create {
+				return
+			}
+			file.order = append(file.order, group)
+		}
+		file.groups[group] = append(file.groups[group], change)
+		if change.RuleFound {
+			file.complete[group] = true
+		}
+	}
+	for _, change := range s.Rules {
+		add(change, true)
+	}
+	for _, change := range s.Unchanged {
+		add(change, false)
+	}
+
+	names := make([]string, 0, len(byFile))
+	docs := make(map[string]*yaml.Node, len(byFile))
+	for name, file := range byFile {
+		groups := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, group := range file.order {
+			changes := file.groups[group]
+			if file.complete[group] {
+				sort.SliceStable(changes, func(i, j int) bool {
+					return changes[i].position < changes[j].position
+				})
+			}
+
+			rules := &yaml.Node{Kind: yaml.SequenceNode}
+			for _, change := range changes {
+				node, err := ruleNode(change)
+				if err != nil {
+					return nil, nil, err
+				}
+				rules.Content = append(rules.Content, node)
+			}
+
+			groupNode := mappingNode(
+				scalarNode("name"), scalarNode(group),
+				scalarNode("rules"), rules,
+			)
+			if file.complete[group] {
+				groupNode.HeadComment = groupComment
+			}
+			groups.Content = append(groups.Content, groupNode)
+		}
+		docs[name] = documentNode(mappingNode(scalarNode("groups"), groups))
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return docs, names, nil
+}
+
+func ruleNode(change RuleChange) (*yaml.Node, error) {
+	rule := ruleYAML{
+		Alert:       change.Alert,
+		Expr:        change.Expr,
+		Labels:      change.Labels,
+		Annotations: change.Annotations,
+	}
+	if change.For > 0 {
+		rule.For = formatDuration(change.For)
+	}
+	if change.KeepFiringFor > 0 {
+		rule.KeepFiringFor = formatDuration(change.KeepFiringFor)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(rule); err != nil {
+		return nil, fmt.Errorf("failed to encode rule %s: %w", change.Alert, err)
+	}
+
+	comments := append([]string{}, change.Reasons...)
+	if len(comments) == 0 {
+		// An unchanged rule, copied to complete its group.
+		return &node, nil
+	}
+	if !change.RuleFound {
+		comments = append(comments, "rule definition was not collected; merge these fields into the existing rule manually")
+	}
+	node.HeadComment = strings.Join(comments, "\n")
+	return &node, nil
+}
+
+func inhibitRulesDocument(rules []InhibitRule) (*yaml.Node, error) {
+	items := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, rule := range rules {
+		var node yaml.Node
+		if err := node.Encode(rule); err != nil {
+			return nil, fmt.Errorf("failed to encode inhibit rule: %w", err)
+		}
+		node.HeadComment = rule.Reason
+		items.Content = append(items.Content, &node)
+	}
+	return documentNode(mappingNode(scalarNode("inhibit_rules"), items)), nil
+}
+
+func documentNode(content *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.DocumentNode, HeadComment: fileHeader, Content: []*yaml.Node{content}}
+}
+
+func mappingNode(pairs ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Content: pairs}
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// ruleFileName derives the patch file name from the rule's source file and cluster.
+func ruleFileName(change RuleChange) string {
+	base := defaultRuleFile
+	if change.File != "" {
+		base = strings.TrimSuffix(filepath.Base(change.File), filepath.Ext(change.File))
+	}
+	if change.Cluster != "" {
+		base = change.Cluster + "-" + base
+	}
+	return base + ".yaml"
+}
+
+func writeYAML(path string, doc *yaml.Node) error {
+	file, err := os.Create(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return fmt.Errorf("failed to create patch file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := yaml.NewEncoder(file)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write patch file %s: %w", path, err)
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,189 @@
+package patch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+func firing(name string, labels map[string]string, start time.Time, d time.Duration) collector.Alert {
+	end := start.Add(d)
+	return collector.Alert{Name: name, Labels: labels, FiredAt: start, ResolvedAt: &end}
+}
+
+func testHistory() *collector.AlertHistory {
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	labels := map[string]string{"namespace": "api", "instance": "a"}
+
+	alerts := []collector.Alert{
+		// Noisy alert: durations 30s, 90s, 2m, 4m, 20m.
+		firing("HighLatency", labels, base, 30*time.Second),
+		firing("HighLatency", labels, base.Add(time.Hour), 90*time.Second),
+		firing("HighLatency", labels, base.Add(2*time.Hour), 2*time.Minute),
+		firing("HighLatency", labels, base.Add(3*time.Hour), 4*time.Minute),
+		firing("HighLatency", labels, base.Add(4*time.Hour), 20*time.Minute),
+		// Flapping alert: re-fires 1m, 2m and 7m after resolving.
+		firing("QueueBacklog", labels, base, time.Minute),
+		firing("QueueBacklog", labels, base.Add(2*time.Minute), time.Minute),
+		firing("QueueBacklog", labels, base.Add(5*time.Minute), time.Minute),
+		firing("QueueBacklog", labels, base.Add(13*time.Minute), time.Minute),
+		// Correlated pair: NodeDown always starts before TargetDown.
+		firing("NodeDown", labels, base, 30*time.Minute),
+		firing("TargetDown", labels, base.Add(time.Minute), 20*time.Minute),
+		firing("NodeDown", labels, base.Add(5*time.Hour), 30*time.Minute),
+		firing("TargetDown", labels, base.Add(5*time.Hour+time.Minute), 20*time.Minute),
+	}
+
+	return &collector.AlertHistory{Alerts: alerts, StartTime: base, EndTime: base.Add(24 * time.Hour)}
+}
+
+func testRecommendations() []analyzer.Recommendation {
+	return []analyzer.Recommendation{
+		{Category: analyzer.RecommendationCategoryTuning, Target: "HighLatency"},
+		{Category: analyzer.RecommendationCategoryStability, Target: "QueueBacklog"},
+		{Category: analyzer.RecommendationCategoryDeduplication, Target: "TargetDown + NodeDown", RelatedAlerts: []string{"TargetDown", "NodeDown"}},
+		{Category: analyzer.RecommendationCategoryDeadRule, Target: "Unused"},
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	rules := []collector.AlertRule{
+		{Name: "HighLatency", Group: "api", File: "/etc/prometheus/rules/api.yml", Query: "latency > 1", Duration: time.Minute, Labels: map[string]string{"severity": "warning"}},
+	}
+
+	set := NewGenerator(testHistory(), rules).Generate(testRecommendations())
+	require.Len(t, set.Rules, 2)
+
+	tuning := set.Rules[0]
+	assert.Equal(t, "HighLatency", tuning.Alert)
+	assert.True(t, tuning.RuleFound)
+	assert.Equal(t, time.Minute, tuning.PreviousFor)
+	// Median duration is 2m, so `for:` grows by the next minute above it, 3m,
+	// which also suppresses the firings that lasted exactly the median.
+	assert.Equal(t, 4*time.Minute, tuning.For)
+	assert.Contains(t, tuning.Reasons[0], "for: 1m -> 4m")
+	assert.Contains(t, tuning.Reasons[0], "3 of 5 resolved firings")
+
+	stability := set.Rules[1]
+	assert.Equal(t, "QueueBacklog", stability.Alert)
+	assert.False(t, stability.RuleFound)
+	assert.Equal(t, 7*time.Minute, stability.KeepFiringFor)
+	assert.Empty(t, set.Unchanged, "the only collected rule of the group is changed")
+
+	require.Len(t, set.InhibitRules, 1)
+	inhibit := set.InhibitRules[0]
+	assert.Equal(t, []string{`alertname="NodeDown"`}, inhibit.SourceMatchers)
+	assert.Equal(t, []string{`alertname="TargetDown"`}, inhibit.TargetMatchers)
+	assert.Equal(t, []string{"namespace"}, inhibit.Equal)
+	assert.Equal(t, "NodeDown fired first in 2 of 2 overlaps with TargetDown", inhibit.Reason)
+}
+
+func TestGenerator_NoPatches(t *testing.T) {
+	set := NewGenerator(&collector.AlertHistory{}, nil).Generate(testRecommendations())
+	assert.True(t, set.IsEmpty())
+
+	var nilSet *Set
+	assert.True(t, nilSet.IsEmpty())
+}
+
+func TestSet_Write(t *testing.T) {
+	rules := []collector.AlertRule{
+		{Name: "APIDown", Cluster: "prod", Group: "api", File: "/etc/prometheus/rules/api.yml", Query: "up == 0", Duration: 5 * time.Minute, Labels: map[string]string{"severity": "critical"}},
+		{Name: "HighLatency", Cluster: "prod", Group: "api", File: "/etc/prometheus/rules/api.yml", Query: "latency > 1", Duration: time.Minute},
+		{Name: "HighErrorRate", Cluster: "prod", Group: "api", File: "/etc/prometheus/rules/api.yml", Query: "errors > 0.1"},
+		{Name: "DiskFull", Cluster: "prod", Group: "node", File: "/etc/prometheus/rules/api.yml", Query: "disk > 0.9"},
+	}
+	history := testHistory()
+	for i := range history.Alerts {
+		if history.Alerts[i].Name == "HighLatency" {
+			history.Alerts[i].Cluster = "prod"
+		}
+	}
+	recs := testRecommendations()
+	recs[0].Target = "HighLatency [prod]"
+
+	dir := filepath.Join(t.TempDir(), "patches")
+	written, err := NewGenerator(history, rules).Generate(recs).Write(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "prod-api.yaml"),
+		filepath.Join(dir, "rules.yaml"),
+		filepath.Join(dir, InhibitRulesFile),
+	}, written)
+
+	data, err := os.ReadFile(filepath.Join(dir, "prod-api.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Proposed by alert-analyzer")
+	assert.Contains(t, string(data), "# for: 1m -> 4m")
+
+	assert.Contains(t, string(data), "# "+groupComment)
+
+	var ruleFile struct {
+		Groups []struct {
+			Name  string `yaml:"name"`
+			Rules []struct {
+				Alert  string            `yaml:"alert"`
+				Expr   string            `yaml:"expr"`
+				For    string            `yaml:"for"`
+				Labels map[string]string `yaml:"labels"`
+			} `yaml:"rules"`
+		} `yaml:"groups"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &ruleFile))
+	// The patched group is complete and keeps its rule order; untouched
+	// groups of the same file are left out.
+	require.Len(t, ruleFile.Groups, 1)
+	group := ruleFile.Groups[0]
+	assert.Equal(t, "api", group.Name)
+	require.Len(t, group.Rules, 3)
+	assert.Equal(t, "APIDown", group.Rules[0].Alert)
+	assert.Equal(t, "up == 0", group.Rules[0].Expr)
+	assert.Equal(t, "5m", group.Rules[0].For)
+	assert.Equal(t, map[string]string{"severity": "critical"}, group.Rules[0].Labels)
+	assert.Equal(t, "HighLatency", group.Rules[1].Alert)
+	assert.Equal(t, "latency > 1", group.Rules[1].Expr)
+	assert.Equal(t, "4m", group.Rules[1].For)
+	assert.Equal(t, "HighErrorRate", group.Rules[2].Alert)
+	assert.Empty(t, group.Rules[2].For)
+	assert.Equal(t, 1, strings.Count(string(data), "# for:"), "only the changed rule is annotated")
+
+	data, err = os.ReadFile(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "keep_firing_for: 7m")
+	assert.Contains(t, string(data), "merge these fields into the existing rule manually")
+
+	data, err = os.ReadFile(filepath.Join(dir, InhibitRulesFile))
+	require.NoError(t, err)
+	var inhibit struct {
+		InhibitRules []InhibitRule `yaml:"inhibit_rules"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &inhibit))
+	require.Len(t, inhibit.InhibitRules, 1)
+	assert.Equal(t, []string{"namespace"}, inhibit.InhibitRules[0].Equal)
+	assert.Contains(t, string(data), "# NodeDown fired first")
+}
+
+func TestMinuteAbove(t *testing.T) {
+	assert.Equal(t, time.Minute, minuteAbove(0))
+	assert.Equal(t, time.Minute, minuteAbove(30*time.Second))
+	assert.Equal(t, 3*time.Minute, minuteAbove(2*time.Minute))
+	assert.Equal(t, 3*time.Minute, minuteAbove(2*time.Minute+time.Second))
+	assert.Equal(t, maxSuggestedDuration, minuteAbove(3*time.Hour))
+}
+
+func TestRoundUpToMinute(t *testing.T) {
+	assert.Equal(t, time.Minute, roundUpToMinute(0))
+	assert.Equal(t, time.Minute, roundUpToMinute(30*time.Second))
+	assert.Equal(t, 2*time.Minute, roundUpToMinute(2*time.Minute))
+	assert.Equal(t, 3*time.Minute, roundUpToMinute(2*time.Minute+time.Second))
+	assert.Equal(t, maxSuggestedDuration, roundUpToMinute(3*time.Hour))
+}
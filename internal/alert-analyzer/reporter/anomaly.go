@@ -0,0 +1,105 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// ReportAnomalies outputs windows of anomalous alert volume.
+func (r *Reporter) ReportAnomalies(report analyzer.AnomalyReport) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportAnomaliesTable(report)
+	case FormatJSON:
+		return r.reportAnomaliesJSON(report)
+	case FormatMarkdown:
+		return r.reportAnomaliesMarkdown(report)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// reportAnomaliesTable outputs the anomalies in table format.
+func (r *Reporter) reportAnomaliesTable(report analyzer.AnomalyReport) error {
+	if len(report.Windows) == 0 {
+		fmt.Fprintf(r.writer, "\n%s\n", anomalyEmptyMessage(report))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "\n=== Alert Volume Anomalies ===")
+	fmt.Fprintf(w, "Score threshold %.1f, %d of %d hours with a baseline\n\n", report.Threshold, report.ScoredHours, report.Hours)
+	fmt.Fprintln(w, "START\tDURATION\tDIRECTION\tFIRINGS\tEXPECTED\tSCORE\tTOP ALERTS")
+	fmt.Fprintln(w, "-----\t--------\t---------\t-------\t--------\t-----\t----------")
+
+	for _, window := range report.Windows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%.1f\t%+.1f\t%s\n",
+			window.Start.Format(time.RFC3339),
+			formatDuration(window.End.Sub(window.Start)),
+			window.Direction,
+			window.Firings,
+			window.Expected,
+			window.Score,
+			formatAnomalyContributors(window.Contributors),
+		)
+	}
+
+	return w.Flush()
+}
+
+// reportAnomaliesJSON outputs the anomalies in JSON format.
+func (r *Reporter) reportAnomaliesJSON(report analyzer.AnomalyReport) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"anomalies": report,
+	})
+}
+
+func (r *Reporter) reportAnomaliesMarkdown(report analyzer.AnomalyReport) error {
+	fmt.Fprintln(r.writer, "## Alert Volume Anomalies")
+	fmt.Fprintln(r.writer)
+	if len(report.Windows) == 0 {
+		fmt.Fprintln(r.writer, anomalyEmptyMessage(report))
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	fmt.Fprintf(r.writer, "Score threshold %.1f, %d of %d hours with a baseline.\n\n", report.Threshold, report.ScoredHours, report.Hours)
+	fmt.Fprintln(r.writer, "| Start | Duration | Direction | Firings | Expected | Score | Top Alerts |")
+	fmt.Fprintln(r.writer, "| --- | --- | --- | ---: | ---: | ---: | --- |")
+	for _, window := range report.Windows {
+		fmt.Fprintf(r.writer, "| %s | %s | %s | %d | %.1f | %+.1f | %s |\n",
+			window.Start.Format(time.RFC3339),
+			formatDuration(window.End.Sub(window.Start)),
+			window.Direction,
+			window.Firings,
+			window.Expected,
+			window.Score,
+			escapeMarkdown(formatAnomalyContributors(window.Contributors)),
+		)
+	}
+	fmt.Fprintln(r.writer)
+
+	return nil
+}
+
+func anomalyEmptyMessage(report analyzer.AnomalyReport) string {
+	if report.ScoredHours == 0 {
+		return "No alert volume baseline: anomaly detection needs at least three weeks of history."
+	}
+	return "No alert volume anomalies detected."
+}
+
+func formatAnomalyContributors(contributors []analyzer.AnomalyContributor) string {
+	parts := make([]string, 0, len(contributors))
+	for _, contributor := range contributors {
+		parts = append(parts, fmt.Sprintf("%s (%+.0f)", contributor.AlertName, contributor.Excess))
+	}
+	return strings.Join(parts, ", ")
+}
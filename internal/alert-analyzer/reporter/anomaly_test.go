@@ -0,0 +1,80 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleAnomalyReport() analyzer.AnomalyReport {
+	start := time.Date(2026, 3, 25, 10, 0, 0, 0, time.UTC)
+	return analyzer.AnomalyReport{
+		Threshold:   3.5,
+		Hours:       672,
+		ScoredHours: 672,
+		Windows: []analyzer.AnomalyWindow{
+			{
+				Start:     start,
+				End:       start.Add(2 * time.Hour),
+				Direction: analyzer.AnomalySpike,
+				Firings:   64,
+				Expected:  10,
+				Score:     30,
+				Contributors: []analyzer.AnomalyContributor{
+					{AlertName: "NodeDown", Severity: "critical", Firings: 50, Excess: 50},
+					{AlertName: "DiskFull", Severity: "warning", Firings: 4, Excess: 4},
+				},
+			},
+		},
+	}
+}
+
+func TestReportAnomalies(t *testing.T) {
+	report := sampleAnomalyReport()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportAnomalies(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Alert Volume Anomalies ===")
+		assert.Contains(t, output, "672 of 672 hours")
+		assert.Contains(t, output, "2026-03-25T10:00:00Z")
+		assert.Contains(t, output, "+30.0")
+		assert.Contains(t, output, "NodeDown (+50), DiskFull (+4)")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportAnomalies(report))
+
+		var output map[string]analyzer.AnomalyReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		require.Len(t, output["anomalies"].Windows, 1)
+		assert.Equal(t, "NodeDown", output["anomalies"].Windows[0].Contributors[0].AlertName)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportAnomalies(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Alert Volume Anomalies")
+		assert.Contains(t, output, "| 2026-03-25T10:00:00Z | 2h 0m | spike | 64 | 10.0 | +30.0 | NodeDown (+50), DiskFull (+4) |")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportAnomalies(analyzer.AnomalyReport{Hours: 168}))
+		assert.Contains(t, buf.String(), "needs at least three weeks of history")
+
+		buf.Reset()
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportAnomalies(analyzer.AnomalyReport{Hours: 672, ScoredHours: 672}))
+		assert.Contains(t, buf.String(), "No alert volume anomalies detected.")
+	})
+}
@@ -0,0 +1,145 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/backtest"
+)
+
+// ReportBacktest outputs the comparison between a candidate rule and the existing rule.
+func (r *Reporter) ReportBacktest(result *backtest.Result) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportBacktestTable(result)
+	case FormatJSON:
+		return r.reportBacktestJSON(result)
+	case FormatMarkdown:
+		return r.reportBacktestMarkdown(result)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// backtestRow is one compared metric; actual and delta are empty without an existing rule.
+type backtestRow struct {
+	metric    string
+	candidate string
+	actual    string
+	delta     string
+}
+
+func backtestRows(result *backtest.Result) []backtestRow {
+	candidate := result.Candidate
+	rows := []backtestRow{
+		{metric: "Firings", candidate: fmt.Sprintf("%d", candidate.Firings)},
+		{metric: "Series", candidate: fmt.Sprintf("%d", candidate.Series)},
+		{metric: "Total Firing Time", candidate: formatDuration(candidate.TotalFiringTime)},
+		{metric: "Avg Duration", candidate: formatDuration(candidate.AvgDuration)},
+		{metric: "Median Duration", candidate: formatDuration(candidate.MedianDuration)},
+		{metric: "Max Duration", candidate: formatDuration(candidate.MaxDuration)},
+	}
+	if result.Actual == nil {
+		return rows
+	}
+
+	actual := result.Actual
+	rows[0].actual = fmt.Sprintf("%d", actual.Firings)
+	rows[0].delta = fmt.Sprintf("%+d (%+.1f%%)", result.FiringDelta, result.FiringDeltaPercent)
+	rows[1].actual = fmt.Sprintf("%d", actual.Series)
+	rows[1].delta = fmt.Sprintf("%+d", candidate.Series-actual.Series)
+	rows[2].actual = formatDuration(actual.TotalFiringTime)
+	rows[2].delta = formatSignedDuration(result.FiringTimeDelta)
+	rows[3].actual = formatDuration(actual.AvgDuration)
+	rows[3].delta = formatSignedDuration(candidate.AvgDuration - actual.AvgDuration)
+	rows[4].actual = formatDuration(actual.MedianDuration)
+	rows[4].delta = formatSignedDuration(candidate.MedianDuration - actual.MedianDuration)
+	rows[5].actual = formatDuration(actual.MaxDuration)
+	rows[5].delta = formatSignedDuration(candidate.MaxDuration - actual.MaxDuration)
+	return rows
+}
+
+// reportBacktestTable outputs backtest results in table format.
+func (r *Reporter) reportBacktestTable(result *backtest.Result) error {
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "\n=== Backtest ===")
+	fmt.Fprintln(w)
+	writeBacktestHeader(w, result)
+	fmt.Fprintln(w)
+
+	if result.Actual != nil {
+		fmt.Fprintln(w, "METRIC\tCANDIDATE\tACTUAL\tDELTA")
+		fmt.Fprintln(w, "------\t---------\t------\t-----")
+		for _, row := range backtestRows(result) {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", row.metric, row.candidate, row.actual, row.delta)
+		}
+	} else {
+		fmt.Fprintln(w, "METRIC\tCANDIDATE")
+		fmt.Fprintln(w, "------\t---------")
+		for _, row := range backtestRows(result) {
+			fmt.Fprintf(w, "%s\t%s\n", row.metric, row.candidate)
+		}
+	}
+
+	fmt.Fprintf(w, "\nPending runs that resolved before `for` elapsed: %d\n", result.SuppressedByFor)
+	return w.Flush()
+}
+
+// reportBacktestJSON outputs backtest results in JSON format.
+func (r *Reporter) reportBacktestJSON(result *backtest.Result) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"backtest": result,
+	})
+}
+
+func (r *Reporter) reportBacktestMarkdown(result *backtest.Result) error {
+	fmt.Fprintln(r.writer, "## Backtest")
+	fmt.Fprintln(r.writer)
+	fmt.Fprintf(r.writer, "- **Expression**: `%s`\n", result.Expr)
+	fmt.Fprintf(r.writer, "- **For**: %s\n", formatDuration(result.For))
+	if result.AlertName != "" {
+		fmt.Fprintf(r.writer, "- **Compared With**: %s\n", escapeMarkdown(result.AlertName))
+	}
+	fmt.Fprintf(r.writer, "- **Window**: %s - %s (step %s)\n", result.Start.Format(time.RFC3339), result.End.Format(time.RFC3339), result.Step)
+	fmt.Fprintf(r.writer, "- **Suppressed By For**: %d\n", result.SuppressedByFor)
+	fmt.Fprintln(r.writer)
+
+	if result.Actual != nil {
+		fmt.Fprintln(r.writer, "| Metric | Candidate | Actual | Delta |")
+		fmt.Fprintln(r.writer, "| --- | ---: | ---: | ---: |")
+		for _, row := range backtestRows(result) {
+			fmt.Fprintf(r.writer, "| %s | %s | %s | %s |\n", row.metric, row.candidate, row.actual, row.delta)
+		}
+	} else {
+		fmt.Fprintln(r.writer, "| Metric | Candidate |")
+		fmt.Fprintln(r.writer, "| --- | ---: |")
+		for _, row := range backtestRows(result) {
+			fmt.Fprintf(r.writer, "| %s | %s |\n", row.metric, row.candidate)
+		}
+	}
+	fmt.Fprintln(r.writer)
+	return nil
+}
+
+func writeBacktestHeader(w io.Writer, result *backtest.Result) {
+	fmt.Fprintf(w, "Expression:\t%s\n", result.Expr)
+	fmt.Fprintf(w, "For:\t%s\n", formatDuration(result.For))
+	if result.AlertName != "" {
+		fmt.Fprintf(w, "Compared With:\t%s\n", result.AlertName)
+	}
+	fmt.Fprintf(w, "Window:\t%s - %s (step %s)\n", result.Start.Format(time.RFC3339), result.End.Format(time.RFC3339), result.Step)
+}
+
+// formatSignedDuration formats a duration delta with an explicit sign.
+func formatSignedDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDuration(-d)
+	}
+	return "+" + formatDuration(d)
+}
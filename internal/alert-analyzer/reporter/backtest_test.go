@@ -0,0 +1,73 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/backtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleBacktestResult() *backtest.Result {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	return &backtest.Result{
+		Expr:               "rate(http_errors_total[5m]) > 0.2",
+		For:                10 * time.Minute,
+		AlertName:          "HighErrorRate",
+		Start:              start,
+		End:                start.Add(14 * 24 * time.Hour),
+		Step:               time.Minute,
+		Candidate:          backtest.Summary{Firings: 4, Series: 1, TotalFiringTime: time.Hour, AvgDuration: 15 * time.Minute, MedianDuration: 12 * time.Minute, MaxDuration: 30 * time.Minute},
+		Actual:             &backtest.Summary{Firings: 10, Series: 2, TotalFiringTime: 2 * time.Hour, AvgDuration: 12 * time.Minute, MedianDuration: 5 * time.Minute, MaxDuration: 40 * time.Minute},
+		FiringDelta:        -6,
+		FiringDeltaPercent: -60,
+		FiringTimeDelta:    -time.Hour,
+		SuppressedByFor:    6,
+	}
+}
+
+func TestReportBacktest(t *testing.T) {
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportBacktest(sampleBacktestResult()))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Backtest ===")
+		assert.Contains(t, output, "HighErrorRate")
+		assert.Contains(t, output, "-6 (-60.0%)")
+		assert.Contains(t, output, "-1h 0m")
+		assert.Contains(t, output, "resolved before `for` elapsed: 6")
+	})
+
+	t.Run("Table Without Comparison", func(t *testing.T) {
+		result := sampleBacktestResult()
+		result.AlertName = ""
+		result.Actual = nil
+
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportBacktest(result))
+		assert.NotContains(t, buf.String(), "ACTUAL")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportBacktest(sampleBacktestResult()))
+
+		var output map[string]backtest.Result
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.Equal(t, -6, output["backtest"].FiringDelta)
+		assert.Equal(t, 10, output["backtest"].Actual.Firings)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportBacktest(sampleBacktestResult()))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Backtest")
+		assert.Contains(t, output, "| Firings | 4 | 10 | -6 (-60.0%) |")
+	})
+}
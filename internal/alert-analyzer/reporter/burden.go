@@ -0,0 +1,133 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// ReportOnCallBurden outputs the on-call burden ("top sleep disruptors") report.
+func (r *Reporter) ReportOnCallBurden(report analyzer.BurdenReport) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportOnCallBurdenTable(report)
+	case FormatJSON:
+		return r.reportOnCallBurdenJSON(report)
+	case FormatMarkdown:
+		return r.reportOnCallBurdenMarkdown(report)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// reportOnCallBurdenTable outputs on-call burden in table format.
+func (r *Reporter) reportOnCallBurdenTable(report analyzer.BurdenReport) error {
+	if len(report.Alerts) == 0 {
+		fmt.Fprintln(r.writer, "\nNo pages found for on-call burden analysis.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "\n=== Top Sleep Disruptors ===")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ALERT NAME\tTEAM\tPAGES\tNIGHT\tWEEKEND\tOFF-HOURS\tMTTR\tFIRING TIME\tSCORE\tSEVERITY")
+	fmt.Fprintln(w, "----------\t----\t-----\t-----\t-------\t---------\t----\t-----------\t-----\t--------")
+
+	for _, result := range report.Alerts {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%.1f\t%s %s\n",
+			result.AlertName,
+			result.Team,
+			result.Pages,
+			result.NightPages,
+			result.WeekendPages,
+			result.OffHoursPages,
+			formatDuration(result.MeanTimeToResolve),
+			formatDuration(result.CumulativeFiringTime),
+			result.SleepDisruptionScore,
+			getSeverityIcon(result.Severity),
+			result.Severity,
+		)
+	}
+
+	fmt.Fprintln(w, "\n=== On-Call Burden by Team ===")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "TEAM\tALERTS\tPAGES\tNIGHT\tWEEKEND\tOFF-HOURS\tMTTR\tFIRING TIME\tSCORE")
+	fmt.Fprintln(w, "----\t------\t-----\t-----\t-------\t---------\t----\t-----------\t-----")
+
+	for _, team := range report.Teams {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%.1f\n",
+			team.Team,
+			team.Alerts,
+			team.Pages,
+			team.NightPages,
+			team.WeekendPages,
+			team.OffHoursPages,
+			formatDuration(team.MeanTimeToResolve),
+			formatDuration(team.CumulativeFiringTime),
+			team.SleepDisruptionScore,
+		)
+	}
+
+	return w.Flush()
+}
+
+// reportOnCallBurdenJSON outputs on-call burden in JSON format.
+func (r *Reporter) reportOnCallBurdenJSON(report analyzer.BurdenReport) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"oncall_burden": report,
+	})
+}
+
+func (r *Reporter) reportOnCallBurdenMarkdown(report analyzer.BurdenReport) error {
+	fmt.Fprintln(r.writer, "## Top Sleep Disruptors")
+	fmt.Fprintln(r.writer)
+	if len(report.Alerts) == 0 {
+		fmt.Fprintln(r.writer, "No pages found for on-call burden analysis.")
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	fmt.Fprintln(r.writer, "| Alert Name | Team | Pages | Night | Weekend | Off-Hours | MTTR | Firing Time | Score | Severity |")
+	fmt.Fprintln(r.writer, "| --- | --- | ---: | ---: | ---: | ---: | --- | --- | ---: | --- |")
+	for _, result := range report.Alerts {
+		fmt.Fprintf(r.writer, "| %s | %s | %d | %d | %d | %d | %s | %s | %.1f | %s %s |\n",
+			escapeMarkdown(result.AlertName),
+			escapeMarkdown(result.Team),
+			result.Pages,
+			result.NightPages,
+			result.WeekendPages,
+			result.OffHoursPages,
+			formatDuration(result.MeanTimeToResolve),
+			formatDuration(result.CumulativeFiringTime),
+			result.SleepDisruptionScore,
+			getSeverityIcon(result.Severity),
+			escapeMarkdown(result.Severity),
+		)
+	}
+	fmt.Fprintln(r.writer)
+
+	fmt.Fprintln(r.writer, "### On-Call Burden by Team")
+	fmt.Fprintln(r.writer)
+	fmt.Fprintln(r.writer, "| Team | Alerts | Pages | Night | Weekend | Off-Hours | MTTR | Firing Time | Score |")
+	fmt.Fprintln(r.writer, "| --- | ---: | ---: | ---: | ---: | ---: | --- | --- | ---: |")
+	for _, team := range report.Teams {
+		fmt.Fprintf(r.writer, "| %s | %d | %d | %d | %d | %d | %s | %s | %.1f |\n",
+			escapeMarkdown(team.Team),
+			team.Alerts,
+			team.Pages,
+			team.NightPages,
+			team.WeekendPages,
+			team.OffHoursPages,
+			formatDuration(team.MeanTimeToResolve),
+			formatDuration(team.CumulativeFiringTime),
+			team.SleepDisruptionScore,
+		)
+	}
+	fmt.Fprintln(r.writer)
+	return nil
+}
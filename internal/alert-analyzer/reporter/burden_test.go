@@ -0,0 +1,111 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleBurdenReport() analyzer.BurdenReport {
+	return analyzer.BurdenReport{
+		Alerts: []analyzer.BurdenResult{
+			{
+				AlertName:            "NodeDown",
+				Team:                 "platform",
+				Severity:             "critical",
+				Pages:                4,
+				OffHoursPages:        3,
+				NightPages:           2,
+				WeekendPages:         1,
+				MeanTimeToResolve:    25 * time.Minute,
+				CumulativeFiringTime: 2 * time.Hour,
+				SleepDisruptionScore: 7,
+			},
+		},
+		Teams: []analyzer.TeamBurden{
+			{
+				Team:                 "platform",
+				Alerts:               1,
+				Pages:                4,
+				OffHoursPages:        3,
+				NightPages:           2,
+				WeekendPages:         1,
+				MeanTimeToResolve:    25 * time.Minute,
+				CumulativeFiringTime: 2 * time.Hour,
+				SleepDisruptionScore: 7,
+			},
+		},
+	}
+}
+
+func TestReportOnCallBurden(t *testing.T) {
+	report := sampleBurdenReport()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportOnCallBurden(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Top Sleep Disruptors ===")
+		assert.Contains(t, output, "=== On-Call Burden by Team ===")
+		assert.Contains(t, output, "NodeDown")
+		assert.Contains(t, output, "25m 0s")
+		assert.Contains(t, output, "7.0")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportOnCallBurden(report))
+
+		var output map[string]analyzer.BurdenReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.Equal(t, 2, output["oncall_burden"].Alerts[0].NightPages)
+		assert.Equal(t, "platform", output["oncall_burden"].Teams[0].Team)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportOnCallBurden(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Top Sleep Disruptors")
+		assert.Contains(t, output, "### On-Call Burden by Team")
+		assert.Contains(t, output, "| NodeDown | platform | 4 | 2 | 1 | 3 | 25m 0s | 2h 0m | 7.0 | 🔴 critical |")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportOnCallBurden(analyzer.BurdenReport{}))
+		assert.Contains(t, buf.String(), "No pages found for on-call burden analysis.")
+	})
+
+	t.Run("Unsupported Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := NewReporter("xml", &buf).ReportOnCallBurden(report)
+		assert.ErrorContains(t, err, "unsupported format")
+	})
+}
+
+func TestReportAnalysis_IncludesOnCallBurden(t *testing.T) {
+	burden := sampleBurdenReport()
+
+	var buf bytes.Buffer
+	err := NewReporter(FormatJSON, &buf).ReportAnalysis(AnalysisReport{OnCallBurden: &burden})
+	require.NoError(t, err)
+
+	var output AnalysisReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	require.NotNil(t, output.OnCallBurden)
+	assert.NotEmpty(t, output.Timestamp)
+	assert.Equal(t, "NodeDown", output.OnCallBurden.Alerts[0].AlertName)
+
+	buf.Reset()
+	require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportAnalysis(AnalysisReport{OnCallBurden: &burden}))
+	assert.Contains(t, buf.String(), "# Alert Analysis Report")
+	assert.Contains(t, buf.String(), "## Top Sleep Disruptors")
+}
@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/exclusion"
+)
+
+// ReportExcluded outputs the alert activity removed by maintenance windows and silences.
+func (r *Reporter) ReportExcluded(report exclusion.Report) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportExcludedTable(report)
+	case FormatJSON:
+		return r.reportExcludedJSON(report)
+	case FormatMarkdown:
+		return r.reportExcludedMarkdown(report)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// reportExcludedTable outputs the excluded activity in table format.
+func (r *Reporter) reportExcludedTable(report exclusion.Report) error {
+	if len(report.Windows) == 0 {
+		fmt.Fprintln(r.writer, "\nNo alert activity inside maintenance windows or silences.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "\n=== Excluded Maintenance Activity ===")
+	fmt.Fprintf(w, "%d firings (%s firing time) excluded from the analysis\n\n", report.Firings, formatDuration(report.FiringTime))
+	fmt.Fprintln(w, "WINDOW\tSOURCE\tFIRINGS\tFIRING TIME\tALERTS")
+	fmt.Fprintln(w, "------\t------\t-------\t-----------\t------")
+
+	for _, window := range report.Windows {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			window.Name,
+			window.Source,
+			window.Firings,
+			formatDuration(window.FiringTime),
+			strings.Join(window.Alerts, ", "),
+		)
+	}
+
+	return w.Flush()
+}
+
+// reportExcludedJSON outputs the excluded activity in JSON format.
+func (r *Reporter) reportExcludedJSON(report exclusion.Report) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"excluded": report,
+	})
+}
+
+func (r *Reporter) reportExcludedMarkdown(report exclusion.Report) error {
+	fmt.Fprintln(r.writer, "## Excluded Maintenance Activity")
+	fmt.Fprintln(r.writer)
+	if len(report.Windows) == 0 {
+		fmt.Fprintln(r.writer, "No alert activity inside maintenance windows or silences.")
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	fmt.Fprintf(r.writer, "%d firings (%s firing time) excluded from the analysis.\n\n", report.Firings, formatDuration(report.FiringTime))
+	fmt.Fprintln(r.writer, "| Window | Source | Firings | Firing Time | Alerts |")
+	fmt.Fprintln(r.writer, "| --- | --- | ---: | ---: | --- |")
+	for _, window := range report.Windows {
+		fmt.Fprintf(r.writer, "| %s | %s | %d | %s | %s |\n",
+			escapeMarkdown(window.Name),
+			window.Source,
+			window.Firings,
+			formatDuration(window.FiringTime),
+			escapeMarkdown(strings.Join(window.Alerts, ", ")),
+		)
+	}
+	fmt.Fprintln(r.writer)
+
+	return nil
+}
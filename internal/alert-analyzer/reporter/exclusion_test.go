@@ -0,0 +1,63 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/exclusion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleExclusionReport() exclusion.Report {
+	return exclusion.Report{
+		Firings:    6,
+		FiringTime: time.Hour,
+		Windows: []exclusion.WindowSummary{
+			{Name: "weekly-patching", Source: exclusion.SourceFile, Firings: 4, FiringTime: 40 * time.Minute, Alerts: []string{"DiskFull", "NodeReboot"}},
+			{Name: "kernel upgrade (abc)", Source: exclusion.SourceSilence, Firings: 2, FiringTime: 20 * time.Minute, Alerts: []string{"NodeDown"}},
+		},
+	}
+}
+
+func TestReportExcluded(t *testing.T) {
+	report := sampleExclusionReport()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportExcluded(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Excluded Maintenance Activity ===")
+		assert.Contains(t, output, "6 firings (1h 0m firing time) excluded")
+		assert.Contains(t, output, "weekly-patching")
+		assert.Contains(t, output, "DiskFull, NodeReboot")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportExcluded(report))
+
+		var output map[string]exclusion.Report
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.Equal(t, 6, output["excluded"].Firings)
+		assert.Equal(t, exclusion.SourceSilence, output["excluded"].Windows[1].Source)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportExcluded(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Excluded Maintenance Activity")
+		assert.Contains(t, output, "| weekly-patching | file | 4 | 40m 0s | DiskFull, NodeReboot |")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportExcluded(exclusion.Report{}))
+		assert.Contains(t, buf.String(), "No alert activity inside maintenance windows or silences.")
+	})
+}
@@ -0,0 +1,498 @@
+package reporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// heatLevels is the number of color steps used by the heatmap and correlation matrix.
+const heatLevels = 5
+
+// Chart geometry in SVG user units. Charts scale to the section width.
+const (
+	chartWidth      = 960
+	chartLabelWidth = 220
+	chartValueWidth = 60
+	chartRowHeight  = 28
+	chartBarHeight  = 18
+	chartAxisHeight = 24
+	chartTicks      = 5
+	chartLabelRunes = 30
+)
+
+var heatmapWeekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// htmlCell is a colored cell of the heatmap or correlation matrix.
+type htmlCell struct {
+	Value string
+	Level int
+}
+
+// htmlMatrixRow is a labeled row of the heatmap or correlation matrix.
+type htmlMatrixRow struct {
+	Label string
+	Cells []htmlCell
+}
+
+// svgChart is a horizontal bar chart drawn as inline SVG, one row per alert.
+type svgChart struct {
+	ID        string
+	Caption   string
+	BarClass  string
+	Width     int
+	Height    int
+	PlotX     int
+	PlotY     int // bottom of the plot area, where the axis ticks are drawn
+	BarY      int
+	BarHeight int
+	TextY     int
+	Rows      []svgRow
+	Ticks     []svgTick
+}
+
+// svgRow is a labeled chart row with its bars and an optional value label.
+type svgRow struct {
+	Label  string
+	Title  string
+	Y      int
+	Bars   []svgBar
+	Value  string
+	ValueX float64
+}
+
+// svgBar is a single bar of a row; Title is shown as its tooltip.
+type svgBar struct {
+	X     float64
+	Width float64
+	Title string
+}
+
+// svgTick is an x axis tick.
+type svgTick struct {
+	X     float64
+	Label string
+}
+
+// analysisViewData is the view model passed to the analysis HTML template.
+type analysisViewData struct {
+	GeneratedAt       string
+	Summary           analyzer.SummaryStats
+	FlappingCount     int
+	TopAlerts         svgChart
+	HeatmapHours      []int
+	Heatmap           []htmlMatrixRow
+	Timeline          svgChart
+	CorrelationLabels []string
+	Correlation       []htmlMatrixRow
+	Recommendations   []analyzer.Recommendation
+}
+
+// renderAnalysisHTML writes a self-contained single-page HTML analysis report
+// to w. Styles and data are inlined and the charts are drawn as inline SVG,
+// so the report renders without network access.
+func renderAnalysisHTML(w io.Writer, report AnalysisReport) error {
+	flappingCount := 0
+	for _, result := range report.Flapping {
+		if result.IsFlapping {
+			flappingCount++
+		}
+	}
+
+	hours := make([]int, 24)
+	for hour := range hours {
+		hours[hour] = hour
+	}
+
+	correlationLabels, correlation := correlationMatrix(report.Correlation)
+
+	data := analysisViewData{
+		GeneratedAt:       time.Now().UTC().Format("2006-01-02 15:04:05 UTC"),
+		Summary:           report.Summary,
+		FlappingCount:     flappingCount,
+		TopAlerts:         topAlertsChart(report.Frequency),
+		HeatmapHours:      hours,
+		Heatmap:           heatmapRows(report.Heatmap),
+		Timeline:          timelineChart(report.Timelines),
+		CorrelationLabels: correlationLabels,
+		Correlation:       correlation,
+		Recommendations:   report.Recommendations,
+	}
+
+	tmpl, err := template.New("analysis").Funcs(analysisFuncMap()).Parse(analysisHTMLTemplate)
+	if err != nil {
+		return fmt.Errorf("parse analysis template: %w", err)
+	}
+	return tmpl.Execute(w, data)
+}
+
+// topAlertsChart builds the firing count bar chart.
+func topAlertsChart(results []analyzer.FrequencyResult) svgChart {
+	chart := newSVGChart("topAlertsChart", "Firings per alert", "bar", len(results), false)
+	maxCount := 0
+	for _, result := range results {
+		maxCount = max(maxCount, result.FiringCount)
+	}
+
+	plotWidth := float64(chartWidth - chartLabelWidth - chartValueWidth)
+	for i, result := range results {
+		row := chart.row(i, result.AlertName)
+		width := 0.0
+		if maxCount > 0 {
+			width = float64(result.FiringCount) / float64(maxCount) * plotWidth
+		}
+		row.Bars = []svgBar{{
+			X:     float64(chart.PlotX),
+			Width: width,
+			Title: fmt.Sprintf("%s: %d firings", result.AlertName, result.FiringCount),
+		}}
+		row.Value = fmt.Sprintf("%d", result.FiringCount)
+		row.ValueX = float64(chart.PlotX) + width
+		chart.Rows = append(chart.Rows, row)
+	}
+	return chart
+}
+
+// timelineChart builds a timeline with one row per alert and one bar per
+// firing interval, on a shared time axis.
+func timelineChart(timelines []analyzer.FlappingTimeline) svgChart {
+	chart := newSVGChart("timelineChart", "Firing intervals per alert", "firing", len(timelines), true)
+
+	var start, end time.Time
+	for _, timeline := range timelines {
+		for _, interval := range timeline.Intervals {
+			if start.IsZero() || interval.Start.Before(start) {
+				start = interval.Start
+			}
+			if interval.End.After(end) {
+				end = interval.End
+			}
+		}
+	}
+	if !end.After(start) {
+		end = start.Add(time.Minute)
+	}
+
+	plotWidth := float64(chartWidth - chartLabelWidth - chartValueWidth)
+	span := float64(end.Sub(start))
+	x := func(t time.Time) float64 {
+		return float64(chart.PlotX) + float64(t.Sub(start))/span*plotWidth
+	}
+
+	for i, timeline := range timelines {
+		row := chart.row(i, timeline.AlertName)
+		for _, interval := range timeline.Intervals {
+			row.Bars = append(row.Bars, svgBar{
+				X:     x(interval.Start),
+				Width: max(x(interval.End)-x(interval.Start), 2),
+				Title: interval.Start.UTC().Format(time.RFC3339) + " → " + interval.End.UTC().Format(time.RFC3339),
+			})
+		}
+		chart.Rows = append(chart.Rows, row)
+	}
+
+	for i := 0; i < chartTicks; i++ {
+		t := start.Add(time.Duration(float64(end.Sub(start)) * float64(i) / float64(chartTicks-1)))
+		chart.Ticks = append(chart.Ticks, svgTick{X: x(t), Label: t.UTC().Format("01-02 15:04")})
+	}
+	return chart
+}
+
+func newSVGChart(id, caption, barClass string, rows int, axis bool) svgChart {
+	chart := svgChart{
+		ID:        id,
+		Caption:   caption,
+		BarClass:  barClass,
+		Width:     chartWidth,
+		Height:    rows * chartRowHeight,
+		PlotX:     chartLabelWidth,
+		PlotY:     rows * chartRowHeight,
+		BarY:      (chartRowHeight - chartBarHeight) / 2,
+		BarHeight: chartBarHeight,
+		TextY:     chartRowHeight/2 + 4,
+		Rows:      make([]svgRow, 0, rows),
+	}
+	if axis {
+		chart.Height += chartAxisHeight
+	}
+	return chart
+}
+
+// row lays out row i, shortening long labels; the full label is kept as tooltip.
+func (c svgChart) row(i int, label string) svgRow {
+	short := label
+	if utf8.RuneCountInString(label) > chartLabelRunes {
+		short = string([]rune(label)[:chartLabelRunes-1]) + "…"
+	}
+	return svgRow{Label: short, Title: label, Y: i * chartRowHeight}
+}
+
+// heatmapRows converts the weekday × hour firing counts into colored table rows.
+func heatmapRows(heatmap [][]int) []htmlMatrixRow {
+	maxCount := 0
+	for _, day := range heatmap {
+		for _, count := range day {
+			if count > maxCount {
+				maxCount = count
+			}
+		}
+	}
+
+	rows := make([]htmlMatrixRow, 0, len(heatmap))
+	for day, hours := range heatmap {
+		if day >= len(heatmapWeekdays) {
+			break
+		}
+		row := htmlMatrixRow{Label: heatmapWeekdays[day], Cells: make([]htmlCell, 0, len(hours))}
+		for _, count := range hours {
+			value := ""
+			if count > 0 {
+				value = fmt.Sprintf("%d", count)
+			}
+			row.Cells = append(row.Cells, htmlCell{Value: value, Level: heatLevel(float64(count), float64(maxCount))})
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// correlationMatrix lays the correlated pairs out as a symmetric matrix of
+// correlation scores. Alerts are ordered by their first appearance in results.
+func correlationMatrix(results []analyzer.CorrelationResult) ([]string, []htmlMatrixRow) {
+	index := make(map[string]int)
+	labels := make([]string, 0)
+	for _, result := range results {
+		for _, name := range []string{result.AlertA, result.AlertB} {
+			if _, ok := index[name]; !ok {
+				index[name] = len(labels)
+				labels = append(labels, name)
+			}
+		}
+	}
+
+	scores := make([][]float64, len(labels))
+	for i := range scores {
+		scores[i] = make([]float64, len(labels))
+	}
+	for _, result := range results {
+		a, b := index[result.AlertA], index[result.AlertB]
+		scores[a][b] = result.CorrelationScore
+		scores[b][a] = result.CorrelationScore
+	}
+
+	rows := make([]htmlMatrixRow, 0, len(labels))
+	for i, label := range labels {
+		row := htmlMatrixRow{Label: label, Cells: make([]htmlCell, 0, len(labels))}
+		for j := range labels {
+			switch {
+			case i == j:
+				row.Cells = append(row.Cells, htmlCell{Value: "—"})
+			case scores[i][j] > 0:
+				row.Cells = append(row.Cells, htmlCell{
+					Value: fmt.Sprintf("%.2f", scores[i][j]),
+					Level: heatLevel(scores[i][j], 1),
+				})
+			default:
+				row.Cells = append(row.Cells, htmlCell{})
+			}
+		}
+		rows = append(rows, row)
+	}
+	return labels, rows
+}
+
+// heatLevel maps value in [0, maxValue] to a color step from 0 (empty) to heatLevels.
+func heatLevel(value, maxValue float64) int {
+	if value <= 0 || maxValue <= 0 {
+		return 0
+	}
+	level := int(value / maxValue * heatLevels)
+	if level < 1 {
+		level = 1
+	}
+	if level > heatLevels {
+		level = heatLevels
+	}
+	return level
+}
+
+// analysisFuncMap returns the template function map for the analysis HTML template.
+func analysisFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"formatDuration": formatDuration,
+		"priorityClass": func(priority string) string {
+			switch priority {
+			case analyzer.RecommendationPriorityCritical:
+				return "badge-critical"
+			case analyzer.RecommendationPriorityHigh:
+				return "badge-high"
+			case analyzer.RecommendationPriorityMedium:
+				return "badge-warning"
+			default:
+				return "badge-info"
+			}
+		},
+	}
+}
+
+// ---------------------------------------------------------------------------
+// HTML Template
+// ---------------------------------------------------------------------------
+
+const analysisHTMLCSS = `
+*,*::before,*::after{box-sizing:border-box;margin:0;padding:0}
+body{font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,sans-serif;background:#f1f5f9;color:#0f172a;line-height:1.5;font-size:14px}
+.container{max-width:1280px;margin:0 auto;padding:2rem 1.5rem}
+header{display:flex;justify-content:space-between;align-items:flex-end;margin-bottom:2rem;padding-bottom:1rem;border-bottom:2px solid #e2e8f0}
+header h1{font-size:1.5rem;font-weight:700}
+.meta{color:#64748b;font-size:.8rem}
+.stat-grid{display:grid;grid-template-columns:repeat(auto-fit,minmax(140px,1fr));gap:1rem;margin-bottom:2rem}
+.stat-card{background:#fff;border:1px solid #e2e8f0;border-top:3px solid #94a3b8;border-radius:10px;padding:1.25rem 1rem;text-align:center;box-shadow:0 1px 3px rgba(0,0,0,.07)}
+.stat-card.s-critical{border-top-color:#ef4444}
+.stat-card.s-info{border-top-color:#3b82f6}
+.stat-value{font-size:1.6rem;font-weight:700;line-height:1.1;color:#334155}
+.stat-label{color:#64748b;font-size:.7rem;text-transform:uppercase;letter-spacing:.06em;margin-top:.35rem}
+section{background:#fff;border:1px solid #e2e8f0;border-radius:10px;padding:1.25rem;margin-bottom:1.5rem;box-shadow:0 1px 3px rgba(0,0,0,.07)}
+section>h2{font-size:.9rem;font-weight:600;color:#334155;margin-bottom:.75rem;padding-bottom:.4rem;border-bottom:1px solid #e2e8f0}
+.chart{display:block;width:100%;height:auto}
+.chart text{font-size:12px;fill:#334155}
+.chart text.value,.chart text.tick{fill:#64748b;font-size:11px}
+.chart line{stroke:#e2e8f0}
+.chart .bar{fill:#3b82f6}
+.chart .firing{fill:#ef4444}
+.tbl-wrap{overflow-x:auto}
+table{width:100%;border-collapse:collapse}
+th{background:#f8fafc;text-align:left;padding:.6rem 1rem;font-size:.7rem;font-weight:600;text-transform:uppercase;letter-spacing:.06em;color:#64748b;white-space:nowrap}
+td{padding:.65rem 1rem;border-top:1px solid #f1f5f9;font-size:.82rem;vertical-align:top}
+table.matrix{width:auto}
+table.matrix th,table.matrix td{padding:.3rem .4rem;text-align:center;font-size:.7rem;min-width:2rem;border:1px solid #fff}
+table.matrix th.row-label{text-align:right}
+.heat-0{background:#f8fafc;color:#94a3b8}
+.heat-1{background:#fee2e2}
+.heat-2{background:#fca5a5}
+.heat-3{background:#f87171}
+.heat-4{background:#ef4444;color:#fff}
+.heat-5{background:#b91c1c;color:#fff}
+.badge{display:inline-block;padding:.15rem .55rem;border-radius:9999px;font-size:.7rem;font-weight:700;white-space:nowrap}
+.badge-critical{background:#fef2f2;color:#dc2626}
+.badge-high{background:#fff7ed;color:#ea580c}
+.badge-warning{background:#fffbeb;color:#d97706}
+.badge-info{background:#eff6ff;color:#2563eb}
+.empty{text-align:center;padding:2rem;color:#94a3b8;font-style:italic}
+`
+
+const analysisHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width,initial-scale=1">
+<title>alert-analyzer — Analysis Report</title>
+<style>` + analysisHTMLCSS + `</style>
+</head>
+<body>
+<div class="container">
+  <header>
+    <h1>🔔 Alert Analysis Report</h1>
+    <span class="meta">Generated: {{.GeneratedAt}}</span>
+  </header>
+
+  <div class="stat-grid">
+    <div class="stat-card s-info"><div class="stat-value">{{.Summary.TotalFirings}}</div><div class="stat-label">Total Firings</div></div>
+    <div class="stat-card"><div class="stat-value">{{.Summary.UniqueAlerts}}</div><div class="stat-label">Unique Alerts</div></div>
+    <div class="stat-card"><div class="stat-value">{{formatDuration .Summary.TotalFiringTime}}</div><div class="stat-label">Total Firing Time</div></div>
+    <div class="stat-card"><div class="stat-value">{{formatDuration .Summary.AvgDuration}}</div><div class="stat-label">Avg Duration</div></div>
+    <div class="stat-card s-critical"><div class="stat-value">{{.FlappingCount}}</div><div class="stat-label">Flapping Alerts</div></div>
+  </div>
+
+  <section>
+    <h2>Top Firing Alerts</h2>
+    {{if .TopAlerts.Rows}}
+    {{template "chart" .TopAlerts}}
+    {{else}}
+    <p class="empty">No alerts to show.</p>
+    {{end}}
+  </section>
+
+  <section>
+    <h2>Firing Heatmap (weekday × hour)</h2>
+    <div class="tbl-wrap">
+      <table class="matrix">
+        <thead><tr><th></th>{{range .HeatmapHours}}<th>{{printf "%02d" .}}</th>{{end}}</tr></thead>
+        <tbody>
+        {{range .Heatmap}}
+          <tr><th class="row-label">{{.Label}}</th>{{range .Cells}}<td class="heat-{{.Level}}">{{.Value}}</td>{{end}}</tr>
+        {{end}}
+        </tbody>
+      </table>
+    </div>
+  </section>
+
+  <section>
+    <h2>Flapping Timeline</h2>
+    {{if .Timeline.Rows}}
+    {{template "chart" .Timeline}}
+    {{else}}
+    <p class="empty">No alerts to show.</p>
+    {{end}}
+  </section>
+
+  <section>
+    <h2>Correlation Matrix</h2>
+    {{if .Correlation}}
+    <div class="tbl-wrap">
+      <table class="matrix">
+        <thead><tr><th></th>{{range .CorrelationLabels}}<th>{{.}}</th>{{end}}</tr></thead>
+        <tbody>
+        {{range .Correlation}}
+          <tr><th class="row-label">{{.Label}}</th>{{range .Cells}}<td class="heat-{{.Level}}">{{.Value}}</td>{{end}}</tr>
+        {{end}}
+        </tbody>
+      </table>
+    </div>
+    {{else}}
+    <p class="empty">No correlated alerts. Run with --show-correlation to populate this section.</p>
+    {{end}}
+  </section>
+
+  <section>
+    <h2>Recommendations</h2>
+    {{if .Recommendations}}
+    <div class="tbl-wrap">
+      <table>
+        <thead><tr><th>Priority</th><th>Category</th><th>Target</th><th>Signal/Noise</th><th>Summary</th><th>Action</th></tr></thead>
+        <tbody>
+        {{range .Recommendations}}
+          <tr>
+            <td><span class="badge {{priorityClass .Priority}}">{{.Priority}}</span></td>
+            <td>{{.Category}}</td>
+            <td>{{if .Target}}{{.Target}}{{else}}—{{end}}</td>
+            <td>{{if .SignalToNoise}}{{.SignalToNoise}}{{else}}—{{end}}</td>
+            <td>{{.Summary}}</td>
+            <td>{{.Action}}</td>
+          </tr>
+        {{end}}
+        </tbody>
+      </table>
+    </div>
+    {{else}}
+    <p class="empty">No recommendations. Run with --show-recommendations to populate this section.</p>
+    {{end}}
+  </section>
+</div>
+
+</body>
+</html>
+{{define "chart"}}<svg id="{{.ID}}" class="chart" viewBox="0 0 {{.Width}} {{.Height}}" role="img" aria-label="{{.Caption}}">
+  {{range .Ticks}}<line x1="{{printf "%.1f" .X}}" x2="{{printf "%.1f" .X}}" y1="0" y2="{{$.PlotY}}"/>
+  <text class="tick" x="{{printf "%.1f" .X}}" y="{{$.PlotY}}" dy="16" text-anchor="middle">{{.Label}}</text>
+  {{end}}{{range .Rows}}<g transform="translate(0,{{.Y}})">
+    <text x="{{$.PlotX}}" dx="-8" y="{{$.TextY}}" text-anchor="end"><title>{{.Title}}</title>{{.Label}}</text>
+    {{range .Bars}}<rect class="{{$.BarClass}}" x="{{printf "%.1f" .X}}" y="{{$.BarY}}" width="{{printf "%.1f" .Width}}" height="{{$.BarHeight}}" rx="2"><title>{{.Title}}</title></rect>
+    {{end}}{{if .Value}}<text class="value" x="{{printf "%.1f" .ValueX}}" dx="6" y="{{$.TextY}}">{{.Value}}</text>{{end}}
+  </g>
+  {{end}}
+</svg>{{end}}`
@@ -0,0 +1,131 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleHTMLReport() AnalysisReport {
+	start := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	heatmap := make([][]int, 7)
+	for day := range heatmap {
+		heatmap[day] = make([]int, 24)
+	}
+	heatmap[1][10] = 4
+	heatmap[6][23] = 1
+
+	return AnalysisReport{
+		Summary: analyzer.SummaryStats{TotalFirings: 12, UniqueAlerts: 2, TotalFiringTime: time.Hour},
+		Frequency: []analyzer.FrequencyResult{
+			{AlertName: "HighCPU", FiringCount: 10, Severity: "critical"},
+			{AlertName: "DiskFull", FiringCount: 2, Severity: "warning"},
+		},
+		Flapping: []analyzer.FlappingResult{
+			{AlertName: "HighCPU", IsFlapping: true},
+		},
+		Correlation: []analyzer.CorrelationResult{
+			{AlertA: "HighCPU", AlertB: "HighLoad", CorrelationScore: 0.85},
+		},
+		Recommendations: []analyzer.Recommendation{
+			{Category: "flapping", Priority: analyzer.RecommendationPriorityHigh, Target: "HighCPU", Summary: "HighCPU flaps <often>", Action: "Add a for: clause"},
+		},
+		Heatmap: heatmap,
+		Timelines: []analyzer.FlappingTimeline{
+			{AlertName: "HighCPU", Intervals: []analyzer.FiringInterval{{Start: start, End: start.Add(5 * time.Minute)}}},
+		},
+	}
+}
+
+func TestRenderAnalysisHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatHTML, &buf).ReportAnalysis(sampleHTMLReport()))
+
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "<!DOCTYPE html>"))
+	assert.Contains(t, output, "Alert Analysis Report")
+	assert.Contains(t, output, `<td class="heat-5">4</td>`)
+	assert.Contains(t, output, `<td class="heat-1">1</td>`)
+	assert.Contains(t, output, `<svg id="topAlertsChart"`)
+	assert.Contains(t, output, "<title>HighCPU: 10 firings</title>")
+	assert.Contains(t, output, `<svg id="timelineChart"`)
+	assert.Contains(t, output, "<title>2026-03-16T10:00:00Z → 2026-03-16T10:05:00Z</title>")
+	assert.NotContains(t, output, "<script", "the report is self-contained")
+	assert.NotContains(t, output, "https://", "the report loads nothing over the network")
+	assert.Contains(t, output, `<td class="heat-4">0.85</td>`)
+	assert.Contains(t, output, `<span class="badge badge-high">high</span>`)
+	assert.Contains(t, output, "HighCPU flaps &lt;often&gt;", "recommendation text is escaped")
+}
+
+func TestRenderAnalysisHTMLEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatHTML, &buf).ReportAnalysis(AnalysisReport{}))
+
+	output := buf.String()
+	assert.Contains(t, output, "No alerts to show.")
+	assert.Contains(t, output, "No correlated alerts.")
+	assert.Contains(t, output, "No recommendations.")
+	assert.NotContains(t, output, `id="topAlertsChart"`)
+	assert.NotContains(t, output, `id="timelineChart"`)
+}
+
+func TestTopAlertsChart(t *testing.T) {
+	chart := topAlertsChart([]analyzer.FrequencyResult{
+		{AlertName: "HighCPU", FiringCount: 10},
+		{AlertName: strings.Repeat("VeryLongAlertName", 3), FiringCount: 5},
+	})
+
+	require.Len(t, chart.Rows, 2)
+	full := float64(chartWidth - chartLabelWidth - chartValueWidth)
+	assert.InDelta(t, full, chart.Rows[0].Bars[0].Width, 0.001, "the largest count spans the plot")
+	assert.InDelta(t, full/2, chart.Rows[1].Bars[0].Width, 0.001)
+	assert.Equal(t, chartRowHeight, chart.Rows[1].Y)
+	assert.Equal(t, chartLabelRunes, utf8.RuneCountInString(chart.Rows[1].Label), "long labels are shortened")
+	assert.Equal(t, strings.Repeat("VeryLongAlertName", 3), chart.Rows[1].Title)
+	assert.Empty(t, chart.Ticks)
+}
+
+func TestTimelineChart(t *testing.T) {
+	start := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	chart := timelineChart([]analyzer.FlappingTimeline{
+		{AlertName: "A", Intervals: []analyzer.FiringInterval{
+			{Start: start, End: start.Add(time.Minute)},
+			{Start: start.Add(3 * time.Hour), End: start.Add(4 * time.Hour)},
+		}},
+		{AlertName: "B", Intervals: []analyzer.FiringInterval{{Start: start.Add(time.Hour), End: start.Add(time.Hour)}}},
+	})
+
+	require.Len(t, chart.Rows, 2)
+	plotWidth := float64(chartWidth - chartLabelWidth - chartValueWidth)
+	a := chart.Rows[0].Bars
+	require.Len(t, a, 2)
+	assert.InDelta(t, chartLabelWidth, a[0].X, 0.001, "the earliest interval starts the axis")
+	assert.InDelta(t, float64(chartLabelWidth)+plotWidth, a[1].X+a[1].Width, 0.001, "the latest interval ends the axis")
+	assert.InDelta(t, 2, chart.Rows[1].Bars[0].Width, 0.001, "empty intervals stay visible")
+
+	require.Len(t, chart.Ticks, chartTicks)
+	assert.Equal(t, "03-16 10:00", chart.Ticks[0].Label)
+	assert.Equal(t, "03-16 14:00", chart.Ticks[chartTicks-1].Label)
+	assert.Equal(t, 2*chartRowHeight+chartAxisHeight, chart.Height)
+}
+
+func TestCorrelationMatrix(t *testing.T) {
+	labels, rows := correlationMatrix([]analyzer.CorrelationResult{
+		{AlertA: "A", AlertB: "B", CorrelationScore: 0.5},
+		{AlertA: "B", AlertB: "C", CorrelationScore: 1},
+	})
+
+	assert.Equal(t, []string{"A", "B", "C"}, labels)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "—", rows[0].Cells[0].Value)
+	assert.Equal(t, "0.50", rows[0].Cells[1].Value)
+	assert.Equal(t, "0.50", rows[1].Cells[0].Value, "matrix is symmetric")
+	assert.Equal(t, "", rows[0].Cells[2].Value)
+	assert.Equal(t, heatLevels, rows[2].Cells[1].Level)
+}
@@ -0,0 +1,161 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// ReportHygiene outputs the rule hygiene scores per rule and per team, followed by
+// the cleanup recommendations when recommendations is not nil.
+func (r *Reporter) ReportHygiene(report analyzer.HygieneReport, recommendations []analyzer.Recommendation) error {
+	switch r.format {
+	case FormatTable:
+		if err := r.reportHygieneTable(report); err != nil {
+			return err
+		}
+		if recommendations != nil {
+			return r.reportRecommendationsTable(recommendations)
+		}
+		return nil
+	case FormatJSON:
+		return r.reportHygieneJSON(report, recommendations)
+	case FormatMarkdown:
+		if err := r.reportHygieneMarkdown(report); err != nil {
+			return err
+		}
+		if recommendations != nil {
+			return r.reportRecommendationsMarkdown(recommendations)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// hygieneIssues joins the issue messages of a rule for a single table cell.
+func hygieneIssues(result analyzer.HygieneResult) string {
+	if len(result.Issues) == 0 {
+		return "-"
+	}
+	messages := make([]string, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// reportHygieneTable outputs rule hygiene in table format.
+func (r *Reporter) reportHygieneTable(report analyzer.HygieneReport) error {
+	if len(report.Rules) == 0 {
+		fmt.Fprintln(r.writer, "\nNo alerting rules found for hygiene scoring.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(w, "\n=== Alert Rule Hygiene (average score %.1f) ===\n", report.AverageScore)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ALERT NAME\tTEAM\tSEVERITY\tSCORE\tRUNBOOK\tFIRINGS\tAVG DURATION\tISSUES")
+	fmt.Fprintln(w, "----------\t----\t--------\t-----\t-------\t-------\t------------\t------")
+
+	for _, result := range report.Rules {
+		fmt.Fprintf(w, "%s\t%s\t%s %s\t%d\t%s\t%d\t%s\t%s\n",
+			result.AlertName,
+			result.Team,
+			getSeverityIcon(result.Severity),
+			result.Severity,
+			result.Score,
+			result.RunbookStatus,
+			result.Firings,
+			formatDuration(result.AvgDuration),
+			hygieneIssues(result),
+		)
+	}
+
+	fmt.Fprintln(w, "\n=== Hygiene by Team ===")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "TEAM\tRULES\tAVG SCORE\tMIN SCORE\tNO RUNBOOK\tBROKEN RUNBOOK\tANNOTATION ISSUES\tBAD SEVERITY\tSHORT-LIVED")
+	fmt.Fprintln(w, "----\t-----\t---------\t---------\t----------\t--------------\t-----------------\t------------\t-----------")
+
+	for _, team := range report.Teams {
+		fmt.Fprintf(w, "%s\t%d\t%.1f\t%d\t%d\t%d\t%d\t%d\t%d\n",
+			team.Team,
+			team.Rules,
+			team.AverageScore,
+			team.MinScore,
+			team.MissingRunbooks,
+			team.BrokenRunbooks,
+			team.AnnotationIssues,
+			team.BadSeverities,
+			team.ShortLived,
+		)
+	}
+
+	return w.Flush()
+}
+
+// reportHygieneJSON outputs rule hygiene in JSON format.
+func (r *Reporter) reportHygieneJSON(report analyzer.HygieneReport, recommendations []analyzer.Recommendation) error {
+	output := map[string]interface{}{
+		"hygiene": report,
+	}
+	if recommendations != nil {
+		output["recommendations"] = recommendations
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func (r *Reporter) reportHygieneMarkdown(report analyzer.HygieneReport) error {
+	fmt.Fprintln(r.writer, "## Alert Rule Hygiene")
+	fmt.Fprintln(r.writer)
+	if len(report.Rules) == 0 {
+		fmt.Fprintln(r.writer, "No alerting rules found for hygiene scoring.")
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	fmt.Fprintf(r.writer, "Average score: **%.1f** / 100\n\n", report.AverageScore)
+	fmt.Fprintln(r.writer, "| Alert Name | Team | Severity | Score | Runbook | Firings | Avg Duration | Issues |")
+	fmt.Fprintln(r.writer, "| --- | --- | --- | ---: | --- | ---: | --- | --- |")
+	for _, result := range report.Rules {
+		fmt.Fprintf(r.writer, "| %s | %s | %s %s | %d | %s | %d | %s | %s |\n",
+			escapeMarkdown(result.AlertName),
+			escapeMarkdown(result.Team),
+			getSeverityIcon(result.Severity),
+			escapeMarkdown(result.Severity),
+			result.Score,
+			result.RunbookStatus,
+			result.Firings,
+			formatDuration(result.AvgDuration),
+			escapeMarkdown(hygieneIssues(result)),
+		)
+	}
+	fmt.Fprintln(r.writer)
+
+	fmt.Fprintln(r.writer, "### Hygiene by Team")
+	fmt.Fprintln(r.writer)
+	fmt.Fprintln(r.writer, "| Team | Rules | Avg Score | Min Score | No Runbook | Broken Runbook | Annotation Issues | Bad Severity | Short-Lived |")
+	fmt.Fprintln(r.writer, "| --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: | ---: |")
+	for _, team := range report.Teams {
+		fmt.Fprintf(r.writer, "| %s | %d | %.1f | %d | %d | %d | %d | %d | %d |\n",
+			escapeMarkdown(team.Team),
+			team.Rules,
+			team.AverageScore,
+			team.MinScore,
+			team.MissingRunbooks,
+			team.BrokenRunbooks,
+			team.AnnotationIssues,
+			team.BadSeverities,
+			team.ShortLived,
+		)
+	}
+	fmt.Fprintln(r.writer)
+	return nil
+}
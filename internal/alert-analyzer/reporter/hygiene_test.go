@@ -0,0 +1,108 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleHygieneReport() analyzer.HygieneReport {
+	return analyzer.HygieneReport{
+		AverageScore: 57.5,
+		Rules: []analyzer.HygieneResult{
+			{
+				AlertName:     "DiskFull",
+				Team:          "storage",
+				Severity:      "warning",
+				Score:         40,
+				RunbookStatus: analyzer.RunbookMissing,
+				Firings:       3,
+				AvgDuration:   2 * time.Minute,
+				Issues: []analyzer.HygieneIssue{
+					{Check: analyzer.HygieneCheckRunbook, Message: "missing runbook_url annotation"},
+					{Check: analyzer.HygieneCheckAnnotations, Message: "summary template error: can't evaluate field labels"},
+				},
+			},
+			{
+				AlertName:     "HighLatency",
+				Team:          "api",
+				Severity:      "critical",
+				Score:         75,
+				RunbookStatus: analyzer.RunbookOK,
+				Issues:        []analyzer.HygieneIssue{},
+			},
+		},
+		Teams: []analyzer.TeamHygiene{
+			{Team: "storage", Rules: 1, AverageScore: 40, MinScore: 40, MissingRunbooks: 1, AnnotationIssues: 1},
+			{Team: "api", Rules: 1, AverageScore: 75, MinScore: 75},
+		},
+	}
+}
+
+func TestReportHygiene(t *testing.T) {
+	report := sampleHygieneReport()
+	recommendations := []analyzer.Recommendation{{
+		Category: analyzer.RecommendationCategoryHygiene,
+		Priority: analyzer.RecommendationPriorityHigh,
+		Target:   "DiskFull",
+		Summary:  "DiskFull scores 40/100 on rule hygiene.",
+		Action:   "Add a runbook.",
+	}}
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportHygiene(report, recommendations))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Alert Rule Hygiene (average score 57.5) ===")
+		assert.Contains(t, output, "=== Hygiene by Team ===")
+		assert.Contains(t, output, "missing runbook_url annotation; summary template error: can't evaluate field labels")
+		assert.Contains(t, output, "=== Recommendations ===")
+		assert.Contains(t, output, "Add a runbook.")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportHygiene(report, nil))
+
+		var output map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.NotContains(t, output, "recommendations")
+
+		var hygiene analyzer.HygieneReport
+		require.NoError(t, json.Unmarshal(output["hygiene"], &hygiene))
+		assert.Equal(t, 40, hygiene.Rules[0].Score)
+		assert.Equal(t, analyzer.HygieneCheckRunbook, hygiene.Rules[0].Issues[0].Check)
+		assert.Equal(t, 1, hygiene.Teams[0].MissingRunbooks)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportHygiene(report, recommendations))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Alert Rule Hygiene")
+		assert.Contains(t, output, "Average score: **57.5** / 100")
+		assert.Contains(t, output, "| HighLatency | api | 🔴 critical | 75 | ok | 0 | 0s | - |")
+		assert.Contains(t, output, "| storage | 1 | 40.0 | 40 | 1 | 0 | 1 | 0 | 0 |")
+		assert.Contains(t, output, "## Recommendations")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportHygiene(analyzer.HygieneReport{}, nil))
+		assert.Contains(t, buf.String(), "No alerting rules found for hygiene scoring.")
+		assert.NotContains(t, buf.String(), "Recommendations")
+	})
+
+	t.Run("Unsupported Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := NewReporter("xml", &buf).ReportHygiene(report, nil)
+		assert.ErrorContains(t, err, "unsupported format")
+	})
+}
@@ -0,0 +1,154 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// ReportIncidents outputs detected alert storms and Alertmanager grouping suggestions.
+func (r *Reporter) ReportIncidents(report analyzer.IncidentReport) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportIncidentsTable(report)
+	case FormatJSON:
+		return r.reportIncidentsJSON(report)
+	case FormatMarkdown:
+		return r.reportIncidentsMarkdown(report)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// reportIncidentsTable outputs incidents in table format.
+func (r *Reporter) reportIncidentsTable(report analyzer.IncidentReport) error {
+	if len(report.Incidents) == 0 {
+		fmt.Fprintln(r.writer, "\nNo incidents detected.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "\n=== Incidents ===")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ID\tSTART\tDURATION\tROOT CANDIDATE\tALERTS\tFIRINGS\tSCOPE\tMEMBERS")
+	fmt.Fprintln(w, "--\t-----\t--------\t--------------\t------\t-------\t-----\t-------")
+
+	for _, incident := range report.Incidents {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+			incident.ID,
+			incident.Start.Format(time.RFC3339),
+			formatDuration(incident.Duration),
+			incident.RootCandidate,
+			len(incident.Alerts),
+			incident.Firings,
+			formatLabelSet(incident.SharedLabels),
+			strings.Join(incident.Alerts, ", "),
+		)
+	}
+
+	if len(report.Suggestions) > 0 {
+		fmt.Fprintln(w, "\n=== Alertmanager Suggestions ===")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "TYPE\tINCIDENTS\tCONFIG\tREASON")
+		fmt.Fprintln(w, "----\t---------\t------\t------")
+
+		for _, suggestion := range report.Suggestions {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n",
+				suggestion.Type,
+				suggestion.Incidents,
+				formatSuggestion(suggestion),
+				suggestion.Description,
+			)
+		}
+	}
+
+	return w.Flush()
+}
+
+// reportIncidentsJSON outputs incidents in JSON format.
+func (r *Reporter) reportIncidentsJSON(report analyzer.IncidentReport) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"incidents": report,
+	})
+}
+
+func (r *Reporter) reportIncidentsMarkdown(report analyzer.IncidentReport) error {
+	fmt.Fprintln(r.writer, "## Incidents")
+	fmt.Fprintln(r.writer)
+	if len(report.Incidents) == 0 {
+		fmt.Fprintln(r.writer, "No incidents detected.")
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	fmt.Fprintln(r.writer, "| ID | Start | Duration | Root Candidate | Alerts | Firings | Scope | Members |")
+	fmt.Fprintln(r.writer, "| ---: | --- | --- | --- | ---: | ---: | --- | --- |")
+	for _, incident := range report.Incidents {
+		fmt.Fprintf(r.writer, "| %d | %s | %s | %s | %d | %d | %s | %s |\n",
+			incident.ID,
+			incident.Start.Format(time.RFC3339),
+			formatDuration(incident.Duration),
+			escapeMarkdown(incident.RootCandidate),
+			len(incident.Alerts),
+			incident.Firings,
+			escapeMarkdown(formatLabelSet(incident.SharedLabels)),
+			escapeMarkdown(strings.Join(incident.Alerts, ", ")),
+		)
+	}
+	fmt.Fprintln(r.writer)
+
+	if len(report.Suggestions) > 0 {
+		fmt.Fprintln(r.writer, "### Alertmanager Suggestions")
+		fmt.Fprintln(r.writer)
+		fmt.Fprintln(r.writer, "| Type | Incidents | Config | Reason |")
+		fmt.Fprintln(r.writer, "| --- | ---: | --- | --- |")
+		for _, suggestion := range report.Suggestions {
+			fmt.Fprintf(r.writer, "| %s | %d | `%s` | %s |\n",
+				suggestion.Type,
+				suggestion.Incidents,
+				escapeMarkdown(formatSuggestion(suggestion)),
+				escapeMarkdown(suggestion.Description),
+			)
+		}
+		fmt.Fprintln(r.writer)
+	}
+	return nil
+}
+
+// formatSuggestion renders a suggestion as a compact Alertmanager config fragment.
+func formatSuggestion(suggestion analyzer.IncidentSuggestion) string {
+	if suggestion.Type == analyzer.SuggestionGroupBy {
+		return fmt.Sprintf("group_by: [%s]", strings.Join(suggestion.GroupBy, ", "))
+	}
+
+	parts := []string{
+		fmt.Sprintf("source_matchers: [%s]", strings.Join(suggestion.SourceMatchers, ", ")),
+		fmt.Sprintf("target_matchers: [%s]", strings.Join(suggestion.TargetMatchers, ", ")),
+	}
+	if len(suggestion.Equal) > 0 {
+		parts = append(parts, fmt.Sprintf("equal: [%s]", strings.Join(suggestion.Equal, ", ")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatLabelSet renders labels as a sorted, comma-separated list of name=value pairs.
+func formatLabelSet(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,84 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleIncidentReport() analyzer.IncidentReport {
+	start := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	return analyzer.IncidentReport{
+		Incidents: []analyzer.Incident{
+			{
+				ID:            1,
+				Start:         start,
+				End:           start.Add(25 * time.Minute),
+				Duration:      25 * time.Minute,
+				RootCandidate: "NodeDown [prod]",
+				Alerts:        []string{"NodeDown [prod]", "TargetDown [prod]"},
+				Firings:       3,
+				SharedLabels:  map[string]string{"cluster": "prod"},
+			},
+		},
+		Suggestions: []analyzer.IncidentSuggestion{
+			{Type: analyzer.SuggestionGroupBy, GroupBy: []string{"cluster"}, Incidents: 2, Description: "group storms"},
+			{
+				Type:           analyzer.SuggestionInhibitRule,
+				SourceMatchers: []string{`alertname="NodeDown"`},
+				TargetMatchers: []string{`alertname="TargetDown"`},
+				Equal:          []string{"cluster"},
+				Incidents:      2,
+				Description:    "inhibit follow-ups",
+			},
+		},
+	}
+}
+
+func TestReportIncidents(t *testing.T) {
+	report := sampleIncidentReport()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportIncidents(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Incidents ===")
+		assert.Contains(t, output, "NodeDown [prod], TargetDown [prod]")
+		assert.Contains(t, output, "cluster=prod")
+		assert.Contains(t, output, "=== Alertmanager Suggestions ===")
+		assert.Contains(t, output, "group_by: [cluster]")
+		assert.Contains(t, output, `source_matchers: [alertname="NodeDown"] target_matchers: [alertname="TargetDown"] equal: [cluster]`)
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportIncidents(report))
+
+		var output map[string]analyzer.IncidentReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.Equal(t, "NodeDown [prod]", output["incidents"].Incidents[0].RootCandidate)
+		assert.Len(t, output["incidents"].Suggestions, 2)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportIncidents(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Incidents")
+		assert.Contains(t, output, "| 1 | 2026-03-16T10:00:00Z | 25m 0s | NodeDown [prod] | 2 | 3 | cluster=prod |")
+		assert.Contains(t, output, "### Alertmanager Suggestions")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportIncidents(analyzer.IncidentReport{}))
+		assert.Contains(t, buf.String(), "No incidents detected.")
+	})
+}
@@ -0,0 +1,139 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// ReportLabelAnalysis outputs label skew and high-cardinality labels.
+func (r *Reporter) ReportLabelAnalysis(report analyzer.LabelReport) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportLabelAnalysisTable(report)
+	case FormatJSON:
+		return r.reportLabelAnalysisJSON(report)
+	case FormatMarkdown:
+		return r.reportLabelAnalysisMarkdown(report)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// reportLabelAnalysisTable outputs the label analysis in table format.
+func (r *Reporter) reportLabelAnalysisTable(report analyzer.LabelReport) error {
+	if len(report.Skew) == 0 && len(report.Cardinality) == 0 {
+		fmt.Fprintln(r.writer, "\nNo label skew or high-cardinality labels detected.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	if len(report.Skew) > 0 {
+		fmt.Fprintln(w, "\n=== Label Skew ===")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "ALERT NAME\tLABEL\tDOMINANT VALUE\tSHARE\tFIRINGS\tVALUES\tSEVERITY")
+		fmt.Fprintln(w, "----------\t-----\t--------------\t-----\t-------\t------\t--------")
+
+		for _, result := range report.Skew {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%.0f%%\t%d/%d\t%d\t%s %s\n",
+				result.AlertName,
+				result.Label,
+				result.Value,
+				result.Share*100,
+				result.ValueFirings,
+				result.Firings,
+				result.DistinctValues,
+				getSeverityIcon(result.Severity),
+				result.Severity,
+			)
+		}
+	}
+
+	if len(report.Cardinality) > 0 {
+		fmt.Fprintln(w, "\n=== High Cardinality Labels ===")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "ALERT NAME\tLABEL\tVALUES\tFIRINGS\tSERIES\tEXAMPLES\tSEVERITY")
+		fmt.Fprintln(w, "----------\t-----\t------\t-------\t------\t--------\t--------")
+
+		for _, result := range report.Cardinality {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t%s %s\n",
+				result.AlertName,
+				result.Label,
+				result.DistinctValues,
+				result.Firings,
+				result.Series,
+				strings.Join(result.Examples, ", "),
+				getSeverityIcon(result.Severity),
+				result.Severity,
+			)
+		}
+	}
+
+	return w.Flush()
+}
+
+// reportLabelAnalysisJSON outputs the label analysis in JSON format.
+func (r *Reporter) reportLabelAnalysisJSON(report analyzer.LabelReport) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"label_analysis": report,
+	})
+}
+
+func (r *Reporter) reportLabelAnalysisMarkdown(report analyzer.LabelReport) error {
+	fmt.Fprintln(r.writer, "## Label Analysis")
+	fmt.Fprintln(r.writer)
+	if len(report.Skew) == 0 && len(report.Cardinality) == 0 {
+		fmt.Fprintln(r.writer, "No label skew or high-cardinality labels detected.")
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	if len(report.Skew) > 0 {
+		fmt.Fprintln(r.writer, "### Label Skew")
+		fmt.Fprintln(r.writer)
+		fmt.Fprintln(r.writer, "| Alert Name | Label | Dominant Value | Share | Firings | Values | Severity |")
+		fmt.Fprintln(r.writer, "| --- | --- | --- | ---: | ---: | ---: | --- |")
+		for _, result := range report.Skew {
+			fmt.Fprintf(r.writer, "| %s | %s | %s | %.0f%% | %d/%d | %d | %s %s |\n",
+				escapeMarkdown(result.AlertName),
+				escapeMarkdown(result.Label),
+				escapeMarkdown(result.Value),
+				result.Share*100,
+				result.ValueFirings,
+				result.Firings,
+				result.DistinctValues,
+				getSeverityIcon(result.Severity),
+				escapeMarkdown(result.Severity),
+			)
+		}
+		fmt.Fprintln(r.writer)
+	}
+
+	if len(report.Cardinality) > 0 {
+		fmt.Fprintln(r.writer, "### High Cardinality Labels")
+		fmt.Fprintln(r.writer)
+		fmt.Fprintln(r.writer, "| Alert Name | Label | Values | Firings | Series | Examples | Severity |")
+		fmt.Fprintln(r.writer, "| --- | --- | ---: | ---: | ---: | --- | --- |")
+		for _, result := range report.Cardinality {
+			fmt.Fprintf(r.writer, "| %s | %s | %d | %d | %d | %s | %s %s |\n",
+				escapeMarkdown(result.AlertName),
+				escapeMarkdown(result.Label),
+				result.DistinctValues,
+				result.Firings,
+				result.Series,
+				escapeMarkdown(strings.Join(result.Examples, ", ")),
+				getSeverityIcon(result.Severity),
+				escapeMarkdown(result.Severity),
+			)
+		}
+		fmt.Fprintln(r.writer)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleLabelReport() analyzer.LabelReport {
+	return analyzer.LabelReport{
+		Skew: []analyzer.LabelSkewResult{
+			{AlertName: "HighLatency", Severity: "warning", Label: "namespace", Value: "checkout", Firings: 10, ValueFirings: 9, Share: 0.9, DistinctValues: 2},
+		},
+		Cardinality: []analyzer.CardinalityResult{
+			{AlertName: "PodCrashLooping", Severity: "critical", Label: "pod", DistinctValues: 25, Firings: 25, Series: 25, Examples: []string{"worker-00", "worker-01"}},
+		},
+	}
+}
+
+func TestReportLabelAnalysis(t *testing.T) {
+	report := sampleLabelReport()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportLabelAnalysis(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Label Skew ===")
+		assert.Contains(t, output, "checkout")
+		assert.Contains(t, output, "90%")
+		assert.Contains(t, output, "9/10")
+		assert.Contains(t, output, "=== High Cardinality Labels ===")
+		assert.Contains(t, output, "worker-00, worker-01")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportLabelAnalysis(report))
+
+		var output map[string]analyzer.LabelReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.Equal(t, "checkout", output["label_analysis"].Skew[0].Value)
+		assert.Equal(t, 25, output["label_analysis"].Cardinality[0].DistinctValues)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportLabelAnalysis(report))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Label Analysis")
+		assert.Contains(t, output, "| HighLatency | namespace | checkout | 90% | 9/10 | 2 |")
+		assert.Contains(t, output, "| PodCrashLooping | pod | 25 | 25 | 25 | worker-00, worker-01 |")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportLabelAnalysis(analyzer.LabelReport{}))
+		assert.Contains(t, buf.String(), "No label skew or high-cardinality labels detected.")
+	})
+
+	t.Run("Included In Analysis", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportAnalysis(AnalysisReport{Labels: &report}))
+		assert.Contains(t, buf.String(), "### High Cardinality Labels")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/exclusion"
 )
 
 // Output format constants
@@ -17,6 +18,7 @@ const (
 	FormatTable    = "table"
 	FormatJSON     = "json"
 	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
 )
 
 // Reporter handles output formatting for analysis results
@@ -256,10 +258,20 @@ type AnalysisReport struct {
 	Timestamp       string                       `json:"timestamp"`
 	Summary         analyzer.SummaryStats        `json:"summary"`
 	Frequency       []analyzer.FrequencyResult   `json:"frequency_analysis"`
+	Excluded        *exclusion.Report            `json:"excluded,omitempty"`
+	Trends          *analyzer.TrendReport        `json:"trends,omitempty"`
 	Flapping        []analyzer.FlappingResult    `json:"flapping_analysis,omitempty"`
 	Correlation     []analyzer.CorrelationResult `json:"correlation_analysis,omitempty"`
 	Temporal        []analyzer.TemporalResult    `json:"temporal_patterns,omitempty"`
+	Labels          *analyzer.LabelReport        `json:"label_analysis,omitempty"`
+	Anomalies       *analyzer.AnomalyReport      `json:"anomalies,omitempty"`
+	Incidents       *analyzer.IncidentReport     `json:"incidents,omitempty"`
+	OnCallBurden    *analyzer.BurdenReport       `json:"oncall_burden,omitempty"`
 	Recommendations []analyzer.Recommendation    `json:"recommendations,omitempty"`
+
+	// Heatmap and Timelines are only rendered by the HTML report.
+	Heatmap   [][]int                     `json:"-"`
+	Timelines []analyzer.FlappingTimeline `json:"-"`
 }
 
 // ReportComplete outputs a complete analysis report
@@ -301,81 +313,77 @@ func (r *Reporter) ReportCompleteWithCorrelation(stats analyzer.SummaryStats, fr
 }
 
 // ReportCompleteWithInsights outputs a complete analysis report including optional flapping and correlation analysis.
-func (r *Reporter) ReportCompleteWithInsights(stats analyzer.SummaryStats, frequency []analyzer.FrequencyResult, flapping []analyzer.FlappingResult, correlation []analyzer.CorrelationResult, temporal []analyzer.TemporalResult, recommendations []analyzer.Recommendation) error { //nolint:gocyclo // complex report function with many output paths
+func (r *Reporter) ReportCompleteWithInsights(stats analyzer.SummaryStats, frequency []analyzer.FrequencyResult, flapping []analyzer.FlappingResult, correlation []analyzer.CorrelationResult, temporal []analyzer.TemporalResult, recommendations []analyzer.Recommendation) error {
+	return r.ReportAnalysis(AnalysisReport{
+		Summary:         stats,
+		Frequency:       frequency,
+		Flapping:        flapping,
+		Correlation:     correlation,
+		Temporal:        temporal,
+		Recommendations: recommendations,
+	})
+}
+
+// ReportAnalysis outputs a complete analysis report with every populated section.
+func (r *Reporter) ReportAnalysis(report AnalysisReport) error {
 	switch r.format {
 	case FormatTable:
-		if err := r.ReportSummary(stats); err != nil {
-			return err
-		}
-		if err := r.ReportFrequency(frequency); err != nil {
-			return err
-		}
-		if len(flapping) > 0 {
-			if err := r.ReportFlapping(flapping); err != nil {
-				return err
-			}
-		}
-		if len(correlation) > 0 {
-			if err := r.ReportCorrelation(correlation); err != nil {
-				return err
-			}
-		}
-		if len(temporal) > 0 {
-			if err := r.ReportTemporalPatterns(temporal); err != nil {
-				return err
-			}
-		}
-		if len(recommendations) > 0 {
-			if err := r.ReportRecommendations(recommendations); err != nil {
-				return err
-			}
-		}
-		return nil
+		return r.reportSections(report)
 	case FormatJSON:
-		report := AnalysisReport{
-			Timestamp:       time.Now().Format(time.RFC3339),
-			Summary:         stats,
-			Frequency:       frequency,
-			Flapping:        flapping,
-			Correlation:     correlation,
-			Temporal:        temporal,
-			Recommendations: recommendations,
+		if report.Timestamp == "" {
+			report.Timestamp = time.Now().Format(time.RFC3339)
 		}
 		encoder := json.NewEncoder(r.writer)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(report)
 	case FormatMarkdown:
 		r.writeMarkdownHeader()
-		if err := r.ReportSummary(stats); err != nil {
-			return err
-		}
-		if err := r.ReportFrequency(frequency); err != nil {
+		return r.reportSections(report)
+	case FormatHTML:
+		return renderAnalysisHTML(r.writer, report)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// reportSections writes each populated section in the reporter's text format.
+func (r *Reporter) reportSections(report AnalysisReport) error {
+	if err := r.ReportSummary(report.Summary); err != nil {
+		return err
+	}
+	if report.Trends != nil {
+		if err := r.ReportFrequencyTrends(report.Frequency, *report.Trends); err != nil {
 			return err
 		}
-		if len(flapping) > 0 {
-			if err := r.ReportFlapping(flapping); err != nil {
-				return err
-			}
+	} else if err := r.ReportFrequency(report.Frequency); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		present bool
+		write   func() error
+	}{
+		{report.Excluded != nil, func() error { return r.ReportExcluded(*report.Excluded) }},
+		{report.Trends != nil, func() error { return r.ReportTrends(*report.Trends) }},
+		{len(report.Flapping) > 0, func() error { return r.ReportFlapping(report.Flapping) }},
+		{len(report.Correlation) > 0, func() error { return r.ReportCorrelation(report.Correlation) }},
+		{len(report.Temporal) > 0, func() error { return r.ReportTemporalPatterns(report.Temporal) }},
+		{report.Labels != nil, func() error { return r.ReportLabelAnalysis(*report.Labels) }},
+		{report.Anomalies != nil, func() error { return r.ReportAnomalies(*report.Anomalies) }},
+		{report.Incidents != nil, func() error { return r.ReportIncidents(*report.Incidents) }},
+		{report.OnCallBurden != nil, func() error { return r.ReportOnCallBurden(*report.OnCallBurden) }},
+		{len(report.Recommendations) > 0, func() error { return r.ReportRecommendations(report.Recommendations) }},
+	}
+
+	for _, section := range sections {
+		if !section.present {
+			continue
 		}
-		if len(correlation) > 0 {
-			if err := r.ReportCorrelation(correlation); err != nil {
-				return err
-			}
-		}
-		if len(temporal) > 0 {
-			if err := r.ReportTemporalPatterns(temporal); err != nil {
-				return err
-			}
-		}
-		if len(recommendations) > 0 {
-			if err := r.ReportRecommendations(recommendations); err != nil {
-				return err
-			}
+		if err := section.write(); err != nil {
+			return err
 		}
-		return nil
-	default:
-		return fmt.Errorf("unsupported format: %s", r.format)
 	}
+	return nil
 }
 
 // ReportFlapping outputs flapping analysis results
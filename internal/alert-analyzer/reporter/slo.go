@@ -0,0 +1,184 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/slo"
+)
+
+// ReportSLOCoverage outputs the burn-rate alerting coverage of each SLO.
+func (r *Reporter) ReportSLOCoverage(results []slo.Coverage) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportSLOCoverageTable(results)
+	case FormatJSON:
+		return r.reportSLOCoverageJSON(results)
+	case FormatMarkdown:
+		return r.reportSLOCoverageMarkdown(results)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// sloCoverageRow holds the formatted cells shared by the table and markdown output.
+type sloCoverageRow struct {
+	name      string
+	objective string
+	status    string
+	rules     string
+	missing   string
+	related   string
+	pages     string
+	tickets   string
+}
+
+func sloCoverageRows(results []slo.Coverage) []sloCoverageRow {
+	rows := make([]sloCoverageRow, 0, len(results))
+	for _, result := range results {
+		row := sloCoverageRow{
+			name:      result.SLO,
+			objective: fmt.Sprintf("%v%% / %s", result.Objective, model.Duration(result.Window)),
+			status:    sloStatusIcon(result.Status) + " " + result.Status,
+			rules:     "-",
+			missing:   "-",
+			related:   fmt.Sprintf("%d (%d firings)", len(result.RelatedAlerts), result.RelatedFirings),
+			pages:     "-",
+			tickets:   "-",
+		}
+		if len(result.BurnRateRules) > 0 {
+			row.rules = strings.Join(result.BurnRateRules, ", ")
+		}
+		if len(result.Missing) > 0 {
+			missing := make([]string, 0, len(result.Missing))
+			for _, window := range result.Missing {
+				missing = append(missing, window.String())
+			}
+			row.missing = strings.Join(missing, ", ")
+		}
+		for _, estimate := range result.Estimates {
+			value := fmt.Sprintf("%d (%s)", estimate.Firings, formatDuration(estimate.FiringTime))
+			if estimate.Severity == slo.SeverityPage {
+				row.pages = value
+			} else {
+				row.tickets = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func sloStatusIcon(status string) string {
+	switch status {
+	case slo.StatusCovered:
+		return "✅"
+	case slo.StatusPartial:
+		return "⚠️"
+	default:
+		return "❌"
+	}
+}
+
+// reportSLOCoverageTable outputs the SLO coverage in table format.
+func (r *Reporter) reportSLOCoverageTable(results []slo.Coverage) error {
+	if len(results) == 0 {
+		fmt.Fprintln(r.writer, "\nNo SLOs defined.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "\n=== SLO Burn-Rate Alert Coverage ===")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "SLO\tOBJECTIVE\tSTATUS\tBURN-RATE RULES\tMISSING WINDOWS\tRELATED ALERTS\tEST. PAGES\tEST. TICKETS")
+	fmt.Fprintln(w, "---\t---------\t------\t---------------\t---------------\t--------------\t----------\t------------")
+	for _, row := range sloCoverageRows(results) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			row.name, row.objective, row.status, row.rules, row.missing, row.related, row.pages, row.tickets)
+	}
+
+	if hasRelatedAlerts(results) {
+		fmt.Fprintln(w, "\n=== Existing Symptom and Cause Alerts ===")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "SLO\tALERT NAME\tMATCHED BY\tFIRINGS\tTOTAL TIME")
+		fmt.Fprintln(w, "---\t----------\t----------\t-------\t----------")
+		for _, result := range results {
+			for _, alert := range result.RelatedAlerts {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+					result.SLO, alert.Name, alert.Reason, alert.Firings, formatDuration(alert.FiringTime))
+			}
+		}
+	}
+
+	return w.Flush()
+}
+
+// reportSLOCoverageJSON outputs the SLO coverage in JSON format.
+func (r *Reporter) reportSLOCoverageJSON(results []slo.Coverage) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"slo_coverage": results,
+	})
+}
+
+func (r *Reporter) reportSLOCoverageMarkdown(results []slo.Coverage) error {
+	fmt.Fprintln(r.writer, "## SLO Burn-Rate Alert Coverage")
+	fmt.Fprintln(r.writer)
+	if len(results) == 0 {
+		fmt.Fprintln(r.writer, "No SLOs defined.")
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	fmt.Fprintln(r.writer, "| SLO | Objective | Status | Burn-Rate Rules | Missing Windows | Related Alerts | Est. Pages | Est. Tickets |")
+	fmt.Fprintln(r.writer, "| --- | --- | --- | --- | --- | --- | ---: | ---: |")
+	for _, row := range sloCoverageRows(results) {
+		fmt.Fprintf(r.writer, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			escapeMarkdown(row.name),
+			escapeMarkdown(row.objective),
+			row.status,
+			escapeMarkdown(row.rules),
+			row.missing,
+			row.related,
+			row.pages,
+			row.tickets,
+		)
+	}
+	fmt.Fprintln(r.writer)
+
+	if hasRelatedAlerts(results) {
+		fmt.Fprintln(r.writer, "### Existing Symptom and Cause Alerts")
+		fmt.Fprintln(r.writer)
+		fmt.Fprintln(r.writer, "| SLO | Alert Name | Matched By | Firings | Total Time |")
+		fmt.Fprintln(r.writer, "| --- | --- | --- | ---: | ---: |")
+		for _, result := range results {
+			for _, alert := range result.RelatedAlerts {
+				fmt.Fprintf(r.writer, "| %s | %s | %s | %d | %s |\n",
+					escapeMarkdown(result.SLO),
+					escapeMarkdown(alert.Name),
+					alert.Reason,
+					alert.Firings,
+					formatDuration(alert.FiringTime),
+				)
+			}
+		}
+		fmt.Fprintln(r.writer)
+	}
+
+	return nil
+}
+
+func hasRelatedAlerts(results []slo.Coverage) bool {
+	for _, result := range results {
+		if len(result.RelatedAlerts) > 0 {
+			return true
+		}
+	}
+	return false
+}
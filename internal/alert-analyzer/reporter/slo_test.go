@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/slo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleSLOCoverage() []slo.Coverage {
+	return []slo.Coverage{
+		{
+			SLO:           "checkout-availability",
+			Objective:     99.9,
+			Window:        30 * 24 * time.Hour,
+			Status:        slo.StatusPartial,
+			BurnRateRules: []string{"CheckoutBudgetBurn"},
+			Covered:       slo.DefaultWindows[:2],
+			Missing:       slo.DefaultWindows[2:],
+			RelatedAlerts: []slo.RelatedAlert{
+				{Name: "CheckoutHighErrorRate", Reason: slo.RelatedBySLIMetric, Firings: 12, FiringTime: time.Hour},
+			},
+			RelatedFirings: 12,
+			Estimates: []slo.Estimate{
+				{Severity: slo.SeverityPage, Firings: 2, FiringTime: 40 * time.Minute},
+				{Severity: slo.SeverityTicket, Firings: 1, FiringTime: 3 * time.Hour},
+			},
+		},
+		{
+			SLO:       "search-latency",
+			Objective: 99,
+			Window:    28 * 24 * time.Hour,
+			Status:    slo.StatusMissing,
+			Missing:   slo.DefaultWindows,
+		},
+	}
+}
+
+func TestReportSLOCoverage(t *testing.T) {
+	results := sampleSLOCoverage()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportSLOCoverage(results))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== SLO Burn-Rate Alert Coverage ===")
+		assert.Contains(t, output, "99.9% / 30d")
+		assert.Contains(t, output, "1d/2h, 3d/6h")
+		assert.Contains(t, output, "1 (12 firings)")
+		assert.Contains(t, output, "2 (40m 0s)")
+		assert.Contains(t, output, "=== Existing Symptom and Cause Alerts ===")
+		assert.Contains(t, output, "CheckoutHighErrorRate")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportSLOCoverage(results))
+
+		var output map[string][]slo.Coverage
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		require.Len(t, output["slo_coverage"], 2)
+		assert.Equal(t, slo.StatusMissing, output["slo_coverage"][1].Status)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportSLOCoverage(results))
+
+		output := buf.String()
+		assert.Contains(t, output, "## SLO Burn-Rate Alert Coverage")
+		assert.Contains(t, output, "| search-latency | 99% / 4w | ❌ missing | - | 1h/5m, 6h/30m, 1d/2h, 3d/6h | 0 (0 firings) | - | - |")
+		assert.Contains(t, output, "| checkout-availability | CheckoutHighErrorRate | sli_metric | 12 | 1h 0m |")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportSLOCoverage(nil))
+		assert.Contains(t, buf.String(), "No SLOs defined.")
+	})
+}
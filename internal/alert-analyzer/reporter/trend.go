@@ -0,0 +1,232 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+)
+
+// ReportFrequencyTrends outputs frequency analysis with period-over-period delta columns.
+func (r *Reporter) ReportFrequencyTrends(results []analyzer.FrequencyResult, trends analyzer.TrendReport) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportFrequencyTrendsTable(results, trends)
+	case FormatJSON:
+		return r.reportFrequencyTrendsJSON(results, trends)
+	case FormatMarkdown:
+		return r.reportFrequencyTrendsMarkdown(results, trends)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// ReportTrends outputs the period comparison: totals, new alerts and disappeared alerts.
+func (r *Reporter) ReportTrends(trends analyzer.TrendReport) error {
+	switch r.format {
+	case FormatTable:
+		return r.reportTrendsTable(trends)
+	case FormatJSON:
+		return r.reportTrendsJSON(trends)
+	case FormatMarkdown:
+		return r.reportTrendsMarkdown(trends)
+	default:
+		return fmt.Errorf("unsupported format: %s", r.format)
+	}
+}
+
+// trendColumns are the delta cells shown next to an alert in the frequency table.
+type trendColumns struct {
+	previous      string
+	firingDelta   string
+	totalDelta    string
+	flappingDelta string
+}
+
+func newTrendColumns(trends analyzer.TrendReport, alertName string) trendColumns {
+	trend, ok := trends.Lookup(alertName)
+	if !ok {
+		return trendColumns{previous: "-", firingDelta: "-", totalDelta: "-", flappingDelta: "-"}
+	}
+
+	firingDelta := fmt.Sprintf("%+d", trend.FiringDelta)
+	switch {
+	case trend.Status == analyzer.TrendStatusNew:
+		firingDelta += " (new)"
+	case trend.PreviousFiringCount > 0:
+		firingDelta += fmt.Sprintf(" (%+.0f%%)", float64(trend.FiringDelta)/float64(trend.PreviousFiringCount)*100)
+	}
+
+	return trendColumns{
+		previous:      fmt.Sprintf("%d", trend.PreviousFiringCount),
+		firingDelta:   firingDelta,
+		totalDelta:    formatSignedDuration(trend.TotalTimeDelta),
+		flappingDelta: fmt.Sprintf("%+.2f", trend.FlappingScoreDelta),
+	}
+}
+
+// reportFrequencyTrendsTable outputs frequency analysis with delta columns in table format.
+func (r *Reporter) reportFrequencyTrendsTable(results []analyzer.FrequencyResult, trends analyzer.TrendReport) error {
+	if len(results) == 0 {
+		fmt.Fprintln(r.writer, "No alerts found in the analysis period.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "=== Alert Frequency Analysis ===")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ALERT NAME\tFIRINGS\tPREV FIRINGS\tΔ FIRINGS\tAVG DURATION\tTOTAL TIME\tΔ TOTAL TIME\tΔ FLAP SCORE\tLAST FIRED\tSEVERITY")
+	fmt.Fprintln(w, "----------\t-------\t------------\t---------\t------------\t----------\t------------\t------------\t----------\t--------")
+
+	for _, result := range results {
+		columns := newTrendColumns(trends, result.AlertName)
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s %s\n",
+			result.AlertName,
+			result.FiringCount,
+			columns.previous,
+			columns.firingDelta,
+			formatDuration(result.AvgDuration),
+			formatDuration(result.TotalTime),
+			columns.totalDelta,
+			columns.flappingDelta,
+			result.LastFired.Format("2006-01-02 15:04"),
+			getSeverityIcon(result.Severity),
+			result.Severity,
+		)
+	}
+
+	return w.Flush()
+}
+
+// reportFrequencyTrendsJSON outputs frequency analysis and trends in JSON format.
+func (r *Reporter) reportFrequencyTrendsJSON(results []analyzer.FrequencyResult, trends analyzer.TrendReport) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"frequency_analysis": results,
+		"trends":             trends,
+	})
+}
+
+func (r *Reporter) reportFrequencyTrendsMarkdown(results []analyzer.FrequencyResult, trends analyzer.TrendReport) error {
+	fmt.Fprintln(r.writer, "## Frequency Analysis")
+	fmt.Fprintln(r.writer)
+	if len(results) == 0 {
+		fmt.Fprintln(r.writer, "No alerts found in the analysis period.")
+		fmt.Fprintln(r.writer)
+		return nil
+	}
+
+	fmt.Fprintln(r.writer, "| Alert Name | Firings | Prev Firings | Δ Firings | Avg Duration | Total Time | Δ Total Time | Δ Flap Score | Last Fired | Severity |")
+	fmt.Fprintln(r.writer, "| --- | ---: | ---: | ---: | --- | --- | ---: | ---: | --- | --- |")
+	for _, result := range results {
+		columns := newTrendColumns(trends, result.AlertName)
+		fmt.Fprintf(r.writer, "| %s | %d | %s | %s | %s | %s | %s | %s | %s | %s %s |\n",
+			escapeMarkdown(result.AlertName),
+			result.FiringCount,
+			columns.previous,
+			columns.firingDelta,
+			formatDuration(result.AvgDuration),
+			formatDuration(result.TotalTime),
+			columns.totalDelta,
+			columns.flappingDelta,
+			result.LastFired.Format("2006-01-02 15:04"),
+			getSeverityIcon(result.Severity),
+			escapeMarkdown(result.Severity),
+		)
+	}
+	fmt.Fprintln(r.writer)
+	return nil
+}
+
+// reportTrendsTable outputs the period comparison in table format.
+func (r *Reporter) reportTrendsTable(trends analyzer.TrendReport) error {
+	w := tabwriter.NewWriter(r.writer, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "\n=== Period Comparison ===")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Current Window:\t%s - %s\n", trends.CurrentStart.Format(time.RFC3339), trends.CurrentEnd.Format(time.RFC3339))
+	fmt.Fprintf(w, "Previous Window:\t%s - %s\n", trends.PreviousStart.Format(time.RFC3339), trends.PreviousEnd.Format(time.RFC3339))
+	fmt.Fprintf(w, "Firings Delta:\t%+d\n", trends.FiringDelta)
+	fmt.Fprintf(w, "Firing Time Delta:\t%s\n", formatSignedDuration(trends.FiringTimeDelta))
+	fmt.Fprintf(w, "New Alerts:\t%d\n", len(trends.New))
+	fmt.Fprintf(w, "Disappeared Alerts:\t%d\n", len(trends.Disappeared))
+
+	changed := trendChanges(trends)
+	if len(changed) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "ALERT NAME\tSTATUS\tFIRINGS\tPREV FIRINGS\tTOTAL TIME\tPREV TOTAL TIME\tSEVERITY")
+		fmt.Fprintln(w, "----------\t------\t-------\t------------\t----------\t---------------\t--------")
+		for _, trend := range changed {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s %s\n",
+				trend.AlertName,
+				trend.Status,
+				trend.FiringCount,
+				trend.PreviousFiringCount,
+				formatDuration(trend.TotalTime),
+				formatDuration(trend.PreviousTotalTime),
+				getSeverityIcon(trend.Severity),
+				trend.Severity,
+			)
+		}
+	}
+
+	return w.Flush()
+}
+
+// reportTrendsJSON outputs the period comparison in JSON format.
+func (r *Reporter) reportTrendsJSON(trends analyzer.TrendReport) error {
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"trends": trends,
+	})
+}
+
+func (r *Reporter) reportTrendsMarkdown(trends analyzer.TrendReport) error {
+	fmt.Fprintln(r.writer, "## Period Comparison")
+	fmt.Fprintln(r.writer)
+	fmt.Fprintf(r.writer, "- **Current Window**: %s - %s\n", trends.CurrentStart.Format(time.RFC3339), trends.CurrentEnd.Format(time.RFC3339))
+	fmt.Fprintf(r.writer, "- **Previous Window**: %s - %s\n", trends.PreviousStart.Format(time.RFC3339), trends.PreviousEnd.Format(time.RFC3339))
+	fmt.Fprintf(r.writer, "- **Firings Delta**: %+d\n", trends.FiringDelta)
+	fmt.Fprintf(r.writer, "- **Firing Time Delta**: %s\n", formatSignedDuration(trends.FiringTimeDelta))
+	fmt.Fprintf(r.writer, "- **New Alerts**: %d\n", len(trends.New))
+	fmt.Fprintf(r.writer, "- **Disappeared Alerts**: %d\n", len(trends.Disappeared))
+	fmt.Fprintln(r.writer)
+
+	changed := trendChanges(trends)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(r.writer, "| Alert Name | Status | Firings | Prev Firings | Total Time | Prev Total Time | Severity |")
+	fmt.Fprintln(r.writer, "| --- | --- | ---: | ---: | --- | --- | --- |")
+	for _, trend := range changed {
+		fmt.Fprintf(r.writer, "| %s | %s | %d | %d | %s | %s | %s %s |\n",
+			escapeMarkdown(trend.AlertName),
+			trend.Status,
+			trend.FiringCount,
+			trend.PreviousFiringCount,
+			formatDuration(trend.TotalTime),
+			formatDuration(trend.PreviousTotalTime),
+			getSeverityIcon(trend.Severity),
+			escapeMarkdown(trend.Severity),
+		)
+	}
+	fmt.Fprintln(r.writer)
+	return nil
+}
+
+// trendChanges returns the alerts that only fired in one of the two windows.
+func trendChanges(trends analyzer.TrendReport) []analyzer.TrendResult {
+	changed := make([]analyzer.TrendResult, 0, len(trends.New)+len(trends.Disappeared))
+	for _, trend := range trends.Alerts {
+		if trend.Status != analyzer.TrendStatusExisting {
+			changed = append(changed, trend)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,139 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleTrendReport() analyzer.TrendReport {
+	currentStart := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	return analyzer.TrendReport{
+		CurrentStart:    currentStart,
+		CurrentEnd:      currentStart.Add(7 * 24 * time.Hour),
+		PreviousStart:   currentStart.Add(-7 * 24 * time.Hour),
+		PreviousEnd:     currentStart,
+		FiringDelta:     5,
+		FiringTimeDelta: 30 * time.Minute,
+		Alerts: []analyzer.TrendResult{
+			{AlertName: "HighCPU", Severity: "critical", Status: analyzer.TrendStatusExisting, FiringCount: 12, PreviousFiringCount: 8, FiringDelta: 4, TotalTimeDelta: 40 * time.Minute, FlappingScoreDelta: 0.5},
+			{AlertName: "NewAlert", Severity: "warning", Status: analyzer.TrendStatusNew, FiringCount: 3, FiringDelta: 3, TotalTime: 15 * time.Minute, TotalTimeDelta: 15 * time.Minute},
+			{AlertName: "OldAlert", Severity: "info", Status: analyzer.TrendStatusDisappeared, PreviousFiringCount: 2, FiringDelta: -2, PreviousTotalTime: 25 * time.Minute, TotalTimeDelta: -25 * time.Minute},
+		},
+		New:         []string{"NewAlert"},
+		Disappeared: []string{"OldAlert"},
+	}
+}
+
+func sampleTrendFrequency() []analyzer.FrequencyResult {
+	lastFired := time.Date(2026, 3, 22, 9, 0, 0, 0, time.UTC)
+	return []analyzer.FrequencyResult{
+		{AlertName: "HighCPU", FiringCount: 12, TotalTime: time.Hour, AvgDuration: 5 * time.Minute, LastFired: lastFired, Severity: "critical"},
+		{AlertName: "NewAlert", FiringCount: 3, TotalTime: 15 * time.Minute, AvgDuration: 5 * time.Minute, LastFired: lastFired, Severity: "warning"},
+	}
+}
+
+func TestReportFrequencyTrends(t *testing.T) {
+	trends := sampleTrendReport()
+	frequency := sampleTrendFrequency()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportFrequencyTrends(frequency, trends))
+
+		output := buf.String()
+		assert.Contains(t, output, "PREV FIRINGS")
+		assert.Contains(t, output, "Δ FLAP SCORE")
+		assert.Contains(t, output, "+4 (+50%)")
+		assert.Contains(t, output, "+3 (new)")
+		assert.Contains(t, output, "+40m 0s")
+		assert.Contains(t, output, "+0.50")
+	})
+
+	t.Run("JSON Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatJSON, &buf).ReportFrequencyTrends(frequency, trends))
+
+		var output struct {
+			Frequency []analyzer.FrequencyResult `json:"frequency_analysis"`
+			Trends    analyzer.TrendReport       `json:"trends"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.Len(t, output.Frequency, 2)
+		assert.Equal(t, []string{"OldAlert"}, output.Trends.Disappeared)
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportFrequencyTrends(frequency, trends))
+
+		output := buf.String()
+		assert.Contains(t, output, "| Alert Name | Firings | Prev Firings | Δ Firings |")
+		assert.Contains(t, output, "| HighCPU | 12 | 8 | +4 (+50%) | 5m 0s | 1h 0m | +40m 0s | +0.50 |")
+	})
+
+	t.Run("Missing Trend", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportFrequencyTrends(frequency, analyzer.TrendReport{}))
+		assert.Contains(t, buf.String(), "| HighCPU | 12 | - | - |")
+	})
+}
+
+func TestReportTrends(t *testing.T) {
+	trends := sampleTrendReport()
+
+	t.Run("Table Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatTable, &buf).ReportTrends(trends))
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Period Comparison ===")
+		assert.Contains(t, output, "2026-03-09T00:00:00Z - 2026-03-16T00:00:00Z")
+		assert.Contains(t, output, "+30m 0s")
+		assert.Contains(t, output, "NewAlert")
+		assert.Contains(t, output, "disappeared")
+		assert.NotContains(t, output, "HighCPU", "existing alerts are shown in the frequency table")
+	})
+
+	t.Run("Markdown Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewReporter(FormatMarkdown, &buf).ReportTrends(trends))
+
+		output := buf.String()
+		assert.Contains(t, output, "## Period Comparison")
+		assert.Contains(t, output, "- **Disappeared Alerts**: 1")
+		assert.Contains(t, output, "| OldAlert | disappeared | 0 | 2 | 0s | 25m 0s |")
+	})
+
+	t.Run("Unsupported Format", func(t *testing.T) {
+		err := NewReporter("xml", &bytes.Buffer{}).ReportTrends(trends)
+		assert.ErrorContains(t, err, "unsupported format")
+	})
+}
+
+func TestReportAnalysisWithTrends(t *testing.T) {
+	trends := sampleTrendReport()
+
+	var buf bytes.Buffer
+	require.NoError(t, NewReporter(FormatTable, &buf).ReportAnalysis(AnalysisReport{
+		Summary:   analyzer.SummaryStats{TotalFirings: 15},
+		Frequency: sampleTrendFrequency(),
+		Trends:    &trends,
+	}))
+
+	output := buf.String()
+	assert.Contains(t, output, "Δ FIRINGS")
+	assert.Contains(t, output, "=== Period Comparison ===")
+
+	buf.Reset()
+	require.NoError(t, NewReporter(FormatJSON, &buf).ReportAnalysis(AnalysisReport{Frequency: sampleTrendFrequency(), Trends: &trends}))
+	var report AnalysisReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.NotNil(t, report.Trends)
+	assert.Equal(t, 5, report.Trends.FiringDelta)
+}
@@ -0,0 +1,252 @@
+// Package routing evaluates Alertmanager routing trees to find the receivers an alert is delivered to.
+package routing
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Route is a single node of an Alertmanager routing tree.
+type Route struct {
+	Receiver string            `yaml:"receiver"`
+	Match    map[string]string `yaml:"match"`
+	MatchRE  map[string]string `yaml:"match_re"`
+	Matchers []string          `yaml:"matchers"`
+	Continue bool              `yaml:"continue"`
+	Routes   []*Route          `yaml:"routes"`
+
+	matchers []matcher
+}
+
+// Tree is a compiled Alertmanager routing tree.
+type Tree struct {
+	root *Route
+}
+
+type config struct {
+	Route *Route `yaml:"route"`
+}
+
+type matchOp string
+
+const (
+	opEqual     matchOp = "="
+	opNotEqual  matchOp = "!="
+	opRegex     matchOp = "=~"
+	opNotRegexp matchOp = "!~"
+)
+
+type matcher struct {
+	name  string
+	op    matchOp
+	value string
+	re    *regexp.Regexp
+}
+
+// LoadFile reads an Alertmanager configuration file and compiles its routing tree.
+func LoadFile(path string) (*Tree, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alertmanager config: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse compiles the routing tree from an Alertmanager configuration document.
+func Parse(data []byte) (*Tree, error) {
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse alertmanager config: %w", err)
+	}
+	if cfg.Route == nil {
+		return nil, fmt.Errorf("alertmanager config has no route section")
+	}
+	if cfg.Route.Receiver == "" {
+		return nil, fmt.Errorf("root route must define a receiver")
+	}
+
+	if err := compile(cfg.Route, cfg.Route.Receiver); err != nil {
+		return nil, err
+	}
+
+	return &Tree{root: cfg.Route}, nil
+}
+
+// Receivers returns the receivers an alert with the given labels is routed to, in tree order.
+func (t *Tree) Receivers(labels map[string]string) []string {
+	if t == nil || t.root == nil {
+		return nil
+	}
+
+	routes := t.root.match(labels)
+	receivers := make([]string, 0, len(routes))
+	seen := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		if seen[route.Receiver] {
+			continue
+		}
+		seen[route.Receiver] = true
+		receivers = append(receivers, route.Receiver)
+	}
+	return receivers
+}
+
+// match walks the tree the same way Alertmanager does: the deepest matching
+// routes win, and siblings are only considered when a match sets continue.
+func (r *Route) match(labels map[string]string) []*Route {
+	if !r.matches(labels) {
+		return nil
+	}
+
+	var matched []*Route
+	for _, child := range r.Routes {
+		routes := child.match(labels)
+		matched = append(matched, routes...)
+		if len(routes) > 0 && !child.Continue {
+			break
+		}
+	}
+
+	if len(matched) == 0 {
+		return []*Route{r}
+	}
+	return matched
+}
+
+func (r *Route) matches(labels map[string]string) bool {
+	for _, m := range r.matchers {
+		if !m.matches(labels[m.name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func compile(route *Route, parentReceiver string) error {
+	if route.Receiver == "" {
+		route.Receiver = parentReceiver
+	}
+
+	route.matchers = make([]matcher, 0, len(route.Match)+len(route.MatchRE)+len(route.Matchers))
+	for name, value := range route.Match {
+		route.matchers = append(route.matchers, matcher{name: name, op: opEqual, value: value})
+	}
+	for name, value := range route.MatchRE {
+		m, err := newMatcher(name, opRegex, value)
+		if err != nil {
+			return err
+		}
+		route.matchers = append(route.matchers, m)
+	}
+	for _, expr := range route.Matchers {
+		m, err := parseMatcher(expr)
+		if err != nil {
+			return err
+		}
+		route.matchers = append(route.matchers, m)
+	}
+
+	for _, child := range route.Routes {
+		if err := compile(child, route.Receiver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseMatcher parses matchers such as severity="critical" or team=~"db|storage".
+func parseMatcher(expr string) (matcher, error) {
+	expr = strings.TrimSpace(expr)
+	for i := 0; i < len(expr); i++ {
+		var op matchOp
+		switch {
+		case strings.HasPrefix(expr[i:], string(opRegex)):
+			op = opRegex
+		case strings.HasPrefix(expr[i:], string(opNotRegexp)):
+			op = opNotRegexp
+		case strings.HasPrefix(expr[i:], string(opNotEqual)):
+			op = opNotEqual
+		case expr[i] == '=':
+			op = opEqual
+		default:
+			continue
+		}
+
+		name := strings.TrimSpace(expr[:i])
+		value := strings.TrimSpace(expr[i+len(op):])
+		if name == "" {
+			return matcher{}, fmt.Errorf("invalid matcher %q: missing label name", expr)
+		}
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return matcher{}, fmt.Errorf("invalid matcher %q: %w", expr, err)
+			}
+			value = unquoted
+		}
+		return newMatcher(name, op, value)
+	}
+
+	return matcher{}, fmt.Errorf("invalid matcher %q: missing operator", expr)
+}
+
+// Matchers is a compiled list of label matchers that must all match.
+type Matchers struct {
+	matchers []matcher
+}
+
+// ParseMatchers compiles Alertmanager-style matchers such as severity="critical"
+// or team=~"db|storage".
+func ParseMatchers(exprs []string) (Matchers, error) {
+	compiled := make([]matcher, 0, len(exprs))
+	for _, expr := range exprs {
+		m, err := parseMatcher(expr)
+		if err != nil {
+			return Matchers{}, err
+		}
+		compiled = append(compiled, m)
+	}
+	return Matchers{matchers: compiled}, nil
+}
+
+// Matches reports whether the labels satisfy every matcher. An empty list matches everything.
+func (m Matchers) Matches(labels map[string]string) bool {
+	for _, matcher := range m.matchers {
+		if !matcher.matches(labels[matcher.name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func newMatcher(name string, op matchOp, value string) (matcher, error) {
+	m := matcher{name: name, op: op, value: value}
+	if op == opRegex || op == opNotRegexp {
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return matcher{}, fmt.Errorf("invalid regex for label %s: %w", name, err)
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+func (m matcher) matches(value string) bool {
+	switch m.op {
+	case opEqual:
+		return value == m.value
+	case opNotEqual:
+		return value != m.value
+	case opRegex:
+		return m.re.MatchString(value)
+	case opNotRegexp:
+		return !m.re.MatchString(value)
+	default:
+		return false
+	}
+}
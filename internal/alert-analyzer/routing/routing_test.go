@@ -0,0 +1,138 @@
+package routing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+global:
+  resolve_timeout: 5m
+route:
+  receiver: default
+  group_by: [alertname]
+  routes:
+    - receiver: database
+      matchers:
+        - team="db"
+    - receiver: platform-pager
+      match:
+        severity: critical
+      continue: true
+      routes:
+        - receiver: platform-night
+          match_re:
+            alertname: "Node.*"
+    - receiver: audit
+      matchers:
+        - severity=~"critical|warning"
+        - namespace!="sandbox"
+    - matchers:
+        - alertname!~"Watchdog|InfoInhibitor"
+        - severity = info
+receivers:
+  - name: default
+`
+
+func TestParse_Receivers(t *testing.T) {
+	tree, err := Parse([]byte(testConfig))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   []string
+	}{
+		{
+			name:   "Falls Back To Root",
+			labels: map[string]string{"alertname": "Unknown", "severity": "none"},
+			want:   []string{"default"},
+		},
+		{
+			name:   "Matchers Equality",
+			labels: map[string]string{"alertname": "SlowQuery", "team": "db", "severity": "critical"},
+			want:   []string{"database"},
+		},
+		{
+			name:   "Nested Match With Continue",
+			labels: map[string]string{"alertname": "NodeDown", "severity": "critical", "namespace": "prod"},
+			want:   []string{"platform-night", "audit"},
+		},
+		{
+			name:   "Continue Without Nested Match",
+			labels: map[string]string{"alertname": "HighLatency", "severity": "critical", "namespace": "sandbox"},
+			want:   []string{"platform-pager"},
+		},
+		{
+			name:   "Inherits Parent Receiver",
+			labels: map[string]string{"alertname": "DiskAlmostFull", "severity": "info"},
+			want:   []string{"default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tree.Receivers(tt.labels))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{name: "Invalid YAML", config: "route: [", wantErr: "failed to parse alertmanager config"},
+		{name: "Missing Route", config: "receivers: []", wantErr: "no route section"},
+		{name: "Missing Root Receiver", config: "route:\n  group_by: [alertname]", wantErr: "root route must define a receiver"},
+		{name: "Invalid Regex", config: "route:\n  receiver: a\n  match_re:\n    alertname: \"(\"", wantErr: "invalid regex"},
+		{name: "Invalid Matcher", config: "route:\n  receiver: a\n  matchers: [\"severity\"]", wantErr: "missing operator"},
+		{name: "Missing Label Name", config: "route:\n  receiver: a\n  matchers: [\"=x\"]", wantErr: "missing label name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.config))
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alertmanager.yml")
+	require.NoError(t, os.WriteFile(path, []byte(testConfig), 0o600))
+
+	tree, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"database"}, tree.Receivers(map[string]string{"team": "db"}))
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.ErrorContains(t, err, "failed to read alertmanager config")
+}
+
+func TestNilTree(t *testing.T) {
+	var tree *Tree
+	assert.Nil(t, tree.Receivers(map[string]string{"alertname": "A"}))
+}
+
+func TestParseMatchers(t *testing.T) {
+	matchers, err := ParseMatchers([]string{`cluster="prod"`, `alertname=~"Node.*"`, `severity!="info"`})
+	require.NoError(t, err)
+
+	assert.True(t, matchers.Matches(map[string]string{"cluster": "prod", "alertname": "NodeDown", "severity": "critical"}))
+	assert.False(t, matchers.Matches(map[string]string{"cluster": "dev", "alertname": "NodeDown"}))
+	assert.False(t, matchers.Matches(map[string]string{"cluster": "prod", "alertname": "NodeDown", "severity": "info"}))
+
+	empty, err := ParseMatchers(nil)
+	require.NoError(t, err)
+	assert.True(t, empty.Matches(map[string]string{"alertname": "A"}), "no matchers match everything")
+
+	_, err = ParseMatchers([]string{"cluster"})
+	assert.ErrorContains(t, err, "missing operator")
+}
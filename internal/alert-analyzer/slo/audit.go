@@ -0,0 +1,260 @@
+package slo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/backtest"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+// Coverage status of an SLO.
+const (
+	StatusCovered = "covered"
+	StatusPartial = "partial"
+	StatusMissing = "missing"
+)
+
+// Reasons an existing alert is related to an SLO.
+const (
+	RelatedBySLOLabel  = "slo_label"
+	RelatedBySLIMetric = "sli_metric"
+	RelatedByConfig    = "configured"
+)
+
+// queryWindow matches durations used as range selectors ("[1h]") or embedded
+// in recording rule names ("ratio_rate5m", "burnrate1h").
+var queryWindow = regexp.MustCompile(`(?:\[|rate)((?:\d+[smhdwy])+)`)
+
+// RelatedAlert is an existing alert that watches the same service as an SLO.
+type RelatedAlert struct {
+	Name       string        `json:"name"`
+	Reason     string        `json:"reason"`
+	Firings    int           `json:"firings"`
+	FiringTime time.Duration `json:"firing_time"`
+}
+
+// Estimate is how often the recommended burn-rate alert of one severity would
+// have fired over the analyzed history.
+type Estimate struct {
+	Severity   string        `json:"severity"`
+	Firings    int           `json:"firings"`
+	FiringTime time.Duration `json:"firing_time"`
+}
+
+// Coverage is the burn-rate alerting coverage of one SLO.
+type Coverage struct {
+	SLO            string           `json:"slo"`
+	Service        string           `json:"service,omitempty"`
+	Objective      float64          `json:"objective"`
+	Window         time.Duration    `json:"window"`
+	Status         string           `json:"status"`
+	BurnRateRules  []string         `json:"burn_rate_rules"`
+	Covered        []BurnRateWindow `json:"covered_windows"`
+	Missing        []BurnRateWindow `json:"missing_windows"`
+	RelatedAlerts  []RelatedAlert   `json:"related_alerts"`
+	RelatedFirings int              `json:"related_firings"`
+	Estimates      []Estimate       `json:"estimates,omitempty"`
+}
+
+// Auditor checks which SLOs are covered by multi-window burn-rate alerting rules.
+type Auditor struct {
+	rules   []collector.AlertRule
+	history *collector.AlertHistory
+}
+
+// NewAuditor creates an auditor for the given rules and alert history.
+func NewAuditor(rules []collector.AlertRule, history *collector.AlertHistory) *Auditor {
+	return &Auditor{rules: rules, history: history}
+}
+
+// Audit returns the coverage of every SLO, in the order given.
+//
+// A rule belongs to an SLO when it carries an `slo` label or matcher with the
+// SLO name, or its expression uses one of the SLI metrics. Such a rule covers a
+// window pair when its expression uses both the long and the short window;
+// rules covering no pair are reported as related symptom or cause alerts.
+func (a *Auditor) Audit(slos []SLO) []Coverage {
+	firings, firingTime := a.firingsByName()
+
+	results := make([]Coverage, 0, len(slos))
+	for _, s := range slos {
+		results = append(results, a.audit(s, firings, firingTime))
+	}
+	return results
+}
+
+func (a *Auditor) audit(s SLO, firings map[string]int, firingTime map[string]time.Duration) Coverage {
+	coverage := Coverage{
+		SLO:           s.Name,
+		Service:       s.Service,
+		Objective:     s.Objective,
+		Window:        s.Window,
+		BurnRateRules: make([]string, 0),
+		Covered:       make([]BurnRateWindow, 0),
+		Missing:       make([]BurnRateWindow, 0),
+		RelatedAlerts: make([]RelatedAlert, 0),
+	}
+
+	related := make(map[string]bool)
+	addRelated := func(name, reason string) {
+		if related[name] {
+			return
+		}
+		related[name] = true
+		coverage.RelatedAlerts = append(coverage.RelatedAlerts, RelatedAlert{
+			Name:       name,
+			Reason:     reason,
+			Firings:    firings[name],
+			FiringTime: firingTime[name],
+		})
+		coverage.RelatedFirings += firings[name]
+	}
+
+	matcher := newRuleMatcher(s)
+	covered := make(map[BurnRateWindow]bool)
+	burnRateRules := make(map[string]bool)
+	for _, rule := range a.rules {
+		reason, ok := matcher.relation(rule)
+		if !ok {
+			continue
+		}
+
+		pairs := coveredWindows(rule.Query)
+		if len(pairs) == 0 {
+			addRelated(rule.Name, reason)
+			continue
+		}
+		if !burnRateRules[rule.Name] {
+			burnRateRules[rule.Name] = true
+			coverage.BurnRateRules = append(coverage.BurnRateRules, rule.Name)
+		}
+		for _, pair := range pairs {
+			covered[pair] = true
+		}
+	}
+
+	for _, name := range s.RelatedAlerts {
+		if !burnRateRules[name] {
+			addRelated(name, RelatedByConfig)
+		}
+	}
+
+	for _, window := range DefaultWindows {
+		if covered[window] {
+			coverage.Covered = append(coverage.Covered, window)
+		} else {
+			coverage.Missing = append(coverage.Missing, window)
+		}
+	}
+	coverage.Status = coverageStatus(len(coverage.Covered))
+
+	sort.SliceStable(coverage.RelatedAlerts, func(i, j int) bool {
+		return coverage.RelatedAlerts[i].Firings > coverage.RelatedAlerts[j].Firings
+	})
+	return coverage
+}
+
+func coverageStatus(coveredWindows int) string {
+	switch coveredWindows {
+	case len(DefaultWindows):
+		return StatusCovered
+	case 0:
+		return StatusMissing
+	default:
+		return StatusPartial
+	}
+}
+
+// firingsByName counts firings and firing time per alert name across clusters.
+func (a *Auditor) firingsByName() (map[string]int, map[string]time.Duration) {
+	firings := make(map[string]int)
+	firingTime := make(map[string]time.Duration)
+	if a.history == nil {
+		return firings, firingTime
+	}
+	for _, alert := range a.history.Alerts {
+		firings[alert.Name]++
+		firingTime[alert.Name] += alert.DurationUntil(a.history.EndTime)
+	}
+	return firings, firingTime
+}
+
+// ruleMatcher decides whether a rule belongs to an SLO.
+type ruleMatcher struct {
+	name     string
+	selector *regexp.Regexp
+	metrics  []*regexp.Regexp
+}
+
+func newRuleMatcher(s SLO) ruleMatcher {
+	matcher := ruleMatcher{
+		name:     s.Name,
+		selector: regexp.MustCompile(`slo\s*=~?\s*["']` + regexp.QuoteMeta(s.Name) + `["']`),
+	}
+	for _, name := range s.MetricNames() {
+		matcher.metrics = append(matcher.metrics,
+			regexp.MustCompile(`(^|[^a-zA-Z0-9_:])`+regexp.QuoteMeta(name)+`($|[^a-zA-Z0-9_:])`))
+	}
+	return matcher
+}
+
+// relation reports why a rule belongs to the SLO, if it does.
+func (m ruleMatcher) relation(rule collector.AlertRule) (string, bool) {
+	if rule.Labels["slo"] == m.name || m.selector.MatchString(rule.Query) {
+		return RelatedBySLOLabel, true
+	}
+	for _, metric := range m.metrics {
+		if metric.MatchString(rule.Query) {
+			return RelatedBySLIMetric, true
+		}
+	}
+	return "", false
+}
+
+// coveredWindows returns the default window pairs whose long and short windows
+// both appear in the expression.
+func coveredWindows(query string) []BurnRateWindow {
+	windows := make(map[time.Duration]bool)
+	for _, match := range queryWindow.FindAllStringSubmatch(query, -1) {
+		if d, err := model.ParseDuration(match[1]); err == nil {
+			windows[time.Duration(d)] = true
+		}
+	}
+
+	pairs := make([]BurnRateWindow, 0)
+	for _, window := range DefaultWindows {
+		if windows[window.Long] && windows[window.Short] {
+			pairs = append(pairs, window)
+		}
+	}
+	return pairs
+}
+
+// EstimateFirings replays the recommended page and ticket burn-rate alerts of an SLO
+// over [start, end] and reports how often each would have fired.
+func EstimateFirings(ctx context.Context, backtester *backtest.Backtester, s SLO, start, end time.Time, step time.Duration) ([]Estimate, error) {
+	estimates := make([]Estimate, 0, 2)
+	for _, severity := range []string{SeverityPage, SeverityTicket} {
+		result, err := backtester.Run(ctx, backtest.Config{
+			Expr:  s.BurnRateExpr(WindowsBySeverity(severity)),
+			Start: start,
+			End:   end,
+			Step:  step,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate %s burn-rate alert for SLO %s: %w", severity, s.Name, err)
+		}
+		estimates = append(estimates, Estimate{
+			Severity:   severity,
+			Firings:    result.Candidate.Firings,
+			FiringTime: result.Candidate.TotalFiringTime,
+		})
+	}
+	return estimates, nil
+}
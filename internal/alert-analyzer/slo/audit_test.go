@@ -0,0 +1,108 @@
+package slo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/backtest"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
+)
+
+func auditTestSLOs(t *testing.T) []SLO {
+	t.Helper()
+	slos, err := Parse([]byte(testSLOFile))
+	require.NoError(t, err)
+	return slos
+}
+
+func TestAuditor_Audit(t *testing.T) {
+	rules := []collector.AlertRule{
+		// Page windows covered through recording rules selected by slo label.
+		{Name: "CheckoutBudgetBurn", Query: `slo:sli_error:ratio_rate1h{slo="checkout-availability"} > 0.0144 and slo:sli_error:ratio_rate5m{slo="checkout-availability"} > 0.0144 or slo:sli_error:ratio_rate6h{slo="checkout-availability"} > 0.006 and slo:sli_error:ratio_rate30m{slo="checkout-availability"} > 0.006`},
+		// Symptom alert on the SLI metric.
+		{Name: "CheckoutHighErrorRate", Query: `sum(rate(http_requests_total{job="checkout",code=~"5.."}[5m])) > 1`},
+		{Name: "CheckoutHighErrorRate", Cluster: "eu", Query: `sum(rate(http_requests_total{job="checkout",code=~"5.."}[5m])) > 1`},
+		// Unrelated metric that shares a prefix.
+		{Name: "HighRequestSize", Query: `http_requests_total_bytes > 1e6`},
+	}
+
+	base := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	resolved := base.Add(10 * time.Minute)
+	history := &collector.AlertHistory{Alerts: []collector.Alert{
+		{Name: "CheckoutHighErrorRate", FiredAt: base, ResolvedAt: &resolved},
+		{Name: "CheckoutHighErrorRate", FiredAt: base, ResolvedAt: &resolved},
+		{Name: "CheckoutPodCrashLooping", FiredAt: base, ResolvedAt: &resolved},
+	}}
+
+	results := NewAuditor(rules, history).Audit(auditTestSLOs(t))
+	require.Len(t, results, 2)
+
+	checkout := results[0]
+	assert.Equal(t, "checkout-availability", checkout.SLO)
+	assert.Equal(t, StatusPartial, checkout.Status)
+	assert.Equal(t, []string{"CheckoutBudgetBurn"}, checkout.BurnRateRules)
+	assert.Equal(t, DefaultWindows[:2], checkout.Covered)
+	assert.Equal(t, DefaultWindows[2:], checkout.Missing)
+	require.Len(t, checkout.RelatedAlerts, 2)
+	assert.Equal(t, RelatedAlert{Name: "CheckoutHighErrorRate", Reason: RelatedBySLIMetric, Firings: 2, FiringTime: 20 * time.Minute}, checkout.RelatedAlerts[0])
+	assert.Equal(t, RelatedByConfig, checkout.RelatedAlerts[1].Reason)
+	assert.Equal(t, 3, checkout.RelatedFirings)
+
+	search := results[1]
+	assert.Equal(t, StatusMissing, search.Status)
+	assert.Empty(t, search.BurnRateRules)
+	assert.Empty(t, search.RelatedAlerts)
+}
+
+func TestAuditor_FullCoverage(t *testing.T) {
+	rules := []collector.AlertRule{
+		{Name: "Page", Labels: map[string]string{"slo": "search-latency"}, Query: `burnrate1h > 13.44 and burnrate5m > 13.44 or burnrate6h > 5.6 and burnrate30m > 5.6`},
+		{Name: "Ticket", Labels: map[string]string{"slo": "search-latency"}, Query: `(x[1d] > 2.8 and x[2h] > 2.8) or (x[3d] > 0.93 and x[360m] > 0.93)`},
+	}
+
+	results := NewAuditor(rules, nil).Audit(auditTestSLOs(t)[1:])
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusCovered, results[0].Status)
+	assert.Equal(t, []string{"Page", "Ticket"}, results[0].BurnRateRules)
+	assert.Empty(t, results[0].Missing)
+}
+
+type fakePrometheus struct {
+	queries []string
+}
+
+func (f *fakePrometheus) QueryRange(_ context.Context, query string, r v1.Range) (model.Value, error) {
+	f.queries = append(f.queries, query)
+	if len(f.queries) > 1 {
+		return model.Matrix{}, nil
+	}
+	// The page expression returns a single 10 minute run.
+	values := make([]model.SamplePair, 0)
+	for ts := r.Start; ts.Before(r.Start.Add(10 * time.Minute)); ts = ts.Add(r.Step) {
+		values = append(values, model.SamplePair{Timestamp: model.TimeFromUnixNano(ts.UnixNano()), Value: 1})
+	}
+	return model.Matrix{{Metric: model.Metric{}, Values: values}}, nil
+}
+
+func TestEstimateFirings(t *testing.T) {
+	client := &fakePrometheus{}
+	logger := zerolog.Nop()
+	end := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	estimates, err := EstimateFirings(context.Background(), backtest.NewBacktester(client, &logger), auditTestSLOs(t)[0], end.Add(-24*time.Hour), end, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, client.queries, 2)
+	assert.Contains(t, client.queries[0], "> (14.4 * 0.001)")
+	assert.Contains(t, client.queries[1], "> (3 * 0.001)")
+
+	require.Len(t, estimates, 2)
+	assert.Equal(t, Estimate{Severity: SeverityPage, Firings: 1, FiringTime: 10 * time.Minute}, estimates[0])
+	assert.Equal(t, Estimate{Severity: SeverityTicket}, estimates[1])
+}
@@ -0,0 +1,149 @@
+package slo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// errorRatioRecord is the recording rule prefix for SLI error ratios; the
+	// rate window is appended, e.g. slo:sli_error:ratio_rate5m.
+	errorRatioRecord = "slo:sli_error:ratio_rate"
+
+	fileHeader = "Multi-window, multi-burn-rate alerts generated by alert-analyzer. Review before applying."
+)
+
+// Rule is a Prometheus recording or alerting rule.
+type Rule struct {
+	Record      string            `yaml:"record,omitempty"`
+	Alert       string            `yaml:"alert,omitempty"`
+	Expr        string            `yaml:"expr"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// RuleGroup is a Prometheus rule group.
+type RuleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []Rule `yaml:"rules"`
+}
+
+// GenerateRuleGroup builds the recommended rule group for an SLO: one error
+// ratio recording rule per window, and a page and a ticket alert that fire
+// when both windows of any of their pairs burn the budget too fast.
+func GenerateRuleGroup(s SLO) RuleGroup {
+	group := RuleGroup{Name: "slo-" + s.Name}
+
+	for _, window := range recordedWindows() {
+		group.Rules = append(group.Rules, Rule{
+			Record: errorRatioRecord + formatDuration(window),
+			Expr:   s.ErrorRatioExpr(window),
+			Labels: s.ruleLabels(nil),
+		})
+	}
+
+	for _, severity := range []string{SeverityPage, SeverityTicket} {
+		windows := WindowsBySeverity(severity)
+		conditions := make([]string, 0, len(windows))
+		descriptions := make([]string, 0, len(windows))
+		for _, window := range windows {
+			threshold := s.threshold(window)
+			conditions = append(conditions, fmt.Sprintf("(%s > %s and %s > %s)",
+				s.recordedRatio(window.Long), threshold,
+				s.recordedRatio(window.Short), threshold,
+			))
+			descriptions = append(descriptions, fmt.Sprintf("%sx over %s", formatFloat(window.BurnRate(s.Window)), window))
+		}
+
+		group.Rules = append(group.Rules, Rule{
+			Alert:  s.BurnRateAlertName(),
+			Expr:   strings.Join(conditions, "\nor\n"),
+			Labels: s.ruleLabels(map[string]string{"severity": severity}),
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("SLO %s is burning its error budget too fast", s.Name),
+				"description": fmt.Sprintf("The %s%% objective over %s is at risk: error budget burn rate above %s.",
+					formatFloat(s.Objective), formatDuration(s.Window), strings.Join(descriptions, " or ")),
+			},
+		})
+	}
+
+	return group
+}
+
+// recordedRatio selects the recorded error ratio of the SLO for a window.
+func (s SLO) recordedRatio(window time.Duration) string {
+	return fmt.Sprintf("%s%s{slo=%q}", errorRatioRecord, formatDuration(window), s.Name)
+}
+
+// ruleLabels merges the SLO labels, the slo label and extra labels.
+func (s SLO) ruleLabels(extra map[string]string) map[string]string {
+	labels := make(map[string]string, len(s.Labels)+len(extra)+1)
+	for name, value := range s.Labels {
+		labels[name] = value
+	}
+	labels["slo"] = s.Name
+	for name, value := range extra {
+		labels[name] = value
+	}
+	return labels
+}
+
+// recordedWindows returns every long and short window of the default pairs, shortest first.
+func recordedWindows() []time.Duration {
+	seen := make(map[time.Duration]bool)
+	windows := make([]time.Duration, 0)
+	for _, window := range DefaultWindows {
+		for _, d := range []time.Duration{window.Long, window.Short} {
+			if !seen[d] {
+				seen[d] = true
+				windows = append(windows, d)
+			}
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	return windows
+}
+
+// WriteRuleGroups stores one rule file per SLO in dir and returns the written paths.
+func WriteRuleGroups(dir string, slos []SLO) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create rules directory: %w", err)
+	}
+
+	written := make([]string, 0, len(slos))
+	for _, s := range slos {
+		var content yaml.Node
+		if err := content.Encode(map[string][]RuleGroup{"groups": {GenerateRuleGroup(s)}}); err != nil {
+			return written, fmt.Errorf("failed to encode rules for SLO %s: %w", s.Name, err)
+		}
+		doc := &yaml.Node{Kind: yaml.DocumentNode, HeadComment: fileHeader, Content: []*yaml.Node{&content}}
+
+		path := filepath.Join(dir, "slo-"+nameSeparator.ReplaceAllString(s.Name, "-")+".yaml")
+		if err := writeYAML(path, doc); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+func writeYAML(path string, doc *yaml.Node) error {
+	file, err := os.Create(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return fmt.Errorf("failed to create rules file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := yaml.NewEncoder(file)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write rules file %s: %w", path, err)
+	}
+	return encoder.Close()
+}
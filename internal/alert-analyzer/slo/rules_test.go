@@ -0,0 +1,58 @@
+package slo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateRuleGroup(t *testing.T) {
+	checkout := auditTestSLOs(t)[0]
+	checkout.Labels = map[string]string{"team": "payments"}
+
+	group := GenerateRuleGroup(checkout)
+	assert.Equal(t, "slo-checkout-availability", group.Name)
+	require.Len(t, group.Rules, 9, "seven recording rules and two alerts")
+
+	assert.Equal(t, "slo:sli_error:ratio_rate5m", group.Rules[0].Record)
+	assert.Equal(t, "slo:sli_error:ratio_rate3d", group.Rules[6].Record)
+	assert.Equal(t, map[string]string{"slo": "checkout-availability", "team": "payments"}, group.Rules[0].Labels)
+
+	page := group.Rules[7]
+	assert.Equal(t, "CheckoutAvailabilityErrorBudgetBurn", page.Alert)
+	assert.Equal(t, SeverityPage, page.Labels["severity"])
+	assert.Equal(t, `(slo:sli_error:ratio_rate1h{slo="checkout-availability"} > (14.4 * 0.001) and slo:sli_error:ratio_rate5m{slo="checkout-availability"} > (14.4 * 0.001))
+or
+(slo:sli_error:ratio_rate6h{slo="checkout-availability"} > (6 * 0.001) and slo:sli_error:ratio_rate30m{slo="checkout-availability"} > (6 * 0.001))`, page.Expr)
+	assert.Contains(t, page.Annotations["description"], "14.4x over 1h/5m or 6x over 6h/30m")
+
+	ticket := group.Rules[8]
+	assert.Equal(t, SeverityTicket, ticket.Labels["severity"])
+	assert.Contains(t, ticket.Expr, "slo:sli_error:ratio_rate3d")
+}
+
+func TestWriteRuleGroups(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "rules")
+	paths, err := WriteRuleGroups(dir, auditTestSLOs(t))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "slo-checkout-availability.yaml"),
+		filepath.Join(dir, "slo-search-latency.yaml"),
+	}, paths)
+
+	data, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Multi-window, multi-burn-rate alerts")
+
+	var parsed struct {
+		Groups []RuleGroup `yaml:"groups"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &parsed))
+	require.Len(t, parsed.Groups, 1)
+	assert.Equal(t, "slo-checkout-availability", parsed.Groups[0].Name)
+	assert.Len(t, parsed.Groups[0].Rules, 9)
+}
@@ -0,0 +1,274 @@
+// Package slo audits alerting rules against SLO definitions and generates
+// multi-window, multi-burn-rate alerting rules for them.
+package slo
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// WindowPlaceholder is replaced with the rate window in SLI expressions,
+	// e.g. `sum(rate(http_requests_total{code!~"5.."}[{{.window}}]))`.
+	WindowPlaceholder = "{{.window}}"
+
+	// DefaultWindow is the SLO compliance window used when none is configured.
+	DefaultWindow = 30 * 24 * time.Hour
+
+	// SeverityPage and SeverityTicket are the severities of the generated alerts.
+	SeverityPage   = "critical"
+	SeverityTicket = "warning"
+)
+
+// BurnRateWindow is one long/short window pair of a multi-window burn-rate alert.
+// The alert fires when both windows burn faster than the rate that would consume
+// BudgetConsumed of the error budget within the long window.
+type BurnRateWindow struct {
+	Severity       string        `json:"severity"`
+	Long           time.Duration `json:"long"`
+	Short          time.Duration `json:"short"`
+	BudgetConsumed float64       `json:"budget_consumed"`
+}
+
+// DefaultWindows are the window pairs recommended by the Google SRE workbook:
+// page on 2% of the budget in 1h or 5% in 6h, ticket on 10% in 1d or 3d.
+var DefaultWindows = []BurnRateWindow{
+	{Severity: SeverityPage, Long: time.Hour, Short: 5 * time.Minute, BudgetConsumed: 0.02},
+	{Severity: SeverityPage, Long: 6 * time.Hour, Short: 30 * time.Minute, BudgetConsumed: 0.05},
+	{Severity: SeverityTicket, Long: 24 * time.Hour, Short: 2 * time.Hour, BudgetConsumed: 0.1},
+	{Severity: SeverityTicket, Long: 3 * 24 * time.Hour, Short: 6 * time.Hour, BudgetConsumed: 0.1},
+}
+
+// BurnRate returns the burn-rate factor of the window pair for an SLO window,
+// e.g. 14.4 for the 1h pair of a 30d SLO.
+func (w BurnRateWindow) BurnRate(sloWindow time.Duration) float64 {
+	return round(w.BudgetConsumed*float64(sloWindow)/float64(w.Long), 2)
+}
+
+// String formats the pair as "1h/5m".
+func (w BurnRateWindow) String() string {
+	return formatDuration(w.Long) + "/" + formatDuration(w.Short)
+}
+
+// SLI holds the PromQL expressions counting good and total events.
+type SLI struct {
+	Good  string `yaml:"good" json:"good"`
+	Total string `yaml:"total" json:"total"`
+}
+
+// SLO is a service level objective definition.
+type SLO struct {
+	Name        string `yaml:"name" json:"name"`
+	Service     string `yaml:"service,omitempty" json:"service,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	SLI         SLI    `yaml:"sli" json:"sli"`
+	// Objective is the target in percent, e.g. 99.9.
+	Objective float64 `yaml:"objective" json:"objective"`
+	// WindowStr is the compliance window, e.g. 30d or 4w.
+	WindowStr string `yaml:"window,omitempty" json:"window,omitempty"`
+	// AlertName overrides the name of the generated burn-rate alerts.
+	AlertName string `yaml:"alert_name,omitempty" json:"alert_name,omitempty"`
+	// RelatedAlerts lists existing symptom or cause alerts to compare against,
+	// in addition to the alerts whose expression uses the SLI metrics.
+	RelatedAlerts []string          `yaml:"related_alerts,omitempty" json:"related_alerts,omitempty"`
+	Labels        map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	Window time.Duration `yaml:"-" json:"window_duration"`
+}
+
+type file struct {
+	SLOs []SLO `yaml:"slos"`
+}
+
+// LoadFile reads and validates SLO definitions from a YAML file.
+func LoadFile(path string) ([]SLO, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SLO file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates SLO definitions from a YAML document.
+func Parse(data []byte) ([]SLO, error) {
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse SLO file: %w", err)
+	}
+	if len(f.SLOs) == 0 {
+		return nil, fmt.Errorf("SLO file defines no slos")
+	}
+
+	seen := make(map[string]bool, len(f.SLOs))
+	for i := range f.SLOs {
+		if err := f.SLOs[i].validate(); err != nil {
+			return nil, err
+		}
+		if seen[f.SLOs[i].Name] {
+			return nil, fmt.Errorf("duplicate SLO name: %s", f.SLOs[i].Name)
+		}
+		seen[f.SLOs[i].Name] = true
+	}
+	return f.SLOs, nil
+}
+
+func (s *SLO) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("SLO name is required")
+	}
+	if strings.TrimSpace(s.SLI.Good) == "" || strings.TrimSpace(s.SLI.Total) == "" {
+		return fmt.Errorf("SLO %s: sli.good and sli.total are required", s.Name)
+	}
+	if !strings.Contains(s.SLI.Good, WindowPlaceholder) || !strings.Contains(s.SLI.Total, WindowPlaceholder) {
+		return fmt.Errorf("SLO %s: sli expressions must use %s as the rate window", s.Name, WindowPlaceholder)
+	}
+	if s.Objective >= 100 {
+		return fmt.Errorf("SLO %s: objective must be a percentage below 100, got %v", s.Name, s.Objective)
+	}
+	if s.Objective < 1 {
+		// A ratio such as 0.999 would silently turn into a 0.999% objective.
+		return fmt.Errorf("SLO %s: objective is a percentage (e.g. 99.9), got %v", s.Name, s.Objective)
+	}
+
+	s.Window = DefaultWindow
+	if s.WindowStr != "" {
+		window, err := model.ParseDuration(s.WindowStr)
+		if err != nil {
+			return fmt.Errorf("SLO %s: invalid window: %w", s.Name, err)
+		}
+		s.Window = time.Duration(window)
+	}
+	if s.Window < DefaultWindows[len(DefaultWindows)-1].Long {
+		return fmt.Errorf("SLO %s: window must be at least %s", s.Name, formatDuration(DefaultWindows[len(DefaultWindows)-1].Long))
+	}
+	return nil
+}
+
+// ErrorBudget returns the allowed error ratio, e.g. 0.001 for a 99.9% objective.
+func (s SLO) ErrorBudget() float64 {
+	return round(1-s.Objective/100, 6)
+}
+
+// ErrorRatioExpr returns the PromQL error ratio of the SLI over the given window.
+func (s SLO) ErrorRatioExpr(window time.Duration) string {
+	w := formatDuration(window)
+	good := strings.ReplaceAll(s.SLI.Good, WindowPlaceholder, w)
+	total := strings.ReplaceAll(s.SLI.Total, WindowPlaceholder, w)
+	return fmt.Sprintf("1 - ((%s) / (%s))", good, total)
+}
+
+// BurnRateExpr returns a self-contained alert expression for the window pairs,
+// joined with `or`. It evaluates the SLI directly, without recording rules.
+func (s SLO) BurnRateExpr(windows []BurnRateWindow) string {
+	parts := make([]string, 0, len(windows))
+	for _, window := range windows {
+		threshold := s.threshold(window)
+		parts = append(parts, fmt.Sprintf("((%s) > %s and (%s) > %s)",
+			s.ErrorRatioExpr(window.Long), threshold,
+			s.ErrorRatioExpr(window.Short), threshold,
+		))
+	}
+	return strings.Join(parts, " or ")
+}
+
+// threshold renders the error ratio above which the window pair burns too fast.
+func (s SLO) threshold(window BurnRateWindow) string {
+	return fmt.Sprintf("(%s * %s)", formatFloat(window.BurnRate(s.Window)), formatFloat(s.ErrorBudget()))
+}
+
+// BurnRateAlertName returns the name of the generated burn-rate alerts.
+func (s SLO) BurnRateAlertName() string {
+	if s.AlertName != "" {
+		return s.AlertName
+	}
+	var name strings.Builder
+	for _, part := range nameSeparator.Split(s.Name, -1) {
+		if part == "" {
+			continue
+		}
+		name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	name.WriteString("ErrorBudgetBurn")
+	return name.String()
+}
+
+// WindowsBySeverity returns the window pairs of one severity.
+func WindowsBySeverity(severity string) []BurnRateWindow {
+	windows := make([]BurnRateWindow, 0)
+	for _, window := range DefaultWindows {
+		if window.Severity == severity {
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+var (
+	nameSeparator = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+	// labelMatchers strips selectors and string literals, whose contents are not metric names.
+	labelMatchers = regexp.MustCompile(`\{[^}]*\}|\[[^\]]*\]|"[^"]*"`)
+	// groupingClauses strips label lists of aggregations and vector matching.
+	groupingClauses = regexp.MustCompile(`\b(by|without|on|ignoring|group_left|group_right)\s*\([^)]*\)`)
+	identifier      = regexp.MustCompile(`[a-zA-Z_:][a-zA-Z0-9_:]*(\s*\()?`)
+)
+
+// promqlKeywords are identifiers that are never metric names.
+var promqlKeywords = map[string]bool{
+	"and": true, "or": true, "unless": true, "bool": true, "offset": true,
+	"by": true, "without": true, "on": true, "ignoring": true,
+	"group_left": true, "group_right": true, "inf": true, "nan": true,
+}
+
+// MetricNames extracts the metric names referenced by the SLI expressions.
+// It is a lexical approximation: identifiers that are not functions, keywords
+// or inside label matchers.
+func (s SLO) MetricNames() []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, expr := range []string{s.SLI.Good, s.SLI.Total} {
+		for _, name := range metricNames(expr) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func metricNames(expr string) []string {
+	expr = strings.ReplaceAll(expr, WindowPlaceholder, "")
+	expr = groupingClauses.ReplaceAllString(expr, "")
+	expr = labelMatchers.ReplaceAllString(expr, "")
+
+	names := make([]string, 0)
+	for _, match := range identifier.FindAllString(expr, -1) {
+		if strings.HasSuffix(match, "(") || promqlKeywords[strings.ToLower(match)] {
+			continue
+		}
+		names = append(names, match)
+	}
+	return names
+}
+
+func formatDuration(d time.Duration) string {
+	return model.Duration(d).String()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func round(value float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,103 @@
+package slo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSLOFile = `
+slos:
+  - name: checkout-availability
+    service: checkout
+    objective: 99.9
+    sli:
+      good: sum(rate(http_requests_total{job="checkout", code!~"5.."}[{{.window}}]))
+      total: sum by (job) (rate(http_requests_total{job="checkout"}[{{.window}}]))
+    related_alerts: [CheckoutPodCrashLooping]
+  - name: search-latency
+    objective: 99
+    window: 28d
+    alert_name: SearchLatencyBudgetBurn
+    sli:
+      good: sum(rate(search_duration_seconds_bucket{le="0.5"}[{{.window}}]))
+      total: sum(rate(search_duration_seconds_count[{{.window}}]))
+`
+
+func TestParse(t *testing.T) {
+	slos, err := Parse([]byte(testSLOFile))
+	require.NoError(t, err)
+	require.Len(t, slos, 2)
+
+	assert.Equal(t, DefaultWindow, slos[0].Window)
+	assert.InDelta(t, 0.001, slos[0].ErrorBudget(), 1e-9)
+	assert.Equal(t, "CheckoutAvailabilityErrorBudgetBurn", slos[0].BurnRateAlertName())
+	assert.Equal(t, 28*24*time.Hour, slos[1].Window)
+	assert.Equal(t, "SearchLatencyBudgetBurn", slos[1].BurnRateAlertName())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"Empty", "slos: []", "defines no slos"},
+		{"Missing Name", "slos: [{objective: 99, sli: {good: 'a[{{.window}}]', total: 'b[{{.window}}]'}}]", "name is required"},
+		{"Missing Placeholder", "slos: [{name: a, objective: 99, sli: {good: 'a[5m]', total: 'b[5m]'}}]", "{{.window}}"},
+		{"Ratio Objective", "slos: [{name: a, objective: 0.999, sli: {good: 'a[{{.window}}]', total: 'b[{{.window}}]'}}]", "objective is a percentage"},
+		{"Objective Out Of Range", "slos: [{name: a, objective: 100, sli: {good: 'a[{{.window}}]', total: 'b[{{.window}}]'}}]", "objective must be a percentage below 100"},
+		{"Short Window", "slos: [{name: a, objective: 99, window: 1d, sli: {good: 'a[{{.window}}]', total: 'b[{{.window}}]'}}]", "window must be at least 3d"},
+		{"Duplicate", "slos: [{name: a, objective: 99, sli: {good: 'a[{{.window}}]', total: 'b[{{.window}}]'}}, {name: a, objective: 99, sli: {good: 'a[{{.window}}]', total: 'b[{{.window}}]'}}]", "duplicate SLO name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slos.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSLOFile), 0o600))
+
+	slos, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, slos, 2)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read SLO file")
+}
+
+func TestBurnRate(t *testing.T) {
+	assert.InDelta(t, 14.4, DefaultWindows[0].BurnRate(30*24*time.Hour), 1e-9)
+	assert.InDelta(t, 6, DefaultWindows[1].BurnRate(30*24*time.Hour), 1e-9)
+	assert.InDelta(t, 3, DefaultWindows[2].BurnRate(30*24*time.Hour), 1e-9)
+	assert.InDelta(t, 1, DefaultWindows[3].BurnRate(30*24*time.Hour), 1e-9)
+	assert.InDelta(t, 13.44, DefaultWindows[0].BurnRate(28*24*time.Hour), 1e-9)
+	assert.Equal(t, "1h/5m", DefaultWindows[0].String())
+}
+
+func TestExpressions(t *testing.T) {
+	slos, err := Parse([]byte(testSLOFile))
+	require.NoError(t, err)
+	checkout := slos[0]
+
+	assert.Equal(t,
+		`1 - ((sum(rate(http_requests_total{job="checkout", code!~"5.."}[1h]))) / (sum by (job) (rate(http_requests_total{job="checkout"}[1h]))))`,
+		checkout.ErrorRatioExpr(time.Hour))
+
+	expr := checkout.BurnRateExpr(WindowsBySeverity(SeverityPage))
+	assert.Contains(t, expr, "[1h]))))) > (14.4 * 0.001) and")
+	assert.Contains(t, expr, "[30m]))))) > (6 * 0.001))")
+	assert.Contains(t, expr, ") or (")
+
+	assert.Equal(t, []string{"http_requests_total"}, checkout.MetricNames())
+	assert.Equal(t, []string{"search_duration_seconds_bucket", "search_duration_seconds_count"}, slos[1].MetricNames())
+}
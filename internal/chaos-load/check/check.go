@@ -0,0 +1,110 @@
+// Package check evaluates load test assertions: per-request checks on
+// responses and global thresholds on the aggregated results.
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Checks are assertions on every response. A response that fails one is
+// counted as a failed check, separately from transport errors.
+type Checks struct {
+	// Status lists the accepted status codes.
+	Status []int `yaml:"status"`
+	// BodyContains must appear in the response body.
+	BodyContains string `yaml:"body_contains"`
+	// JSONPath must select a value in the JSON body, equal to Equals if set.
+	JSONPath string `yaml:"jsonpath"`
+	Equals   string `yaml:"equals"`
+	// Headers must be present in the response.
+	Headers []string `yaml:"headers"`
+	// MaxLatency is the slowest acceptable response.
+	MaxLatency time.Duration `yaml:"max_latency"`
+
+	path *JSONPath
+}
+
+// Compile validates the checks. It must be called before Evaluate.
+func (c *Checks) Compile() error {
+	if c.Equals != "" && c.JSONPath == "" {
+		return fmt.Errorf("check equals requires a jsonpath")
+	}
+	if c.MaxLatency < 0 {
+		return fmt.Errorf("check max_latency must not be negative")
+	}
+	if c.JSONPath != "" {
+		path, err := ParseJSONPath(c.JSONPath)
+		if err != nil {
+			return fmt.Errorf("check: %w", err)
+		}
+		c.path = path
+	}
+	return nil
+}
+
+// ParseJSONPathCheck splits a "$.path" or "$.path == value" flag into the
+// JSONPath and Equals fields.
+func (c *Checks) ParseJSONPathCheck(expr string) {
+	path, value, found := strings.Cut(expr, "==")
+	c.JSONPath = strings.TrimSpace(path)
+	if found {
+		c.Equals = strings.TrimSpace(value)
+	}
+}
+
+// Empty reports whether no check is configured.
+func (c *Checks) Empty() bool {
+	return c == nil || len(c.Status) == 0 && c.BodyContains == "" && c.JSONPath == "" &&
+		len(c.Headers) == 0 && c.MaxLatency == 0
+}
+
+// NeedsBody reports whether the checks inspect the response body.
+func (c *Checks) NeedsBody() bool {
+	return c != nil && (c.BodyContains != "" || c.path != nil)
+}
+
+// Evaluate returns the names of the checks the response fails.
+func (c *Checks) Evaluate(resp *http.Response, body []byte, latency time.Duration) []string {
+	if c == nil {
+		return nil
+	}
+
+	var failed []string
+	if len(c.Status) > 0 && !containsStatus(c.Status, resp.StatusCode) {
+		failed = append(failed, fmt.Sprintf("status in %v", c.Status))
+	}
+	if c.BodyContains != "" && !bytes.Contains(body, []byte(c.BodyContains)) {
+		failed = append(failed, fmt.Sprintf("body contains %q", c.BodyContains))
+	}
+	if c.path != nil {
+		value, err := c.path.Find(body)
+		switch {
+		case c.Equals != "" && (err != nil || value != c.Equals):
+			failed = append(failed, fmt.Sprintf("%s == %s", c.JSONPath, c.Equals))
+		case c.Equals == "" && err != nil:
+			failed = append(failed, c.JSONPath+" exists")
+		}
+	}
+	for _, header := range c.Headers {
+		if resp.Header.Get(header) == "" {
+			failed = append(failed, "header "+header)
+		}
+	}
+	if c.MaxLatency > 0 && latency >= c.MaxLatency {
+		failed = append(failed, fmt.Sprintf("latency < %v", c.MaxLatency))
+	}
+	return failed
+}
+
+func containsStatus(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package check
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestChecksEvaluate(t *testing.T) {
+	checks := &Checks{
+		Status:       []int{200, 204},
+		BodyContains: "ok",
+		JSONPath:     "$.status",
+		Equals:       "ok",
+		Headers:      []string{"X-Request-Id"},
+		MaxLatency:   100 * time.Millisecond,
+	}
+	if err := checks.Compile(); err != nil {
+		t.Fatalf("Compile() failed: %v", err)
+	}
+	if !checks.NeedsBody() {
+		t.Fatal("expected body checks to need the body")
+	}
+
+	good := &http.Response{StatusCode: 200, Header: http.Header{"X-Request-Id": []string{"abc"}}}
+	if failed := checks.Evaluate(good, []byte(`{"status":"ok"}`), 10*time.Millisecond); len(failed) != 0 {
+		t.Fatalf("expected all checks to pass, got %v", failed)
+	}
+
+	bad := &http.Response{StatusCode: 500, Header: http.Header{}}
+	failed := checks.Evaluate(bad, []byte(`{"status":"down"}`), time.Second)
+	want := []string{
+		"status in [200 204]",
+		`body contains "ok"`,
+		"$.status == ok",
+		"header X-Request-Id",
+		"latency < 100ms",
+	}
+	if strings.Join(failed, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected failed checks:\n got %v\nwant %v", failed, want)
+	}
+}
+
+func TestChecksJSONPathExists(t *testing.T) {
+	checks := &Checks{}
+	checks.ParseJSONPathCheck("$.data.id")
+	if err := checks.Compile(); err != nil {
+		t.Fatalf("Compile() failed: %v", err)
+	}
+
+	resp := &http.Response{StatusCode: 200, Header: http.Header{}}
+	if failed := checks.Evaluate(resp, []byte(`{"data":{"id":1}}`), 0); len(failed) != 0 {
+		t.Fatalf("expected check to pass, got %v", failed)
+	}
+	if failed := checks.Evaluate(resp, []byte(`{"data":{}}`), 0); len(failed) != 1 || failed[0] != "$.data.id exists" {
+		t.Fatalf("expected missing path to fail, got %v", failed)
+	}
+}
+
+func TestJSONPathFindConcurrent(t *testing.T) {
+	path, err := ParseJSONPath("$.items[*].id")
+	if err != nil {
+		t.Fatalf("ParseJSONPath() failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for worker := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				want := fmt.Sprintf("w%d-%d", worker, i)
+				got, err := path.Find([]byte(fmt.Sprintf(`{"items":[{"id":%q},{"id":"other"}]}`, want)))
+				if err != nil || got != want {
+					t.Errorf("Find() = %q, %v, want %q", got, err, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParseJSONPathRejectsRange(t *testing.T) {
+	for _, expr := range []string{"{range .items[*]}{.id}{end}", "{.items[?(@.ok==true)]}{end}"} {
+		if _, err := ParseJSONPath(expr); err == nil || !strings.Contains(err.Error(), "range blocks are not supported") {
+			t.Errorf("ParseJSONPath(%q) error = %v, want range blocks rejected", expr, err)
+		}
+	}
+	if _, err := ParseJSONPath("{.items[?(@.ok==true)].id}"); err != nil {
+		t.Errorf("ParseJSONPath() with a filter failed: %v", err)
+	}
+}
+
+func TestChecksParseJSONPathCheckWithValue(t *testing.T) {
+	checks := &Checks{}
+	checks.ParseJSONPathCheck("$.status == ok")
+	if checks.JSONPath != "$.status" || checks.Equals != "ok" {
+		t.Fatalf("unexpected split: %q / %q", checks.JSONPath, checks.Equals)
+	}
+}
+
+func TestChecksCompileRejectsEqualsWithoutPath(t *testing.T) {
+	if err := (&Checks{Equals: "ok"}).Compile(); err == nil {
+		t.Fatal("expected an error for equals without jsonpath")
+	}
+}
+
+func TestChecksNil(t *testing.T) {
+	var checks *Checks
+	if !checks.Empty() || checks.NeedsBody() {
+		t.Fatal("expected nil checks to be empty")
+	}
+	if failed := checks.Evaluate(&http.Response{StatusCode: 500}, nil, time.Hour); failed != nil {
+		t.Fatalf("expected no failures for nil checks, got %v", failed)
+	}
+}
@@ -0,0 +1,115 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPath is a parsed JSONPath expression such as $.data.token or
+// $.items[0].id. It is safe for concurrent use by the workers.
+type JSONPath struct {
+	expr string
+	path *jsonpath.JSONPath
+}
+
+// ParseJSONPath parses a JSONPath expression once, for every later Find.
+// Both $.a.b and the {.a.b} template form are accepted, except {range}
+// blocks: only the first selected value is used, which $.items[*].id
+// selects as well.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	template := jsonPathTemplate(expr)
+	parsed, err := jsonpath.Parse(expr, template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q: %w", expr, err)
+	}
+	// Evaluating range and end identifiers is the only thing that changes a
+	// parsed jsonpath.JSONPath, so without them one parse can be shared.
+	if hasIdentifier(parsed.Root) {
+		return nil, fmt.Errorf("invalid jsonpath %q: range blocks are not supported, select the value with a path such as $.items[*].id", expr)
+	}
+
+	path := jsonpath.New(expr)
+	if err := path.Parse(template); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q: %w", expr, err)
+	}
+	return &JSONPath{expr: expr, path: path}, nil
+}
+
+// hasIdentifier reports whether the parse tree contains a range or end identifier.
+func hasIdentifier(list *jsonpath.ListNode) bool {
+	if list == nil {
+		return false
+	}
+	for _, node := range list.Nodes {
+		switch node := node.(type) {
+		case *jsonpath.IdentifierNode:
+			return true
+		case *jsonpath.ListNode:
+			if hasIdentifier(node) {
+				return true
+			}
+		case *jsonpath.FilterNode:
+			if hasIdentifier(node.Left) || hasIdentifier(node.Right) {
+				return true
+			}
+		case *jsonpath.UnionNode:
+			for _, branch := range node.Nodes {
+				if hasIdentifier(branch) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// jsonPathTemplate turns $.a.b into the {.a.b} template syntax of the parser.
+func jsonPathTemplate(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "{") {
+		return expr
+	}
+	expr = strings.TrimPrefix(expr, "$")
+	if !strings.HasPrefix(expr, ".") && !strings.HasPrefix(expr, "[") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
+
+// String returns the expression as written.
+func (p *JSONPath) String() string {
+	return p.expr
+}
+
+// Find returns the first value the expression selects in a JSON document:
+// strings as they are, anything else as JSON.
+func (p *JSONPath) Find(body []byte) (string, error) {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", fmt.Errorf("response is not JSON: %w", err)
+	}
+	results, err := p.path.FindResults(data)
+	if err != nil {
+		return "", err
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return "", fmt.Errorf("no match for %s", p.expr)
+	}
+	return StringValue(results[0][0].Interface())
+}
+
+// StringValue renders a decoded JSON value as text: strings as they are,
+// anything else as JSON.
+func StringValue(value interface{}) (string, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
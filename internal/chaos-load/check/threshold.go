@@ -0,0 +1,186 @@
+package check
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+type metricKind int
+
+const (
+	kindDuration metricKind = iota
+	kindRate
+	kindNumber
+)
+
+// metrics maps threshold metric names to how they are read from a snapshot.
+var metrics = map[string]struct {
+	kind  metricKind
+	value func(stats.SnapshotStats) float64
+}{
+	"p50":               {kindDuration, func(s stats.SnapshotStats) float64 { return s.Latency.P50.Seconds() }},
+	"p90":               {kindDuration, func(s stats.SnapshotStats) float64 { return s.Latency.P90.Seconds() }},
+	"p95":               {kindDuration, func(s stats.SnapshotStats) float64 { return s.Latency.P95.Seconds() }},
+	"p99":               {kindDuration, func(s stats.SnapshotStats) float64 { return s.Latency.P99.Seconds() }},
+	"p99.9":             {kindDuration, func(s stats.SnapshotStats) float64 { return s.Latency.P999.Seconds() }},
+	"mean":              {kindDuration, func(s stats.SnapshotStats) float64 { return s.Latency.Mean.Seconds() }},
+	"max":               {kindDuration, func(s stats.SnapshotStats) float64 { return s.Latency.Max.Seconds() }},
+	"error_rate":        {kindRate, func(s stats.SnapshotStats) float64 { return ratio(s.Errors, s.TotalRequests) }},
+	"failed_check_rate": {kindRate, func(s stats.SnapshotStats) float64 { return ratio(s.FailedChecks, s.TotalRequests) }},
+	"rps":               {kindNumber, func(s stats.SnapshotStats) float64 { return s.RPS }},
+	"requests":          {kindNumber, func(s stats.SnapshotStats) float64 { return float64(s.TotalRequests) }},
+	"dropped":           {kindNumber, func(s stats.SnapshotStats) float64 { return float64(s.Dropped) }},
+}
+
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// Threshold is a pass/fail condition on the aggregated results, such as
+// "p99 < 300ms", "error_rate < 1%" or "rps > 400".
+type Threshold struct {
+	Expr   string
+	Metric string
+	Op     string
+	Value  float64
+
+	kind metricKind
+}
+
+// Outcome is the result of evaluating a threshold.
+type Outcome struct {
+	Threshold Threshold
+	Actual    string
+	Passed    bool
+}
+
+// ParseThreshold parses "<metric> <op> <value>". Latency metrics take a
+// duration, rates a percentage or fraction, and rps, requests and dropped a number.
+func ParseThreshold(expr string) (Threshold, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 3 {
+		return Threshold{}, fmt.Errorf("invalid threshold %q: expected <metric> <op> <value>, e.g. \"p99 < 300ms\"", expr)
+	}
+
+	metric, ok := metrics[fields[0]]
+	if !ok {
+		return Threshold{}, fmt.Errorf("invalid threshold %q: unknown metric %q", expr, fields[0])
+	}
+	t := Threshold{Expr: expr, Metric: fields[0], Op: fields[1], kind: metric.kind}
+	switch t.Op {
+	case "<", "<=", ">", ">=":
+	default:
+		return Threshold{}, fmt.Errorf("invalid threshold %q: unknown operator %q", expr, t.Op)
+	}
+
+	var err error
+	switch metric.kind {
+	case kindDuration:
+		var d time.Duration
+		d, err = time.ParseDuration(fields[2])
+		t.Value = d.Seconds()
+	case kindRate:
+		if percent, found := strings.CutSuffix(fields[2], "%"); found {
+			t.Value, err = strconv.ParseFloat(percent, 64)
+			t.Value /= 100
+		} else {
+			t.Value, err = strconv.ParseFloat(fields[2], 64)
+		}
+	case kindNumber:
+		t.Value, err = strconv.ParseFloat(fields[2], 64)
+	}
+	if err != nil {
+		return Threshold{}, fmt.Errorf("invalid threshold %q: invalid value %q", expr, fields[2])
+	}
+	return t, nil
+}
+
+// ParseThresholds parses a list of threshold expressions.
+func ParseThresholds(exprs []string) ([]Threshold, error) {
+	thresholds := make([]Threshold, 0, len(exprs))
+	for _, expr := range exprs {
+		t, err := ParseThreshold(expr)
+		if err != nil {
+			return nil, err
+		}
+		thresholds = append(thresholds, t)
+	}
+	return thresholds, nil
+}
+
+// UpperBound reports whether the threshold caps the metric (< or <=). Only
+// those can be judged before a test ends: a lower bound such as "rps > 400"
+// may still be met once a ramp-up is over.
+func (t Threshold) UpperBound() bool {
+	return t.Op == "<" || t.Op == "<="
+}
+
+// Evaluate checks the threshold against a snapshot.
+func (t Threshold) Evaluate(s stats.SnapshotStats) Outcome {
+	actual := metrics[t.Metric].value(s)
+
+	var passed bool
+	switch t.Op {
+	case "<":
+		passed = actual < t.Value
+	case "<=":
+		passed = actual <= t.Value
+	case ">":
+		passed = actual > t.Value
+	case ">=":
+		passed = actual >= t.Value
+	}
+	return Outcome{Threshold: t, Actual: t.format(actual), Passed: passed}
+}
+
+func (t Threshold) format(value float64) string {
+	switch t.kind {
+	case kindDuration:
+		return time.Duration(math.Round(value * float64(time.Second))).String()
+	case kindRate:
+		return strconv.FormatFloat(value*100, 'f', 2, 64) + "%"
+	case kindNumber:
+		if t.Metric == "rps" {
+			return strconv.FormatFloat(value, 'f', 2, 64)
+		}
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// EvaluateAll checks every threshold and returns the outcomes and the number that failed.
+func EvaluateAll(thresholds []Threshold, s stats.SnapshotStats) ([]Outcome, int) {
+	outcomes := make([]Outcome, 0, len(thresholds))
+	failed := 0
+	for _, t := range thresholds {
+		outcome := t.Evaluate(s)
+		if !outcome.Passed {
+			failed++
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, failed
+}
+
+// FprintOutcomes writes the threshold verdicts.
+func FprintOutcomes(w io.Writer, outcomes []Outcome) {
+	if len(outcomes) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n=== Thresholds ===\n")
+	for _, o := range outcomes {
+		verdict := "PASS"
+		if !o.Passed {
+			verdict = "FAIL"
+		}
+		fmt.Fprintf(w, "  [%s] %s (actual: %s)\n", verdict, o.Threshold.Expr, o.Actual)
+	}
+}
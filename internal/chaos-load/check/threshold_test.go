@@ -0,0 +1,84 @@
+package check
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+func TestParseThreshold(t *testing.T) {
+	cases := []struct {
+		expr  string
+		value float64
+	}{
+		{"p99 < 300ms", 0.3},
+		{"error_rate < 1%", 0.01},
+		{"error_rate <= 0.05", 0.05},
+		{"rps > 400", 400},
+		{"failed_check_rate < 0.5%", 0.005},
+	}
+	for _, tc := range cases {
+		threshold, err := ParseThreshold(tc.expr)
+		if err != nil {
+			t.Fatalf("ParseThreshold(%q) failed: %v", tc.expr, err)
+		}
+		if diff := threshold.Value - tc.value; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("ParseThreshold(%q) value = %v, want %v", tc.expr, threshold.Value, tc.value)
+		}
+	}
+}
+
+func TestParseThresholdRejectsInvalid(t *testing.T) {
+	for _, expr := range []string{"p99<300ms", "p42 < 1s", "p99 == 1s", "p99 < fast", "error_rate < lots"} {
+		if _, err := ParseThreshold(expr); err == nil {
+			t.Errorf("expected ParseThreshold(%q) to fail", expr)
+		}
+	}
+}
+
+func TestEvaluateAll(t *testing.T) {
+	thresholds, err := ParseThresholds([]string{"p99 < 300ms", "error_rate < 1%", "rps > 400"})
+	if err != nil {
+		t.Fatalf("ParseThresholds() failed: %v", err)
+	}
+
+	snapshot := stats.SnapshotStats{
+		TotalRequests: 1000,
+		Errors:        20,
+		RPS:           512.25,
+		Latency:       stats.LatencyStats{P99: 212 * time.Millisecond},
+	}
+	outcomes, failed := EvaluateAll(thresholds, snapshot)
+	if failed != 1 {
+		t.Fatalf("expected 1 failed threshold, got %d", failed)
+	}
+	if !outcomes[0].Passed || outcomes[1].Passed || !outcomes[2].Passed {
+		t.Fatalf("unexpected outcomes: %+v", outcomes)
+	}
+	if outcomes[0].Actual != "212ms" || outcomes[1].Actual != "2.00%" || outcomes[2].Actual != "512.25" {
+		t.Fatalf("unexpected actual values: %q %q %q", outcomes[0].Actual, outcomes[1].Actual, outcomes[2].Actual)
+	}
+
+	var buf bytes.Buffer
+	FprintOutcomes(&buf, outcomes)
+	if !strings.Contains(buf.String(), "[FAIL] error_rate < 1% (actual: 2.00%)") {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestUpperBound(t *testing.T) {
+	upper, err := ParseThreshold("p99 < 1s")
+	if err != nil {
+		t.Fatalf("ParseThreshold() failed: %v", err)
+	}
+	lower, err := ParseThreshold("rps >= 10")
+	if err != nil {
+		t.Fatalf("ParseThreshold() failed: %v", err)
+	}
+	if !upper.UpperBound() || lower.UpperBound() {
+		t.Fatal("expected < to be an upper bound and >= not")
+	}
+}
@@ -0,0 +1,347 @@
+// Package experiment combines load and chaos: it drives an HTTP load profile
+// while injecting Kubernetes faults at scheduled offsets, then reports error
+// rate and latency per phase and how long the target took to recover.
+// Steady-state probes watch the target throughout and abort the experiment
+// when it breaches a hard limit.
+package experiment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	loadhttp "github.com/neogan/sre-toolkit/internal/chaos-load/http"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/rate"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/scenario"
+)
+
+// defaultRecovery holds the conditions a recovered interval meets unless the
+// experiment sets its own.
+var defaultRecovery = []string{"error_rate < 1%", "failed_check_rate < 1%"}
+
+// Experiment is a parsed experiment file.
+type Experiment struct {
+	Name     string   `yaml:"name"`
+	Load     Load     `yaml:"load"`
+	Faults   []Fault  `yaml:"faults"`
+	Recovery Recovery `yaml:"recovery"`
+	// Prometheus is the server queried by Prometheus probes.
+	Prometheus *PrometheusServer `yaml:"prometheus"`
+	Probes     []Probe           `yaml:"probes"`
+
+	// dir resolves the scenario path, normally the directory of the file.
+	dir string
+	// conditions holds the parsed recovery conditions.
+	conditions []check.Threshold
+}
+
+// Load is the load profile that runs for the whole experiment: either a
+// single request or a scenario file.
+type Load struct {
+	URL         string            `yaml:"url"`
+	Method      string            `yaml:"method"`
+	Body        string            `yaml:"body"`
+	Headers     map[string]string `yaml:"headers"`
+	Scenario    string            `yaml:"scenario"`
+	Concurrency int               `yaml:"concurrency"`
+	Duration    time.Duration     `yaml:"duration"`
+	// Rate and Stages switch to an open model, as the --rate and --stages flags.
+	Rate       string   `yaml:"rate"`
+	Stages     string   `yaml:"stages"`
+	Thresholds []string `yaml:"thresholds"`
+	// Checks are applied to every response of the target request, so that
+	// responses such as 503 count as failed checks.
+	Checks *check.Checks `yaml:"checks"`
+
+	rps    float64
+	stages []rate.Stage
+}
+
+// Fault is one fault injected At an offset from the start of the load.
+// Exactly one of the fault kinds is set.
+type Fault struct {
+	Name             string                 `yaml:"name"`
+	At               time.Duration          `yaml:"at"`
+	PodKill          *PodKillFault          `yaml:"pod_kill"`
+	NetworkPartition *NetworkPartitionFault `yaml:"network_partition"`
+	NodeDrain        *NodeDrainFault        `yaml:"node_drain"`
+}
+
+// PodKillFault kills Count random pods matching Selector. A zero
+// GracePeriod kills them at once.
+type PodKillFault struct {
+	Namespace   string        `yaml:"namespace"`
+	Selector    string        `yaml:"selector"`
+	Count       int           `yaml:"count"`
+	Interval    time.Duration `yaml:"interval"`
+	GracePeriod time.Duration `yaml:"grace_period"`
+}
+
+// NetworkPartitionFault isolates the pods matching Selector for Duration.
+type NetworkPartitionFault struct {
+	Namespace string        `yaml:"namespace"`
+	Selector  string        `yaml:"selector"`
+	Duration  time.Duration `yaml:"duration"`
+}
+
+// NodeDrainFault cordons and drains Node. DaemonSet pods are left alone.
+// With a Duration the node is uncordoned once it has passed; otherwise it
+// stays cordoned.
+type NodeDrainFault struct {
+	Node               string        `yaml:"node"`
+	GracePeriod        time.Duration `yaml:"grace_period"`
+	Timeout            time.Duration `yaml:"timeout"`
+	DeleteEmptyDirData bool          `yaml:"delete_emptydir_data"`
+	Duration           time.Duration `yaml:"duration"`
+	Concurrency        int           `yaml:"concurrency"`
+}
+
+// Recovery decides when the target has recovered from a fault: every
+// interval of a Stable window meets all Conditions, which are threshold
+// expressions such as "error_rate < 1%" or "p99 < 500ms".
+type Recovery struct {
+	Conditions []string      `yaml:"conditions"`
+	Stable     time.Duration `yaml:"stable"`
+}
+
+// LoadFile reads and validates an experiment file.
+func LoadFile(path string) (*Experiment, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read experiment: %w", err)
+	}
+	return Parse(data, filepath.Dir(path))
+}
+
+// Parse validates an experiment document. A relative scenario path is
+// resolved against dir, normally the directory of the experiment file.
+func Parse(data []byte, dir string) (*Experiment, error) {
+	e := &Experiment{dir: dir}
+	if err := yaml.Unmarshal(data, e); err != nil {
+		return nil, fmt.Errorf("failed to parse experiment: %w", err)
+	}
+	if err := e.compile(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// compile validates the experiment and fills in defaults.
+func (e *Experiment) compile() error {
+	if err := e.Load.validate(); err != nil {
+		return err
+	}
+	if len(e.Faults) == 0 {
+		return fmt.Errorf("experiment defines no faults")
+	}
+
+	names := make(map[string]bool, len(e.Faults))
+	for i := range e.Faults {
+		fault := &e.Faults[i]
+		if fault.Name == "" {
+			fault.Name = fmt.Sprintf("fault-%d", i+1)
+		}
+		if names[fault.Name] {
+			return fmt.Errorf("fault %s: name is used twice", fault.Name)
+		}
+		names[fault.Name] = true
+		if err := fault.validate(); err != nil {
+			return fmt.Errorf("fault %s: %w", fault.Name, err)
+		}
+		if fault.At >= e.Load.Duration {
+			return fmt.Errorf("fault %s: starts at %v, after the load ends at %v", fault.Name, fault.At, e.Load.Duration)
+		}
+		// Faults run one after another, so their windows never overlap.
+		if i > 0 && fault.At < e.Faults[i-1].At+e.Faults[i-1].minDuration() {
+			return fmt.Errorf("fault %s: starts before fault %s ends", fault.Name, e.Faults[i-1].Name)
+		}
+	}
+
+	probes := make(map[string]bool, len(e.Probes))
+	for i := range e.Probes {
+		probe := &e.Probes[i]
+		if probe.Name == "" {
+			probe.Name = fmt.Sprintf("probe-%d", i+1)
+		}
+		if probes[probe.Name] {
+			return fmt.Errorf("probe %s: name is used twice", probe.Name)
+		}
+		probes[probe.Name] = true
+		if err := probe.validate(e.Prometheus); err != nil {
+			return fmt.Errorf("probe %s: %w", probe.Name, err)
+		}
+	}
+
+	if len(e.Recovery.Conditions) == 0 {
+		e.Recovery.Conditions = append([]string(nil), defaultRecovery...)
+	}
+	conditions, err := check.ParseThresholds(e.Recovery.Conditions)
+	if err != nil {
+		return fmt.Errorf("recovery: %w", err)
+	}
+	e.conditions = conditions
+	if e.Recovery.Stable < 0 {
+		return fmt.Errorf("recovery: stable must not be negative")
+	}
+	if e.Recovery.Stable == 0 {
+		e.Recovery.Stable = 5 * time.Second
+	}
+	return nil
+}
+
+// validate checks the load profile and parses its rate. With stages, the
+// load lasts as long as the stages.
+func (l *Load) validate() error {
+	if (l.URL == "") == (l.Scenario == "") {
+		return fmt.Errorf("load: exactly one of url and scenario is required")
+	}
+	if l.Concurrency < 0 {
+		return fmt.Errorf("load: concurrency must not be negative")
+	}
+	if l.Concurrency == 0 {
+		l.Concurrency = 10
+	}
+	if l.Rate != "" && l.Stages != "" {
+		return fmt.Errorf("load: rate and stages cannot be used together")
+	}
+
+	var err error
+	if l.Rate != "" {
+		if l.rps, err = rate.Parse(l.Rate); err != nil {
+			return fmt.Errorf("load: %w", err)
+		}
+	}
+	if l.Stages != "" {
+		if l.stages, err = rate.ParseStages(l.Stages); err != nil {
+			return fmt.Errorf("load: %w", err)
+		}
+		l.Duration = rate.Ramping(l.stages).Duration()
+	}
+	if l.Duration <= 0 {
+		return fmt.Errorf("load: duration is required")
+	}
+	if _, err := check.ParseThresholds(l.Thresholds); err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+	if l.Checks != nil {
+		if l.Scenario != "" {
+			return fmt.Errorf("load: checks apply to url only; scenario steps have their own")
+		}
+		if err := l.Checks.Compile(); err != nil {
+			return fmt.Errorf("load: %w", err)
+		}
+	}
+	return nil
+}
+
+func (f *Fault) validate() error {
+	if f.At < 0 {
+		return fmt.Errorf("at must not be negative")
+	}
+
+	kinds := 0
+	if f.PodKill != nil {
+		kinds++
+		if f.PodKill.Count < 0 {
+			return fmt.Errorf("pod_kill: count must not be negative")
+		}
+	}
+	if f.NetworkPartition != nil {
+		kinds++
+		if f.NetworkPartition.Duration <= 0 {
+			return fmt.Errorf("network_partition: duration is required")
+		}
+	}
+	if f.NodeDrain != nil {
+		kinds++
+		if f.NodeDrain.Node == "" {
+			return fmt.Errorf("node_drain: node is required")
+		}
+		if f.NodeDrain.Timeout == 0 {
+			f.NodeDrain.Timeout = 5 * time.Minute
+		}
+		if f.NodeDrain.Duration < 0 || f.NodeDrain.Concurrency < 0 {
+			return fmt.Errorf("node_drain: duration and concurrency must not be negative")
+		}
+	}
+	if kinds != 1 {
+		return fmt.Errorf("exactly one of pod_kill, network_partition and node_drain is required")
+	}
+	return nil
+}
+
+// Kind names the kind of fault.
+func (f *Fault) Kind() string {
+	switch {
+	case f.PodKill != nil:
+		return "pod-kill"
+	case f.NetworkPartition != nil:
+		return "network-partition"
+	default:
+		return "node-drain"
+	}
+}
+
+// minDuration is how long the fault lasts at least; pod kills and drains
+// take as long as the cluster needs.
+func (f *Fault) minDuration() time.Duration {
+	switch {
+	case f.NetworkPartition != nil:
+		return f.NetworkPartition.Duration
+	case f.NodeDrain != nil:
+		return f.NodeDrain.Duration
+	default:
+		return 0
+	}
+}
+
+// PoolConfig builds the load configuration of the experiment. The caller adds
+// outputs and client settings.
+func (e *Experiment) PoolConfig() (loadhttp.PoolConfig, error) {
+	cfg := loadhttp.PoolConfig{
+		Config: runner.Config{
+			Concurrency: e.Load.Concurrency,
+			Duration:    e.Load.Duration,
+			Rate:        e.Load.rps,
+			Stages:      e.Load.stages,
+			Name:        e.Name,
+		},
+		TargetURL: e.Load.URL,
+		Method:    e.Load.Method,
+		Body:      e.Load.Body,
+		Headers:   e.Load.Headers,
+	}
+	if !e.Load.Checks.Empty() {
+		cfg.Checks = e.Load.Checks
+	}
+
+	exprs := e.Load.Thresholds
+	if e.Load.Scenario != "" {
+		path := e.Load.Scenario
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(e.dir, path)
+		}
+		sc, err := scenario.LoadFile(path)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Scenario = sc
+		// Thresholds from the scenario file come first, as with chaos-load run.
+		exprs = append(append([]string(nil), sc.Thresholds...), exprs...)
+		if cfg.Name == "" {
+			cfg.Name = sc.Name
+		}
+	}
+
+	thresholds, err := check.ParseThresholds(exprs)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Thresholds = thresholds
+	return cfg, nil
+}
@@ -0,0 +1,187 @@
+package experiment
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const validExperiment = `
+name: checkout-resilience
+load:
+  url: http://checkout.shop.svc:8080/healthz
+  concurrency: 20
+  duration: 5m
+  rate: 100/s
+  thresholds:
+    - p99 < 1s
+  checks:
+    status: [200]
+faults:
+  - name: kill-two-pods
+    at: 60s
+    pod_kill:
+      namespace: shop
+      selector: app=checkout
+      count: 2
+  - name: partition
+    at: 120s
+    network_partition:
+      namespace: shop
+      selector: app=checkout
+      duration: 30s
+  - at: 200s
+    node_drain:
+      node: worker-2
+recovery:
+  conditions:
+    - error_rate < 0.5%
+    - p99 < 500ms
+  stable: 10s
+`
+
+func TestParse(t *testing.T) {
+	e, err := Parse([]byte(validExperiment), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(e.Faults) != 3 {
+		t.Fatalf("expected 3 faults, got %d", len(e.Faults))
+	}
+	if e.Faults[1].At != 120*time.Second || e.Faults[1].NetworkPartition.Duration != 30*time.Second {
+		t.Fatalf("unexpected partition fault: %+v", e.Faults[1])
+	}
+	if e.Faults[2].Name != "fault-3" || e.Faults[2].Kind() != "node-drain" || e.Faults[2].NodeDrain.Timeout != 5*time.Minute {
+		t.Fatalf("expected drain defaults, got %+v", e.Faults[2])
+	}
+	if len(e.conditions) != 2 || e.Recovery.Stable != 10*time.Second {
+		t.Fatalf("unexpected recovery: %+v", e.Recovery)
+	}
+
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Rate != 100 || cfg.Concurrency != 20 || cfg.Name != "checkout-resilience" || len(cfg.Thresholds) != 1 || cfg.Checks == nil {
+		t.Fatalf("unexpected pool config: %+v", cfg)
+	}
+}
+
+func TestParse_Defaults(t *testing.T) {
+	e, err := Parse([]byte(`
+load:
+  url: http://localhost:8080
+  stages: 30s:10,1m:50
+faults:
+  - at: 45s
+    pod_kill:
+      selector: app=web
+`), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Load.Duration != 90*time.Second {
+		t.Fatalf("expected the stages to set the duration, got %v", e.Load.Duration)
+	}
+	if e.Load.Concurrency != 10 || e.Recovery.Stable != 5*time.Second {
+		t.Fatalf("unexpected defaults: %+v %+v", e.Load, e.Recovery)
+	}
+	if len(e.conditions) != 2 || e.conditions[1].Metric != "failed_check_rate" {
+		t.Fatalf("expected the default recovery condition, got %v", e.Recovery.Conditions)
+	}
+}
+
+func TestParse_Probes(t *testing.T) {
+	e, err := Parse([]byte(validExperiment+`
+prometheus:
+  url: http://prometheus.monitoring:9090
+probes:
+  - name: error-ratio
+    prometheus:
+      query: sum(rate(http_requests_total{code=~"5.."}[1m])) / sum(rate(http_requests_total[1m]))
+      steady: < 1%
+      limit: < 20%
+  - interval: 2s
+    abort_after: 3
+    http:
+      url: http://checkout.shop.svc:8080/healthz
+      abort_on_failure: true
+`), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(e.Probes) != 2 {
+		t.Fatalf("expected 2 probes, got %d", len(e.Probes))
+	}
+	ratio := e.Probes[0]
+	if ratio.Interval != 5*time.Second || ratio.AbortAfter != 1 || ratio.Prometheus.limit == nil || ratio.Prometheus.limit.value != 0.2 {
+		t.Fatalf("unexpected prometheus probe: %+v %+v", ratio, ratio.Prometheus)
+	}
+	health := e.Probes[1]
+	if health.Name != "probe-2" || health.HTTP.Method != "GET" || health.HTTP.Timeout != 5*time.Second || health.Limit() == "" {
+		t.Fatalf("unexpected http probe: %+v %+v", health, health.HTTP)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	load := "load:\n  url: http://localhost:8080\n  duration: 5m\n"
+	tests := map[string]string{
+		"no target":       "load:\n  duration: 5m\nfaults:\n  - pod_kill: {selector: app=web}\n",
+		"no faults":       load,
+		"two kinds":       load + "faults:\n  - pod_kill: {selector: app=web}\n    node_drain: {node: worker-1}\n",
+		"no kind":         load + "faults:\n  - at: 10s\n",
+		"after the load":  load + "faults:\n  - at: 5m\n    pod_kill: {selector: app=web}\n",
+		"overlapping":     load + "faults:\n  - at: 10s\n    network_partition: {duration: 30s}\n  - at: 20s\n    pod_kill: {selector: app=web}\n",
+		"duplicate name":  load + "faults:\n  - name: kill\n    pod_kill: {}\n  - name: kill\n    at: 1m\n    pod_kill: {}\n",
+		"no partition":    load + "faults:\n  - network_partition: {selector: app=web}\n",
+		"no node":         load + "faults:\n  - node_drain: {}\n",
+		"drain overlaps":  load + "faults:\n  - at: 10s\n    node_drain: {node: worker-1, duration: 1m}\n  - at: 30s\n    pod_kill: {}\n",
+		"bad condition":   load + "faults:\n  - pod_kill: {}\nrecovery:\n  conditions: [\"errors < 1\"]\n",
+		"bad threshold":   "load:\n  url: http://localhost:8080\n  duration: 5m\n  thresholds: [\"p99 <\"]\nfaults:\n  - pod_kill: {}\n",
+		"scenario checks": "load:\n  scenario: checkout.yaml\n  duration: 5m\n  checks: {status: [200]}\nfaults:\n  - pod_kill: {}\n",
+		"rate and stages": "load:\n  url: http://localhost:8080\n  rate: 10/s\n  stages: 10s:10\nfaults:\n  - pod_kill: {}\n",
+		"probe kinds":     load + "faults:\n  - pod_kill: {}\nprobes:\n  - interval: 5s\n",
+		"no prometheus":   load + "faults:\n  - pod_kill: {}\nprobes:\n  - prometheus: {query: up, steady: \"> 0\"}\n",
+		"no steady":       load + "faults:\n  - pod_kill: {}\nprometheus: {url: http://prom:9090}\nprobes:\n  - prometheus: {query: up}\n",
+		"bad limit":       load + "faults:\n  - pod_kill: {}\nprometheus: {url: http://prom:9090}\nprobes:\n  - prometheus: {query: up, steady: \"> 0\", limit: \"up > 0\"}\n",
+		"duplicate probe": load + "faults:\n  - pod_kill: {}\nprobes:\n  - {name: health, http: {url: http://a}}\n  - {name: health, http: {url: http://b}}\n",
+	}
+
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(doc), "."); err == nil {
+				t.Fatalf("expected an error for:\n%s", doc)
+			}
+		})
+	}
+}
+
+func TestLoadFile_ResolvesScenario(t *testing.T) {
+	dir := t.TempDir()
+	scenario := "name: checkout\nthresholds: [\"error_rate < 5%\"]\nflows:\n  - steps:\n      - url: http://localhost:8080/\n"
+	if err := os.WriteFile(filepath.Join(dir, "checkout.yaml"), []byte(scenario), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	doc := "load:\n  scenario: checkout.yaml\n  duration: 1m\n  thresholds: [\"p99 < 1s\"]\nfaults:\n  - at: 10s\n    pod_kill: {}\n"
+	path := filepath.Join(dir, "experiment.yaml")
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Scenario == nil || cfg.Name != "checkout" {
+		t.Fatalf("expected the scenario to be loaded, got %+v", cfg)
+	}
+	if len(cfg.Thresholds) != 2 || !strings.HasPrefix(cfg.Thresholds[0].Expr, "error_rate") {
+		t.Fatalf("expected scenario thresholds first, got %+v", cfg.Thresholds)
+	}
+}
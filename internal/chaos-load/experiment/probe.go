@@ -0,0 +1,322 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/neogan/sre-toolkit/pkg/logging"
+	"github.com/neogan/sre-toolkit/pkg/prometheus"
+)
+
+// Probe statuses.
+const (
+	// ProbeSteady means the probe met its steady-state condition.
+	ProbeSteady = "steady"
+	// ProbeDeviated means the probe left the steady state but stayed within
+	// its hard limit.
+	ProbeDeviated = "deviated"
+	// ProbeBreached means the probe breached its hard limit.
+	ProbeBreached = "breached"
+	// ProbeError means a Prometheus probe could not be evaluated.
+	ProbeError = "error"
+)
+
+// Probe stages: once before the load, periodically while it runs and once
+// after it ends.
+const (
+	StageBefore = "before"
+	StageDuring = "during"
+	StageAfter  = "after"
+)
+
+// PrometheusServer is the server Prometheus probes query.
+type PrometheusServer struct {
+	URL             string            `yaml:"url"`
+	Timeout         time.Duration     `yaml:"timeout"`
+	Insecure        bool              `yaml:"insecure"`
+	TenantID        string            `yaml:"tenant_id"`
+	BearerTokenFile string            `yaml:"bearer_token_file"`
+	CAFile          string            `yaml:"ca_file"`
+	Headers         map[string]string `yaml:"headers"`
+}
+
+// Probe checks one aspect of the steady state of the target: a PromQL query
+// compared against thresholds, or an HTTP health check. Exactly one of
+// Prometheus and HTTP is set.
+type Probe struct {
+	Name string `yaml:"name"`
+	// Interval is how often the probe runs while the load runs; 5s by default.
+	Interval   time.Duration    `yaml:"interval"`
+	Prometheus *PrometheusProbe `yaml:"prometheus"`
+	HTTP       *HTTPProbe       `yaml:"http"`
+	// AbortAfter is how many results in a row must breach the hard limit
+	// before the experiment is aborted; 1 by default.
+	AbortAfter int `yaml:"abort_after"`
+}
+
+// PrometheusProbe evaluates Query, which must return a single value. Steady
+// is the condition of the steady state, such as "< 0.01" or "< 1%"; the
+// optional Limit is the hard limit, such as "< 20%".
+type PrometheusProbe struct {
+	Query  string `yaml:"query"`
+	Steady string `yaml:"steady"`
+	Limit  string `yaml:"limit"`
+
+	steady bound
+	limit  *bound
+}
+
+// HTTPProbe requests URL and expects one of Status, any 2xx by default. With
+// AbortOnFailure a failed check breaches the hard limit.
+type HTTPProbe struct {
+	URL            string        `yaml:"url"`
+	Method         string        `yaml:"method"`
+	Status         []int         `yaml:"status"`
+	Timeout        time.Duration `yaml:"timeout"`
+	AbortOnFailure bool          `yaml:"abort_on_failure"`
+}
+
+// ProbeResult is one evaluation of a probe. Offset is relative to the start
+// of the load; results of the before stage have none.
+type ProbeResult struct {
+	Probe  string
+	Stage  string
+	Phase  string
+	Offset time.Duration
+	// Value is the query result of a Prometheus probe or the status code of
+	// an HTTP probe.
+	Value  float64
+	Status string
+	Err    error
+}
+
+// bound is a condition on a probe value: "<op> <value>", where the value may
+// be a percentage.
+type bound struct {
+	expr  string
+	op    string
+	value float64
+}
+
+func parseBound(expr string) (bound, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 2 {
+		return bound{}, fmt.Errorf("invalid condition %q: expected <op> <value>, e.g. \"< 0.05\"", expr)
+	}
+	b := bound{expr: expr, op: fields[0]}
+	switch b.op {
+	case "<", "<=", ">", ">=":
+	default:
+		return bound{}, fmt.Errorf("invalid condition %q: unknown operator %q", expr, b.op)
+	}
+
+	var err error
+	if percent, found := strings.CutSuffix(fields[1], "%"); found {
+		b.value, err = strconv.ParseFloat(percent, 64)
+		b.value /= 100
+	} else {
+		b.value, err = strconv.ParseFloat(fields[1], 64)
+	}
+	if err != nil {
+		return bound{}, fmt.Errorf("invalid condition %q: invalid value %q", expr, fields[1])
+	}
+	return b, nil
+}
+
+// holds reports whether v meets the condition.
+func (b bound) holds(v float64) bool {
+	switch b.op {
+	case "<":
+		return v < b.value
+	case "<=":
+		return v <= b.value
+	case ">":
+		return v > b.value
+	default:
+		return v >= b.value
+	}
+}
+
+func (p *Probe) validate(server *PrometheusServer) error {
+	if p.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	if p.Interval == 0 {
+		p.Interval = 5 * time.Second
+	}
+	if p.AbortAfter < 0 {
+		return fmt.Errorf("abort_after must not be negative")
+	}
+	if p.AbortAfter == 0 {
+		p.AbortAfter = 1
+	}
+	if (p.Prometheus == nil) == (p.HTTP == nil) {
+		return fmt.Errorf("exactly one of prometheus and http is required")
+	}
+
+	if p.HTTP != nil {
+		if p.HTTP.URL == "" {
+			return fmt.Errorf("http: url is required")
+		}
+		if p.HTTP.Method == "" {
+			p.HTTP.Method = http.MethodGet
+		}
+		if p.HTTP.Timeout == 0 {
+			p.HTTP.Timeout = 5 * time.Second
+		}
+		return nil
+	}
+
+	if server == nil || server.URL == "" {
+		return fmt.Errorf("prometheus: the experiment sets no prometheus url")
+	}
+	if p.Prometheus.Query == "" {
+		return fmt.Errorf("prometheus: query is required")
+	}
+	if p.Prometheus.Steady == "" {
+		return fmt.Errorf("prometheus: steady is required")
+	}
+	var err error
+	if p.Prometheus.steady, err = parseBound(p.Prometheus.Steady); err != nil {
+		return fmt.Errorf("prometheus: %w", err)
+	}
+	if p.Prometheus.Limit != "" {
+		limit, err := parseBound(p.Prometheus.Limit)
+		if err != nil {
+			return fmt.Errorf("prometheus: %w", err)
+		}
+		p.Prometheus.limit = &limit
+	}
+	return nil
+}
+
+// Limit describes the hard limit of the probe, or "" if it has none.
+func (p *Probe) Limit() string {
+	switch {
+	case p.Prometheus != nil && p.Prometheus.limit != nil:
+		return p.Prometheus.Limit
+	case p.HTTP != nil && p.HTTP.AbortOnFailure:
+		return "health check failed"
+	default:
+		return ""
+	}
+}
+
+// prober evaluates the probes of an experiment.
+type prober struct {
+	prometheus *prometheus.Client
+	client     *http.Client
+}
+
+// newProber creates the Prometheus client if the experiment queries one.
+func newProber(e *Experiment) (*prober, error) {
+	p := &prober{client: &http.Client{}}
+	if e.Prometheus == nil || e.Prometheus.URL == "" {
+		return p, nil
+	}
+
+	logger := logging.GetLogger()
+	client, err := prometheus.NewClient(&prometheus.Config{
+		URL:             e.Prometheus.URL,
+		Timeout:         e.Prometheus.Timeout,
+		Insecure:        e.Prometheus.Insecure,
+		TenantID:        e.Prometheus.TenantID,
+		BearerTokenFile: e.Prometheus.BearerTokenFile,
+		CAFile:          e.Prometheus.CAFile,
+		Headers:         e.Prometheus.Headers,
+	}, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client: %w", err)
+	}
+	p.prometheus = client
+	return p, nil
+}
+
+// run evaluates a probe once. The caller fills in the stage, phase and offset.
+func (p *prober) run(ctx context.Context, probe *Probe) ProbeResult {
+	result := ProbeResult{Probe: probe.Name}
+	if probe.HTTP != nil {
+		code, err := p.check(ctx, probe.HTTP)
+		result.Value, result.Err = float64(code), err
+		switch {
+		case err == nil:
+			result.Status = ProbeSteady
+		case probe.HTTP.AbortOnFailure:
+			result.Status = ProbeBreached
+		default:
+			result.Status = ProbeDeviated
+		}
+		return result
+	}
+
+	value, err := p.query(ctx, probe.Prometheus.Query)
+	result.Value, result.Err = value, err
+	switch {
+	case err != nil:
+		result.Status = ProbeError
+	case probe.Prometheus.limit != nil && !probe.Prometheus.limit.holds(value):
+		result.Status = ProbeBreached
+	case !probe.Prometheus.steady.holds(value):
+		result.Status = ProbeDeviated
+	default:
+		result.Status = ProbeSteady
+	}
+	return result
+}
+
+// check sends the request of an HTTP probe and returns its status code. A
+// status that is not expected is an error.
+func (p *prober) check(ctx context.Context, probe *HTTPProbe) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, probe.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, probe.Method, probe.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if len(probe.Status) == 0 {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+	} else if !slices.Contains(probe.Status, resp.StatusCode) {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// query runs an instant query that must return a single value.
+func (p *prober) query(ctx context.Context, query string) (float64, error) {
+	value, err := p.prometheus.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := value.(type) {
+	case *model.Scalar:
+		return float64(v.Value), nil
+	case model.Vector:
+		switch len(v) {
+		case 0:
+			return 0, fmt.Errorf("query returned no data")
+		case 1:
+			return float64(v[0].Value), nil
+		default:
+			return 0, fmt.Errorf("query returned %d series, expected one; aggregate them with sum or max", len(v))
+		}
+	default:
+		return 0, fmt.Errorf("query returned a %s, expected a scalar or a single sample", value.Type())
+	}
+}
@@ -0,0 +1,122 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseBound(t *testing.T) {
+	tests := []struct {
+		expr  string
+		value float64
+		holds bool
+	}{
+		{"< 0.05", 0.01, true},
+		{"< 0.05", 0.05, false},
+		{"<= 5%", 0.05, true},
+		{"> 100", 150, true},
+		{">= 1", 0.5, false},
+	}
+	for _, tt := range tests {
+		b, err := parseBound(tt.expr)
+		if err != nil {
+			t.Fatalf("parseBound(%q) failed: %v", tt.expr, err)
+		}
+		if got := b.holds(tt.value); got != tt.holds {
+			t.Errorf("%q holds for %v = %v, want %v", tt.expr, tt.value, got, tt.holds)
+		}
+	}
+
+	for _, expr := range []string{"0.05", "< abc", "== 1", "error_rate < 1%"} {
+		if _, err := parseBound(expr); err == nil {
+			t.Errorf("expected an error for %q", expr)
+		}
+	}
+}
+
+// prometheusAPI serves instant queries, answering each query with the
+// samples of results.
+func prometheusAPI(t *testing.T, results map[string][]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse query: %v", err)
+		}
+		var samples []string
+		for i, value := range results[r.Form.Get("query")] {
+			samples = append(samples, fmt.Sprintf(`{"metric":{"pod":"p%d"},"value":[1700000000,%q]}`, i, value))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[%s]}}`, strings.Join(samples, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProberPrometheus(t *testing.T) {
+	api := prometheusAPI(t, map[string][]string{
+		"steady":   {"0.002"},
+		"deviated": {"0.08"},
+		"breached": {"0.5"},
+		"series":   {"0.1", "0.2"},
+	})
+	e := &Experiment{Prometheus: &PrometheusServer{URL: api.URL}}
+	p, err := newProber(e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string]string{
+		"steady":   ProbeSteady,
+		"deviated": ProbeDeviated,
+		"breached": ProbeBreached,
+		"series":   ProbeError,
+		"empty":    ProbeError,
+	}
+	for query, want := range tests {
+		probe := Probe{Name: query, Prometheus: &PrometheusProbe{Query: query, Steady: "< 5%", Limit: "< 0.2"}}
+		if err := probe.validate(e.Prometheus); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result := p.run(context.Background(), &probe)
+		if result.Status != want {
+			t.Errorf("query %s: expected %s, got %s (%v)", query, want, result.Status, result.Err)
+		}
+	}
+}
+
+func TestProberHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	p, err := newProber(&Experiment{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		probe HTTPProbe
+		want  string
+	}{
+		{HTTPProbe{URL: server.URL + "/up"}, ProbeSteady},
+		{HTTPProbe{URL: server.URL + "/down"}, ProbeDeviated},
+		{HTTPProbe{URL: server.URL + "/down", Status: []int{200, 503}}, ProbeSteady},
+		{HTTPProbe{URL: server.URL + "/down", AbortOnFailure: true}, ProbeBreached},
+	}
+	for _, tt := range tests {
+		probe := Probe{Name: "health", HTTP: &tt.probe}
+		if err := probe.validate(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result := p.run(context.Background(), &probe); result.Status != tt.want {
+			t.Errorf("%+v: expected %s, got %s (%v)", tt.probe, tt.want, result.Status, result.Err)
+		}
+	}
+}
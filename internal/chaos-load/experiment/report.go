@@ -0,0 +1,250 @@
+package experiment
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+// Report is the outcome of an experiment: the totals of every phase, how
+// the target recovered from each fault and the timeline of the probes.
+type Report struct {
+	Phases []stats.PhaseStats
+	Faults []FaultReport
+	Probes []ProbeResult
+	// Aborted is set when a probe breached its hard limit or the experiment
+	// was interrupted.
+	Aborted error
+}
+
+// FaultReport tells how long the target took to recover from a fault.
+type FaultReport struct {
+	Window
+	// Recovered is set once the recovery conditions held for the stable
+	// window; TimeToRecover is measured from the end of the fault to the
+	// start of that window.
+	Recovered     bool
+	TimeToRecover time.Duration
+	// Observed is how long the target was watched after the fault, up to the
+	// next fault or the end of the load.
+	Observed time.Duration
+}
+
+// Analyze builds the report of an experiment from the collector's snapshot,
+// its per-second series and the fault windows. An interval is healthy when
+// it has requests and meets every condition.
+func Analyze(snapshot stats.SnapshotStats, series []stats.Interval, windows []Window, conditions []check.Threshold, stable time.Duration) Report {
+	report := Report{Phases: snapshot.Phases}
+	for i, window := range windows {
+		until := snapshot.Elapsed
+		if i+1 < len(windows) {
+			until = windows[i+1].Start
+		}
+		fault := FaultReport{Window: window, Observed: until - window.End}
+		if window.Err == nil {
+			fault.TimeToRecover, fault.Recovered = timeToRecover(series, window.End, until, conditions, stable)
+		}
+		report.Faults = append(report.Faults, fault)
+	}
+	return report
+}
+
+// timeToRecover finds the first run of healthy intervals lasting at least
+// stable between from and until, and returns how long after from it started.
+// Intervals are only as precise as the series, normally one second.
+func timeToRecover(series []stats.Interval, from, until time.Duration, conditions []check.Threshold, stable time.Duration) (time.Duration, bool) {
+	runStart := time.Duration(-1)
+	for _, interval := range series {
+		end := interval.Offset + interval.Duration
+		if end <= from {
+			continue
+		}
+		if interval.Offset >= until {
+			break
+		}
+		if !healthy(interval, conditions) {
+			runStart = -1
+			continue
+		}
+		if runStart < 0 {
+			runStart = interval.Offset
+		}
+		if end-runStart >= stable {
+			return max(0, runStart-from), true
+		}
+	}
+	return 0, false
+}
+
+// healthy reports whether an interval meets every recovery condition.
+func healthy(interval stats.Interval, conditions []check.Threshold) bool {
+	if interval.Requests == 0 {
+		return false
+	}
+	snapshot := stats.SnapshotStats{
+		TotalRequests: interval.Requests,
+		Errors:        interval.Errors,
+		FailedChecks:  interval.FailedChecks,
+		Elapsed:       interval.Duration,
+		RPS:           interval.RPS(),
+		StatusCodes:   interval.StatusCodes,
+		Latency:       interval.Latency,
+	}
+	for _, condition := range conditions {
+		if !condition.Evaluate(snapshot).Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the number of faults that could not be injected.
+func (r Report) Failed() int {
+	failed := 0
+	for _, fault := range r.Faults {
+		if fault.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Unrecovered returns the number of injected faults the target did not
+// recover from.
+func (r Report) Unrecovered() int {
+	unrecovered := 0
+	for _, fault := range r.Faults {
+		if fault.Err == nil && !fault.Recovered {
+			unrecovered++
+		}
+	}
+	return unrecovered
+}
+
+// Fprint writes the phase table, the time to recover from each fault and the
+// probe timeline.
+func (r Report) Fprint(w io.Writer) {
+	if len(r.Phases) > 0 {
+		fmt.Fprintf(w, "\n=== Experiment Phases ===\n")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  PHASE\tWINDOW\tREQUESTS\tERROR RATE\tFAILED CHECKS\tP50\tP95\tP99\tMAX")
+		for _, phase := range r.Phases {
+			fmt.Fprintf(tw, "  %s\t%v-%v\t%d\t%.2f%%\t%.2f%%\t%v\t%v\t%v\t%v\n",
+				phase.Name, round(phase.Start), round(phase.End), phase.Requests, phase.ErrorRate()*100,
+				phase.FailedCheckRate()*100, phase.Latency.P50, phase.Latency.P95, phase.Latency.P99, phase.Latency.Max)
+		}
+		tw.Flush()
+	}
+
+	if r.Aborted != nil {
+		fmt.Fprintf(w, "\n%v; faults were rolled back\n", capitalize(r.Aborted.Error()))
+	}
+
+	if len(r.Faults) > 0 {
+		fmt.Fprintf(w, "\nTime to Recover:\n")
+		for _, fault := range r.Faults {
+			switch {
+			case fault.Err != nil:
+				fmt.Fprintf(w, "  %s (%s): not injected: %v\n", fault.Fault, fault.Kind, fault.Err)
+			case fault.Recovered:
+				fmt.Fprintf(w, "  %s (%s): %v\n", fault.Fault, fault.Kind, round(fault.TimeToRecover))
+			case r.Aborted != nil:
+				fmt.Fprintf(w, "  %s (%s): not recovered before the abort\n", fault.Fault, fault.Kind)
+			default:
+				fmt.Fprintf(w, "  %s (%s): not recovered within %v\n", fault.Fault, fault.Kind, round(fault.Observed))
+			}
+		}
+	}
+
+	if len(r.Probes) > 0 {
+		fmt.Fprintf(w, "\nSteady-State Probes:\n")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  PROBE\tPHASE\tWINDOW\tCHECKS\tSTATUS\tVALUE")
+		for _, segment := range timeline(r.Probes) {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%d\t%s\t%s\n",
+				segment.probe, segment.phase, segment.window(), segment.checks, segment.status, segment.value())
+		}
+		tw.Flush()
+	}
+}
+
+// segment is a run of probe results with the same phase and status.
+type segment struct {
+	probe, phase, status string
+	from, to             time.Duration
+	checks               int
+	min, max             float64
+	err                  error
+}
+
+// timeline groups the results of each probe into segments, in the order the
+// probes first reported.
+func timeline(results []ProbeResult) []segment {
+	var order []string
+	byProbe := make(map[string][]segment)
+	for _, result := range results {
+		segments, seen := byProbe[result.Probe]
+		if !seen {
+			order = append(order, result.Probe)
+		}
+		if n := len(segments); n > 0 && segments[n-1].phase == result.Phase && segments[n-1].status == result.Status {
+			last := &segments[n-1]
+			last.to = result.Offset
+			last.checks++
+			last.min, last.max = min(last.min, result.Value), max(last.max, result.Value)
+			if result.Err != nil {
+				last.err = result.Err
+			}
+			continue
+		}
+		byProbe[result.Probe] = append(segments, segment{
+			probe: result.Probe, phase: result.Phase, status: result.Status,
+			from: result.Offset, to: result.Offset, checks: 1,
+			min: result.Value, max: result.Value, err: result.Err,
+		})
+	}
+
+	var all []segment
+	for _, probe := range order {
+		all = append(all, byProbe[probe]...)
+	}
+	return all
+}
+
+func (s segment) window() string {
+	if s.from == s.to {
+		return round(s.from).String()
+	}
+	return fmt.Sprintf("%v-%v", round(s.from), round(s.to))
+}
+
+// value shows the range of values of the segment, or its last error.
+func (s segment) value() string {
+	switch {
+	case s.err != nil:
+		return s.err.Error()
+	case s.min == s.max:
+		return strconv.FormatFloat(s.min, 'g', 4, 64)
+	default:
+		return strconv.FormatFloat(s.min, 'g', 4, 64) + "-" + strconv.FormatFloat(s.max, 'g', 4, 64)
+	}
+}
+
+// capitalize upper-cases the first letter of an error message.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// round rounds phase boundaries for display.
+func round(d time.Duration) time.Duration {
+	return d.Round(100 * time.Millisecond)
+}
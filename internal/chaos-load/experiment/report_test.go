@@ -0,0 +1,136 @@
+package experiment
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+// secondSeries builds one-second intervals of 100 requests with the given
+// error counts.
+func secondSeries(errors ...int) []stats.Interval {
+	series := make([]stats.Interval, 0, len(errors))
+	for i, errs := range errors {
+		series = append(series, stats.Interval{
+			Offset:   time.Duration(i) * time.Second,
+			Duration: time.Second,
+			Requests: 100,
+			Errors:   errs,
+			Latency:  stats.LatencyStats{P99: 50 * time.Millisecond},
+		})
+	}
+	return series
+}
+
+func mustConditions(t *testing.T, exprs ...string) []check.Threshold {
+	t.Helper()
+	conditions, err := check.ParseThresholds(exprs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return conditions
+}
+
+func TestTimeToRecover(t *testing.T) {
+	conditions := mustConditions(t, "error_rate < 1%")
+	// The fault ends at 2.5s; errors last until 5s, with a healthy blip at 3s.
+	series := secondSeries(0, 0, 40, 0, 30, 0, 0, 0, 0)
+
+	ttr, ok := timeToRecover(series, 2500*time.Millisecond, 9*time.Second, conditions, 3*time.Second)
+	if !ok || ttr != 2500*time.Millisecond {
+		t.Fatalf("expected recovery 2.5s after the fault, got %v %v", ttr, ok)
+	}
+
+	if _, ok := timeToRecover(series, 2500*time.Millisecond, 7*time.Second, conditions, 3*time.Second); ok {
+		t.Fatal("expected no recovery when the stable window does not fit before the next fault")
+	}
+
+	ttr, ok = timeToRecover(secondSeries(0, 0, 0, 0), 1500*time.Millisecond, 4*time.Second, conditions, 2*time.Second)
+	if !ok || ttr != 0 {
+		t.Fatalf("expected an unaffected target to recover at once, got %v %v", ttr, ok)
+	}
+}
+
+func TestTimeToRecover_LatencyAndIdleIntervals(t *testing.T) {
+	series := secondSeries(0, 0, 0, 0)
+	series[1].Latency.P99 = 2 * time.Second
+	series[2].Requests = 0
+
+	ttr, ok := timeToRecover(series, time.Second, 4*time.Second, mustConditions(t, "p99 < 500ms"), time.Second)
+	if !ok || ttr != 2*time.Second {
+		t.Fatalf("expected slow and idle intervals to count as unhealthy, got %v %v", ttr, ok)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	snapshot := stats.SnapshotStats{
+		Elapsed: 10 * time.Second,
+		Phases: []stats.PhaseStats{
+			{Name: PhaseBaseline, End: 2 * time.Second, Requests: 200},
+			{Name: FaultPhase("partition"), Start: 2 * time.Second, End: 4 * time.Second, Requests: 200, Errors: 150},
+			{Name: RecoveryPhase("partition"), Start: 4 * time.Second, End: 8 * time.Second, Requests: 400, Errors: 20},
+			{Name: FaultPhase("kill"), Start: 8 * time.Second, End: 8 * time.Second},
+			{Name: RecoveryPhase("kill"), Start: 8 * time.Second, End: 10 * time.Second, Requests: 200},
+		},
+	}
+	series := secondSeries(0, 0, 100, 50, 20, 0, 0, 0, 0, 0)
+	windows := []Window{
+		{Fault: "partition", Kind: "network-partition", Start: 2 * time.Second, End: 4 * time.Second},
+		{Fault: "kill", Kind: "pod-kill", Start: 8 * time.Second, End: 8 * time.Second, Err: errors.New("no running pods found")},
+	}
+
+	report := Analyze(snapshot, series, windows, mustConditions(t, "error_rate < 1%"), 2*time.Second)
+	if len(report.Faults) != 2 {
+		t.Fatalf("expected 2 faults, got %+v", report.Faults)
+	}
+	partition := report.Faults[0]
+	if !partition.Recovered || partition.TimeToRecover != time.Second || partition.Observed != 4*time.Second {
+		t.Fatalf("unexpected partition recovery: %+v", partition)
+	}
+	if report.Failed() != 1 || report.Unrecovered() != 0 {
+		t.Fatalf("expected one failed fault and none unrecovered, got %d and %d", report.Failed(), report.Unrecovered())
+	}
+
+	var buf bytes.Buffer
+	report.Fprint(&buf)
+	output := buf.String()
+	for _, s := range []string{
+		"=== Experiment Phases ===",
+		"fault: partition",
+		"75.00%",
+		"partition (network-partition): 1s",
+		"kill (pod-kill): not injected: no running pods found",
+	} {
+		if !strings.Contains(output, s) {
+			t.Errorf("expected output to contain %q. Output:\n%s", s, output)
+		}
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	results := []ProbeResult{
+		{Probe: "ratio", Phase: StageBefore, Status: ProbeSteady, Value: 0.01},
+		{Probe: "health", Phase: StageBefore, Status: ProbeSteady, Value: 200},
+		{Probe: "ratio", Phase: PhaseBaseline, Offset: 5 * time.Second, Status: ProbeSteady, Value: 0.01},
+		{Probe: "ratio", Phase: PhaseBaseline, Offset: 10 * time.Second, Status: ProbeSteady, Value: 0.02},
+		{Probe: "ratio", Phase: FaultPhase("kill"), Offset: 15 * time.Second, Status: ProbeDeviated, Value: 0.1},
+		{Probe: "ratio", Phase: FaultPhase("kill"), Offset: 20 * time.Second, Status: ProbeError, Err: errors.New("query returned no data")},
+	}
+
+	segments := timeline(results)
+	if len(segments) != 5 || segments[4].probe != "health" {
+		t.Fatalf("expected 4 ratio segments then health, got %+v", segments)
+	}
+	baseline := segments[1]
+	if baseline.checks != 2 || baseline.window() != "5s-10s" || baseline.value() != "0.01-0.02" {
+		t.Fatalf("unexpected baseline segment: %+v", baseline)
+	}
+	if segments[3].value() != "query returned no data" {
+		t.Fatalf("expected the error as value, got %q", segments[3].value())
+	}
+}
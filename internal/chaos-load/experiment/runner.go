@@ -0,0 +1,365 @@
+package experiment
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	loadhttp "github.com/neogan/sre-toolkit/internal/chaos-load/http"
+	k8schaos "github.com/neogan/sre-toolkit/internal/chaos-load/k8s"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+)
+
+// PhaseBaseline is the phase before the first fault.
+const PhaseBaseline = "baseline"
+
+// FaultPhase names the phase during which a fault is in effect.
+func FaultPhase(fault string) string {
+	return "fault: " + fault
+}
+
+// RecoveryPhase names the phase after a fault, up to the next fault or the
+// end of the load.
+func RecoveryPhase(fault string) string {
+	return "recovery: " + fault
+}
+
+// Injector injects one fault and returns once it is over.
+type Injector interface {
+	Run(ctx context.Context) error
+}
+
+// Reverter is implemented by injectors whose fault outlasts Run, such as a
+// cordoned node. Faults that end with Run, such as a network partition, are
+// rolled back by canceling it.
+type Reverter interface {
+	Revert(ctx context.Context) error
+}
+
+// errAborted is the cause of an experiment stopped by a probe.
+var errAborted = errors.New("experiment aborted")
+
+// rollbackTimeout bounds the rollback of an aborted experiment.
+const rollbackTimeout = 30 * time.Second
+
+// Window is when a fault was in effect, relative to the start of the load.
+type Window struct {
+	Fault string
+	Kind  string
+	Start time.Duration
+	End   time.Duration
+	// Err is set if the fault could not be injected.
+	Err error
+}
+
+// Runner runs the load of an experiment and injects its faults on schedule.
+type Runner struct {
+	experiment *Experiment
+	pool       *loadhttp.Pool
+	injector   func(Fault) Injector
+	out        io.Writer
+}
+
+// New creates a runner injecting faults through client. With dryRun the
+// faults only log what they would do.
+func New(e *Experiment, pool *loadhttp.Pool, client kubernetes.Interface, dryRun bool) *Runner {
+	return &Runner{
+		experiment: e,
+		pool:       pool,
+		injector: func(f Fault) Injector {
+			return newInjector(client, f, dryRun)
+		},
+		out: os.Stdout,
+	}
+}
+
+// newInjector builds the chaos operation of a fault.
+func newInjector(client kubernetes.Interface, f Fault, dryRun bool) Injector {
+	switch {
+	case f.PodKill != nil:
+		return k8schaos.NewPodKiller(client, k8schaos.KillerConfig{
+			Namespace:     f.PodKill.Namespace,
+			LabelSelector: f.PodKill.Selector,
+			GracePeriod:   f.PodKill.GracePeriod,
+			Interval:      f.PodKill.Interval,
+			Count:         f.PodKill.Count,
+			DryRun:        dryRun,
+		})
+	case f.NetworkPartition != nil:
+		return k8schaos.NewNetworkPartition(client, k8schaos.NetworkPartitionConfig{
+			Namespace:     f.NetworkPartition.Namespace,
+			LabelSelector: f.NetworkPartition.Selector,
+			Duration:      f.NetworkPartition.Duration,
+			DryRun:        dryRun,
+		})
+	default:
+		return nodeDrain{k8schaos.NewNodeDrainer(client, k8schaos.DrainerConfig{
+			NodeName:           f.NodeDrain.Node,
+			GracePeriod:        f.NodeDrain.GracePeriod,
+			Timeout:            f.NodeDrain.Timeout,
+			IgnoreDaemonSets:   true,
+			DeleteEmptyDirData: f.NodeDrain.DeleteEmptyDirData,
+			DryRun:             dryRun,
+			Duration:           f.NodeDrain.Duration,
+			Concurrency:        f.NodeDrain.Concurrency,
+		})}
+	}
+}
+
+// nodeDrain rolls a drain back by uncordoning the node.
+type nodeDrain struct {
+	*k8schaos.NodeDrainer
+}
+
+// Revert uncordons the drained node.
+func (d nodeDrain) Revert(ctx context.Context) error {
+	return d.Uncordon(ctx)
+}
+
+// Run checks the steady state, generates the load while injecting the faults
+// and probing the target, then prints the report. It fails if the steady
+// state is not met before or after the load, a probe breaches its hard limit,
+// the load test fails, a fault cannot be injected or the target does not
+// recover from a fault.
+func (r *Runner) Run(ctx context.Context) error {
+	collector := r.pool.Collector()
+	probes := r.experiment.Probes
+	prober, err := newProber(r.experiment)
+	if err != nil {
+		return err
+	}
+
+	before := r.probeAll(ctx, prober, StageBefore, 0)
+	if deviated := unsteady(before); deviated > 0 {
+		Report{Probes: before}.Fprint(r.out)
+		return fmt.Errorf("steady state not met before the experiment: %d of %d probes deviated", deviated, len(probes))
+	}
+
+	// Faults and probes are scheduled from the start of the load, not from
+	// the creation of the pool or the probes before it.
+	collector.Begin()
+	collector.SetPhase(PhaseBaseline)
+	loadCtx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+
+	faultCtx, stopFaults := context.WithCancel(loadCtx)
+	defer stopFaults()
+	injected := make(chan injection, 1)
+	go func() {
+		injected <- r.inject(faultCtx, collector)
+	}()
+
+	probeCtx, stopProbes := context.WithCancel(loadCtx)
+	defer stopProbes()
+	watched := make(chan []ProbeResult, 1)
+	go func() {
+		watched <- r.watch(probeCtx, prober, collector, abort)
+	}()
+
+	loadErr := r.pool.RunContext(loadCtx)
+	// A fault still running when the load ends is stopped, which ends a
+	// network partition early.
+	stopFaults()
+	stopProbes()
+	done := <-injected
+	during := <-watched
+
+	// The load ends early when a probe aborts the experiment or ctx ends,
+	// such as on an interrupt. Either way the faults are rolled back.
+	var aborted error
+	if cause := context.Cause(loadCtx); cause != nil {
+		aborted = cause
+		if !errors.Is(cause, errAborted) {
+			aborted = fmt.Errorf("%w: %w", errAborted, cause)
+		}
+		r.rollback(ctx, done.reverters)
+	}
+	// An interrupted experiment is not probed again.
+	var after []ProbeResult
+	if ctx.Err() == nil {
+		after = r.probeAll(ctx, prober, StageAfter, time.Since(collector.Start()))
+	}
+
+	report := Analyze(collector.Snapshot(), collector.Series(), done.windows, r.experiment.conditions, r.experiment.Recovery.Stable)
+	report.Probes = append(append(before, during...), after...)
+	report.Aborted = aborted
+	report.Fprint(r.out)
+
+	var errs []error
+	if aborted != nil {
+		errs = append(errs, aborted)
+	}
+	if loadErr != nil {
+		errs = append(errs, loadErr)
+	}
+	if failed := report.Failed(); failed > 0 {
+		errs = append(errs, fmt.Errorf("%d of %d faults could not be injected", failed, len(done.windows)))
+	}
+	// An abort cuts the recovery short, so it is not judged.
+	if unrecovered := report.Unrecovered(); unrecovered > 0 && aborted == nil {
+		errs = append(errs, fmt.Errorf("the target did not recover from %d of %d faults", unrecovered, len(done.windows)))
+	}
+	if deviated := unsteady(after); deviated > 0 {
+		errs = append(errs, fmt.Errorf("steady state not restored after the experiment: %d of %d probes deviated", deviated, len(probes)))
+	}
+	return errors.Join(errs...)
+}
+
+// injection is what inject leaves behind: the fault windows and the faults
+// to revert if the experiment is aborted.
+type injection struct {
+	windows   []Window
+	reverters []Reverter
+}
+
+// inject runs the faults one after another, each at its offset from the
+// start of the load, and returns their windows. A fault that fails is logged
+// and the experiment carries on with the next one.
+func (r *Runner) inject(ctx context.Context, collector *stats.Collector) injection {
+	logger := logging.GetLogger()
+	start := collector.Start()
+
+	var done injection
+	for _, fault := range r.experiment.Faults {
+		if !sleepUntil(ctx, start.Add(fault.At)) {
+			break
+		}
+
+		logger.Info().Str("fault", fault.Name).Str("kind", fault.Kind()).Msg("Injecting fault")
+		collector.SetPhase(FaultPhase(fault.Name))
+		window := Window{Fault: fault.Name, Kind: fault.Kind(), Start: time.Since(start)}
+		injector := r.injector(fault)
+		if reverter, ok := injector.(Reverter); ok {
+			done.reverters = append(done.reverters, reverter)
+		}
+		err := injector.Run(ctx)
+		window.End = time.Since(start)
+		collector.SetPhase(RecoveryPhase(fault.Name))
+
+		if err != nil && ctx.Err() == nil {
+			window.Err = err
+			logger.Error().Err(err).Str("fault", fault.Name).Msg("Failed to inject fault")
+		} else {
+			logger.Info().Str("fault", fault.Name).Dur("duration", window.End-window.Start).Msg("Fault ended")
+		}
+		done.windows = append(done.windows, window)
+	}
+	return done
+}
+
+// rollback reverts the faults that outlast their injection. It runs after the
+// load ended early, so it must not depend on the canceled contexts.
+func (r *Runner) rollback(ctx context.Context, reverters []Reverter) {
+	logger := logging.GetLogger()
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+	for _, reverter := range reverters {
+		if err := reverter.Revert(ctx); err != nil {
+			logger.Error().Err(err).Msg("Failed to roll back fault")
+		}
+	}
+}
+
+// probeAll runs every probe once, for the steady-state checks before and
+// after the load.
+func (r *Runner) probeAll(ctx context.Context, prober *prober, stage string, offset time.Duration) []ProbeResult {
+	results := make([]ProbeResult, 0, len(r.experiment.Probes))
+	for i := range r.experiment.Probes {
+		result := prober.run(ctx, &r.experiment.Probes[i])
+		result.Stage, result.Phase, result.Offset = stage, stage, offset
+		results = append(results, result)
+	}
+	return results
+}
+
+// watch runs every probe at its interval until ctx ends and returns the
+// results in time order. A probe breaching its hard limit AbortAfter times in
+// a row aborts the experiment.
+func (r *Runner) watch(ctx context.Context, prober *prober, collector *stats.Collector, abort context.CancelCauseFunc) []ProbeResult {
+	logger := logging.GetLogger()
+	start := collector.Start()
+
+	var (
+		mu      sync.Mutex
+		results []ProbeResult
+		wg      sync.WaitGroup
+	)
+	for i := range r.experiment.Probes {
+		probe := &r.experiment.Probes[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(probe.Interval)
+			defer ticker.Stop()
+
+			breaches := 0
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+
+				phase := collector.Phase()
+				result := prober.run(ctx, probe)
+				if ctx.Err() != nil {
+					// The probe was cut short by the end of the load.
+					return
+				}
+				result.Stage, result.Phase, result.Offset = StageDuring, phase, time.Since(start)
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+
+				if result.Status != ProbeBreached {
+					breaches = 0
+					continue
+				}
+				breaches++
+				logger.Warn().Str("probe", probe.Name).Str("limit", probe.Limit()).Int("breaches", breaches).Msg("Probe breached its hard limit")
+				if breaches >= probe.AbortAfter {
+					abort(fmt.Errorf("%w: probe %s breached its hard limit (%s) at %v", errAborted, probe.Name, probe.Limit(), round(result.Offset)))
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	slices.SortStableFunc(results, func(a, b ProbeResult) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+	return results
+}
+
+// unsteady returns the number of results that did not meet the steady state.
+func unsteady(results []ProbeResult) int {
+	n := 0
+	for _, result := range results {
+		if result.Status != ProbeSteady {
+			n++
+		}
+	}
+	return n
+}
+
+// sleepUntil waits for t and reports false if ctx ends first.
+func sleepUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
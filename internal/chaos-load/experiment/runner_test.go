@@ -0,0 +1,341 @@
+package experiment
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	loadhttp "github.com/neogan/sre-toolkit/internal/chaos-load/http"
+)
+
+// outage makes the test server fail while it runs.
+type outage struct {
+	failing  *atomic.Bool
+	duration time.Duration
+}
+
+func (o outage) Run(ctx context.Context) error {
+	o.failing.Store(true)
+	defer o.failing.Store(false)
+	select {
+	case <-ctx.Done():
+	case <-time.After(o.duration):
+	}
+	return nil
+}
+
+// revertedOutage is an outage whose rollback is recorded.
+type revertedOutage struct {
+	outage
+	reverted *atomic.Bool
+}
+
+func (o revertedOutage) Revert(context.Context) error {
+	o.reverted.Store(true)
+	return nil
+}
+
+func TestRunnerRun(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	doc := `
+name: outage
+load:
+  url: ` + server.URL + `
+  concurrency: 2
+  rate: 50/s
+  duration: 3500ms
+  checks:
+    status: [200]
+faults:
+  - name: outage
+    at: 1s
+    pod_kill: {}
+recovery:
+  stable: 1s
+`
+	e, err := Parse([]byte(doc), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pool, err := loadhttp.NewPool(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	r := New(e, pool, fake.NewSimpleClientset(), false)
+	r.injector = func(Fault) Injector { return outage{failing: &failing, duration: 500 * time.Millisecond} }
+	r.out = &out
+
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Run() failed: %v\n%s", err, out.String())
+	}
+
+	phases := pool.Collector().Snapshot().Phases
+	if len(phases) != 3 || phases[0].Name != PhaseBaseline || phases[1].Name != FaultPhase("outage") {
+		t.Fatalf("unexpected phases: %+v", phases)
+	}
+	if phases[1].Start < time.Second || phases[1].End-phases[1].Start < 500*time.Millisecond {
+		t.Fatalf("expected the fault window at 1s for 500ms, got %v-%v", phases[1].Start, phases[1].End)
+	}
+	if phases[0].FailedChecks != 0 || phases[1].FailedChecks == 0 {
+		t.Fatalf("expected failed checks only during the fault, got %+v and %+v", phases[0], phases[1])
+	}
+	if !strings.Contains(out.String(), "outage (pod-kill): ") || strings.Contains(out.String(), "not recovered") {
+		t.Fatalf("expected a time to recover. Output:\n%s", out.String())
+	}
+}
+
+// timedFault records when it was injected.
+type timedFault struct {
+	at *atomic.Int64
+}
+
+func (f timedFault) Run(context.Context) error {
+	f.at.Store(time.Now().UnixNano())
+	return nil
+}
+
+func TestRunnerRunSchedulesFaultsFromLoadStart(t *testing.T) {
+	var firstRequest atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			time.Sleep(300 * time.Millisecond) // a slow probe before the load
+			return
+		}
+		firstRequest.CompareAndSwap(0, time.Now().UnixNano())
+	}))
+	defer server.Close()
+
+	e, err := Parse([]byte("load:\n  url: "+server.URL+"\n  concurrency: 2\n  rate: 20/s\n  duration: 1s\n"+
+		"faults:\n  - name: kill\n    at: 200ms\n    pod_kill: {}\n"+
+		"recovery:\n  stable: 100ms\n"+
+		"probes:\n  - name: health\n    interval: 1m\n    http:\n      url: "+server.URL+"/health\n"), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pool, err := loadhttp.NewPool(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var injected atomic.Int64
+	r := New(e, pool, fake.NewSimpleClientset(), false)
+	r.injector = func(Fault) Injector { return timedFault{at: &injected} }
+	r.out = &bytes.Buffer{}
+
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if injected.Load() == 0 || firstRequest.Load() == 0 {
+		t.Fatal("expected the load to run and the fault to be injected")
+	}
+	// Measured from the creation of the pool, the slow probe would have used
+	// up the offset and the fault would be injected with the first requests.
+	if offset := time.Duration(injected.Load() - firstRequest.Load()); offset < 150*time.Millisecond {
+		t.Fatalf("expected the fault 200ms into the load, injected after %v", offset)
+	}
+}
+
+func TestRunnerRunFailsWithoutRecovery(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	e, err := Parse([]byte("load:\n  url: "+server.URL+"\n  concurrency: 2\n  rate: 50/s\n  duration: 3s\n"+
+		"  checks: {status: [200]}\nfaults:\n  - at: 500ms\n    pod_kill: {}\nrecovery:\n  stable: 1s\n"), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pool, err := loadhttp.NewPool(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	r := New(e, pool, fake.NewSimpleClientset(), false)
+	// The fault ends after a second, but the target keeps failing.
+	r.injector = func(Fault) Injector {
+		failing.Store(true)
+		return outage{failing: &atomic.Bool{}, duration: time.Second}
+	}
+	r.out = &out
+
+	err = r.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "did not recover") {
+		t.Fatalf("expected the experiment to fail without recovery, got %v\n%s", err, out.String())
+	}
+}
+
+func TestRunnerRunAbortsOnProbeBreach(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	e, err := Parse([]byte("load:\n  url: "+server.URL+"\n  concurrency: 2\n  rate: 20/s\n  duration: 10s\n"+
+		"faults:\n  - name: drain\n    at: 500ms\n    node_drain: {node: worker-1}\n"+
+		"probes:\n  - name: health\n    interval: 200ms\n    abort_after: 2\n    http:\n      url: "+server.URL+"\n      abort_on_failure: true\n"), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pool, err := loadhttp.NewPool(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	var reverted atomic.Bool
+	r := New(e, pool, fake.NewSimpleClientset(), false)
+	r.injector = func(Fault) Injector {
+		return revertedOutage{outage: outage{failing: &failing, duration: time.Minute}, reverted: &reverted}
+	}
+	r.out = &out
+
+	start := time.Now()
+	err = r.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "probe health breached its hard limit") {
+		t.Fatalf("expected the experiment to be aborted, got %v\n%s", err, out.String())
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the load to stop early, ran for %v", elapsed)
+	}
+	if !reverted.Load() {
+		t.Fatal("expected the drain to be rolled back")
+	}
+	if strings.Contains(err.Error(), "steady state not restored") || strings.Contains(err.Error(), "did not recover") {
+		t.Fatalf("expected only the abort to fail the experiment, got %v", err)
+	}
+	for _, s := range []string{
+		"Experiment aborted: probe health breached its hard limit",
+		"Steady-State Probes:",
+		"health  before",
+		"fault: drain",
+		"breached",
+		"unexpected status 503",
+		"drain (node-drain): not recovered before the abort",
+	} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("expected output to contain %q. Output:\n%s", s, out.String())
+		}
+	}
+}
+
+func TestRunnerRunRollsBackWhenInterrupted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	e, err := Parse([]byte("load:\n  url: "+server.URL+"\n  concurrency: 2\n  rate: 20/s\n  duration: 10s\n"+
+		"faults:\n  - name: drain\n    at: 200ms\n    node_drain: {node: worker-1}\n"), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pool, err := loadhttp.NewPool(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	var failing, reverted atomic.Bool
+	r := New(e, pool, fake.NewSimpleClientset(), false)
+	r.injector = func(Fault) Injector {
+		return revertedOutage{outage: outage{failing: &failing, duration: time.Minute}, reverted: &reverted}
+	}
+	r.out = &out
+
+	// An interrupt cancels the context of the experiment.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	err = r.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "experiment aborted") {
+		t.Fatalf("expected the experiment to be aborted, got %v\n%s", err, out.String())
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the load to stop early, ran for %v", elapsed)
+	}
+	if !reverted.Load() {
+		t.Fatal("expected the drain to be rolled back")
+	}
+	if !strings.Contains(out.String(), "faults were rolled back") {
+		t.Errorf("expected the report to tell about the rollback. Output:\n%s", out.String())
+	}
+}
+
+func TestRunnerRunChecksSteadyStateFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	e, err := Parse([]byte("load:\n  url: "+server.URL+"\n  duration: 5s\nfaults:\n  - pod_kill: {}\n"+
+		"probes:\n  - http: {url: "+server.URL+"}\n"), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := e.PoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pool, err := loadhttp.NewPool(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	r := New(e, pool, fake.NewSimpleClientset(), false)
+	r.injector = func(Fault) Injector {
+		t.Error("expected no fault to be injected")
+		return outage{failing: &atomic.Bool{}}
+	}
+	r.out = &out
+
+	err = r.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "steady state not met before the experiment") {
+		t.Fatalf("expected the steady-state check to fail, got %v", err)
+	}
+	if pool.Collector().Snapshot().TotalRequests != 0 {
+		t.Fatal("expected no load to be generated")
+	}
+}
@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// resolver finds descriptors by their full name.
+type resolver interface {
+	FindDescriptorByName(protoreflect.FullName) (protoreflect.Descriptor, error)
+}
+
+// LoadProtos compiles .proto files. Files and their imports are looked up in
+// importPaths, or relative to the working directory if none are given.
+func LoadProtos(ctx context.Context, files, importPaths []string) (*protoregistry.Files, error) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: importPaths}),
+	}
+	compiled, err := compiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile proto files: %w", err)
+	}
+
+	registry := new(protoregistry.Files)
+	for _, file := range compiled {
+		if err := registry.RegisterFile(file); err != nil {
+			return nil, fmt.Errorf("failed to register %s: %w", file.Path(), err)
+		}
+	}
+	return registry, nil
+}
+
+// ReflectProtos asks the server, over gRPC server reflection, for the file
+// defining service and every file it depends on.
+func ReflectProtos(ctx context.Context, conn grpc.ClientConnInterface, service string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("server reflection failed: %w", err)
+	}
+	defer stream.CloseSend() //nolint:errcheck // the stream is only read from until here
+
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	request := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}
+	var pending []string
+	for request != nil {
+		files, err := reflectFiles(stream, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if seen[file.GetName()] {
+				continue
+			}
+			seen[file.GetName()] = true
+			set.File = append(set.File, file)
+			pending = append(pending, file.GetDependency()...)
+		}
+
+		request = nil
+		for len(pending) > 0 && request == nil {
+			name := pending[0]
+			pending = pending[1:]
+			if !seen[name] {
+				request = &reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+				}
+			}
+		}
+	}
+
+	registry, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptors from server reflection: %w", err)
+	}
+	return registry, nil
+}
+
+// reflectFiles sends one reflection request and decodes the files in the response.
+func reflectFiles(stream reflectionpb.ServerReflection_ServerReflectionInfoClient, request *reflectionpb.ServerReflectionRequest) ([]*descriptorpb.FileDescriptorProto, error) {
+	if err := stream.Send(request); err != nil {
+		return nil, fmt.Errorf("server reflection failed: %w", err)
+	}
+	resp, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("server reflection failed: stream closed")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("server reflection failed: %w", err)
+	}
+	if e := resp.GetErrorResponse(); e != nil {
+		return nil, fmt.Errorf("server reflection failed: %s", e.GetErrorMessage())
+	}
+
+	var files []*descriptorpb.FileDescriptorProto
+	for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("invalid descriptor from server reflection: %w", err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// splitMethod splits "pkg.Service/Method" or "pkg.Service.Method" into the
+// service and method names.
+func splitMethod(name string) (string, string, error) {
+	name = strings.TrimPrefix(name, "/")
+	i := strings.LastIndexAny(name, "/.")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", fmt.Errorf("invalid method %q: expected package.Service/Method", name)
+	}
+	return name[:i], name[i+1:], nil
+}
+
+// findMethod looks up a unary method by name.
+func findMethod(r resolver, name string) (protoreflect.MethodDescriptor, error) {
+	service, method, err := splitMethod(name)
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := r.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found: %w", service, err)
+	}
+	sd, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("service %s has no method %s", service, method)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, fmt.Errorf("method %s is streaming; only unary methods are supported", name)
+	}
+	return md, nil
+}
@@ -0,0 +1,200 @@
+// Package grpc provides gRPC load testing functionality for chaos engineering.
+// Messages are encoded dynamically from descriptors obtained by server
+// reflection or compiled from .proto files.
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+// PoolConfig holds configuration for the gRPC worker pool
+type PoolConfig struct {
+	runner.Config
+
+	// Target is the server address, host:port.
+	Target string
+	// Method is the unary method to call, package.Service/Method.
+	Method string
+	// Data is the request message in protobuf JSON; empty sends an empty message.
+	Data string
+	// Metadata is sent with every call.
+	Metadata map[string]string
+	// ProtoFiles describe the service; without them the descriptors are
+	// fetched by server reflection. ImportPaths are searched for the files.
+	ProtoFiles  []string
+	ImportPaths []string
+	// Plaintext disables TLS; Insecure keeps TLS but skips verification.
+	Plaintext bool
+	Insecure  bool
+	// Connections spreads the calls over several connections.
+	Connections int
+	// Timeout is the deadline of each call. Zero means no deadline.
+	Timeout time.Duration
+}
+
+// Validate checks the pool configuration.
+func (c PoolConfig) Validate() error {
+	if c.Target == "" {
+		return fmt.Errorf("target is required")
+	}
+	if c.Method == "" {
+		return fmt.Errorf("method is required")
+	}
+	if c.Plaintext && c.Insecure {
+		return fmt.Errorf("--plaintext and --insecure cannot be used together")
+	}
+	if c.Connections < 0 {
+		return fmt.Errorf("connections must not be negative")
+	}
+	return c.Config.Validate()
+}
+
+// Pool manages a pool of gRPC workers
+type Pool struct {
+	config     PoolConfig
+	conns      []*grpc.ClientConn
+	next       atomic.Uint64
+	fullMethod string
+	method     protoreflect.MethodDescriptor
+	request    proto.Message
+	collector  *stats.Collector
+}
+
+// NewPool connects to the target, resolves the method and encodes the request.
+func NewPool(ctx context.Context, cfg PoolConfig) (*Pool, error) {
+	creds := insecure.NewCredentials()
+	if !cfg.Plaintext {
+		creds = credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: cfg.Insecure, // #nosec G402
+		})
+	}
+
+	p := &Pool{config: cfg, collector: stats.NewCollector()}
+	connections := max(cfg.Connections, 1)
+	for i := 0; i < connections; i++ {
+		conn, err := grpc.NewClient(cfg.Target, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			p.Close() //nolint:errcheck,gosec // the dial error is reported instead
+			return nil, fmt.Errorf("failed to connect to %s: %w", cfg.Target, err)
+		}
+		p.conns = append(p.conns, conn)
+	}
+
+	if err := p.resolve(ctx); err != nil {
+		p.Close() //nolint:errcheck,gosec // the resolve error is reported instead
+		return nil, err
+	}
+	return p, nil
+}
+
+// resolve finds the method descriptor and encodes the request message.
+func (p *Pool) resolve(ctx context.Context) error {
+	var r resolver
+	if len(p.config.ProtoFiles) > 0 {
+		files, err := LoadProtos(ctx, p.config.ProtoFiles, p.config.ImportPaths)
+		if err != nil {
+			return err
+		}
+		r = files
+	} else {
+		service, _, err := splitMethod(p.config.Method)
+		if err != nil {
+			return err
+		}
+		files, err := ReflectProtos(ctx, p.conns[0], service)
+		if err != nil {
+			return fmt.Errorf("%w (use --proto if the server does not support reflection)", err)
+		}
+		r = files
+	}
+
+	method, err := findMethod(r, p.config.Method)
+	if err != nil {
+		return err
+	}
+	p.method = method
+	p.fullMethod = fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+
+	request := dynamicpb.NewMessage(method.Input())
+	if strings.TrimSpace(p.config.Data) != "" {
+		if err := protojson.Unmarshal([]byte(p.config.Data), request); err != nil {
+			return fmt.Errorf("invalid request data for %s: %w", method.Input().FullName(), err)
+		}
+	}
+	p.request = request
+	return nil
+}
+
+// Run starts the load test
+func (p *Pool) Run() error {
+	return p.RunContext(context.Background())
+}
+
+// RunContext starts the load test and stops it early when ctx ends.
+func (p *Pool) RunContext(ctx context.Context) error {
+	defer p.Close() //nolint:errcheck // connections are closed on exit
+	p.collector.SetStatusText(func(code int) string {
+		return codes.Code(code).String() //nolint:gosec // gRPC codes are small non-negative numbers
+	})
+	return runner.New(p.config.Config, p.collector, p.send).RunContext(ctx)
+}
+
+// Close closes the connections to the target.
+func (p *Pool) Close() error {
+	var firstErr error
+	for _, conn := range p.conns {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	p.conns = nil
+	return firstErr
+}
+
+// send performs one call and records its status code and latency measured
+// from start. Calls that end with a status other than OK count as errors.
+func (p *Pool) send(ctx context.Context, recorder *stats.Recorder, start time.Time) {
+	ctx, cancel := p.callContext(ctx)
+	defer cancel()
+	if len(p.config.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(p.config.Metadata))
+	}
+
+	conn := p.conns[(p.next.Add(1)-1)%uint64(len(p.conns))]
+	response := dynamicpb.NewMessage(p.method.Output())
+	err := conn.Invoke(ctx, p.fullMethod, p.request, response)
+
+	recorder.Add(stats.Result{
+		StatusCode: int(status.Code(err)),
+		Duration:   time.Since(start),
+		Error:      err,
+	})
+}
+
+// callContext returns the context of one call, bounded by the configured
+// timeout unless it is zero.
+func (p *Pool) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.config.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.config.Timeout)
+}
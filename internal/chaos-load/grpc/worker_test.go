@@ -0,0 +1,200 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/mock"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
+)
+
+// startMock serves the gRPC mock service on a free port and returns its address.
+func startMock(t *testing.T, cfg mock.ServerConfig) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0") //nolint:noctx // ListenConfig not needed for test server
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	cfg.GRPC = true
+	server := mock.NewServer(cfg)
+	go server.ServeGRPC(lis) //nolint:errcheck // the server stops with the test
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func newTestPool(t *testing.T, cfg PoolConfig) *Pool {
+	t.Helper()
+	cfg.Plaintext = true
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = 2
+	}
+	if cfg.Duration == 0 {
+		cfg.Duration = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool, err := NewPool(ctx, cfg)
+	if err != nil {
+		t.Fatalf("NewPool() failed: %v", err)
+	}
+	return pool
+}
+
+func TestPoolRunResolvesMethodByReflection(t *testing.T) {
+	target := startMock(t, mock.ServerConfig{})
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{Requests: 5},
+		Target: target,
+		Method: mock.GRPCMethod,
+		Data:   `{"message": "hello"}`,
+	})
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+
+	snapshot := pool.collector.Snapshot()
+	if snapshot.TotalRequests != 5 || snapshot.Errors != 0 {
+		t.Fatalf("expected 5 successful calls, got %d with %d errors", snapshot.TotalRequests, snapshot.Errors)
+	}
+	if snapshot.StatusCodes[int(codes.OK)] != 5 {
+		t.Fatalf("expected 5 OK codes, got %v", snapshot.StatusCodes)
+	}
+	if got := pool.collector.StatusLabel(int(codes.OK)); got != "0 OK" {
+		t.Fatalf("expected gRPC code names in reports, got %q", got)
+	}
+}
+
+func TestPoolRunRecordsErrorCodes(t *testing.T) {
+	target := startMock(t, mock.ServerConfig{ErrorRate: 100, GRPCErrorCode: "RESOURCE_EXHAUSTED"})
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{Requests: 4},
+		Target: target,
+		Method: mock.GRPCMethod,
+	})
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+
+	snapshot := pool.collector.Snapshot()
+	if snapshot.Errors != 4 {
+		t.Fatalf("expected 4 errors, got %d", snapshot.Errors)
+	}
+	if snapshot.StatusCodes[int(codes.ResourceExhausted)] != 4 {
+		t.Fatalf("expected 4 RESOURCE_EXHAUSTED codes, got %v", snapshot.StatusCodes)
+	}
+}
+
+func TestPoolCallContextTimeout(t *testing.T) {
+	for _, timeout := range []time.Duration{0, 2 * time.Second} {
+		pool := &Pool{config: PoolConfig{Timeout: timeout}}
+		ctx, cancel := pool.callContext(context.Background())
+		deadline, ok := ctx.Deadline()
+		cancel()
+		if ok != (timeout > 0) {
+			t.Fatalf("timeout %v: expected a deadline only for a non-zero timeout, got %v", timeout, ok)
+		}
+		if ok && time.Until(deadline) > timeout {
+			t.Errorf("timeout %v: deadline is %v away", timeout, time.Until(deadline))
+		}
+	}
+}
+
+func TestNewPoolLoadsProtoFiles(t *testing.T) {
+	dir := t.TempDir()
+	proto := `syntax = "proto3";
+package chaosload.mock.v1;
+service MockService {
+  rpc Echo(EchoRequest) returns (EchoResponse);
+}
+message EchoRequest { string message = 1; }
+message EchoResponse { string message = 1; }
+`
+	if err := os.WriteFile(filepath.Join(dir, "echo.proto"), []byte(proto), 0o600); err != nil {
+		t.Fatalf("failed to write proto: %v", err)
+	}
+
+	target := startMock(t, mock.ServerConfig{})
+	pool := newTestPool(t, PoolConfig{
+		Config:      runner.Config{Requests: 1},
+		Target:      target,
+		Method:      "chaosload.mock.v1.MockService.Echo",
+		Data:        `{"message": "hello"}`,
+		ProtoFiles:  []string{"echo.proto"},
+		ImportPaths: []string{dir},
+	})
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+	if got := pool.collector.Snapshot().StatusCodes[int(codes.OK)]; got != 1 {
+		t.Fatalf("expected 1 OK call, got %d", got)
+	}
+}
+
+func TestNewPoolRejectsInvalidRequests(t *testing.T) {
+	target := startMock(t, mock.ServerConfig{})
+	cases := []struct {
+		name   string
+		method string
+		data   string
+		want   string
+	}{
+		{"unknown method", mock.GRPCService + "/Missing", "", "has no method Missing"},
+		{"unknown service", "chaosload.Missing/Echo", "", "server reflection failed"},
+		{"invalid data", mock.GRPCMethod, `{"unknown": 1}`, "invalid request data"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := NewPool(ctx, PoolConfig{Target: target, Method: tc.method, Data: tc.data, Plaintext: true})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestSplitMethod(t *testing.T) {
+	for _, name := range []string{"pkg.Svc/Method", "/pkg.Svc/Method", "pkg.Svc.Method"} {
+		service, method, err := splitMethod(name)
+		if err != nil || service != "pkg.Svc" || method != "Method" {
+			t.Errorf("splitMethod(%q) = %q, %q, %v", name, service, method, err)
+		}
+	}
+	for _, name := range []string{"Method", "pkg.Svc/", ""} {
+		if _, _, err := splitMethod(name); err == nil {
+			t.Errorf("expected splitMethod(%q) to fail", name)
+		}
+	}
+}
+
+func TestPoolConfigValidate(t *testing.T) {
+	cases := []struct {
+		cfg  PoolConfig
+		want string
+	}{
+		{PoolConfig{Method: "pkg.Svc/M"}, "target is required"},
+		{PoolConfig{Target: "localhost:50051"}, "method is required"},
+		{PoolConfig{Target: "localhost:50051", Method: "pkg.Svc/M", Plaintext: true, Insecure: true}, "cannot be used together"},
+		{PoolConfig{Target: "localhost:50051", Method: "pkg.Svc/M", Config: runner.Config{Rate: -1}}, "rate must not be negative"},
+	}
+	for _, tc := range cases {
+		err := tc.cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("expected error containing %q, got %v", tc.want, err)
+		}
+	}
+	if err := (PoolConfig{Target: "localhost:50051", Method: "pkg.Svc/M"}).Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+// ClientConfig controls how requests reach the target.
+type ClientConfig struct {
+	// Timeout bounds each request, including reading the response body.
+	// Zero means no timeout.
+	Timeout time.Duration
+	// HTTP2 negotiates HTTP/2 over TLS; H2C speaks HTTP/2 without TLS to
+	// http:// targets. Requests use HTTP/1.1 otherwise.
+	HTTP2 bool
+	H2C   bool
+	// Insecure skips TLS certificate verification.
+	Insecure bool
+	// CACert verifies the target against a custom CA bundle.
+	CACert string
+	// ClientCert and ClientKey authenticate the client with mTLS.
+	ClientCert string
+	ClientKey  string
+	// DisableKeepAlive opens a new connection for every request, to simulate
+	// connection storms.
+	DisableKeepAlive bool
+	// MaxConnsPerHost caps the connections to the target; zero is unlimited.
+	MaxConnsPerHost int
+	// Proxy sends requests through an HTTP proxy.
+	Proxy string
+}
+
+// Validate checks the client settings.
+func (c ClientConfig) Validate() error {
+	if c.HTTP2 && c.H2C {
+		return fmt.Errorf("--http2 and --h2c cannot be used together")
+	}
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return fmt.Errorf("client certificate requires both --client-cert and --client-key")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	if c.MaxConnsPerHost < 0 {
+		return fmt.Errorf("max-conns-per-host must not be negative")
+	}
+	if c.Proxy != "" {
+		if _, err := url.Parse(c.Proxy); err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+	}
+	return nil
+}
+
+// newClient builds the HTTP client. maxIdle is the number of idle
+// connections kept for reuse, normally the concurrency.
+func newClient(cfg ClientConfig, maxIdle int) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.Insecure, // #nosec G402
+	}
+	if cfg.CACert != "" {
+		pem, err := os.ReadFile(cfg.CACert) //nolint:gosec // path is provided by the operator
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CACert)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if cfg.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	protocols := new(http.Protocols)
+	switch {
+	case cfg.H2C:
+		protocols.SetUnencryptedHTTP2(true)
+	case cfg.HTTP2:
+		protocols.SetHTTP2(true)
+	default:
+		protocols.SetHTTP1(true)
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig:     tlsConfig,
+		Protocols:           protocols,
+		MaxIdleConns:        maxIdle,
+		MaxIdleConnsPerHost: maxIdle,
+		MaxConnsPerHost:     cfg.MaxConnsPerHost,
+		IdleConnTimeout:     90 * time.Second,
+		DisableKeepAlives:   cfg.DisableKeepAlive,
+	}
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}, nil
+}
+
+// trace measures the timing breakdown of one request.
+type trace struct {
+	mu           sync.Mutex
+	getConn      time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timing       stats.Timing
+}
+
+// withTrace returns the request with a trace attached.
+func withTrace(req *http.Request) (*http.Request, *trace) {
+	t := &trace{}
+	ct := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.getConn = time.Now()
+		},
+		// Dual-stack dialing may start several connects; the first that
+		// succeeds is measured from the first that started.
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if err == nil && t.timing.Connect == 0 {
+				t.timing.Connect = time.Since(t.connectStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if err == nil {
+				t.timing.TLSHandshake = time.Since(t.tlsStart)
+			}
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.getConn.IsZero() {
+				t.timing.TTFB = time.Since(t.getConn)
+			}
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), ct)), t
+}
+
+// result returns the timing measured so far.
+func (t *trace) result() stats.Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timing
+}
@@ -0,0 +1,256 @@
+package http
+
+import (
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
+)
+
+func TestClientConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ClientConfig
+		wantErr bool
+	}{
+		{name: "defaults", cfg: ClientConfig{}},
+		{name: "mtls", cfg: ClientConfig{ClientCert: "client.pem", ClientKey: "client-key.pem"}},
+		{name: "http2 and h2c", cfg: ClientConfig{HTTP2: true, H2C: true}, wantErr: true},
+		{name: "cert without key", cfg: ClientConfig{ClientCert: "client.pem"}, wantErr: true},
+		{name: "key without cert", cfg: ClientConfig{ClientKey: "client-key.pem"}, wantErr: true},
+		{name: "negative timeout", cfg: ClientConfig{Timeout: -time.Second}, wantErr: true},
+		{name: "negative max conns", cfg: ClientConfig{MaxConnsPerHost: -1}, wantErr: true},
+		{name: "invalid proxy", cfg: ClientConfig{Proxy: "http://proxy:port"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewPoolRejectsMissingCACert(t *testing.T) {
+	_, err := NewPool(PoolConfig{
+		Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 1},
+		TargetURL: "https://example.com",
+		Client:    ClientConfig{CACert: filepath.Join(t.TempDir(), "missing.pem")},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a missing CA certificate")
+	}
+}
+
+// writeCACert writes the certificate of a TLS test server as a PEM bundle.
+func writeCACert(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("failed to write CA certificate: %v", err)
+	}
+	return path
+}
+
+func TestPoolRunSpeaksHTTP2OverTLS(t *testing.T) {
+	var http2 atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 {
+			http2.Add(1)
+		}
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	pool := mustNewPool(t, PoolConfig{
+		Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 3},
+		TargetURL: server.URL,
+		Client:    ClientConfig{HTTP2: true, CACert: writeCACert(t, server)},
+	})
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	snapshot := pool.collector.Snapshot()
+	if snapshot.Errors != 0 || snapshot.StatusCodes[http.StatusOK] != 3 {
+		t.Fatalf("expected 3 successful requests, got %+v", snapshot)
+	}
+	if got := http2.Load(); got != 3 {
+		t.Fatalf("expected 3 HTTP/2 requests, got %d", got)
+	}
+	if snapshot.Timing.Handshakes != 1 {
+		t.Fatalf("expected one TLS handshake on a reused connection, got %d", snapshot.Timing.Handshakes)
+	}
+}
+
+func TestPoolRunSpeaksH2C(t *testing.T) {
+	var http2 atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 {
+			http2.Add(1)
+		}
+	}))
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetHTTP1(true)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	defer server.Close()
+
+	pool := mustNewPool(t, PoolConfig{
+		Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 2},
+		TargetURL: server.URL,
+		Client:    ClientConfig{H2C: true},
+	})
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if got := http2.Load(); got != 2 {
+		t.Fatalf("expected 2 HTTP/2 requests, got %d", got)
+	}
+}
+
+func TestPoolRunRejectsUnknownCAWithoutInsecure(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	pool := mustNewPool(t, PoolConfig{
+		Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 1},
+		TargetURL: server.URL,
+	})
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if errs := pool.collector.Snapshot().Errors; errs != 1 {
+		t.Fatalf("expected a certificate error, got %d errors", errs)
+	}
+
+	pool = mustNewPool(t, PoolConfig{
+		Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 1},
+		TargetURL: server.URL,
+		Client:    ClientConfig{Insecure: true},
+	})
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if errs := pool.collector.Snapshot().Errors; errs != 0 {
+		t.Fatalf("expected --insecure to skip verification, got %d errors", errs)
+	}
+}
+
+func TestPoolRunDisableKeepAliveOpensConnectionPerRequest(t *testing.T) {
+	var connections atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	for _, tt := range []struct {
+		disable bool
+		want    int64
+	}{
+		{disable: false, want: 1},
+		{disable: true, want: 4},
+	} {
+		connections.Store(0)
+		pool := mustNewPool(t, PoolConfig{
+			Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 4},
+			TargetURL: server.URL,
+			Client:    ClientConfig{DisableKeepAlive: tt.disable},
+		})
+		if err := pool.Run(); err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+		if got := connections.Load(); got != tt.want {
+			t.Fatalf("disable keep-alive %v: expected %d connections, got %d", tt.disable, tt.want, got)
+		}
+		if got := pool.collector.Snapshot().Timing.Connections; int64(got) != tt.want {
+			t.Fatalf("disable keep-alive %v: expected %d measured connects, got %d", tt.disable, tt.want, got)
+		}
+	}
+}
+
+func TestPoolRunAppliesTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	pool := mustNewPool(t, PoolConfig{
+		Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 1},
+		TargetURL: server.URL,
+		Client:    ClientConfig{Timeout: 50 * time.Millisecond},
+	})
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if errs := pool.collector.Snapshot().Errors; errs != 1 {
+		t.Fatalf("expected the request to time out, got %d errors", errs)
+	}
+}
+
+func TestNewClientTimeout(t *testing.T) {
+	for _, timeout := range []time.Duration{0, 2 * time.Second} {
+		client, err := newClient(ClientConfig{Timeout: timeout}, 1)
+		if err != nil {
+			t.Fatalf("newClient() failed: %v", err)
+		}
+		if client.Timeout != timeout {
+			t.Errorf("expected timeout %v, got %v", timeout, client.Timeout)
+		}
+	}
+}
+
+func TestPoolDoAppliesHeaders(t *testing.T) {
+	var host, tenant, accept atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host.Store(r.Host)
+		tenant.Store(r.Header.Get("X-Tenant"))
+		accept.Store(r.Header.Get("Accept"))
+	}))
+	defer server.Close()
+
+	pool := mustNewPool(t, PoolConfig{
+		Config:    runner.Config{Concurrency: 1, Duration: 5 * time.Second, Requests: 1},
+		TargetURL: server.URL,
+		Headers:   map[string]string{"Host": "api.example.com", "X-Tenant": "load-test", "Accept": "text/plain"},
+	})
+	req, err := pool.newRequest(t.Context())
+	if err != nil {
+		t.Fatalf("newRequest() failed: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, _, err := pool.do(req)
+	if err != nil {
+		t.Fatalf("do() failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := host.Load(); got != "api.example.com" {
+		t.Fatalf("expected Host override, got %v", got)
+	}
+	if got := tenant.Load(); got != "load-test" {
+		t.Fatalf("expected X-Tenant header, got %v", got)
+	}
+	if got := accept.Load(); got != "application/json" {
+		t.Fatalf("expected the request header to win, got %v", got)
+	}
+}
@@ -4,27 +4,40 @@ package http
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/scenario"
 	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
-	"github.com/neogan/sre-toolkit/pkg/logging"
 )
 
 // PoolConfig holds configuration for the worker pool
 type PoolConfig struct {
+	runner.Config
+
 	TargetURL     string
 	Method        string
 	Body          string
 	BearerToken   string //nolint:gosec // BearerToken is a configuration field for HTTP load testing, not a hardcoded credential
 	BasicUsername string
 	BasicPassword string
-	Concurrency   int
-	Duration      time.Duration
-	Requests      int // Optional limit on total requests
-	UI            bool
+	// Headers are sent with every request; a Host header sets the request host.
+	// Scenario headers take precedence.
+	Headers map[string]string
+
+	// Client controls timeouts, TLS, HTTP/2, connection reuse and proxying.
+	Client ClientConfig
+
+	// Scenario replaces the single target request with multi-step flows; each
+	// iteration, and each entry counted by Requests, runs one flow.
+	Scenario *scenario.Scenario
+
+	// Checks are applied to every response of the target request.
+	Checks *check.Checks
 }
 
 // Pool manages a pool of HTTP workers
@@ -34,21 +47,17 @@ type Pool struct {
 	collector *stats.Collector
 }
 
-// NewPool creates a new worker pool
-func NewPool(cfg PoolConfig) *Pool {
+// NewPool creates a new worker pool. It fails if the TLS certificates cannot be loaded.
+func NewPool(cfg PoolConfig) (*Pool, error) {
+	client, err := newClient(cfg.Client, cfg.Concurrency)
+	if err != nil {
+		return nil, err
+	}
 	return &Pool{
-		config: cfg,
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        cfg.Concurrency,
-				MaxIdleConnsPerHost: cfg.Concurrency,
-				IdleConnTimeout:     90 * time.Second,
-				DisableKeepAlives:   false,
-			},
-		},
+		config:    cfg,
+		client:    client,
 		collector: stats.NewCollector(),
-	}
+	}, nil
 }
 
 // Validate ensures the pool configuration does not contain conflicting auth modes.
@@ -61,113 +70,153 @@ func (c PoolConfig) Validate() error {
 		return fmt.Errorf("basic authentication requires --basic-username")
 	}
 
-	return nil
+	if err := c.Client.Validate(); err != nil {
+		return err
+	}
+
+	return c.Config.Validate()
 }
 
 // Run starts the load test
 func (p *Pool) Run() error {
-	logger := logging.GetLogger()
-	ctx, cancel := context.WithTimeout(context.Background(), p.config.Duration)
-	defer cancel()
-
-	var wg sync.WaitGroup
-	requestsCh := make(chan struct{}, p.config.Concurrency)
-
-	// If request limit is set, feed the channel
-	if p.config.Requests > 0 {
-		go func() {
-			for i := 0; i < p.config.Requests; i++ {
-				select {
-				case <-ctx.Done():
-					return
-				case requestsCh <- struct{}{}:
-				}
-			}
-			close(requestsCh)
-		}()
-	} else {
-		// Infinite mode
-		go func() {
-			for {
-				select {
-				case <-ctx.Done():
-					close(requestsCh)
-					return
-				case requestsCh <- struct{}{}:
-				}
-			}
-		}()
+	return p.RunContext(context.Background())
+}
+
+// RunContext starts the load test and stops it early when ctx ends.
+func (p *Pool) RunContext(ctx context.Context) error {
+	if p.config.Scenario != nil {
+		p.collector.SetSteps(p.config.Scenario.StepNames())
 	}
+	return runner.New(p.config.Config, p.collector, p.iterate).RunContext(ctx)
+}
 
-	logger.Info().
-		Int("concurrency", p.config.Concurrency).
-		Dur("duration", p.config.Duration).
-		Msg("Starting workers")
+// Collector returns the collector the pool records into.
+func (p *Pool) Collector() *stats.Collector {
+	return p.collector
+}
 
-	var uiCtx context.Context
-	var uiCancel context.CancelFunc
-	if p.config.UI {
-		uiCtx, uiCancel = context.WithCancel(context.Background())
-		defer uiCancel()
-		ui := stats.NewUI(p.collector, time.Second)
-		go ui.Run(uiCtx)
+// iterate runs one iteration: the target request, or a scenario flow.
+func (p *Pool) iterate(ctx context.Context, recorder *stats.Recorder, start time.Time) {
+	if p.config.Scenario == nil {
+		p.send(ctx, recorder, start)
+		return
 	}
+	p.runFlow(ctx, recorder, start)
+}
 
-	for i := 0; i < p.config.Concurrency; i++ {
-		wg.Add(1)
-		go func(id int) {
-			defer wg.Done()
-			p.worker(ctx, id, requestsCh)
-		}(i)
-	}
+// runFlow runs the steps of a scenario flow in order. The first step's latency
+// is measured from start, the others from when they are sent. A step that
+// fails, or whose values cannot be extracted, ends the iteration because later
+// steps depend on it.
+func (p *Pool) runFlow(ctx context.Context, recorder *stats.Recorder, start time.Time) {
+	iteration := p.config.Scenario.Next()
+	for i := range iteration.Flow.Steps {
+		step := &iteration.Flow.Steps[i]
+		if i > 0 {
+			start = time.Now()
+		}
 
-	// Wait for completion
-	wg.Wait()
+		result := stats.Result{Step: step.Name}
+		req, err := iteration.NewRequest(ctx, step)
+		var resp *http.Response
+		var tr *trace
+		if err == nil {
+			resp, tr, err = p.do(req)
+		}
+		if err == nil {
+			result.StatusCode = resp.StatusCode
+			err = p.readStep(iteration, step, resp, start, &result)
+			result.Timing = tr.result()
+		}
+		result.Duration = time.Since(start)
+		result.Error = err
+		recorder.Add(result)
+		if err != nil {
+			return
+		}
 
-	if p.config.UI {
-		uiCancel() // explicit call to cancel early before sleep
-		// Give UI a moment to print its final render
-		time.Sleep(100 * time.Millisecond)
-	} else {
-		logger.Info().Msg("Load test completed")
+		if pause := step.Pause(); pause > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pause):
+			}
+		}
 	}
+}
 
-	// Report results
-	p.collector.Report()
+// readStep consumes the response body, checks it and extracts the step's
+// variables from it.
+func (p *Pool) readStep(iteration *scenario.Iteration, step *scenario.Step, resp *http.Response, start time.Time, result *stats.Result) error {
+	defer resp.Body.Close()
+	if len(step.Extract) == 0 && !step.Checks.NeedsBody() {
+		if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+			return err
+		}
+		result.FailedChecks = step.Checks.Evaluate(resp, nil, time.Since(start))
+		return nil
+	}
 
-	return nil
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	result.FailedChecks = step.Checks.Evaluate(resp, body, time.Since(start))
+	return iteration.Extract(step, resp, body)
 }
 
-func (p *Pool) worker(ctx context.Context, _ /* id */ int, requests <-chan struct{}) {
-	for range requests {
-		// check context cancellation
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
+// send performs one request and records its latency measured from start.
+func (p *Pool) send(ctx context.Context, recorder *stats.Recorder, start time.Time) {
+	req, err := p.newRequest(ctx)
+	var resp *http.Response
+	var tr *trace
+	if err == nil {
+		resp, tr, err = p.do(req)
+	}
 
-		start := time.Now()
+	result := stats.Result{Error: err}
+	if err == nil {
+		result.StatusCode = resp.StatusCode
+		result.Error = p.readResponse(resp, p.config.Checks, start, &result)
+		result.Timing = tr.result()
+	}
+	result.Duration = time.Since(start)
 
-		req, err := p.newRequest(ctx)
-		var resp *http.Response
-		if err == nil {
-			resp, err = p.client.Do(req) //nolint:gosec // SSRF is acceptable in chaos load testing tool
-		}
-		duration := time.Since(start)
+	recorder.Add(result)
+}
 
-		result := stats.Result{
-			Duration: duration,
-			Error:    err,
+// do sends the request with the configured headers and traces its timing.
+func (p *Pool) do(req *http.Request) (*http.Response, *trace, error) {
+	for name, value := range p.config.Headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+		} else if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
 		}
+	}
+	req, tr := withTrace(req)
+	resp, err := p.client.Do(req) //nolint:gosec // SSRF is acceptable in chaos load testing tool
+	return resp, tr, err
+}
 
-		if err == nil {
-			result.StatusCode = resp.StatusCode
-			resp.Body.Close()
-		}
+// readResponse consumes the response body and records the checks the response
+// fails. The body is read to the end so the connection can be reused. Latency
+// for checks is taken when the body has been read.
+func (p *Pool) readResponse(resp *http.Response, checks *check.Checks, start time.Time, result *stats.Result) error {
+	defer resp.Body.Close()
 
-		p.collector.Add(result)
+	var body []byte
+	var err error
+	if checks.NeedsBody() {
+		body, err = io.ReadAll(resp.Body)
+	} else {
+		_, err = io.Copy(io.Discard, resp.Body)
 	}
+	if err != nil {
+		return err
+	}
+	result.FailedChecks = checks.Evaluate(resp, body, time.Since(start))
+	return nil
 }
 
 func (p *Pool) newRequest(ctx context.Context) (*http.Request, error) {
@@ -3,13 +3,22 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/output"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/rate"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/runner"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/scenario"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
 )
 
 type capturedRequest struct {
@@ -82,8 +91,18 @@ func (s *stubTransport) firstRequest(t *testing.T) capturedRequest {
 	return s.requests[0]
 }
 
-func newTestPool(cfg PoolConfig, transport http.RoundTripper) *Pool {
-	pool := NewPool(cfg)
+func mustNewPool(t *testing.T, cfg PoolConfig) *Pool {
+	t.Helper()
+	pool, err := NewPool(cfg)
+	if err != nil {
+		t.Fatalf("NewPool() failed: %v", err)
+	}
+	return pool
+}
+
+func newTestPool(t *testing.T, cfg PoolConfig, transport http.RoundTripper) *Pool {
+	t.Helper()
+	pool := mustNewPool(t, cfg)
 	pool.client = &http.Client{
 		Timeout:   2 * time.Second,
 		Transport: transport,
@@ -94,11 +113,13 @@ func newTestPool(cfg PoolConfig, transport http.RoundTripper) *Pool {
 
 func TestPoolRunHonorsRequestLimit(t *testing.T) {
 	transport := &stubTransport{statusCode: http.StatusOK}
-	pool := newTestPool(PoolConfig{
-		TargetURL:   "https://example.com",
-		Concurrency: 5,
-		Duration:    100 * time.Millisecond,
-		Requests:    20,
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 5,
+			Duration:    100 * time.Millisecond,
+			Requests:    20,
+		},
+		TargetURL: "https://example.com",
 	}, transport)
 
 	if err := pool.Run(); err != nil {
@@ -115,10 +136,12 @@ func TestPoolRunStopsAroundDuration(t *testing.T) {
 		statusCode: http.StatusOK,
 		delay:      10 * time.Millisecond,
 	}
-	pool := newTestPool(PoolConfig{
-		TargetURL:   "https://example.com",
-		Concurrency: 2,
-		Duration:    50 * time.Millisecond,
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 2,
+			Duration:    50 * time.Millisecond,
+		},
+		TargetURL: "https://example.com",
 	}, transport)
 
 	start := time.Now()
@@ -134,11 +157,13 @@ func TestPoolRunStopsAroundDuration(t *testing.T) {
 
 func TestPoolRunCapturesRequestErrors(t *testing.T) {
 	transport := &stubTransport{err: errors.New("boom")}
-	pool := newTestPool(PoolConfig{
-		TargetURL:   "https://example.com",
-		Concurrency: 1,
-		Duration:    50 * time.Millisecond,
-		Requests:    1,
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    50 * time.Millisecond,
+			Requests:    1,
+		},
+		TargetURL: "https://example.com",
 	}, transport)
 
 	if err := pool.Run(); err != nil {
@@ -152,13 +177,15 @@ func TestPoolRunCapturesRequestErrors(t *testing.T) {
 
 func TestPoolRunUsesMethodAndBody(t *testing.T) {
 	transport := &stubTransport{statusCode: http.StatusOK}
-	pool := newTestPool(PoolConfig{
-		TargetURL:   "https://example.com",
-		Method:      http.MethodPost,
-		Body:        "hello world",
-		Concurrency: 1,
-		Duration:    time.Second,
-		Requests:    1,
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    time.Second,
+			Requests:    1,
+		},
+		TargetURL: "https://example.com",
+		Method:    http.MethodPost,
+		Body:      "hello world",
 	}, transport)
 
 	if err := pool.Run(); err != nil {
@@ -175,7 +202,7 @@ func TestPoolRunUsesMethodAndBody(t *testing.T) {
 }
 
 func TestPoolNewRequestSetsBearerToken(t *testing.T) {
-	pool := NewPool(PoolConfig{
+	pool := mustNewPool(t, PoolConfig{
 		TargetURL:   "https://example.com",
 		BearerToken: "token-123",
 	})
@@ -191,7 +218,7 @@ func TestPoolNewRequestSetsBearerToken(t *testing.T) {
 }
 
 func TestPoolNewRequestSetsBasicAuth(t *testing.T) {
-	pool := NewPool(PoolConfig{
+	pool := mustNewPool(t, PoolConfig{
 		TargetURL:     "https://example.com",
 		BasicUsername: "demo",
 		BasicPassword: "secret",
@@ -251,7 +278,7 @@ func TestPoolConfigValidate_Valid(t *testing.T) {
 }
 
 func TestPoolNewRequest_DefaultMethodIsGET(t *testing.T) {
-	pool := NewPool(PoolConfig{TargetURL: "https://example.com"})
+	pool := mustNewPool(t, PoolConfig{TargetURL: "https://example.com"})
 	req, err := pool.newRequest(context.Background())
 	if err != nil {
 		t.Fatalf("newRequest() failed: %v", err)
@@ -262,9 +289,355 @@ func TestPoolNewRequest_DefaultMethodIsGET(t *testing.T) {
 }
 
 func TestPoolNewRequest_InvalidURLReturnsError(t *testing.T) {
-	pool := NewPool(PoolConfig{TargetURL: "://bad url"})
+	pool := mustNewPool(t, PoolConfig{TargetURL: "://bad url"})
 	_, err := pool.newRequest(context.Background())
 	if err == nil {
 		t.Fatal("expected error for invalid URL")
 	}
 }
+
+func TestPoolRunOpenModelSendsPlannedRequests(t *testing.T) {
+	transport := &stubTransport{statusCode: http.StatusOK}
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 5,
+			Duration:    200 * time.Millisecond,
+			Rate:        100,
+		},
+		TargetURL: "https://example.com",
+	}, transport)
+
+	start := time.Now()
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if got := transport.requestCount(); got != 20 {
+		t.Fatalf("expected 20 requests, got %d", got)
+	}
+	if elapsed < 200*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Fatalf("expected runtime around 200ms, got %v", elapsed)
+	}
+}
+
+func TestPoolRunOpenModelDropsWhenSaturated(t *testing.T) {
+	transport := &stubTransport{
+		statusCode: http.StatusOK,
+		delay:      150 * time.Millisecond,
+	}
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    100 * time.Millisecond,
+			Rate:        100,
+		},
+		TargetURL: "https://example.com",
+	}, transport)
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+
+	snapshot := pool.collector.Snapshot()
+	if snapshot.TotalRequests != 1 {
+		t.Fatalf("expected 1 request while the only slot was busy, got %d", snapshot.TotalRequests)
+	}
+	if snapshot.Dropped != 9 {
+		t.Fatalf("expected 9 dropped iterations, got %d", snapshot.Dropped)
+	}
+}
+
+func TestPoolRunOpenModelRampsThroughStages(t *testing.T) {
+	transport := &stubTransport{
+		statusCode: http.StatusOK,
+		delay:      20 * time.Millisecond,
+	}
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 2,
+			Stages:      []rate.Stage{{Duration: 100 * time.Millisecond, Target: 40}},
+		},
+		TargetURL: "https://example.com",
+	}, transport)
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+
+	if got := transport.requestCount(); got != 2 {
+		t.Fatalf("expected 2 requests from a 0→40/s ramp over 100ms, got %d", got)
+	}
+
+	var report strings.Builder
+	pool.collector.FprintReport(&report)
+	if !strings.Contains(report.String(), "Latency (from intended send time):") {
+		t.Fatalf("expected open-model latency section, got:\n%s", report.String())
+	}
+}
+
+func TestPoolConfigValidateRejectsRateWithStages(t *testing.T) {
+	err := (PoolConfig{
+		Config: runner.Config{
+			Rate:   10,
+			Stages: []rate.Stage{{Duration: time.Second, Target: 10}},
+		},
+		TargetURL: "https://example.com",
+	}).Validate()
+	if err == nil {
+		t.Fatal("expected validation error when both rate and stages are set")
+	}
+}
+
+// handlerTransport serves requests with an in-process handler.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (h handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	h.handler.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+func TestPoolRunScenarioPassesExtractedValues(t *testing.T) {
+	var authorized atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		user := strings.TrimPrefix(string(body), "user=")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"token":"tok-%s"}`, user)
+	})
+	mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Authorization"), "Bearer tok-") {
+			authorized.Add(1)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	sc, err := scenario.Parse([]byte(`
+base_url: https://api.example.com
+variables:
+  user: alice
+flows:
+  - steps:
+      - name: login
+        method: POST
+        url: /login
+        body: user=${user}
+        extract:
+          - name: token
+            jsonpath: $.token
+      - name: orders
+        url: /orders
+        headers:
+          Authorization: Bearer ${token}
+`), "")
+	if err != nil {
+		t.Fatalf("scenario.Parse() failed: %v", err)
+	}
+
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 2,
+			Duration:    time.Second,
+			Requests:    5,
+		},
+		Scenario: sc,
+	}, handlerTransport{handler: mux})
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+
+	if got := authorized.Load(); got != 5 {
+		t.Fatalf("expected 5 authorized order requests, got %d", got)
+	}
+	snapshot := pool.collector.Snapshot()
+	if len(snapshot.Steps) != 2 || snapshot.Steps[0].Name != "login" || snapshot.Steps[1].Name != "orders" {
+		t.Fatalf("expected login and orders step stats in scenario order, got %+v", snapshot.Steps)
+	}
+	if snapshot.Steps[0].Requests != 5 || snapshot.Steps[1].Requests != 5 {
+		t.Fatalf("expected 5 requests per step, got %+v", snapshot.Steps)
+	}
+}
+
+func TestPoolRunScenarioStopsFlowOnFailedExtraction(t *testing.T) {
+	transport := &stubTransport{statusCode: http.StatusOK}
+	sc, err := scenario.Parse([]byte(`
+flows:
+  - steps:
+      - name: login
+        url: https://example.com/login
+        extract:
+          - name: token
+            jsonpath: $.token
+      - name: orders
+        url: https://example.com/orders?token=${token}
+`), "")
+	if err != nil {
+		t.Fatalf("scenario.Parse() failed: %v", err)
+	}
+
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    time.Second,
+			Requests:    3,
+		},
+		Scenario: sc,
+	}, transport)
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+
+	if got := transport.requestCount(); got != 3 {
+		t.Fatalf("expected only the 3 login requests, got %d", got)
+	}
+	snapshot := pool.collector.Snapshot()
+	if snapshot.Errors != 3 || len(snapshot.Steps) != 1 || snapshot.Steps[0].Errors != 3 {
+		t.Fatalf("expected 3 failed login steps, got %+v", snapshot)
+	}
+}
+
+func TestPoolRunCountsFailedChecksSeparately(t *testing.T) {
+	transport := &stubTransport{statusCode: http.StatusServiceUnavailable}
+	checks := &check.Checks{Status: []int{http.StatusOK}, BodyContains: "ok"}
+	if err := checks.Compile(); err != nil {
+		t.Fatalf("Compile() failed: %v", err)
+	}
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    time.Second,
+			Requests:    4,
+		},
+		TargetURL: "https://example.com",
+		Checks:    checks,
+	}, transport)
+
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Pool.Run() failed: %v", err)
+	}
+
+	snapshot := pool.collector.Snapshot()
+	if snapshot.Errors != 0 || snapshot.FailedChecks != 4 {
+		t.Fatalf("expected 0 errors and 4 failed checks, got %d and %d", snapshot.Errors, snapshot.FailedChecks)
+	}
+	if snapshot.CheckFailures["status in [200]"] != 4 || snapshot.CheckFailures[`body contains "ok"`] != 0 {
+		t.Fatalf("unexpected check failures: %v", snapshot.CheckFailures)
+	}
+}
+
+func TestPoolRunFailsOnThresholds(t *testing.T) {
+	transport := &stubTransport{err: errors.New("boom")}
+	thresholds, err := check.ParseThresholds([]string{"error_rate < 1%", "requests >= 3"})
+	if err != nil {
+		t.Fatalf("ParseThresholds() failed: %v", err)
+	}
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    time.Second,
+			Requests:    3,
+			Thresholds:  thresholds,
+		},
+		TargetURL: "https://example.com",
+	}, transport)
+
+	err = pool.Run()
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 thresholds failed") {
+		t.Fatalf("expected threshold failure, got %v", err)
+	}
+}
+
+func TestPoolRunAbortsOnFailedThreshold(t *testing.T) {
+	transport := &stubTransport{err: errors.New("boom"), delay: 5 * time.Millisecond}
+	thresholds, err := check.ParseThresholds([]string{"error_rate < 1%", "rps > 1000000"})
+	if err != nil {
+		t.Fatalf("ParseThresholds() failed: %v", err)
+	}
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    5 * time.Second,
+			Thresholds:  thresholds,
+			AbortOnFail: true,
+			AbortDelay:  50 * time.Millisecond,
+		},
+		TargetURL: "https://example.com",
+	}, transport)
+
+	start := time.Now()
+	if err := pool.Run(); err == nil {
+		t.Fatal("expected the run to fail its thresholds")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the run to abort early, took %v", elapsed)
+	}
+}
+
+type recordingExporter struct {
+	intervals int
+	results   *output.Results
+}
+
+func (e *recordingExporter) Observe(context.Context, stats.Interval, stats.SnapshotStats) error {
+	e.intervals++
+	return errors.New("unreachable")
+}
+
+func (e *recordingExporter) Export(_ context.Context, results *output.Results) error {
+	e.results = results
+	return nil
+}
+
+func TestPoolRunExportsResults(t *testing.T) {
+	transport := &stubTransport{statusCode: http.StatusOK}
+	thresholds, err := check.ParseThresholds([]string{"error_rate < 1%"})
+	if err != nil {
+		t.Fatalf("ParseThresholds() failed: %v", err)
+	}
+	exporter := &recordingExporter{}
+	pool := newTestPool(t, PoolConfig{
+		Config: runner.Config{
+			Concurrency: 1,
+			Duration:    time.Second,
+			Requests:    3,
+			Thresholds:  thresholds,
+			Name:        "smoke",
+			Outputs:     []output.Exporter{exporter},
+		},
+		TargetURL: "https://example.com",
+	}, transport)
+
+	// A failing interval export is logged and does not fail the run.
+	if err := pool.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	if exporter.intervals == 0 {
+		t.Fatal("expected at least the final interval to be observed")
+	}
+	results := exporter.results
+	if results == nil {
+		t.Fatal("expected the results to be exported")
+	}
+	if results.Name != "smoke" || results.Summary.Requests != 3 || len(results.Thresholds) != 1 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	requests := 0
+	for _, point := range results.Series {
+		requests += point.Requests
+	}
+	if requests != 3 {
+		t.Fatalf("expected the series to cover all 3 requests, got %d", requests)
+	}
+}
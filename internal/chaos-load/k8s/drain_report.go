@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// DrainReport tells what a drain did with the pods of a node. Times are
+// relative to Start, when the drain began.
+type DrainReport struct {
+	Node      string
+	Start     time.Time
+	Evictions []Eviction
+	// Uncordoned is when the node was uncordoned, or 0 if it was left cordoned.
+	Uncordoned time.Duration
+}
+
+// Eviction is what happened to one pod of the drained node.
+type Eviction struct {
+	Namespace string
+	Pod       string
+	// Controlled is set if a controller such as a ReplicaSet owns the pod,
+	// so that a replacement is expected.
+	Controlled bool
+	// Attempts counts the evictions sent, more than one when a
+	// PodDisruptionBudget blocked the first ones.
+	Attempts  int
+	EvictedAt time.Duration
+	// Err is set if the pod could not be evicted.
+	Err error
+	// Replacement is the pod that took over on Node, ready RescheduledIn
+	// after the eviction. It is empty if none became ready before the drain
+	// timed out.
+	Replacement   string
+	Node          string
+	RescheduledIn time.Duration
+}
+
+// Fprint writes a table of the evicted pods and where they were rescheduled.
+func (r DrainReport) Fprint(w io.Writer) {
+	fmt.Fprintf(w, "\n=== Node Drain: %s ===\n", r.Node)
+	if len(r.Evictions) == 0 {
+		fmt.Fprintln(w, "  No pods to evict")
+	} else {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  POD\tEVICTED\tATTEMPTS\tREPLACEMENT\tNODE\tRESCHEDULED IN")
+		for _, e := range r.Evictions {
+			pod := e.Namespace + "/" + e.Pod
+			switch {
+			case e.Err != nil:
+				fmt.Fprintf(tw, "  %s\t-\t%d\t-\t-\tnot evicted: %v\n", pod, e.Attempts, e.Err)
+			case !e.Controlled:
+				fmt.Fprintf(tw, "  %s\t%v\t%d\t-\t-\tnot managed by a controller\n", pod, round(e.EvictedAt), e.Attempts)
+			case e.Replacement == "":
+				fmt.Fprintf(tw, "  %s\t%v\t%d\t-\t-\tnot rescheduled\n", pod, round(e.EvictedAt), e.Attempts)
+			default:
+				fmt.Fprintf(tw, "  %s\t%v\t%d\t%s\t%s\t%v\n", pod, round(e.EvictedAt), e.Attempts, e.Replacement, e.Node, round(e.RescheduledIn))
+			}
+		}
+		tw.Flush()
+	}
+
+	if r.Uncordoned > 0 {
+		fmt.Fprintf(w, "\nNode uncordoned after %v\n", round(r.Uncordoned))
+	} else {
+		fmt.Fprintf(w, "\nNode left cordoned\n")
+	}
+}
+
+// round rounds report times for display.
+func round(d time.Duration) time.Duration {
+	return d.Round(100 * time.Millisecond)
+}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/neogan/sre-toolkit/pkg/logging"
@@ -10,6 +11,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -21,56 +23,319 @@ type DrainerConfig struct {
 	IgnoreDaemonSets   bool
 	DeleteEmptyDirData bool
 	DryRun             bool
+	// Duration keeps the node cordoned for this long from the start of the
+	// drain, then uncordons it, also when the context of Run is canceled.
+	// 0 leaves it cordoned.
+	Duration time.Duration
+	// Concurrency is how many pods are evicted in parallel; 1 by default.
+	Concurrency int
 }
 
+const (
+	// defaultEvictionBackoff is the first wait before retrying an eviction
+	// blocked by a PodDisruptionBudget; it doubles up to maxEvictionBackoff.
+	defaultEvictionBackoff = time.Second
+	maxEvictionBackoff     = 30 * time.Second
+	// defaultReschedulePoll is how often replacement pods are looked for.
+	defaultReschedulePoll = 2 * time.Second
+)
+
 // NodeDrainer cordons and evicts all pods from a node.
 type NodeDrainer struct {
 	client kubernetes.Interface
 	config DrainerConfig
+	// cordoned is set once Run has cordoned the node, so that Uncordon leaves
+	// a node that was cordoned beforehand alone.
+	cordoned bool
+	// started is set once Run has got as far as cordoning the node, so that
+	// report describes a drain.
+	started bool
+	report  DrainReport
+
+	backoff      time.Duration
+	pollInterval time.Duration
 }
 
 // NewNodeDrainer creates a new NodeDrainer.
 func NewNodeDrainer(client kubernetes.Interface, cfg DrainerConfig) *NodeDrainer {
-	return &NodeDrainer{client: client, config: cfg}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	return &NodeDrainer{
+		client:       client,
+		config:       cfg,
+		backoff:      defaultEvictionBackoff,
+		pollInterval: defaultReschedulePoll,
+	}
 }
 
-// Run cordons the node then evicts all evictable pods.
+// Run cordons the node, evicts all evictable pods and waits for their
+// replacements to become ready elsewhere. Evictions blocked by a
+// PodDisruptionBudget are retried until the timeout; a pod that cannot be
+// evicted does not stop the others. With a Duration the node is uncordoned
+// once it has passed, or earlier if ctx is canceled.
 func (d *NodeDrainer) Run(ctx context.Context) error {
 	logger := logging.GetLogger()
 
 	if d.config.DryRun {
-		logger.Info().Str("node", d.config.NodeName).Msg("[dry-run] would cordon and drain node")
+		logger.Info().Str("node", d.config.NodeName).Dur("duration", d.config.Duration).Msg("[dry-run] would cordon and drain node")
 		return nil
 	}
 
+	start := time.Now()
+	d.started, d.report = false, DrainReport{}
+
 	// Cordon the node
 	if err := d.cordon(ctx); err != nil {
 		return err
 	}
 	logger.Info().Str("node", d.config.NodeName).Msg("Node cordoned")
+	d.started, d.report = true, DrainReport{Node: d.config.NodeName, Start: start}
+
+	if d.config.Duration > 0 {
+		// Ensure the node is uncordoned when ctx is canceled
+		defer d.uncordonAfter(start)
+	}
+
+	err := d.drain(ctx, start)
+
+	if d.config.Duration > 0 {
+		logger.Info().Dur("duration", d.config.Duration).Msg("Keeping node cordoned")
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("Interrupted, uncordoning node early")
+		case <-time.After(time.Until(start.Add(d.config.Duration))):
+		}
+	}
+	return err
+}
+
+// uncordonAfter uncordons the node at the end of Run. It uses its own
+// context, as the one of Run may have been canceled.
+func (d *NodeDrainer) uncordonAfter(start time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := d.Uncordon(ctx); err != nil {
+		logger := logging.GetLogger()
+		logger.Error().Err(err).Msg("Failed to uncordon node")
+		return
+	}
+	d.report.Uncordoned = time.Since(start)
+}
+
+// drain evicts the pods of the node, Concurrency at a time, then waits for
+// their replacements.
+func (d *NodeDrainer) drain(ctx context.Context, start time.Time) error {
+	logger := logging.GetLogger()
 
 	// List pods on the node
 	pods, err := d.listEvictablePods(ctx)
 	if err != nil {
 		return err
 	}
+	// Pods that exist before the drain are not replacements.
+	known, err := d.podsByNamespace(ctx, pods)
+	if err != nil {
+		return err
+	}
 
-	logger.Info().Str("node", d.config.NodeName).Int("pods", len(pods)).Msg("Evicting pods")
+	logger.Info().Str("node", d.config.NodeName).Int("pods", len(pods)).Int("concurrency", d.config.Concurrency).Msg("Evicting pods")
 
 	drainCtx, cancel := context.WithTimeout(ctx, d.config.Timeout)
 	defer cancel()
 
-	for _, pod := range pods {
-		if err := d.evict(drainCtx, pod); err != nil {
-			return fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	evictions := make([]Eviction, len(pods))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for range min(d.config.Concurrency, len(pods)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				evictions[i] = d.evictWithRetry(drainCtx, pods[i], start)
+			}
+		}()
+	}
+	for i := range pods {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	d.waitForReplacements(drainCtx, pods, evictions, known)
+	d.report.Evictions = evictions
+
+	failed := 0
+	for _, eviction := range evictions {
+		if eviction.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to evict %d of %d pods from node %s", failed, len(pods), d.config.NodeName)
+	}
+	return nil
+}
+
+// evictWithRetry evicts a pod, backing off while a PodDisruptionBudget
+// blocks the eviction. Other errors are not retried.
+func (d *NodeDrainer) evictWithRetry(ctx context.Context, pod corev1.Pod, start time.Time) Eviction {
+	logger := logging.GetLogger()
+	eviction := Eviction{Namespace: pod.Namespace, Pod: pod.Name, Controlled: metav1.GetControllerOf(&pod) != nil}
+
+	backoff := d.backoff
+	for {
+		eviction.Attempts++
+		err := d.evict(ctx, pod)
+		if err == nil {
+			eviction.EvictedAt = time.Since(start)
+			logger.Info().
+				Str("pod", pod.Name).
+				Str("namespace", pod.Namespace).
+				Int("attempts", eviction.Attempts).
+				Msg("Pod evicted")
+			return eviction
+		}
+		if !errors.IsTooManyRequests(err) {
+			eviction.Err = err
+			logger.Error().Err(err).Str("pod", pod.Name).Str("namespace", pod.Namespace).Msg("Failed to evict pod")
+			return eviction
 		}
-		logger.Info().
+
+		logger.Warn().
 			Str("pod", pod.Name).
 			Str("namespace", pod.Namespace).
-			Msg("Pod evicted")
+			Dur("retry_in", backoff).
+			Msg("Eviction blocked by a PodDisruptionBudget")
+		select {
+		case <-ctx.Done():
+			eviction.Err = fmt.Errorf("blocked by a PodDisruptionBudget until the drain ended: %w", err)
+			return eviction
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxEvictionBackoff)
 	}
+}
 
-	return nil
+// podsByNamespace returns the UIDs of the pods in the namespaces of pods.
+func (d *NodeDrainer) podsByNamespace(ctx context.Context, pods []corev1.Pod) (map[types.UID]bool, error) {
+	uids := make(map[types.UID]bool)
+	seen := make(map[string]bool)
+	for _, pod := range pods {
+		if seen[pod.Namespace] {
+			continue
+		}
+		seen[pod.Namespace] = true
+		list, err := d.client.CoreV1().Pods(pod.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods in namespace %s: %w", pod.Namespace, err)
+		}
+		for _, p := range list.Items {
+			uids[p.UID] = true
+		}
+	}
+	return uids, nil
+}
+
+// waitForReplacements polls until every evicted pod with a controller has a
+// new ready pod from the same controller on another node, or ctx ends. Each
+// replacement is matched to one evicted pod.
+func (d *NodeDrainer) waitForReplacements(ctx context.Context, pods []corev1.Pod, evictions []Eviction, known map[types.UID]bool) {
+	logger := logging.GetLogger()
+	start := d.report.Start
+
+	waiting := 0
+	for i := range evictions {
+		if evictions[i].Err == nil && evictions[i].Controlled {
+			waiting++
+		}
+	}
+	if waiting == 0 {
+		return
+	}
+	logger.Info().Int("pods", waiting).Msg("Waiting for evicted pods to be rescheduled")
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+	for {
+		for i := range evictions {
+			eviction := &evictions[i]
+			if eviction.Err != nil || !eviction.Controlled || eviction.Replacement != "" {
+				continue
+			}
+			replacement, err := d.findReplacement(ctx, pods[i], known)
+			if err != nil {
+				logger.Warn().Err(err).Msg("Failed to look for replacement pods")
+				continue
+			}
+			if replacement == nil {
+				continue
+			}
+			known[replacement.UID] = true
+			eviction.Replacement = replacement.Name
+			eviction.Node = replacement.Spec.NodeName
+			eviction.RescheduledIn = time.Since(start) - eviction.EvictedAt
+			waiting--
+			logger.Info().
+				Str("pod", eviction.Pod).
+				Str("replacement", eviction.Replacement).
+				Str("node", eviction.Node).
+				Dur("took", eviction.RescheduledIn).
+				Msg("Pod rescheduled")
+		}
+		if waiting == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Warn().Int("pods", waiting).Msg("Evicted pods not rescheduled before the drain ended")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// findReplacement returns a ready pod of the same controller as pod that is
+// not known yet and runs on another node, or nil if there is none.
+func (d *NodeDrainer) findReplacement(ctx context.Context, pod corev1.Pod, known map[types.UID]bool) (*corev1.Pod, error) {
+	owner := metav1.GetControllerOf(&pod)
+	list, err := d.client.CoreV1().Pods(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		candidate := &list.Items[i]
+		if known[candidate.UID] || candidate.Spec.NodeName == "" || candidate.Spec.NodeName == d.config.NodeName {
+			continue
+		}
+		if ref := metav1.GetControllerOf(candidate); ref == nil || ref.UID != owner.UID {
+			continue
+		}
+		if podReady(candidate) {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// podReady reports whether a pod is running and ready.
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Report returns what the last Run did with the pods of the node. ok is
+// false if Run failed before cordoning the node, or was a dry run.
+func (d *NodeDrainer) Report() (report DrainReport, ok bool) {
+	return d.report, d.started
 }
 
 func (d *NodeDrainer) cordon(ctx context.Context) error {
@@ -87,6 +352,29 @@ func (d *NodeDrainer) cordon(ctx context.Context) error {
 	if _, err := d.client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
 		return fmt.Errorf("failed to cordon node %s: %w", d.config.NodeName, err)
 	}
+	d.cordoned = true
+	return nil
+}
+
+// Uncordon makes the node schedulable again if Run cordoned it. Evicted pods
+// are not moved back.
+func (d *NodeDrainer) Uncordon(ctx context.Context) error {
+	if !d.cordoned {
+		return nil
+	}
+
+	node, err := d.client.CoreV1().Nodes().Get(ctx, d.config.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", d.config.NodeName, err)
+	}
+	node.Spec.Unschedulable = false
+	if _, err := d.client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to uncordon node %s: %w", d.config.NodeName, err)
+	}
+	d.cordoned = false
+
+	logger := logging.GetLogger()
+	logger.Info().Str("node", d.config.NodeName).Msg("Node uncordoned")
 	return nil
 }
 
@@ -100,7 +388,8 @@ func (d *NodeDrainer) listEvictablePods(ctx context.Context) ([]corev1.Pod, erro
 
 	evictable := make([]corev1.Pod, 0, len(allPods.Items))
 	for _, pod := range allPods.Items {
-		if d.shouldSkip(pod) {
+		// Not every client honors the field selector.
+		if pod.Spec.NodeName != d.config.NodeName || d.shouldSkip(pod) {
 			continue
 		}
 		evictable = append(evictable, pod)
@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
@@ -8,8 +9,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func testNode(unschedulable bool) *corev1.Node {
@@ -27,6 +33,31 @@ func podOnNode(namespace, name string) *corev1.Pod {
 	}
 }
 
+// replicaSetPod returns a pod owned by the ReplicaSet "web".
+func replicaSetPod(name, node string) *corev1.Pod {
+	pod := podOnNode("default", name)
+	pod.UID = types.UID(name)
+	pod.Spec.NodeName = node
+	controller := true
+	pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web", UID: "rs-web", Controller: &controller}}
+	return pod
+}
+
+// onEviction handles evictions with react, which gets the evicted pod's name.
+func onEviction(client *fake.Clientset, react func(pod string) error) {
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		return true, nil, react(eviction.Name)
+	})
+}
+
+func pdbBlocked() error {
+	return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+}
+
 func TestNodeDrainer_DryRun(t *testing.T) {
 	client := fake.NewSimpleClientset(
 		testNode(false),
@@ -77,6 +108,32 @@ func TestNodeDrainer_AlreadyCordoned(t *testing.T) {
 	require.NoError(t, drainer.Run(context.Background()))
 }
 
+func TestNodeDrainer_Uncordon(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(testNode(false))
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second})
+
+	require.NoError(t, drainer.Run(ctx))
+	require.NoError(t, drainer.Uncordon(ctx))
+
+	node, err := client.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable, "node should be schedulable again")
+}
+
+func TestNodeDrainer_UncordonLeavesPreviouslyCordonedNode(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(testNode(true))
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second})
+
+	require.NoError(t, drainer.Run(ctx))
+	require.NoError(t, drainer.Uncordon(ctx))
+
+	node, err := client.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable, "node cordoned beforehand should stay cordoned")
+}
+
 func TestNodeDrainer_SkipsDaemonSetPods(t *testing.T) {
 	ds := podOnNode("kube-system", "ds-pod")
 	ds.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "fluentd"}}
@@ -146,4 +203,157 @@ func TestNodeDrainer_NodeNotFound(t *testing.T) {
 	err := drainer.Run(context.Background())
 	assert.Error(t, err)
 	assert.ErrorContains(t, err, "failed to get node")
+	_, ok := drainer.Report()
+	assert.False(t, ok, "nothing was drained")
+}
+
+func TestNodeDrainer_RetriesPDBBlockedEvictions(t *testing.T) {
+	client := fake.NewSimpleClientset(testNode(false), podOnNode("default", "pod-a"))
+	attempts := 0
+	onEviction(client, func(string) error {
+		attempts++
+		if attempts < 3 {
+			return pdbBlocked()
+		}
+		return nil
+	})
+
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second})
+	drainer.backoff = time.Millisecond
+
+	require.NoError(t, drainer.Run(context.Background()))
+	report, ok := drainer.Report()
+	require.True(t, ok)
+	require.Len(t, report.Evictions, 1)
+	assert.Equal(t, 3, report.Evictions[0].Attempts)
+	assert.NoError(t, report.Evictions[0].Err)
+}
+
+func TestNodeDrainer_PDBBlockedUntilTimeout(t *testing.T) {
+	client := fake.NewSimpleClientset(testNode(false), podOnNode("default", "pod-a"))
+	onEviction(client, func(string) error { return pdbBlocked() })
+
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 50 * time.Millisecond})
+	drainer.backoff = 5 * time.Millisecond
+
+	err := drainer.Run(context.Background())
+	assert.ErrorContains(t, err, "failed to evict 1 of 1 pods")
+	eviction := drainerReport(t, drainer).Evictions[0]
+	assert.Greater(t, eviction.Attempts, 1)
+	assert.ErrorContains(t, eviction.Err, "PodDisruptionBudget")
+}
+
+func TestNodeDrainer_ContinuesAfterEvictionError(t *testing.T) {
+	client := fake.NewSimpleClientset(testNode(false), podOnNode("default", "pod-a"), podOnNode("default", "pod-b"))
+	onEviction(client, func(pod string) error {
+		if pod == "pod-a" {
+			return apierrors.NewForbidden(policyv1.Resource("evictions"), pod, nil)
+		}
+		return nil
+	})
+
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second})
+
+	err := drainer.Run(context.Background())
+	assert.ErrorContains(t, err, "failed to evict 1 of 2 pods")
+	evicted := map[string]bool{}
+	for _, eviction := range drainerReport(t, drainer).Evictions {
+		evicted[eviction.Pod] = eviction.Err == nil
+	}
+	assert.Equal(t, map[string]bool{"pod-a": false, "pod-b": true}, evicted)
+}
+
+func TestNodeDrainer_ParallelEvictions(t *testing.T) {
+	client := fake.NewSimpleClientset(testNode(false), podOnNode("default", "pod-a"), podOnNode("default", "pod-b"))
+	blocked := 0
+	// pod-a is blocked for a while; with two workers pod-b does not wait for it.
+	onEviction(client, func(pod string) error {
+		if pod == "pod-a" && blocked < 5 {
+			blocked++
+			return pdbBlocked()
+		}
+		return nil
+	})
+
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second, Concurrency: 2})
+	drainer.backoff = 10 * time.Millisecond
+
+	require.NoError(t, drainer.Run(context.Background()))
+	at := map[string]time.Duration{}
+	for _, eviction := range drainerReport(t, drainer).Evictions {
+		at[eviction.Pod] = eviction.EvictedAt
+	}
+	assert.Less(t, at["pod-b"], at["pod-a"], "pod-b should be evicted while pod-a is blocked")
+}
+
+func TestNodeDrainer_ReportsReplacements(t *testing.T) {
+	client := fake.NewSimpleClientset(testNode(false), replicaSetPod("web-1", "node-1"), replicaSetPod("web-2", "node-2"))
+	onEviction(client, func(string) error {
+		// The ReplicaSet controller creates a replacement on another node.
+		replacement := replicaSetPod("web-3", "node-3")
+		replacement.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		return client.Tracker().Add(replacement)
+	})
+
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second})
+	drainer.pollInterval = 5 * time.Millisecond
+
+	require.NoError(t, drainer.Run(context.Background()))
+	eviction := drainerReport(t, drainer).Evictions[0]
+	assert.Equal(t, "web-3", eviction.Replacement, "the pre-existing web-2 is not a replacement")
+	assert.Equal(t, "node-3", eviction.Node)
+}
+
+func TestNodeDrainer_WaitsForReadyReplacement(t *testing.T) {
+	client := fake.NewSimpleClientset(testNode(false), replicaSetPod("web-1", "node-1"))
+	onEviction(client, func(string) error {
+		// Not ready yet, so never counted.
+		return client.Tracker().Add(replicaSetPod("web-2", "node-2"))
+	})
+
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 50 * time.Millisecond})
+	drainer.pollInterval = 5 * time.Millisecond
+
+	require.NoError(t, drainer.Run(context.Background()))
+	var buf bytes.Buffer
+	drainerReport(t, drainer).Fprint(&buf)
+	assert.Contains(t, buf.String(), "default/web-1")
+	assert.Contains(t, buf.String(), "not rescheduled")
+	assert.Contains(t, buf.String(), "Node left cordoned")
+}
+
+func TestNodeDrainer_UncordonsAfterDuration(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(testNode(false))
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second, Duration: 50 * time.Millisecond})
+
+	start := time.Now()
+	require.NoError(t, drainer.Run(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "node should stay cordoned for the duration")
+
+	node, err := client.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable, "node should be uncordoned after the duration")
+	assert.Positive(t, drainerReport(t, drainer).Uncordoned)
+}
+
+func TestNodeDrainer_UncordonsWhenCanceled(t *testing.T) {
+	client := fake.NewSimpleClientset(testNode(false))
+	drainer := NewNodeDrainer(client, DrainerConfig{NodeName: "node-1", Timeout: 10 * time.Second, Duration: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, drainer.Run(ctx))
+
+	node, err := client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable, "node should be uncordoned when interrupted")
+}
+
+// drainerReport returns the report of a drain that got as far as cordoning the node.
+func drainerReport(t *testing.T, drainer *NodeDrainer) DrainReport {
+	t.Helper()
+	report, ok := drainer.Report()
+	require.True(t, ok, "expected the node to be cordoned")
+	return report
 }
@@ -0,0 +1,148 @@
+package mock
+
+import (
+	"context"
+	_ "embed" // embeds the MockService definition
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/neogan/sre-toolkit/pkg/logging"
+)
+
+// GRPCService and GRPCMethod name the service served in gRPC mode.
+const (
+	GRPCService = "chaosload.mock.v1.MockService"
+	GRPCMethod  = GRPCService + "/Echo"
+)
+
+//go:embed mock.proto
+var mockProto string
+
+// parseCode parses a gRPC code name such as UNAVAILABLE; empty means UNAVAILABLE.
+func parseCode(name string) (codes.Code, error) {
+	if name == "" {
+		return codes.Unavailable, nil
+	}
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); err != nil || code == codes.OK {
+		return 0, fmt.Errorf("invalid gRPC error code %q, e.g. UNAVAILABLE or INTERNAL", name)
+	}
+	return code, nil
+}
+
+func (s *Server) runGRPC() error {
+	logger := logging.GetLogger()
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port)) //nolint:noctx // the mock server listens for its whole lifetime
+	if err != nil {
+		return err
+	}
+
+	logger.Info().
+		Int("port", s.config.Port).
+		Int("error_rate", s.config.ErrorRate).
+		Str("error_code", s.config.GRPCErrorCode).
+		Int("timeout_rate", s.config.TimeoutRate).
+		Dur("timeout_duration", s.config.TimeoutDuration).
+		Dur("latency", s.config.Latency).
+		Dur("jitter", s.config.Jitter).
+		Msg("Starting chaos gRPC mock server")
+
+	return s.ServeGRPC(lis)
+}
+
+// ServeGRPC serves the gRPC MockService, with server reflection, on lis.
+func (s *Server) ServeGRPC(lis net.Listener) error {
+	errorCode, err := parseCode(s.config.GRPCErrorCode)
+	if err != nil {
+		return err
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{"mock.proto": mockProto}),
+		}),
+	}
+	compiled, err := compiler.Compile(context.Background(), "mock.proto")
+	if err != nil {
+		return fmt.Errorf("failed to compile mock service: %w", err)
+	}
+	files := new(protoregistry.Files)
+	if err := files.RegisterFile(compiled[0]); err != nil {
+		return err
+	}
+	service := compiled[0].Services().ByName("MockService")
+	echo := service.Methods().ByName("Echo")
+
+	s.grpcServer = grpc.NewServer()
+	s.grpcServer.RegisterService(&grpc.ServiceDesc{
+		ServiceName: GRPCService,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Echo",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				return s.handleEcho(ctx, echo, errorCode, dec)
+			},
+		}},
+		Metadata: "mock.proto",
+	}, s)
+	reflectionpb.RegisterServerReflectionServer(s.grpcServer, reflection.NewServerV1(reflection.ServerOptions{
+		Services:           s.grpcServer,
+		DescriptorResolver: files,
+	}))
+
+	return s.grpcServer.Serve(lis)
+}
+
+// Stop stops a gRPC mock server.
+func (s *Server) Stop() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
+
+func (s *Server) handleEcho(ctx context.Context, method protoreflect.MethodDescriptor, errorCode codes.Code, dec func(any) error) (any, error) {
+	logger := logging.GetLogger()
+	start := time.Now()
+
+	req := dynamicpb.NewMessage(method.Input())
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+
+	s.simulateLatency()
+
+	if s.shouldTimeout() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(s.config.TimeoutDuration):
+		}
+		logger.Info().Str("code", codes.DeadlineExceeded.String()).Dur("duration", time.Since(start)).Msg("Timeout simulated")
+		return nil, status.Error(codes.DeadlineExceeded, "simulated timeout")
+	}
+
+	if s.shouldFail() {
+		logger.Info().Str("code", errorCode.String()).Dur("duration", time.Since(start)).Msg("Call processed")
+		return nil, status.Error(errorCode, "simulated error")
+	}
+
+	resp := dynamicpb.NewMessage(method.Output())
+	message := method.Input().Fields().ByName("message")
+	resp.Set(method.Output().Fields().ByName("message"), req.Get(message))
+
+	logger.Info().Str("code", codes.OK.String()).Dur("duration", time.Since(start)).Msg("Call processed")
+	return resp, nil
+}
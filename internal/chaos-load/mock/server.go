@@ -1,4 +1,4 @@
-// Package mock provides a configurable HTTP mock server for chaos load testing.
+// Package mock provides a configurable HTTP and gRPC mock server for chaos load testing.
 package mock
 
 import (
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"github.com/neogan/sre-toolkit/pkg/logging"
 )
 
@@ -20,12 +22,18 @@ type ServerConfig struct {
 	TimeoutDuration       time.Duration // How long to delay before returning 504
 	Latency               time.Duration // Sleep duration
 	Jitter                time.Duration // Latency variation (+/-)
+
+	// GRPC serves the gRPC MockService instead of HTTP. Errors then return
+	// GRPCErrorCode, e.g. UNAVAILABLE, and timeouts DEADLINE_EXCEEDED.
+	GRPC          bool
+	GRPCErrorCode string
 }
 
 // Server represents a chaos mock server
 type Server struct {
-	config ServerConfig
-	server *http.Server
+	config     ServerConfig
+	server     *http.Server
+	grpcServer *grpc.Server
 }
 
 // NewServer creates a new mock server
@@ -49,6 +57,14 @@ func (c ServerConfig) Validate() error {
 	if c.TimeoutRate > 0 && c.TimeoutDuration <= 0 {
 		return errors.New("timeout simulation requires --timeout-duration > 0")
 	}
+	if c.GRPC {
+		if c.ConnectionFailureRate > 0 {
+			return errors.New("connection failure simulation is not supported with --grpc")
+		}
+		if _, err := parseCode(c.GRPCErrorCode); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -57,6 +73,10 @@ func (c ServerConfig) Validate() error {
 func (s *Server) Run() error {
 	logger := logging.GetLogger()
 
+	if s.config.GRPC {
+		return s.runGRPC()
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", s.handleRequest)
 
@@ -83,20 +103,7 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	logger := logging.GetLogger()
 	start := time.Now()
 
-	// Simulate latency with jitter
-	if s.config.Latency > 0 {
-		delay := s.config.Latency
-		if s.config.Jitter > 0 {
-			jitterNanos := s.config.Jitter.Nanoseconds()
-			// Random offset in range [-jitter, +jitter]
-			offsetNanos := rand.Int63n(2*jitterNanos+1) - jitterNanos //nolint:gosec // math/rand is sufficient for load testing jitter
-			delay += time.Duration(offsetNanos)
-			if delay < 0 {
-				delay = 0
-			}
-		}
-		time.Sleep(delay)
-	}
+	s.simulateLatency()
 
 	// Simulate connection failure
 	if s.shouldFailConnection() {
@@ -130,12 +137,8 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 
 	// Simulate error
 	statusCode := http.StatusOK
-	if s.config.ErrorRate > 0 {
-		// rand.Intn(100) returns 0-99
-		// If ErrorRate is 10, we want 10% chance. 0-9 < 10.
-		if rand.Intn(100) < s.config.ErrorRate { //nolint:gosec // math/rand is sufficient for load testing jitter
-			statusCode = http.StatusInternalServerError
-		}
+	if s.shouldFail() {
+		statusCode = http.StatusInternalServerError
 	}
 
 	w.WriteHeader(statusCode)
@@ -153,6 +156,28 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 		Msg("Request processed")
 }
 
+// simulateLatency sleeps for the configured latency with jitter.
+func (s *Server) simulateLatency() {
+	if s.config.Latency <= 0 {
+		return
+	}
+	delay := s.config.Latency
+	if s.config.Jitter > 0 {
+		jitterNanos := s.config.Jitter.Nanoseconds()
+		// Random offset in range [-jitter, +jitter]
+		offsetNanos := rand.Int63n(2*jitterNanos+1) - jitterNanos //nolint:gosec // math/rand is sufficient for load testing jitter
+		delay += time.Duration(offsetNanos)
+		if delay < 0 {
+			delay = 0
+		}
+	}
+	time.Sleep(delay)
+}
+
+func (s *Server) shouldFail() bool {
+	return s.config.ErrorRate > 0 && rand.Intn(100) < s.config.ErrorRate //nolint:gosec // math/rand is sufficient for load testing jitter
+}
+
 func (s *Server) shouldFailConnection() bool {
 	return s.config.ConnectionFailureRate > 0 && rand.Intn(100) < s.config.ConnectionFailureRate //nolint:gosec // math/rand is sufficient for load testing jitter
 }
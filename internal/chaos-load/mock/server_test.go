@@ -252,3 +252,12 @@ func TestServer_Run_StartsAndShutdown(t *testing.T) {
 		t.Fatal("server did not stop within timeout")
 	}
 }
+
+func TestServerConfigValidate_GRPC(t *testing.T) {
+	assert.EqualError(t, (ServerConfig{GRPC: true, ConnectionFailureRate: 5}).Validate(),
+		"connection failure simulation is not supported with --grpc")
+	assert.EqualError(t, (ServerConfig{GRPC: true, GRPCErrorCode: "BROKEN"}).Validate(),
+		`invalid gRPC error code "BROKEN", e.g. UNAVAILABLE or INTERNAL`)
+	assert.Error(t, (ServerConfig{GRPC: true, GRPCErrorCode: "OK"}).Validate())
+	assert.NoError(t, (ServerConfig{GRPC: true, ErrorRate: 10, GRPCErrorCode: "internal"}).Validate())
+}
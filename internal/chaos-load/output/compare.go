@@ -0,0 +1,169 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// rateTolerance is the increase in an error or failed-check rate, in absolute
+// terms, that counts as a regression: relative changes of tiny rates are noise.
+const rateTolerance = 0.001
+
+type unit int
+
+const (
+	unitMillis unit = iota
+	unitRate
+	unitRPS
+)
+
+// Comparison is the change of one metric between two runs.
+type Comparison struct {
+	Metric     string
+	Base       float64
+	Current    float64
+	Regression bool
+
+	unit unit
+}
+
+// Change returns the relative change from the base run, or NaN when the base is zero.
+func (c Comparison) Change() float64 {
+	if c.Base == 0 {
+		if c.Current == 0 {
+			return 0
+		}
+		return math.NaN()
+	}
+	return (c.Current - c.Base) / c.Base
+}
+
+// Compare compares the current run with the base run. Latencies that grow and
+// request rates that fall by more than tolerance, a fraction such as 0.1, are
+// regressions, as are error and failed-check rates that grow by more than 0.1
+// percentage points. Steps are compared when both runs have them.
+func Compare(base, current *Results, tolerance float64) []Comparison {
+	comparisons := compareSummary(base.Summary, current.Summary, tolerance)
+
+	baseSteps := make(map[string]Step, len(base.Steps))
+	for _, step := range base.Steps {
+		baseSteps[step.Name] = step
+	}
+	for _, step := range current.Steps {
+		b, ok := baseSteps[step.Name]
+		if !ok {
+			continue
+		}
+		prefix := step.Name + " "
+		comparisons = append(comparisons,
+			latencyComparison(prefix+"p95", b.Latency.P95, step.Latency.P95, tolerance),
+			latencyComparison(prefix+"p99", b.Latency.P99, step.Latency.P99, tolerance),
+			rateComparison(prefix+"error_rate", ratio(b.Errors, b.Requests), ratio(step.Errors, step.Requests)),
+		)
+	}
+	return comparisons
+}
+
+func compareSummary(base, current Summary, tolerance float64) []Comparison {
+	rps := Comparison{Metric: "rps", Base: base.RPS, Current: current.RPS, unit: unitRPS}
+	rps.Regression = current.RPS < base.RPS*(1-tolerance)
+
+	return []Comparison{
+		rps,
+		latencyComparison("p50", base.Latency.P50, current.Latency.P50, tolerance),
+		latencyComparison("p90", base.Latency.P90, current.Latency.P90, tolerance),
+		latencyComparison("p95", base.Latency.P95, current.Latency.P95, tolerance),
+		latencyComparison("p99", base.Latency.P99, current.Latency.P99, tolerance),
+		latencyComparison("p99.9", base.Latency.P999, current.Latency.P999, tolerance),
+		latencyComparison("mean", base.Latency.Mean, current.Latency.Mean, tolerance),
+		latencyComparison("max", base.Latency.Max, current.Latency.Max, tolerance),
+		rateComparison("error_rate", base.ErrorRate, current.ErrorRate),
+		rateComparison("failed_check_rate", base.FailedCheckRate(), current.FailedCheckRate()),
+	}
+}
+
+func latencyComparison(metric string, base, current, tolerance float64) Comparison {
+	return Comparison{
+		Metric:     metric,
+		Base:       base,
+		Current:    current,
+		Regression: current > base*(1+tolerance),
+		unit:       unitMillis,
+	}
+}
+
+func rateComparison(metric string, base, current float64) Comparison {
+	return Comparison{
+		Metric:     metric,
+		Base:       base,
+		Current:    current,
+		Regression: current-base > rateTolerance,
+		unit:       unitRate,
+	}
+}
+
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// Regressions counts the comparisons that regressed.
+func Regressions(comparisons []Comparison) int {
+	count := 0
+	for _, c := range comparisons {
+		if c.Regression {
+			count++
+		}
+	}
+	return count
+}
+
+// FprintComparisons writes the comparisons as a table, marking regressions.
+func FprintComparisons(w io.Writer, base, current *Results, comparisons []Comparison) {
+	fmt.Fprintf(w, "\n=== Comparison ===\n")
+	fmt.Fprintf(w, "Base:    %s\n", describe(base))
+	fmt.Fprintf(w, "Current: %s\n\n", describe(current))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METRIC\tBASE\tCURRENT\tCHANGE\t")
+	for _, c := range comparisons {
+		marker := ""
+		if c.Regression {
+			marker = "REGRESSION"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Metric, c.format(c.Base), c.format(c.Current), formatChange(c.Change()), marker)
+	}
+	tw.Flush()
+}
+
+func describe(r *Results) string {
+	name := r.Name
+	if name == "" {
+		name = "unnamed run"
+	}
+	return fmt.Sprintf("%s (%s, %d requests)", name, r.StartedAt.Format(time.RFC3339), r.Summary.Requests)
+}
+
+func (c Comparison) format(value float64) string {
+	switch c.unit {
+	case unitMillis:
+		return time.Duration(math.Round(value * float64(time.Millisecond))).String()
+	case unitRate:
+		return strconv.FormatFloat(value*100, 'f', 2, 64) + "%"
+	default:
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	}
+}
+
+func formatChange(change float64) string {
+	if math.IsNaN(change) {
+		return "new"
+	}
+	return fmt.Sprintf("%+.1f%%", change*100)
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func findComparison(t *testing.T, comparisons []Comparison, metric string) Comparison {
+	t.Helper()
+	for _, c := range comparisons {
+		if c.Metric == metric {
+			return c
+		}
+	}
+	t.Fatalf("no comparison for %s", metric)
+	return Comparison{}
+}
+
+func TestCompare(t *testing.T) {
+	base := &Results{
+		Summary: Summary{Requests: 1000, RPS: 100, ErrorRate: 0.001, Latency: Latency{P50: 20, P99: 100, Max: 200}},
+		Steps:   []Step{{Name: "login", Requests: 100, Latency: Latency{P99: 50}}},
+	}
+	current := &Results{
+		Summary: Summary{Requests: 1000, RPS: 95, ErrorRate: 0.02, Latency: Latency{P50: 21, P99: 150, Max: 200}},
+		Steps: []Step{
+			{Name: "login", Requests: 100, Errors: 1, Latency: Latency{P99: 80}},
+			{Name: "checkout", Requests: 100},
+		},
+	}
+
+	comparisons := Compare(base, current, 0.1)
+
+	for metric, regression := range map[string]bool{
+		"rps":              false, // -5% is within tolerance
+		"p50":              false,
+		"p99":              true,
+		"max":              false,
+		"error_rate":       true,
+		"login p99":        true,
+		"login error_rate": true,
+	} {
+		if got := findComparison(t, comparisons, metric).Regression; got != regression {
+			t.Errorf("%s: expected regression %v, got %v", metric, regression, got)
+		}
+	}
+	for _, c := range comparisons {
+		if strings.HasPrefix(c.Metric, "checkout ") {
+			t.Fatalf("expected steps missing from the base run to be skipped, got %s", c.Metric)
+		}
+	}
+	if got := findComparison(t, comparisons, "p99").Change(); math.Abs(got-0.5) > 1e-9 {
+		t.Fatalf("expected p99 change of 50%%, got %v", got)
+	}
+	if got := Regressions(comparisons); got != 4 {
+		t.Fatalf("expected 4 regressions, got %d", got)
+	}
+}
+
+func TestCompare_RPSDrop(t *testing.T) {
+	base := &Results{Summary: Summary{RPS: 100}}
+	current := &Results{Summary: Summary{RPS: 80}}
+	if !findComparison(t, Compare(base, current, 0.1), "rps").Regression {
+		t.Fatal("expected a 20% drop in rps to be a regression")
+	}
+}
+
+func TestFprintComparisons(t *testing.T) {
+	base := &Results{Name: "before", Summary: Summary{Latency: Latency{P99: 100}}}
+	current := &Results{Name: "after", Summary: Summary{Latency: Latency{P99: 150}}}
+
+	var buf bytes.Buffer
+	FprintComparisons(&buf, base, current, Compare(base, current, 0.1))
+	out := buf.String()
+
+	for _, want := range []string{"=== Comparison ===", "Base:    before", "Current: after", "+50.0%", "REGRESSION", "150ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+// csvExporter writes the time series to a file, one row per interval.
+type csvExporter struct {
+	path string
+}
+
+func (e *csvExporter) Observe(context.Context, stats.Interval, stats.SnapshotStats) error {
+	return nil
+}
+
+func (e *csvExporter) Export(_ context.Context, results *Results) error {
+	f, err := os.Create(e.path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return fmt.Errorf("failed to create time series: %w", err)
+	}
+	if err := WriteCSV(f, results.Series); err != nil {
+		f.Close() //nolint:errcheck,gosec // the write error is reported instead
+		return fmt.Errorf("failed to write time series: %w", err)
+	}
+	return f.Close()
+}
+
+// WriteCSV writes the time series with a column per response status code
+// seen during the run; transport errors have their own column. Runs split
+// into phases get a last column naming the phase of each interval.
+func WriteCSV(w io.Writer, series []Point) error {
+	codeSet := make(map[int]bool)
+	phased := false
+	for _, point := range series {
+		for code := range point.StatusCodes {
+			codeSet[code] = true
+		}
+		phased = phased || point.Phase != ""
+	}
+	codes := make([]int, 0, len(codeSet))
+	for code := range codeSet {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	header := []string{
+		"offset_seconds", "duration_seconds", "rps", "requests", "errors", "failed_checks",
+		"p50_ms", "p90_ms", "p95_ms", "p99_ms", "p99_9_ms", "mean_ms", "max_ms",
+	}
+	for _, code := range codes {
+		header = append(header, "status_"+strconv.Itoa(code))
+	}
+	if phased {
+		header = append(header, "phase")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, p := range series {
+		row := []string{
+			formatFloat(p.Offset), formatFloat(p.Duration), formatFloat(p.RPS),
+			strconv.Itoa(p.Requests), strconv.Itoa(p.Errors), strconv.Itoa(p.FailedChecks),
+			formatFloat(p.Latency.P50), formatFloat(p.Latency.P90), formatFloat(p.Latency.P95),
+			formatFloat(p.Latency.P99), formatFloat(p.Latency.P999), formatFloat(p.Latency.Mean),
+			formatFloat(p.Latency.Max),
+		}
+		for _, code := range codes {
+			row = append(row, strconv.Itoa(p.StatusCodes[code]))
+		}
+		if phased {
+			row = append(row, p.Phase)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
@@ -0,0 +1,73 @@
+// Package output exports load test results in machine-readable form: a JSON
+// results document, a CSV time series and Prometheus metrics pushed to a
+// Pushgateway or a remote-write endpoint.
+package output
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+// Exporter receives the results of a load test.
+type Exporter interface {
+	// Observe is called with every interval rolled up while the test runs.
+	Observe(ctx context.Context, interval stats.Interval, snapshot stats.SnapshotStats) error
+	// Export is called once with the final results.
+	Export(ctx context.Context, results *Results) error
+}
+
+// Spec is a parsed --out flag.
+type Spec struct {
+	Kind   string
+	Target string
+}
+
+// ParseSpec parses "<kind>=<target>", e.g. "json=results.json",
+// "csv=timeseries.csv" or "prometheus=http://pushgateway:9091".
+func ParseSpec(value string) (Spec, error) {
+	kind, target, found := strings.Cut(value, "=")
+	spec := Spec{Kind: strings.TrimSpace(kind), Target: strings.TrimSpace(target)}
+	if !found || spec.Target == "" {
+		return Spec{}, fmt.Errorf("invalid output %q: expected <kind>=<target>, e.g. json=results.json", value)
+	}
+	switch spec.Kind {
+	case "json", "csv", "prometheus":
+		return spec, nil
+	default:
+		return Spec{}, fmt.Errorf("invalid output %q: unknown kind %q (expected json, csv or prometheus)", value, spec.Kind)
+	}
+}
+
+// New returns the exporter for a spec. The test name labels pushed metrics.
+func New(spec Spec, name string) (Exporter, error) {
+	switch spec.Kind {
+	case "json":
+		return &jsonExporter{path: spec.Target}, nil
+	case "csv":
+		return &csvExporter{path: spec.Target}, nil
+	case "prometheus":
+		return NewPrometheus(spec.Target, name)
+	default:
+		return nil, fmt.Errorf("unknown output kind %q", spec.Kind)
+	}
+}
+
+// NewAll parses the --out flags and returns their exporters.
+func NewAll(values []string, name string) ([]Exporter, error) {
+	exporters := make([]Exporter, 0, len(values))
+	for _, value := range values {
+		spec, err := ParseSpec(value)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := New(spec, name)
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, exporter)
+	}
+	return exporters, nil
+}
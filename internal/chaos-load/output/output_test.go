@@ -0,0 +1,186 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec("csv=out/timeseries.csv")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Kind != "csv" || spec.Target != "out/timeseries.csv" {
+		t.Fatalf("unexpected spec: %+v", spec)
+	}
+
+	for _, value := range []string{"results.json", "json=", "xml=results.xml"} {
+		if _, err := ParseSpec(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestNewAll_RejectsInvalidPrometheusURL(t *testing.T) {
+	if _, err := NewAll([]string{"prometheus=pushgateway:9091"}, "test"); err == nil {
+		t.Fatal("expected a URL without scheme to be rejected")
+	}
+}
+
+func testResults(t *testing.T) *Results {
+	t.Helper()
+	threshold, err := check.ParseThreshold("p99 < 300ms")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	snapshot := stats.SnapshotStats{
+		TotalRequests: 200,
+		Errors:        2,
+		Elapsed:       2 * time.Second,
+		RPS:           100,
+		StatusCodes:   map[int]int{200: 190, 503: 8},
+		Latency:       stats.LatencyStats{P50: 20 * time.Millisecond, P99: 150 * time.Millisecond, Max: 250 * time.Millisecond},
+	}
+	series := []stats.Interval{
+		{Duration: time.Second, Requests: 100, StatusCodes: map[int]int{200: 100}},
+		{Offset: time.Second, Duration: time.Second, Requests: 100, Errors: 2, StatusCodes: map[int]int{200: 90, 503: 8}},
+	}
+	outcomes := []check.Outcome{threshold.Evaluate(snapshot)}
+	return NewResults("checkout", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), snapshot, series, outcomes)
+}
+
+func TestNewResults(t *testing.T) {
+	r := testResults(t)
+	if r.Summary.ErrorRate != 0.01 {
+		t.Fatalf("expected error rate 0.01, got %v", r.Summary.ErrorRate)
+	}
+	if r.Summary.Latency.P99 != 150 || r.Summary.Latency.Max != 250 {
+		t.Fatalf("expected latencies in milliseconds, got %+v", r.Summary.Latency)
+	}
+	if len(r.Thresholds) != 1 || !r.Thresholds[0].Passed || r.Thresholds[0].Actual != "150ms" {
+		t.Fatalf("unexpected thresholds: %+v", r.Thresholds)
+	}
+	if len(r.Series) != 2 || r.Series[1].Offset != 1 || r.Series[1].RPS != 100 {
+		t.Fatalf("unexpected series: %+v", r.Series)
+	}
+	if r.Summary.Timing != nil {
+		t.Fatalf("expected no timing without measurements, got %+v", r.Summary.Timing)
+	}
+}
+
+func TestNewResults_Phases(t *testing.T) {
+	snapshot := stats.SnapshotStats{
+		TotalRequests: 30,
+		Phases: []stats.PhaseStats{
+			{Name: "baseline", End: 10 * time.Second, Requests: 20},
+			{Name: "fault: partition", Start: 10 * time.Second, End: 15 * time.Second, Requests: 10, Errors: 4,
+				Latency: stats.LatencyStats{P99: 900 * time.Millisecond}},
+		},
+	}
+	series := []stats.Interval{{Offset: 10 * time.Second, Duration: time.Second, Phase: "fault: partition"}}
+	r := NewResults("experiment", time.Now(), snapshot, series, nil)
+
+	if len(r.Phases) != 2 {
+		t.Fatalf("expected 2 phases, got %+v", r.Phases)
+	}
+	fault := r.Phases[1]
+	if fault.Start != 10 || fault.End != 15 || fault.ErrorRate != 0.4 || fault.Latency.P99 != 900 {
+		t.Fatalf("unexpected fault phase: %+v", fault)
+	}
+	if r.Series[0].Phase != "fault: partition" {
+		t.Fatalf("expected the series to be annotated, got %+v", r.Series[0])
+	}
+}
+
+func TestNewResults_Timing(t *testing.T) {
+	snapshot := stats.SnapshotStats{
+		TotalRequests: 10,
+		Timing: stats.TimingStats{
+			Connections: 2,
+			Measured:    10,
+			Connect:     stats.LatencyStats{P50: time.Millisecond},
+			TTFB:        stats.LatencyStats{P99: 40 * time.Millisecond},
+		},
+	}
+	r := NewResults("api", time.Now(), snapshot, nil, nil)
+	if r.Summary.Timing == nil {
+		t.Fatal("expected timing in the summary")
+	}
+	if r.Summary.Timing.Connections != 2 || r.Summary.Timing.Connect.P50 != 1 || r.Summary.Timing.TTFB.P99 != 40 {
+		t.Fatalf("unexpected timing: %+v", r.Summary.Timing)
+	}
+}
+
+func TestJSONExporter_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	exporters, err := NewAll([]string{"json=" + path}, "checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := testResults(t)
+	if err := exporters[0].Export(context.Background(), want); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := ReadResults(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "checkout" || !got.StartedAt.Equal(want.StartedAt) {
+		t.Fatalf("unexpected run: %s at %v", got.Name, got.StartedAt)
+	}
+	if got.Summary.StatusCodes[503] != 8 || len(got.Series) != 2 {
+		t.Fatalf("unexpected results: %+v", got)
+	}
+}
+
+func TestReadResults_InvalidFile(t *testing.T) {
+	if _, err := ReadResults(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testResults(t).Series); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "offset_seconds,duration_seconds,rps,requests,errors") ||
+		!strings.HasSuffix(lines[0], ",status_200,status_503") {
+		t.Fatalf("unexpected header: %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "1.000,1.000,100.000,100,2,0,") || !strings.HasSuffix(lines[2], ",90,8") {
+		t.Fatalf("unexpected row: %s", lines[2])
+	}
+}
+
+func TestWriteCSV_Phases(t *testing.T) {
+	series := []Point{
+		{Duration: 1, Requests: 10, StatusCodes: map[int]int{200: 10}, Phase: "baseline"},
+		{Offset: 1, Duration: 1, Requests: 10, Errors: 10, Phase: "fault: partition"},
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, series); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",status_200,phase") {
+		t.Fatalf("unexpected header: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[2], ",0,fault: partition") {
+		t.Fatalf("unexpected row: %s", lines[2])
+	}
+}
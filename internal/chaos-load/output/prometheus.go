@@ -0,0 +1,250 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+	"github.com/neogan/sre-toolkit/pkg/metrics"
+)
+
+// pushJob is the Pushgateway job the metrics are grouped under.
+const pushJob = "chaos_load"
+
+// pushTimeout bounds a single push, so that an unresponsive Pushgateway or
+// remote-write endpoint cannot hold up the run.
+const pushTimeout = 10 * time.Second
+
+// latencyQuantiles are exported as the quantile label of the latency gauge.
+var latencyQuantiles = []struct {
+	label string
+	value func(stats.LatencyStats) time.Duration
+}{
+	{"0.5", func(l stats.LatencyStats) time.Duration { return l.P50 }},
+	{"0.9", func(l stats.LatencyStats) time.Duration { return l.P90 }},
+	{"0.95", func(l stats.LatencyStats) time.Duration { return l.P95 }},
+	{"0.99", func(l stats.LatencyStats) time.Duration { return l.P99 }},
+	{"0.999", func(l stats.LatencyStats) time.Duration { return l.P999 }},
+	{"1", func(l stats.LatencyStats) time.Duration { return l.Max }},
+}
+
+// Prometheus pushes the load test metrics after every interval, so they can
+// be graphed next to the target's own metrics. Rates and latencies describe
+// the last interval; request, error and status counts are cumulative.
+//
+// Interval pushes run in the background, so that a slow endpoint cannot hold
+// up the intervals of the run. At most one push waits while another is in
+// flight; later intervals are coalesced into it, as a push always sends the
+// latest values. The final push of Export is synchronous.
+type Prometheus struct {
+	registry *prometheus.Registry
+	pusher   *push.Pusher
+	writer   *metrics.RemoteWriter
+
+	startPusher sync.Once
+	pending     chan struct{}
+	stopPusher  context.CancelFunc
+	pusherDone  chan struct{}
+	mu          sync.Mutex
+	pushErr     error
+
+	rps          prometheus.Gauge
+	targetRPS    prometheus.Gauge
+	latency      *prometheus.GaugeVec
+	requests     prometheus.Gauge
+	errors       prometheus.Gauge
+	failedChecks prometheus.Gauge
+	dropped      prometheus.Gauge
+	responses    *prometheus.GaugeVec
+	thresholds   *prometheus.GaugeVec
+}
+
+// NewPrometheus returns an exporter for target. A URL whose path ends in
+// /write or /push, such as Prometheus' /api/v1/write or Mimir's /api/v1/push,
+// is a remote-write endpoint; any other URL is a Pushgateway. The metrics are
+// labelled test=<name>.
+func NewPrometheus(target, name string) (*Prometheus, error) {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid prometheus output %q: expected a Pushgateway or remote-write URL", target)
+	}
+	if name == "" {
+		name = "chaos-load"
+	}
+
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		rps: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_requests_per_second",
+			Help: "Requests sent per second during the last interval",
+		}),
+		targetRPS: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_target_requests_per_second",
+			Help: "Planned arrival rate of an open-model load test",
+		}),
+		latency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_latency_seconds",
+			Help: "Latency percentiles of the last interval; quantile 1 is the maximum",
+		}, []string{"quantile"}),
+		requests: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_requests",
+			Help: "Requests sent since the start of the test",
+		}),
+		errors: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_errors",
+			Help: "Requests that failed without a response since the start of the test",
+		}),
+		failedChecks: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_failed_checks",
+			Help: "Responses that failed a check since the start of the test",
+		}),
+		dropped: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_dropped",
+			Help: "Open-model iterations dropped because every worker was busy",
+		}),
+		responses: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_responses",
+			Help: "Responses per status code since the start of the test",
+		}, []string{"code"}),
+		thresholds: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sre_toolkit_chaos_load_threshold_passed",
+			Help: "Whether a threshold passed (1) or failed (0) at the end of the test",
+		}, []string{"threshold"}),
+	}
+	p.registry.MustRegister(p.rps, p.targetRPS, p.latency, p.requests, p.errors,
+		p.failedChecks, p.dropped, p.responses, p.thresholds)
+
+	if isRemoteWrite(u) {
+		p.writer, err = metrics.NewRemoteWriter(&metrics.RemoteWriteConfig{
+			URL:            target,
+			Timeout:        pushTimeout,
+			ExternalLabels: map[string]string{"test": name},
+			MetricPrefix:   metrics.DefaultRemoteWritePrefix,
+		}, p.registry)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		p.pusher = push.New(target, pushJob).
+			Client(&http.Client{Timeout: pushTimeout}).
+			Grouping("test", name).
+			Gatherer(p.registry)
+	}
+	return p, nil
+}
+
+func isRemoteWrite(u *url.URL) bool {
+	path := strings.TrimSuffix(u.Path, "/")
+	return strings.HasSuffix(path, "/write") || strings.HasSuffix(path, "/push")
+}
+
+// Observe sets the metrics from the interval and the running totals, and
+// queues a background push. It returns the error of an earlier push that
+// failed since the last call, if any.
+func (p *Prometheus) Observe(_ context.Context, interval stats.Interval, snapshot stats.SnapshotStats) error {
+	p.rps.Set(interval.RPS())
+	for _, q := range latencyQuantiles {
+		p.latency.WithLabelValues(q.label).Set(q.value(interval.Latency).Seconds())
+	}
+	p.setTotals(snapshot.TotalRequests, snapshot.Errors, snapshot.FailedChecks, snapshot.Dropped,
+		snapshot.TargetRPS, snapshot.StatusCodes)
+
+	p.startPusher.Do(p.startBackgroundPush)
+	select {
+	case p.pending <- struct{}{}:
+	default:
+		// A push is already waiting and will send these values.
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.pushErr
+	p.pushErr = nil
+	return err
+}
+
+// Export stops the background pushes and pushes the final totals and the
+// threshold verdicts.
+func (p *Prometheus) Export(ctx context.Context, results *Results) error {
+	p.stopBackgroundPush()
+
+	s := results.Summary
+	p.setTotals(s.Requests, s.Errors, s.FailedChecks, s.Dropped, s.TargetRPS, s.StatusCodes)
+	for _, t := range results.Thresholds {
+		passed := 0.0
+		if t.Passed {
+			passed = 1
+		}
+		p.thresholds.WithLabelValues(t.Expr).Set(passed)
+	}
+	return p.push(ctx)
+}
+
+func (p *Prometheus) setTotals(requests, errors, failedChecks, dropped int, targetRPS float64, statusCodes map[int]int) {
+	p.requests.Set(float64(requests))
+	p.errors.Set(float64(errors))
+	p.failedChecks.Set(float64(failedChecks))
+	p.dropped.Set(float64(dropped))
+	p.targetRPS.Set(targetRPS)
+	for code, count := range statusCodes {
+		p.responses.WithLabelValues(strconv.Itoa(code)).Set(float64(count))
+	}
+}
+
+// startBackgroundPush starts the goroutine that runs the queued pushes.
+func (p *Prometheus) startBackgroundPush() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.pending = make(chan struct{}, 1)
+	p.stopPusher = cancel
+	p.pusherDone = make(chan struct{})
+
+	go func() {
+		defer close(p.pusherDone)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.pending:
+			}
+			if err := p.push(ctx); err != nil && ctx.Err() == nil {
+				p.mu.Lock()
+				p.pushErr = err
+				p.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// stopBackgroundPush cancels a push in flight, drops a queued one and waits
+// for the goroutine to end. The final push supersedes them.
+func (p *Prometheus) stopBackgroundPush() {
+	p.startPusher.Do(func() {})
+	if p.stopPusher == nil {
+		return
+	}
+	p.stopPusher()
+	<-p.pusherDone
+}
+
+// push sends the metrics, giving up after pushTimeout.
+func (p *Prometheus) push(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+
+	if p.writer != nil {
+		return p.writer.Write(ctx, time.Now())
+	}
+	if err := p.pusher.PushContext(ctx); err != nil {
+		return fmt.Errorf("pushgateway push failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package output
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+type recordedRequest struct {
+	method, path, encoding, body string
+}
+
+func newRecordingServer(t *testing.T) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read body: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, recordedRequest{r.Method, r.URL.Path, r.Header.Get("Content-Encoding"), string(body)})
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+// waitForRequests waits until the server has received n requests.
+func waitForRequests(t *testing.T, requests func() []recordedRequest, n int) []recordedRequest {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got := requests()
+		if len(got) >= n || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPrometheus_Pushgateway(t *testing.T) {
+	server, requests := newRecordingServer(t)
+	exporter, err := NewPrometheus(server.URL, "checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exporter.writer != nil {
+		t.Fatal("expected a Pushgateway URL not to use remote write")
+	}
+
+	interval := stats.Interval{Duration: time.Second, Requests: 50, Latency: stats.LatencyStats{P99: 120 * time.Millisecond}}
+	snapshot := stats.SnapshotStats{TotalRequests: 50, StatusCodes: map[int]int{200: 50}}
+	if err := exporter.Observe(context.Background(), interval, snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := waitForRequests(t, requests, 1)
+	if len(got) != 1 {
+		t.Fatalf("expected one push, got %d", len(got))
+	}
+	if got[0].method != http.MethodPut || got[0].path != "/metrics/job/chaos_load/test/checkout" {
+		t.Fatalf("unexpected push: %s %s", got[0].method, got[0].path)
+	}
+	for _, want := range []string{
+		"sre_toolkit_chaos_load_requests_per_second",
+		"sre_toolkit_chaos_load_latency_seconds",
+		"sre_toolkit_chaos_load_responses",
+	} {
+		if !strings.Contains(got[0].body, want) {
+			t.Errorf("expected push to contain %s", want)
+		}
+	}
+}
+
+func TestPrometheus_RemoteWrite(t *testing.T) {
+	server, requests := newRecordingServer(t)
+	exporter, err := NewPrometheus(server.URL+"/api/v1/write", "checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := &Results{
+		Summary:    Summary{Requests: 10, StatusCodes: map[int]int{200: 10}},
+		Thresholds: []Threshold{{Expr: "p99 < 300ms", Passed: true}},
+	}
+	if err := exporter.Export(context.Background(), results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("expected one write, got %d", len(got))
+	}
+	if got[0].method != http.MethodPost || got[0].path != "/api/v1/write" || got[0].encoding != "snappy" {
+		t.Fatalf("unexpected write: %s %s (%s)", got[0].method, got[0].path, got[0].encoding)
+	}
+}
+
+func TestPrometheus_PushgatewayHung(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	exporter, err := NewPrometheus(server.URL, "checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	for range 5 {
+		if err := exporter.Observe(context.Background(), stats.Interval{Duration: time.Second}, stats.SnapshotStats{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Observe took %v, expected pushes not to hold up the intervals", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err := exporter.Export(ctx, &Results{}); err == nil {
+		t.Fatal("expected the final push to a hung Pushgateway to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Export returned after %v, expected it to stop with the run", elapsed)
+	}
+}
+
+func TestPrometheus_CoalescesPushes(t *testing.T) {
+	var mu sync.Mutex
+	pushes := 0
+	first := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		pushes++
+		n := pushes
+		mu.Unlock()
+		if n == 1 {
+			close(first)
+			<-release
+		}
+	}))
+	defer server.Close()
+
+	exporter, err := NewPrometheus(server.URL, "checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	observe := func(requests int) {
+		snapshot := stats.SnapshotStats{TotalRequests: requests}
+		if err := exporter.Observe(context.Background(), stats.Interval{Duration: time.Second}, snapshot); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	observe(1)
+	<-first
+	// The first push is in flight; the following intervals queue one push.
+	for i := 2; i <= 10; i++ {
+		observe(i)
+	}
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := pushes
+		mu.Unlock()
+		if n >= 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if pushes != 2 {
+		t.Fatalf("expected the queued intervals to be coalesced into one push, got %d pushes", pushes)
+	}
+}
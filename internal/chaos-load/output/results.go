@@ -0,0 +1,239 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+// Results is the JSON document describing a load test run. Latencies are in
+// milliseconds and durations in seconds, so the document is easy to consume
+// from other tools.
+type Results struct {
+	Name       string      `json:"name,omitempty"`
+	StartedAt  time.Time   `json:"started_at"`
+	Duration   float64     `json:"duration_seconds"`
+	Summary    Summary     `json:"summary"`
+	Steps      []Step      `json:"steps,omitempty"`
+	Phases     []Phase     `json:"phases,omitempty"`
+	Thresholds []Threshold `json:"thresholds,omitempty"`
+	Series     []Point     `json:"series,omitempty"`
+}
+
+// Summary holds the totals of the whole run.
+type Summary struct {
+	Requests      int            `json:"requests"`
+	Errors        int            `json:"errors"`
+	FailedChecks  int            `json:"failed_checks"`
+	ErrorRate     float64        `json:"error_rate"`
+	RPS           float64        `json:"rps"`
+	TargetRPS     float64        `json:"target_rps,omitempty"`
+	Planned       int            `json:"planned,omitempty"`
+	Dropped       int            `json:"dropped,omitempty"`
+	StatusCodes   map[int]int    `json:"status_codes"`
+	CheckFailures map[string]int `json:"check_failures,omitempty"`
+	Latency       Latency        `json:"latency"`
+	Timing        *Timing        `json:"timing,omitempty"`
+}
+
+// FailedCheckRate returns the share of requests that failed a check.
+func (s Summary) FailedCheckRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.FailedChecks) / float64(s.Requests)
+}
+
+// Latency holds latency percentiles in milliseconds.
+type Latency struct {
+	P50  float64 `json:"p50_ms"`
+	P90  float64 `json:"p90_ms"`
+	P95  float64 `json:"p95_ms"`
+	P99  float64 `json:"p99_ms"`
+	P999 float64 `json:"p99_9_ms"`
+	Mean float64 `json:"mean_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// Timing breaks the latency of HTTP requests down by phase. Connect and
+// TLSHandshake only cover the requests that opened a new connection.
+type Timing struct {
+	Connections  int     `json:"connections"`
+	Handshakes   int     `json:"tls_handshakes"`
+	Connect      Latency `json:"connect"`
+	TLSHandshake Latency `json:"tls_handshake"`
+	TTFB         Latency `json:"ttfb"`
+}
+
+// Step holds the totals of one scenario step.
+type Step struct {
+	Name         string      `json:"name"`
+	Requests     int         `json:"requests"`
+	Errors       int         `json:"errors"`
+	FailedChecks int         `json:"failed_checks"`
+	StatusCodes  map[int]int `json:"status_codes"`
+	Latency      Latency     `json:"latency"`
+}
+
+// Phase holds the totals of one phase of the run, such as the window of an
+// injected fault.
+type Phase struct {
+	Name         string      `json:"name"`
+	Start        float64     `json:"start_seconds"`
+	End          float64     `json:"end_seconds"`
+	Requests     int         `json:"requests"`
+	Errors       int         `json:"errors"`
+	FailedChecks int         `json:"failed_checks"`
+	ErrorRate    float64     `json:"error_rate"`
+	StatusCodes  map[int]int `json:"status_codes"`
+	Latency      Latency     `json:"latency"`
+}
+
+// Threshold is the verdict of one threshold.
+type Threshold struct {
+	Expr   string `json:"expr"`
+	Actual string `json:"actual"`
+	Passed bool   `json:"passed"`
+}
+
+// Point is one interval of the time series, normally one second.
+type Point struct {
+	Offset       float64     `json:"offset_seconds"`
+	Duration     float64     `json:"duration_seconds"`
+	RPS          float64     `json:"rps"`
+	Requests     int         `json:"requests"`
+	Errors       int         `json:"errors"`
+	FailedChecks int         `json:"failed_checks"`
+	StatusCodes  map[int]int `json:"status_codes,omitempty"`
+	Latency      Latency     `json:"latency"`
+	Phase        string      `json:"phase,omitempty"`
+}
+
+// NewResults builds the results document from a snapshot, the interval series
+// and the threshold outcomes.
+func NewResults(name string, startedAt time.Time, snapshot stats.SnapshotStats, series []stats.Interval, outcomes []check.Outcome) *Results {
+	r := &Results{
+		Name:      name,
+		StartedAt: startedAt.UTC(),
+		Duration:  snapshot.Elapsed.Seconds(),
+		Summary: Summary{
+			Requests:      snapshot.TotalRequests,
+			Errors:        snapshot.Errors,
+			FailedChecks:  snapshot.FailedChecks,
+			RPS:           snapshot.RPS,
+			TargetRPS:     snapshot.TargetRPS,
+			Planned:       snapshot.Planned,
+			Dropped:       snapshot.Dropped,
+			StatusCodes:   snapshot.StatusCodes,
+			CheckFailures: snapshot.CheckFailures,
+			Latency:       newLatency(snapshot.Latency),
+		},
+	}
+	if snapshot.TotalRequests > 0 {
+		r.Summary.ErrorRate = float64(snapshot.Errors) / float64(snapshot.TotalRequests)
+	}
+	if snapshot.Timing.Measured > 0 {
+		r.Summary.Timing = &Timing{
+			Connections:  snapshot.Timing.Connections,
+			Handshakes:   snapshot.Timing.Handshakes,
+			Connect:      newLatency(snapshot.Timing.Connect),
+			TLSHandshake: newLatency(snapshot.Timing.TLSHandshake),
+			TTFB:         newLatency(snapshot.Timing.TTFB),
+		}
+	}
+
+	for _, step := range snapshot.Steps {
+		r.Steps = append(r.Steps, Step{
+			Name:         step.Name,
+			Requests:     step.Requests,
+			Errors:       step.Errors,
+			FailedChecks: step.FailedChecks,
+			StatusCodes:  step.StatusCodes,
+			Latency:      newLatency(step.Latency),
+		})
+	}
+	for _, phase := range snapshot.Phases {
+		r.Phases = append(r.Phases, Phase{
+			Name:         phase.Name,
+			Start:        phase.Start.Seconds(),
+			End:          phase.End.Seconds(),
+			Requests:     phase.Requests,
+			Errors:       phase.Errors,
+			FailedChecks: phase.FailedChecks,
+			ErrorRate:    phase.ErrorRate(),
+			StatusCodes:  phase.StatusCodes,
+			Latency:      newLatency(phase.Latency),
+		})
+	}
+	for _, o := range outcomes {
+		r.Thresholds = append(r.Thresholds, Threshold{Expr: o.Threshold.Expr, Actual: o.Actual, Passed: o.Passed})
+	}
+	for _, interval := range series {
+		r.Series = append(r.Series, Point{
+			Offset:       interval.Offset.Seconds(),
+			Duration:     interval.Duration.Seconds(),
+			RPS:          interval.RPS(),
+			Requests:     interval.Requests,
+			Errors:       interval.Errors,
+			FailedChecks: interval.FailedChecks,
+			StatusCodes:  interval.StatusCodes,
+			Latency:      newLatency(interval.Latency),
+			Phase:        interval.Phase,
+		})
+	}
+	return r
+}
+
+func newLatency(l stats.LatencyStats) Latency {
+	return Latency{
+		P50:  milliseconds(l.P50),
+		P90:  milliseconds(l.P90),
+		P95:  milliseconds(l.P95),
+		P99:  milliseconds(l.P99),
+		P999: milliseconds(l.P999),
+		Mean: milliseconds(l.Mean),
+		Max:  milliseconds(l.Max),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// ReadResults loads a results document written by --out json.
+func ReadResults(path string) (*Results, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+	r := &Results{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse results %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// jsonExporter writes the results document to a file.
+type jsonExporter struct {
+	path string
+}
+
+func (e *jsonExporter) Observe(context.Context, stats.Interval, stats.SnapshotStats) error {
+	return nil
+}
+
+func (e *jsonExporter) Export(_ context.Context, results *Results) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	if err := os.WriteFile(e.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	return nil
+}
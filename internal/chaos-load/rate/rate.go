@@ -0,0 +1,145 @@
+// Package rate schedules open-model load: iterations start at planned times
+// derived from a target arrival rate, independent of how fast responses come back.
+package rate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stage ramps the arrival rate linearly to Target (iterations per second) over Duration.
+type Stage struct {
+	Duration time.Duration
+	Target   float64
+}
+
+// Parse parses a rate such as "500/s", "30/m", "10/100ms" or "500" (per second)
+// and returns it in iterations per second.
+func Parse(s string) (float64, error) {
+	count, per, found := strings.Cut(strings.TrimSpace(s), "/")
+	value, err := strconv.ParseFloat(count, 64)
+	if err != nil || value < 0 || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid rate %q: expected a non-negative number such as 500/s", s)
+	}
+	if !found {
+		return value, nil
+	}
+
+	if per != "" && (per[0] < '0' || per[0] > '9') {
+		per = "1" + per
+	}
+	unit, err := time.ParseDuration(per)
+	if err != nil || unit <= 0 {
+		return 0, fmt.Errorf("invalid rate %q: unknown time unit %q", s, per)
+	}
+	return value / unit.Seconds(), nil
+}
+
+// ParseStages parses a comma-separated list of duration:rate stages, e.g.
+// "30s:100,2m:500,30s:0" ramps up to 100/s, then to 500/s and back down to 0.
+func ParseStages(s string) ([]Stage, error) {
+	parts := strings.Split(s, ",")
+	stages := make([]Stage, 0, len(parts))
+	for _, part := range parts {
+		durationStr, targetStr, found := strings.Cut(strings.TrimSpace(part), ":")
+		if !found {
+			return nil, fmt.Errorf("invalid stage %q: expected duration:rate", part)
+		}
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid stage %q: duration must be positive", part)
+		}
+		target, err := Parse(targetStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stage %q: %w", part, err)
+		}
+		stages = append(stages, Stage{Duration: duration, Target: target})
+	}
+	return stages, nil
+}
+
+type segment struct {
+	start      time.Duration
+	duration   time.Duration
+	from, to   float64
+	cumulative float64
+}
+
+// Schedule gives the planned start offset of every iteration of an open-model run.
+type Schedule struct {
+	segments []segment
+	duration time.Duration
+	total    float64
+}
+
+// Constant returns a schedule sending rps iterations per second for duration.
+func Constant(rps float64, duration time.Duration) *Schedule {
+	return newSchedule([]segment{{duration: duration, from: rps, to: rps}})
+}
+
+// Ramping returns a schedule that starts at zero and ramps linearly to the
+// target of each stage in turn.
+func Ramping(stages []Stage) *Schedule {
+	segments := make([]segment, 0, len(stages))
+	from := 0.0
+	for _, stage := range stages {
+		segments = append(segments, segment{duration: stage.Duration, from: from, to: stage.Target})
+		from = stage.Target
+	}
+	return newSchedule(segments)
+}
+
+func newSchedule(segments []segment) *Schedule {
+	s := &Schedule{segments: segments}
+	for i := range s.segments {
+		seg := &s.segments[i]
+		seg.start = s.duration
+		seg.cumulative = s.total
+		s.duration += seg.duration
+		s.total += (seg.from + seg.to) / 2 * seg.duration.Seconds()
+	}
+	return s
+}
+
+// Duration returns the length of the schedule.
+func (s *Schedule) Duration() time.Duration {
+	return s.duration
+}
+
+// Planned returns the number of iterations the schedule starts.
+func (s *Schedule) Planned() int {
+	return int(math.Ceil(s.total - 1e-9))
+}
+
+// Offset returns when iteration i (counting from zero) is due, relative to the
+// start of the run, and false once the schedule has no iteration i.
+func (s *Schedule) Offset(i int) (time.Duration, bool) {
+	if i < 0 || i >= s.Planned() {
+		return 0, false
+	}
+
+	n := float64(i)
+	for _, seg := range s.segments {
+		seconds := seg.duration.Seconds()
+		segTotal := (seg.from + seg.to) / 2 * seconds
+		if n >= seg.cumulative+segTotal {
+			continue
+		}
+
+		// The number of iterations started after t seconds of the segment is
+		// from*t + (to-from)/(2*d)*t^2; solve it for the remaining iterations.
+		remaining := n - seg.cumulative
+		a := (seg.to - seg.from) / (2 * seconds)
+		b := seg.from
+		denominator := b + math.Sqrt(math.Max(b*b+4*a*remaining, 0))
+		if denominator == 0 {
+			return seg.start, true
+		}
+		t := 2 * remaining / denominator
+		return seg.start + time.Duration(t*float64(time.Second)), true
+	}
+	return 0, false
+}
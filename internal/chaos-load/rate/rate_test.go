@@ -0,0 +1,138 @@
+package rate
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+	}{
+		{"500/s", 500},
+		{"500", 500},
+		{"30/m", 0.5},
+		{"10/100ms", 100},
+		{"120/2m", 1},
+		{"0", 0},
+	}
+	for _, tc := range cases {
+		got, err := Parse(tc.in)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Parse(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, in := range []string{"", "fast", "-5/s", "10/fortnight", "10/0s"} {
+		if _, err := Parse(in); err == nil {
+			t.Fatalf("Parse(%q) should fail", in)
+		}
+	}
+}
+
+func TestParseStages(t *testing.T) {
+	stages, err := ParseStages("30s:100, 2m:500/s,30s:0")
+	if err != nil {
+		t.Fatalf("ParseStages failed: %v", err)
+	}
+	want := []Stage{
+		{Duration: 30 * time.Second, Target: 100},
+		{Duration: 2 * time.Minute, Target: 500},
+		{Duration: 30 * time.Second, Target: 0},
+	}
+	if len(stages) != len(want) {
+		t.Fatalf("expected %d stages, got %d", len(want), len(stages))
+	}
+	for i := range want {
+		if stages[i] != want[i] {
+			t.Fatalf("stage %d: got %+v, want %+v", i, stages[i], want[i])
+		}
+	}
+}
+
+func TestParseStages_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"30s":       "expected duration:rate",
+		"0s:100":    "duration must be positive",
+		"soon:100":  "duration must be positive",
+		"30s:lots":  "invalid rate",
+		"30s:10,1m": "expected duration:rate",
+	}
+	for in, wantErr := range cases {
+		_, err := ParseStages(in)
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("ParseStages(%q) error = %v, want %q", in, err, wantErr)
+		}
+	}
+}
+
+func TestConstantSchedule(t *testing.T) {
+	s := Constant(10, time.Second)
+	if s.Duration() != time.Second {
+		t.Fatalf("expected duration 1s, got %v", s.Duration())
+	}
+	if s.Planned() != 10 {
+		t.Fatalf("expected 10 planned iterations, got %d", s.Planned())
+	}
+	for i := 0; i < 10; i++ {
+		offset, ok := s.Offset(i)
+		if !ok {
+			t.Fatalf("iteration %d should be scheduled", i)
+		}
+		if want := time.Duration(i) * 100 * time.Millisecond; offset != want {
+			t.Fatalf("iteration %d: offset %v, want %v", i, offset, want)
+		}
+	}
+	if _, ok := s.Offset(10); ok {
+		t.Fatal("iteration 10 is beyond the schedule")
+	}
+}
+
+func TestRampingSchedule(t *testing.T) {
+	// Ramp 0 -> 10/s over 2s (10 iterations), hold 10/s for 1s, ramp down over 2s.
+	s := Ramping([]Stage{
+		{Duration: 2 * time.Second, Target: 10},
+		{Duration: time.Second, Target: 10},
+		{Duration: 2 * time.Second, Target: 0},
+	})
+	if s.Duration() != 5*time.Second {
+		t.Fatalf("expected duration 5s, got %v", s.Duration())
+	}
+	if s.Planned() != 30 {
+		t.Fatalf("expected 30 planned iterations, got %d", s.Planned())
+	}
+
+	checks := map[int]time.Duration{
+		0:  0,
+		5:  time.Duration(1.4142135 * float64(time.Second)), // 2.5*t^2 = 5
+		10: 2 * time.Second,
+		15: 2500 * time.Millisecond,
+		20: 3 * time.Second,
+		25: 3*time.Second + time.Duration(0.5857864*float64(time.Second)), // 10t - 2.5t^2 = 5
+	}
+	for i, want := range checks {
+		got, ok := s.Offset(i)
+		if !ok {
+			t.Fatalf("iteration %d should be scheduled", i)
+		}
+		if diff := got - want; diff < -time.Millisecond || diff > time.Millisecond {
+			t.Fatalf("iteration %d: offset %v, want %v", i, got, want)
+		}
+	}
+
+	previous := time.Duration(-1)
+	for i := 0; i < s.Planned(); i++ {
+		offset, _ := s.Offset(i)
+		if offset < previous {
+			t.Fatalf("offsets must not decrease: iteration %d at %v after %v", i, offset, previous)
+		}
+		previous = offset
+	}
+}
@@ -0,0 +1,377 @@
+// Package runner drives load tests independently of the protocol: it runs
+// iterations on a closed model or an open-model rate schedule, rolls up the
+// per-second series, evaluates thresholds and exports the results.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/output"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/rate"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+	"github.com/neogan/sre-toolkit/pkg/logging"
+)
+
+// Config holds the load settings shared by every protocol.
+type Config struct {
+	Concurrency int
+	Duration    time.Duration
+	Requests    int // Optional limit on total requests
+	UI          bool
+
+	// Rate switches to an open model sending Rate requests per second for
+	// Duration; Concurrency then caps the requests in flight.
+	Rate float64
+	// Stages ramps the open-model rate instead of holding it constant.
+	Stages []rate.Stage
+
+	// Thresholds decide whether the test passes. With AbortOnFail the test
+	// stops as soon as an upper-bound threshold fails, but not before AbortDelay.
+	Thresholds  []check.Threshold
+	AbortOnFail bool
+	AbortDelay  time.Duration
+
+	// Name identifies the run in exported results.
+	Name string
+	// Outputs receive every interval while the test runs and the final results.
+	Outputs []output.Exporter
+}
+
+// Validate checks the load settings.
+func (c Config) Validate() error {
+	if c.Rate < 0 {
+		return fmt.Errorf("rate must not be negative")
+	}
+
+	if c.Rate > 0 && len(c.Stages) > 0 {
+		return fmt.Errorf("--rate and --stages cannot be used together")
+	}
+
+	return nil
+}
+
+// Iterate runs one iteration and records its results. Latency is measured
+// from start, the time the iteration was due.
+type Iterate func(ctx context.Context, recorder *stats.Recorder, start time.Time)
+
+// Runner runs iterations of a load test.
+type Runner struct {
+	config    Config
+	collector *stats.Collector
+	iterate   Iterate
+	// outputFailed marks outputs whose failure has already been logged.
+	outputFailed []bool
+}
+
+// New creates a runner recording into collector.
+func New(cfg Config, collector *stats.Collector, iterate Iterate) *Runner {
+	return &Runner{
+		config:       cfg,
+		collector:    collector,
+		iterate:      iterate,
+		outputFailed: make([]bool, len(cfg.Outputs)),
+	}
+}
+
+// schedule returns the open-model schedule, or nil for a closed-model run.
+func (c Config) schedule() *rate.Schedule {
+	switch {
+	case len(c.Stages) > 0:
+		return rate.Ramping(c.Stages)
+	case c.Rate > 0:
+		return rate.Constant(c.Rate, c.Duration)
+	default:
+		return nil
+	}
+}
+
+// Run generates the load, prints the report and thresholds and exports the
+// results. It fails if a threshold or an export fails.
+func (r *Runner) Run() error {
+	return r.RunContext(context.Background())
+}
+
+// RunContext is Run, stopping the load early when ctx ends. The results of
+// the requests made so far are still reported.
+func (r *Runner) RunContext(parent context.Context) error {
+	logger := logging.GetLogger()
+	sched := r.config.schedule()
+
+	if sched != nil {
+		r.collector.SetSchedule(sched.Planned(), sched.Duration())
+		logger.Info().
+			Int("max_in_flight", r.config.Concurrency).
+			Dur("duration", sched.Duration()).
+			Int("planned", sched.Planned()).
+			Msg("Starting open-model load")
+	} else {
+		logger.Info().
+			Int("concurrency", r.config.Concurrency).
+			Dur("duration", r.config.Duration).
+			Msg("Starting workers")
+	}
+
+	var uiCtx context.Context
+	var uiCancel context.CancelFunc
+	if r.config.UI {
+		uiCtx, uiCancel = context.WithCancel(context.Background())
+		defer uiCancel()
+		ui := stats.NewUI(r.collector, time.Second)
+		go ui.Run(uiCtx)
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	if r.config.AbortOnFail && len(r.config.Thresholds) > 0 {
+		go r.watchThresholds(ctx, cancel)
+	}
+
+	r.collector.Begin()
+	rollCtx, stopRolling := context.WithCancel(parent)
+	rolled := make(chan struct{})
+	go func() {
+		defer close(rolled)
+		r.roll(rollCtx)
+	}()
+
+	if sched != nil {
+		r.runOpen(ctx, sched)
+	} else {
+		r.runClosed(ctx)
+	}
+
+	stopRolling()
+	<-rolled
+	// The final interval and the results are exported even if parent ended
+	// the load early.
+	exportCtx := context.WithoutCancel(parent)
+	r.observe(exportCtx, r.collector.Roll())
+
+	if r.config.UI {
+		uiCancel() // explicit call to cancel early before sleep
+		// Give UI a moment to print its final render
+		time.Sleep(100 * time.Millisecond)
+	} else {
+		logger.Info().Msg("Load test completed")
+	}
+
+	// Report results
+	r.collector.Report()
+
+	outcomes, failed := r.evaluate()
+	if err := r.export(exportCtx, outcomes); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d thresholds failed", failed, len(outcomes))
+	}
+	return nil
+}
+
+// roll closes an interval every second until ctx ends.
+func (r *Runner) roll(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.observe(ctx, r.collector.Roll())
+		}
+	}
+}
+
+// observe hands an interval to the outputs. An output that fails is logged
+// once and the test carries on.
+func (r *Runner) observe(ctx context.Context, interval stats.Interval) {
+	if len(r.config.Outputs) == 0 {
+		return
+	}
+	snapshot := r.collector.Snapshot()
+	for i, out := range r.config.Outputs {
+		if err := out.Observe(ctx, interval, snapshot); err != nil && !r.outputFailed[i] {
+			r.outputFailed[i] = true
+			logger := logging.GetLogger()
+			logger.Warn().Err(err).Msg("Failed to export load test interval")
+		}
+	}
+}
+
+// export writes the final results to every output.
+func (r *Runner) export(ctx context.Context, outcomes []check.Outcome) error {
+	if len(r.config.Outputs) == 0 {
+		return nil
+	}
+	results := output.NewResults(r.config.Name, r.collector.Start(), r.collector.Snapshot(), r.collector.Series(), outcomes)
+	for _, out := range r.config.Outputs {
+		if err := out.Export(ctx, results); err != nil {
+			return fmt.Errorf("failed to export results: %w", err)
+		}
+	}
+	return nil
+}
+
+// watchThresholds cancels the run once an upper-bound threshold fails. Lower
+// bounds are left to the final verdict.
+func (r *Runner) watchThresholds(ctx context.Context, cancel context.CancelFunc) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(r.config.AbortDelay):
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		snapshot := r.collector.Snapshot()
+		for _, threshold := range r.config.Thresholds {
+			if threshold.UpperBound() && !threshold.Evaluate(snapshot).Passed {
+				logger := logging.GetLogger()
+				logger.Warn().Str("threshold", threshold.Expr).Msg("Threshold failed, aborting load test")
+				cancel()
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// evaluate prints the threshold outcomes and returns them with the number that failed.
+func (r *Runner) evaluate() ([]check.Outcome, int) {
+	if len(r.config.Thresholds) == 0 {
+		return nil, 0
+	}
+
+	outcomes, failed := check.EvaluateAll(r.config.Thresholds, r.collector.Snapshot())
+	check.FprintOutcomes(os.Stdout, outcomes)
+	return outcomes, failed
+}
+
+// runClosed keeps Concurrency workers busy: each sends its next request as
+// soon as the previous one completes.
+func (r *Runner) runClosed(parent context.Context) {
+	ctx, cancel := context.WithTimeout(parent, r.config.Duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	requestsCh := make(chan struct{}, r.config.Concurrency)
+
+	// If request limit is set, feed the channel. It is closed however the
+	// feeder ends, so that workers ranging over it stop when ctx is done.
+	if r.config.Requests > 0 {
+		go func() {
+			defer close(requestsCh)
+			for i := 0; i < r.config.Requests; i++ {
+				select {
+				case <-ctx.Done():
+					return
+				case requestsCh <- struct{}{}:
+				}
+			}
+		}()
+	} else {
+		// Infinite mode
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					close(requestsCh)
+					return
+				case requestsCh <- struct{}{}:
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < r.config.Concurrency; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			r.worker(ctx, id, requestsCh)
+		}(i)
+	}
+
+	// Wait for completion
+	wg.Wait()
+}
+
+func (r *Runner) worker(ctx context.Context, _ /* id */ int, requests <-chan struct{}) {
+	recorder := r.collector.NewRecorder()
+	for range requests {
+		// check context cancellation
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		r.iterate(ctx, recorder, time.Now())
+	}
+}
+
+// runOpen starts iterations at the times planned by the schedule, whether or
+// not earlier requests have completed. An iteration due while Concurrency
+// requests are already in flight is dropped rather than delayed, so a slow
+// target cannot lower the offered load without it showing up in the report.
+func (r *Runner) runOpen(ctx context.Context, sched *rate.Schedule) {
+	var wg sync.WaitGroup
+	// Each in-flight slot carries its own recorder, so concurrent requests never share a shard.
+	slots := make(chan *stats.Recorder, r.config.Concurrency)
+	for i := 0; i < r.config.Concurrency; i++ {
+		slots <- r.collector.NewRecorder()
+	}
+
+	begin := time.Now()
+	for i := 0; r.config.Requests <= 0 || i < r.config.Requests; i++ {
+		offset, ok := sched.Offset(i)
+		if !ok {
+			break
+		}
+		start := begin.Add(offset)
+		if !sleepUntil(ctx, start) {
+			break
+		}
+
+		var recorder *stats.Recorder
+		select {
+		case recorder = <-slots:
+		default:
+			r.collector.AddDropped()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.iterate(ctx, recorder, start)
+			slots <- recorder
+		}()
+	}
+
+	// Hold the run open until the schedule ends, even if the last stages ramp to zero.
+	sleepUntil(ctx, begin.Add(sched.Duration()))
+	wg.Wait()
+}
+
+// sleepUntil waits for t and reports false if ctx ends first.
+func sleepUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,121 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/rate"
+	"github.com/neogan/sre-toolkit/internal/chaos-load/stats"
+)
+
+func TestRunnerRunsIterations(t *testing.T) {
+	var calls atomic.Int64
+	collector := stats.NewCollector()
+	r := New(Config{Concurrency: 3, Duration: time.Second, Requests: 7}, collector,
+		func(_ context.Context, recorder *stats.Recorder, start time.Time) {
+			calls.Add(1)
+			recorder.Add(stats.Result{StatusCode: 200, Duration: time.Since(start)})
+		})
+
+	if err := r.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if got := calls.Load(); got != 7 {
+		t.Fatalf("expected 7 iterations, got %d", got)
+	}
+	if got := collector.Snapshot().TotalRequests; got != 7 {
+		t.Fatalf("expected 7 recorded requests, got %d", got)
+	}
+	if len(collector.Series()) == 0 {
+		t.Fatal("expected the final interval to be rolled up")
+	}
+}
+
+func TestRunnerRunContextStopsEarly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	collector := stats.NewCollector()
+	r := New(Config{Concurrency: 2, Duration: 10 * time.Second}, collector,
+		func(_ context.Context, recorder *stats.Recorder, start time.Time) {
+			time.Sleep(10 * time.Millisecond)
+			recorder.Add(stats.Result{StatusCode: 200, Duration: time.Since(start)})
+		})
+
+	start := time.Now()
+	if err := r.RunContext(ctx); err != nil {
+		t.Fatalf("RunContext() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the run to stop with the context, took %v", elapsed)
+	}
+	if collector.Snapshot().TotalRequests == 0 {
+		t.Fatal("expected the requests made so far to be recorded")
+	}
+}
+
+func TestRunnerRunContextStopsOpenModelIterations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	collector := stats.NewCollector()
+	r := New(Config{Concurrency: 2, Rate: 20, Duration: 10 * time.Second}, collector,
+		func(ctx context.Context, recorder *stats.Recorder, start time.Time) {
+			// A request to a target that never answers.
+			select {
+			case <-ctx.Done():
+			case <-time.After(10 * time.Second):
+			}
+			recorder.Add(stats.Result{Error: ctx.Err(), Duration: time.Since(start)})
+		})
+
+	start := time.Now()
+	if err := r.RunContext(ctx); err != nil {
+		t.Fatalf("RunContext() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected in-flight iterations to stop with the context, took %v", elapsed)
+	}
+}
+
+func TestRunnerAbortOnFailStopsRequestLimitedRun(t *testing.T) {
+	thresholds, err := check.ParseThresholds([]string{"error_rate < 1%"})
+	if err != nil {
+		t.Fatalf("ParseThresholds() failed: %v", err)
+	}
+	collector := stats.NewCollector()
+	// Fast iterations keep idle workers waiting for the next request when the
+	// threshold aborts the run.
+	r := New(Config{Concurrency: 8, Duration: 30 * time.Second, Requests: 1_000_000_000, Thresholds: thresholds, AbortOnFail: true}, collector,
+		func(_ context.Context, recorder *stats.Recorder, start time.Time) {
+			recorder.Add(stats.Result{StatusCode: 500, Error: errors.New("server error"), Duration: time.Since(start)})
+		})
+
+	done := make(chan error, 1)
+	go func() { done <- r.Run() }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected the failed threshold to fail the run")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the run to stop once the threshold failed, it is still running")
+	}
+	if got := collector.Snapshot().TotalRequests; got == 0 || got >= 1_000_000_000 {
+		t.Fatalf("expected the run to stop early, got %d requests", got)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	if err := (Config{Rate: -1}).Validate(); err == nil {
+		t.Fatal("expected a negative rate to be rejected")
+	}
+	if err := (Config{Rate: 10, Stages: []rate.Stage{{Duration: time.Second, Target: 5}}}).Validate(); err == nil {
+		t.Fatal("expected rate and stages to be rejected together")
+	}
+	if err := (Config{Rate: 10}).Validate(); err != nil {
+		t.Fatalf("expected a valid config, got %v", err)
+	}
+}
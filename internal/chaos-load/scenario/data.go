@@ -0,0 +1,80 @@
+package scenario
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+)
+
+// loadData reads variable rows from a CSV file with a header line, or from a
+// JSON file holding an array of objects.
+func loadData(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file: %w", err)
+	}
+
+	var rows []map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = parseCSV(string(data))
+	case ".json":
+		rows, err = parseJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported data file %s: expected .csv or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data file %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("data file %s has no rows", path)
+	}
+	return rows, nil
+}
+
+func parseCSV(data string) ([]map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			row[strings.TrimSpace(name)] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseJSON(data []byte) ([]map[string]string, error) {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		row := make(map[string]string, len(object))
+		for name, value := range object {
+			text, err := check.StringValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			row[name] = text
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,72 @@
+package scenario
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+)
+
+// Extraction captures a value from a response into a variable for later
+// steps, either with a JSONPath expression on the JSON body (such as
+// $.data.token) or with the first capture group of a regular expression.
+type Extraction struct {
+	Name     string `yaml:"name"`
+	JSONPath string `yaml:"jsonpath"`
+	Regex    string `yaml:"regex"`
+	// Header applies the regular expression to a response header instead of the body.
+	Header string `yaml:"header"`
+
+	path  *check.JSONPath
+	regex *regexp.Regexp
+}
+
+func (e *Extraction) compile() error {
+	if e.Name == "" {
+		return fmt.Errorf("extraction needs a name")
+	}
+
+	switch {
+	case e.JSONPath != "" && e.Regex != "":
+		return fmt.Errorf("extract %s: jsonpath and regex are mutually exclusive", e.Name)
+	case e.JSONPath != "":
+		if e.Header != "" {
+			return fmt.Errorf("extract %s: header extraction needs a regex", e.Name)
+		}
+		path, err := check.ParseJSONPath(e.JSONPath)
+		if err != nil {
+			return fmt.Errorf("extract %s: %w", e.Name, err)
+		}
+		e.path = path
+	case e.Regex != "":
+		regex, err := regexp.Compile(e.Regex)
+		if err != nil {
+			return fmt.Errorf("extract %s: invalid regex: %w", e.Name, err)
+		}
+		if regex.NumSubexp() > 1 {
+			return fmt.Errorf("extract %s: regex must have at most one capture group", e.Name)
+		}
+		e.regex = regex
+	default:
+		return fmt.Errorf("extract %s: set either jsonpath or regex", e.Name)
+	}
+	return nil
+}
+
+func (e *Extraction) extract(resp *http.Response, body []byte) (string, error) {
+	if e.path != nil {
+		return e.path.Find(body)
+	}
+
+	text := string(body)
+	if e.Header != "" {
+		text = strings.Join(resp.Header.Values(e.Header), "\n")
+	}
+	match := e.regex.FindStringSubmatch(text)
+	if match == nil {
+		return "", fmt.Errorf("no match for %s", e.Regex)
+	}
+	return match[len(match)-1], nil
+}
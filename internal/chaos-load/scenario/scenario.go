@@ -0,0 +1,318 @@
+// Package scenario loads multi-step request flows for load tests: weighted
+// flows of request templates, variables from data files, values extracted
+// from earlier responses and think times between steps.
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neogan/sre-toolkit/internal/chaos-load/check"
+)
+
+// variablePattern matches ${name} references in templates.
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// Scenario is a compiled scenario file.
+type Scenario struct {
+	Name    string            `yaml:"name"`
+	BaseURL string            `yaml:"base_url"`
+	Headers map[string]string `yaml:"headers"`
+	// Variables are available to every flow.
+	Variables map[string]string `yaml:"variables"`
+	// Data is a CSV or JSON file; each iteration takes the next row as variables.
+	Data  string `yaml:"data"`
+	Flows []Flow `yaml:"flows"`
+	// Thresholds decide whether the test passes, e.g. "p99 < 300ms".
+	Thresholds []string `yaml:"thresholds"`
+
+	rows        []map[string]string
+	next        atomic.Uint64
+	totalWeight int
+}
+
+// Flow is a sequence of steps run in order by one iteration. Iterations pick
+// a flow at random in proportion to its weight.
+type Flow struct {
+	Name   string `yaml:"name"`
+	Weight int    `yaml:"weight"`
+	Steps  []Step `yaml:"steps"`
+}
+
+// Step is a request template. URL, header values and body may reference
+// variables as ${name}.
+type Step struct {
+	Name    string            `yaml:"name"`
+	Method  string            `yaml:"method"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	Body    string            `yaml:"body"`
+	Extract []Extraction      `yaml:"extract"`
+	Checks  *check.Checks     `yaml:"checks"`
+	// ThinkTime pauses the iteration after the step, either a fixed duration
+	// such as "1s" or a uniformly random one such as "1s-3s".
+	ThinkTime string `yaml:"think_time"`
+
+	thinkMin, thinkMax time.Duration
+}
+
+// Iteration is one run of a flow with its own set of variables.
+type Iteration struct {
+	Flow *Flow
+	Vars map[string]string
+
+	scenario *Scenario
+}
+
+// LoadFile reads and compiles a scenario file.
+func LoadFile(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	return Parse(data, filepath.Dir(path))
+}
+
+// Parse compiles a scenario document. A relative data file path is resolved
+// against dir, normally the directory of the scenario file.
+func Parse(data []byte, dir string) (*Scenario, error) {
+	s := &Scenario{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+
+	if s.Data != "" {
+		dataPath := s.Data
+		if !filepath.IsAbs(dataPath) {
+			dataPath = filepath.Join(dir, dataPath)
+		}
+		rows, err := loadData(dataPath)
+		if err != nil {
+			return nil, err
+		}
+		s.rows = rows
+	}
+
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile validates the scenario, fills in defaults and checks that every
+// variable a step references is defined before the step runs.
+func (s *Scenario) compile() error {
+	if len(s.Flows) == 0 {
+		return fmt.Errorf("scenario defines no flows")
+	}
+	if _, err := check.ParseThresholds(s.Thresholds); err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	for name := range s.Variables {
+		known[name] = true
+	}
+	if len(s.rows) > 0 {
+		for name := range s.rows[0] {
+			known[name] = true
+		}
+	}
+
+	for i := range s.Flows {
+		flow := &s.Flows[i]
+		if flow.Name == "" {
+			flow.Name = fmt.Sprintf("flow-%d", i+1)
+		}
+		if flow.Weight < 0 {
+			return fmt.Errorf("flow %s: weight must not be negative", flow.Name)
+		}
+		if flow.Weight == 0 {
+			flow.Weight = 1
+		}
+		if len(flow.Steps) == 0 {
+			return fmt.Errorf("flow %s: no steps defined", flow.Name)
+		}
+		s.totalWeight += flow.Weight
+
+		defined := make(map[string]bool, len(known))
+		for name := range known {
+			defined[name] = true
+		}
+		for j := range flow.Steps {
+			if err := s.compileStep(&flow.Steps[j], defined); err != nil {
+				return fmt.Errorf("flow %s, step %d: %w", flow.Name, j+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) compileStep(step *Step, defined map[string]bool) error {
+	if step.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if step.Method == "" {
+		step.Method = http.MethodGet
+	}
+	step.Method = strings.ToUpper(step.Method)
+	if step.Name == "" {
+		step.Name = step.Method + " " + step.URL
+	}
+
+	templates := []string{step.URL, step.Body}
+	for _, value := range s.Headers {
+		templates = append(templates, value)
+	}
+	for _, value := range step.Headers {
+		templates = append(templates, value)
+	}
+	for _, template := range templates {
+		for _, match := range variablePattern.FindAllStringSubmatch(template, -1) {
+			if !defined[match[1]] {
+				return fmt.Errorf("step %s references undefined variable %q", step.Name, match[1])
+			}
+		}
+	}
+
+	for i := range step.Extract {
+		if err := step.Extract[i].compile(); err != nil {
+			return fmt.Errorf("step %s: %w", step.Name, err)
+		}
+		defined[step.Extract[i].Name] = true
+	}
+
+	if step.Checks != nil {
+		if err := step.Checks.Compile(); err != nil {
+			return fmt.Errorf("step %s: %w", step.Name, err)
+		}
+	}
+
+	thinkMin, thinkMax, err := parseThinkTime(step.ThinkTime)
+	if err != nil {
+		return fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	step.thinkMin, step.thinkMax = thinkMin, thinkMax
+	return nil
+}
+
+func parseThinkTime(value string) (time.Duration, time.Duration, error) {
+	if value == "" {
+		return 0, 0, nil
+	}
+	lowStr, highStr, isRange := strings.Cut(value, "-")
+	low, err := time.ParseDuration(strings.TrimSpace(lowStr))
+	if err != nil || low < 0 {
+		return 0, 0, fmt.Errorf("invalid think_time %q", value)
+	}
+	if !isRange {
+		return low, low, nil
+	}
+	high, err := time.ParseDuration(strings.TrimSpace(highStr))
+	if err != nil || high < low {
+		return 0, 0, fmt.Errorf("invalid think_time %q: expected min-max such as 1s-3s", value)
+	}
+	return low, high, nil
+}
+
+// Next starts a new iteration: it picks a flow by weight and takes the next
+// data row, cycling through the rows. It is safe for concurrent use.
+func (s *Scenario) Next() *Iteration {
+	vars := make(map[string]string, len(s.Variables))
+	for name, value := range s.Variables {
+		vars[name] = value
+	}
+	if len(s.rows) > 0 {
+		row := s.rows[(s.next.Add(1)-1)%uint64(len(s.rows))]
+		for name, value := range row {
+			vars[name] = value
+		}
+	}
+
+	pick := rand.IntN(s.totalWeight) //nolint:gosec // flow selection does not need a cryptographic source
+	for i := range s.Flows {
+		pick -= s.Flows[i].Weight
+		if pick < 0 {
+			return &Iteration{Flow: &s.Flows[i], Vars: vars, scenario: s}
+		}
+	}
+	return &Iteration{Flow: &s.Flows[len(s.Flows)-1], Vars: vars, scenario: s}
+}
+
+// StepNames returns the names of all steps in the order they are defined.
+func (s *Scenario) StepNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, flow := range s.Flows {
+		for _, step := range flow.Steps {
+			if !seen[step.Name] {
+				seen[step.Name] = true
+				names = append(names, step.Name)
+			}
+		}
+	}
+	return names
+}
+
+// NewRequest renders the step with the iteration's variables.
+func (it *Iteration) NewRequest(ctx context.Context, step *Step) (*http.Request, error) {
+	s := it.scenario
+	url := it.expand(step.URL)
+	if strings.HasPrefix(url, "/") && s.BaseURL != "" {
+		url = strings.TrimSuffix(s.BaseURL, "/") + url
+	}
+
+	var body io.Reader = http.NoBody
+	if step.Body != "" {
+		body = strings.NewReader(it.expand(step.Body))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, step.Method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range s.Headers {
+		req.Header.Set(name, it.expand(value))
+	}
+	for name, value := range step.Headers {
+		req.Header.Set(name, it.expand(value))
+	}
+	return req, nil
+}
+
+// Extract stores the values the step extracts from its response as variables.
+func (it *Iteration) Extract(step *Step, resp *http.Response, body []byte) error {
+	for _, extraction := range step.Extract {
+		value, err := extraction.extract(resp, body)
+		if err != nil {
+			return fmt.Errorf("extract %s: %w", extraction.Name, err)
+		}
+		it.Vars[extraction.Name] = value
+	}
+	return nil
+}
+
+// Pause returns how long to think after the step.
+func (step *Step) Pause() time.Duration {
+	if step.thinkMax <= step.thinkMin {
+		return step.thinkMin
+	}
+	return step.thinkMin + rand.N(step.thinkMax-step.thinkMin) //nolint:gosec // think time does not need a cryptographic source
+}
+
+func (it *Iteration) expand(template string) string {
+	return variablePattern.ReplaceAllStringFunc(template, func(ref string) string {
+		return it.Vars[ref[2:len(ref)-1]]
+	})
+}
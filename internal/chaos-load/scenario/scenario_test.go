@@ -0,0 +1,224 @@
+package scenario
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const loginScenario = `
+name: checkout
+base_url: https://api.example.com
+headers:
+  User-Agent: chaos-load
+variables:
+  tenant: acme
+data: users.csv
+flows:
+  - name: login-and-browse
+    weight: 3
+    steps:
+      - name: login
+        method: post
+        url: /login
+        body: '{"user":"${username}","password":"${password}","tenant":"${tenant}"}'
+        extract:
+          - name: token
+            jsonpath: $.data.token
+        think_time: 10ms-20ms
+      - name: orders
+        url: /orders
+        headers:
+          Authorization: Bearer ${token}
+        checks:
+          status: [200]
+          jsonpath: $.orders
+  - name: health
+    steps:
+      - url: /health
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "users.csv", "username,password\nalice,a1\nbob,b2\n")
+	path := writeFile(t, dir, "scenario.yaml", loginScenario)
+
+	s, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() failed: %v", err)
+	}
+
+	if len(s.Flows) != 2 || s.totalWeight != 4 {
+		t.Fatalf("expected 2 flows with total weight 4, got %d flows and %d", len(s.Flows), s.totalWeight)
+	}
+	login := s.Flows[0].Steps[0]
+	if login.Method != http.MethodPost {
+		t.Fatalf("expected method to be upper-cased, got %s", login.Method)
+	}
+	if login.thinkMin != 10*time.Millisecond || login.thinkMax != 20*time.Millisecond {
+		t.Fatalf("unexpected think time range %v-%v", login.thinkMin, login.thinkMax)
+	}
+	if orders := s.Flows[0].Steps[1]; orders.Checks == nil || !orders.Checks.NeedsBody() {
+		t.Fatalf("expected compiled checks on the orders step, got %+v", orders.Checks)
+	}
+	if got := s.Flows[1].Steps[0].Name; got != "GET /health" {
+		t.Fatalf("expected default step name, got %q", got)
+	}
+	if got := strings.Join(s.StepNames(), ","); got != "login,orders,GET /health" {
+		t.Fatalf("unexpected step names %q", got)
+	}
+}
+
+func TestParseRejectsUndefinedVariable(t *testing.T) {
+	_, err := Parse([]byte(`
+flows:
+  - steps:
+      - name: orders
+        url: https://api.example.com/orders
+        headers:
+          Authorization: Bearer ${token}
+      - name: login
+        url: https://api.example.com/login
+        extract:
+          - name: token
+            jsonpath: $.token
+`), "")
+	if err == nil || !strings.Contains(err.Error(), `undefined variable "token"`) {
+		t.Fatalf("expected undefined variable error, got %v", err)
+	}
+}
+
+func TestParseRejectsInvalidScenarios(t *testing.T) {
+	cases := map[string]string{
+		"no flows":         `name: empty`,
+		"no steps":         "flows:\n  - name: a\n",
+		"missing url":      "flows:\n  - steps:\n      - method: GET\n",
+		"bad think time":   "flows:\n  - steps:\n      - url: /a\n        think_time: 3s-1s\n",
+		"bad extraction":   "flows:\n  - steps:\n      - url: /a\n        extract:\n          - name: x\n",
+		"negative weight":  "flows:\n  - weight: -1\n    steps:\n      - url: /a\n",
+		"two capture refs": "flows:\n  - steps:\n      - url: /a\n        extract:\n          - name: x\n            regex: '(a)(b)'\n",
+		"bad threshold":    "thresholds: ['p99 fast']\nflows:\n  - steps:\n      - url: /a\n",
+		"bad check":        "flows:\n  - steps:\n      - url: /a\n        checks:\n          equals: ok\n",
+	}
+	for name, doc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(doc), ""); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestNextCyclesDataRowsAndRendersRequest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "users.json", `[{"username":"alice","password":"a1"},{"username":"bob","password":7}]`)
+	s, err := Parse([]byte(strings.Replace(loginScenario, "users.csv", "users.json", 1)), dir)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	users := []string{s.Next().Vars["username"], s.Next().Vars["username"], s.Next().Vars["username"]}
+	if strings.Join(users, ",") != "alice,bob,alice" {
+		t.Fatalf("expected rows to cycle, got %v", users)
+	}
+
+	it := s.Next()
+	req, err := it.NewRequest(context.Background(), &s.Flows[0].Steps[0])
+	if err != nil {
+		t.Fatalf("NewRequest() failed: %v", err)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if req.URL.String() != "https://api.example.com/login" {
+		t.Fatalf("unexpected URL %s", req.URL)
+	}
+	if string(body) != `{"user":"bob","password":"7","tenant":"acme"}` {
+		t.Fatalf("unexpected body %s", body)
+	}
+	if req.Header.Get("User-Agent") != "chaos-load" {
+		t.Fatalf("expected scenario headers on every step, got %v", req.Header)
+	}
+}
+
+func TestNextHonorsWeights(t *testing.T) {
+	s, err := Parse([]byte(`
+flows:
+  - name: heavy
+    weight: 9
+    steps:
+      - url: https://example.com/a
+  - name: light
+    steps:
+      - url: https://example.com/b
+`), "")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	heavy := 0
+	for i := 0; i < 1000; i++ {
+		if s.Next().Flow.Name == "heavy" {
+			heavy++
+		}
+	}
+	if heavy < 850 || heavy > 950 {
+		t.Fatalf("expected about 900 of 1000 iterations on the heavy flow, got %d", heavy)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	s, err := Parse([]byte(`
+flows:
+  - steps:
+      - name: login
+        url: https://example.com/login
+        extract:
+          - name: token
+            jsonpath: $.data.token
+          - name: first_id
+            jsonpath: $.items[0].id
+          - name: session
+            regex: 'session=(\w+)'
+            header: Set-Cookie
+          - name: greeting
+            jsonpath: '{.data.greeting}'
+`), "")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	step := &s.Flows[0].Steps[0]
+	resp := &http.Response{Header: http.Header{"Set-Cookie": []string{"session=abc123; Path=/"}}}
+	body := []byte(`{"data":{"token":"t-1","greeting":"hi"},"items":[{"id":42}]}`)
+
+	it := s.Next()
+	if err := it.Extract(step, resp, body); err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	want := map[string]string{"token": "t-1", "first_id": "42", "session": "abc123", "greeting": "hi"}
+	for name, value := range want {
+		if it.Vars[name] != value {
+			t.Errorf("expected %s=%q, got %q", name, value, it.Vars[name])
+		}
+	}
+
+	if err := it.Extract(step, resp, []byte(`{"data":{}}`)); err == nil {
+		t.Fatal("expected an error when the JSONPath does not match")
+	}
+}
@@ -6,66 +6,482 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"text/tabwriter"
 	"time"
 )
 
 // Result represents the result of a single request
 type Result struct {
+	// StatusCode is the HTTP status code, or the gRPC status code of a gRPC call.
 	StatusCode int
 	Duration   time.Duration
 	Error      error
+	// Step names the scenario step the request belongs to, if any.
+	Step string
+	// FailedChecks names the response checks the request failed. Unlike
+	// Error, the request completed and its latency is recorded.
+	FailedChecks []string
+	// Timing breaks the latency down, where the client measures it.
+	Timing Timing
 }
 
-// Collector aggregates results from multiple workers
+// Timing breaks down where the time of a request went. Connect and
+// TLSHandshake are zero when the request reused a connection.
+type Timing struct {
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// TTFB is the time from asking for a connection to the first response byte.
+	TTFB time.Duration
+}
+
+// Collector aggregates results from multiple workers. Each worker records into
+// its own Recorder, so workers never contend on a shared lock; the shards are
+// merged whenever a snapshot or report is taken.
 type Collector struct {
-	results []Result
-	mu      sync.Mutex
-	start   time.Time
+	mu    sync.Mutex
+	start time.Time
+	// begun is set once Begin has marked the start of the load.
+	begun     bool
+	recorders []*Recorder
+	shared    *Recorder
+	// steps lists scenario step names in report order.
+	steps []string
+	// statusText names status codes in reports, if set.
+	statusText func(code int) string
+
+	// planned, plannedDuration and dropped describe open-model runs, where
+	// latency is measured from the intended send time.
+	planned         int
+	plannedDuration time.Duration
+	dropped         int
+
+	// series holds the intervals rolled up so far; rolledAt is the start of
+	// the interval being recorded.
+	series   []Interval
+	rolledAt time.Time
+
+	// phase is the name of the current phase, shared with the recorders;
+	// phases lists the phases in the order they started.
+	phase  atomic.Pointer[string]
+	phases []phaseMark
+}
+
+// phaseMark records when a phase started, relative to the start of the test.
+type phaseMark struct {
+	name  string
+	start time.Duration
+}
+
+// Recorder is one shard of a Collector, meant to be used by a single worker.
+type Recorder struct {
+	mu    sync.Mutex
+	tally tally
+	// interval holds the results since the collector last rolled up an interval.
+	interval tally
+	steps    map[string]*tally
+	// phase points at the collector's current phase; phases holds the
+	// totals per phase.
+	phase  *atomic.Pointer[string]
+	phases map[string]*tally
+}
+
+// tally holds the counters of a shard, or of all shards once merged.
+type tally struct {
+	requests     int
+	errors       int
+	failedChecks int
+	// checks counts failures per check name.
+	checks      map[string]int
+	statusCodes map[int]int
+	latency     Histogram
+	// connect, tlsHandshake and ttfb hold the timing breakdown.
+	connect      Histogram
+	tlsHandshake Histogram
+	ttfb         Histogram
+}
+
+// Interval summarizes a slice of a load test, normally one second long.
+type Interval struct {
+	// Offset is the start of the interval relative to the start of the test.
+	Offset       time.Duration
+	Duration     time.Duration
+	Requests     int
+	Errors       int
+	FailedChecks int
+	StatusCodes  map[int]int
+	Latency      LatencyStats
+	// Phase is the phase in effect for most of the interval, if any.
+	Phase string
+}
+
+// RPS returns the request rate over the interval.
+func (i Interval) RPS() float64 {
+	if i.Duration <= 0 {
+		return 0
+	}
+	return float64(i.Requests) / i.Duration.Seconds()
+}
+
+// TimingStats summarizes the timing breakdown of successful requests.
+type TimingStats struct {
+	// Connections and Handshakes count the new connections and TLS
+	// handshakes; Measured counts the requests with a TTFB.
+	Connections  int
+	Handshakes   int
+	Measured     int
+	Connect      LatencyStats
+	TLSHandshake LatencyStats
+	TTFB         LatencyStats
+}
+
+// StepStats summarizes the requests of one scenario step.
+type StepStats struct {
+	Name         string
+	Requests     int
+	Errors       int
+	FailedChecks int
+	StatusCodes  map[int]int
+	Latency      LatencyStats
+}
+
+// PhaseStats summarizes the requests that completed during one phase of a
+// test. Start and End are relative to the start of the test; the last phase
+// ends when the snapshot is taken.
+type PhaseStats struct {
+	Name         string
+	Start        time.Duration
+	End          time.Duration
+	Requests     int
+	Errors       int
+	FailedChecks int
+	StatusCodes  map[int]int
+	Latency      LatencyStats
+}
+
+// ErrorRate returns the share of requests of the phase that failed.
+func (p PhaseStats) ErrorRate() float64 {
+	if p.Requests == 0 {
+		return 0
+	}
+	return float64(p.Errors) / float64(p.Requests)
+}
+
+// FailedCheckRate returns the share of requests of the phase that failed a check.
+func (p PhaseStats) FailedCheckRate() float64 {
+	if p.Requests == 0 {
+		return 0
+	}
+	return float64(p.FailedChecks) / float64(p.Requests)
+}
+
+// LatencyStats summarizes the latency of successful requests.
+type LatencyStats struct {
+	P50  time.Duration
+	P90  time.Duration
+	P95  time.Duration
+	P99  time.Duration
+	P999 time.Duration
+	Mean time.Duration
+	Max  time.Duration
 }
 
 // NewCollector creates a new stats collector
 func NewCollector() *Collector {
-	return &Collector{
-		results: make([]Result, 0, 1000),
-		start:   time.Now(),
+	c := &Collector{start: time.Now()}
+	c.rolledAt = c.start
+	c.shared = c.NewRecorder()
+	return c
+}
+
+// NewRecorder returns a new shard whose results are included in the collector's reports.
+func (c *Collector) NewRecorder() *Recorder {
+	r := &Recorder{
+		tally:    newTally(),
+		interval: newTally(),
+		steps:    make(map[string]*tally),
+		phase:    &c.phase,
+		phases:   make(map[string]*tally),
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recorders = append(c.recorders, r)
+	return r
 }
 
-// Add records a single result
+// Add records a single result in a shard shared by all callers. Workers that
+// record many results should use their own Recorder instead.
 func (c *Collector) Add(r Result) {
+	c.shared.Add(r)
+}
+
+// Add records a single result
+func (r *Recorder) Add(res Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tally.add(res)
+	r.interval.add(res)
+	if res.Step != "" {
+		// Steps only keep totals; the interval series covers the whole test.
+		addTo(r.steps, res.Step, res)
+	}
+	if name := r.phase.Load(); name != nil {
+		addTo(r.phases, *name, res)
+	}
+}
+
+// addTo records res in the tally named name, creating it if needed.
+func addTo(tallies map[string]*tally, name string, res Result) {
+	t, ok := tallies[name]
+	if !ok {
+		created := newTally()
+		t = &created
+		tallies[name] = t
+	}
+	t.add(res)
+}
+
+func newTally() tally {
+	return tally{statusCodes: make(map[int]int), checks: make(map[string]int)}
+}
+
+func (t *tally) add(res Result) {
+	t.requests++
+	if res.Error != nil {
+		t.errors++
+		// A failed gRPC call still has a status code; a failed HTTP request
+		// only has one if the response arrived before the error.
+		if res.StatusCode != 0 {
+			t.statusCodes[res.StatusCode]++
+		}
+		return
+	}
+
+	if len(res.FailedChecks) > 0 {
+		t.failedChecks++
+		for _, name := range res.FailedChecks {
+			t.checks[name]++
+		}
+	}
+
+	t.statusCodes[res.StatusCode]++
+	t.latency.Record(res.Duration)
+	if res.Timing.Connect > 0 {
+		t.connect.Record(res.Timing.Connect)
+	}
+	if res.Timing.TLSHandshake > 0 {
+		t.tlsHandshake.Record(res.Timing.TLSHandshake)
+	}
+	if res.Timing.TTFB > 0 {
+		t.ttfb.Record(res.Timing.TTFB)
+	}
+}
+
+func (t *tally) latencyStats() LatencyStats {
+	return histogramStats(&t.latency)
+}
+
+func (t *tally) timingStats() TimingStats {
+	return TimingStats{
+		Connections:  int(t.connect.Count()),
+		Handshakes:   int(t.tlsHandshake.Count()),
+		Measured:     int(t.ttfb.Count()),
+		Connect:      histogramStats(&t.connect),
+		TLSHandshake: histogramStats(&t.tlsHandshake),
+		TTFB:         histogramStats(&t.ttfb),
+	}
+}
+
+func histogramStats(h *Histogram) LatencyStats {
+	return LatencyStats{
+		P50:  h.Quantile(0.5),
+		P90:  h.Quantile(0.9),
+		P95:  h.Quantile(0.95),
+		P99:  h.Quantile(0.99),
+		P999: h.Quantile(0.999),
+		Mean: h.Mean(),
+		Max:  h.Max(),
+	}
+}
+
+func (t *tally) merge(other *tally) {
+	t.requests += other.requests
+	t.errors += other.errors
+	t.failedChecks += other.failedChecks
+	for name, count := range other.checks {
+		t.checks[name] += count
+	}
+	for code, count := range other.statusCodes {
+		t.statusCodes[code] += count
+	}
+	t.latency.Merge(&other.latency)
+	t.connect.Merge(&other.connect)
+	t.tlsHandshake.Merge(&other.tlsHandshake)
+	t.ttfb.Merge(&other.ttfb)
+}
+
+// merged returns the counters of all shards combined, overall, per step and
+// per phase.
+func (c *Collector) merged() (tally, map[string]*tally, map[string]*tally) {
+	c.mu.Lock()
+	recorders := append([]*Recorder(nil), c.recorders...)
+	c.mu.Unlock()
+
+	total := newTally()
+	steps := make(map[string]*tally)
+	phases := make(map[string]*tally)
+	for _, r := range recorders {
+		r.mu.Lock()
+		total.merge(&r.tally)
+		mergeInto(steps, r.steps)
+		mergeInto(phases, r.phases)
+		r.mu.Unlock()
+	}
+	return total, steps, phases
+}
+
+// mergeInto merges the named tallies of a shard into dst.
+func mergeInto(dst, src map[string]*tally) {
+	for name, t := range src {
+		merged, ok := dst[name]
+		if !ok {
+			created := newTally()
+			merged = &created
+			dst[name] = merged
+		}
+		merged.merge(t)
+	}
+}
+
+// SetPhase starts a new phase of the test, such as the window of an injected
+// fault. Results recorded from now on count towards the phase until the next
+// one starts. Phase names are expected to be unique.
+func (c *Collector) SetPhase(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.phases = append(c.phases, phaseMark{name: name, start: time.Since(c.start)})
+	c.phase.Store(&name)
+}
+
+// Phase returns the name of the current phase, or "" before the first one.
+func (c *Collector) Phase() string {
+	if name := c.phase.Load(); name != nil {
+		return *name
+	}
+	return ""
+}
+
+// phaseDuring returns the phase in effect for the largest part of the time
+// between start and end, or "" if no phase had started by then.
+func (c *Collector) phaseDuring(start, end time.Duration) string {
+	name, longest := "", time.Duration(0)
+	for i, mark := range c.phases {
+		until := end
+		if i+1 < len(c.phases) {
+			until = min(end, c.phases[i+1].start)
+		}
+		if overlap := until - max(start, mark.start); overlap > longest {
+			name, longest = mark.name, overlap
+		}
+	}
+	return name
+}
+
+// SetSteps sets the order in which scenario steps are reported. Steps not
+// listed follow in alphabetical order.
+func (c *Collector) SetSteps(names []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps = append([]string(nil), names...)
+}
+
+// SetStatusText sets how status codes are named in reports, e.g. the gRPC
+// code names. HTTP status codes are shown as numbers only.
+func (c *Collector) SetStatusText(text func(code int) string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.results = append(c.results, r)
+	c.statusText = text
+}
+
+// StatusLabel returns the status code as shown in reports.
+func (c *Collector) StatusLabel(code int) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.statusText == nil {
+		return strconv.Itoa(code)
+	}
+	return strconv.Itoa(code) + " " + c.statusText(code)
+}
+
+// SetSchedule records that an open-model run plans to start planned iterations over duration.
+func (c *Collector) SetSchedule(planned int, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.planned = planned
+	c.plannedDuration = duration
+}
+
+// AddDropped records an iteration that was not sent because every worker was busy.
+func (c *Collector) AddDropped() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropped++
+}
+
+// targetRPS returns the planned arrival rate, zero for closed-model runs.
+func (c *Collector) targetRPS() float64 {
+	if c.planned == 0 || c.plannedDuration <= 0 {
+		return 0
+	}
+	return float64(c.planned) / c.plannedDuration.Seconds()
 }
 
 // SnapshotStats contains a snapshot of the current metrics
 type SnapshotStats struct {
 	TotalRequests int
 	Errors        int
+	// FailedChecks counts completed requests that failed a response check,
+	// CheckFailures the failures per check.
+	FailedChecks  int
+	CheckFailures map[string]int
 	Elapsed       time.Duration
 	RPS           float64
+	TargetRPS     float64
+	Planned       int
+	Dropped       int
 	StatusCodes   map[int]int
+	Latency       LatencyStats
+	Timing        TimingStats
+	Steps         []StepStats
+	// Phases lists the phases in the order they started.
+	Phases []PhaseStats
 }
 
 // Snapshot returns the current aggregated metrics
 func (c *Collector) Snapshot() SnapshotStats {
+	total, steps, phases := c.merged()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	stats := SnapshotStats{
-		TotalRequests: len(c.results),
+		TotalRequests: total.requests,
+		Errors:        total.errors,
+		FailedChecks:  total.failedChecks,
+		CheckFailures: total.checks,
 		Elapsed:       time.Since(c.start),
-		StatusCodes:   make(map[int]int),
-	}
-
-	for _, r := range c.results {
-		if r.Error != nil {
-			stats.Errors++
-		} else {
-			stats.StatusCodes[r.StatusCode]++
-		}
+		TargetRPS:     c.targetRPS(),
+		Planned:       c.planned,
+		Dropped:       c.dropped,
+		StatusCodes:   total.statusCodes,
+		Latency:       total.latencyStats(),
+		Timing:        total.timingStats(),
+		Steps:         c.stepStats(steps),
 	}
+	stats.Phases = c.phaseStats(phases, stats.Elapsed)
 
 	if stats.Elapsed.Seconds() > 0 {
 		stats.RPS = float64(stats.TotalRequests) / stats.Elapsed.Seconds()
@@ -74,6 +490,138 @@ func (c *Collector) Snapshot() SnapshotStats {
 	return stats
 }
 
+func (c *Collector) stepStats(steps map[string]*tally) []StepStats {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(steps))
+	listed := make(map[string]bool, len(c.steps))
+	for _, name := range c.steps {
+		if _, ok := steps[name]; ok {
+			names = append(names, name)
+			listed[name] = true
+		}
+	}
+	var rest []string
+	for name := range steps {
+		if !listed[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	names = append(names, rest...)
+
+	result := make([]StepStats, 0, len(names))
+	for _, name := range names {
+		step := steps[name]
+		result = append(result, StepStats{
+			Name:         name,
+			Requests:     step.requests,
+			Errors:       step.errors,
+			FailedChecks: step.failedChecks,
+			StatusCodes:  step.statusCodes,
+			Latency:      step.latencyStats(),
+		})
+	}
+	return result
+}
+
+func (c *Collector) phaseStats(phases map[string]*tally, elapsed time.Duration) []PhaseStats {
+	if len(c.phases) == 0 {
+		return nil
+	}
+
+	result := make([]PhaseStats, 0, len(c.phases))
+	for i, mark := range c.phases {
+		end := elapsed
+		if i+1 < len(c.phases) {
+			end = c.phases[i+1].start
+		}
+		phase := PhaseStats{Name: mark.name, Start: mark.start, End: end, StatusCodes: map[int]int{}}
+		if t, ok := phases[mark.name]; ok {
+			phase.Requests = t.requests
+			phase.Errors = t.errors
+			phase.FailedChecks = t.failedChecks
+			phase.StatusCodes = t.statusCodes
+			phase.Latency = t.latencyStats()
+		}
+		result = append(result, phase)
+	}
+	return result
+}
+
+// Roll closes the current interval, adds it to the series and returns it.
+// Callers roll once a second while the test runs, and once more at the end;
+// only the shards' results since the previous roll are kept in memory.
+func (c *Collector) Roll() Interval {
+	c.mu.Lock()
+	recorders := append([]*Recorder(nil), c.recorders...)
+	c.mu.Unlock()
+
+	merged := newTally()
+	for _, r := range recorders {
+		r.mu.Lock()
+		merged.merge(&r.interval)
+		r.interval = newTally()
+		r.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	interval := Interval{
+		Offset:       c.rolledAt.Sub(c.start),
+		Duration:     now.Sub(c.rolledAt),
+		Requests:     merged.requests,
+		Errors:       merged.errors,
+		FailedChecks: merged.failedChecks,
+		StatusCodes:  merged.statusCodes,
+		Latency:      merged.latencyStats(),
+	}
+	interval.Phase = c.phaseDuring(interval.Offset, interval.Offset+interval.Duration)
+	c.rolledAt = now
+	c.series = append(c.series, interval)
+	return interval
+}
+
+// Series returns the intervals rolled up so far.
+func (c *Collector) Series() []Interval {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interval(nil), c.series...)
+}
+
+// Begin marks the start of the load. Times are measured from it rather than
+// from the creation of the collector, so that setup does not count; phases
+// started before it start with the load. Only the first call has an effect,
+// which lets a caller that schedules work from the start mark it before the
+// runner does.
+func (c *Collector) Begin() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.begun {
+		return
+	}
+	c.begun = true
+
+	now := time.Now()
+	shift := now.Sub(c.start)
+	c.start = now
+	c.rolledAt = now
+	for i := range c.phases {
+		c.phases[i].start = max(c.phases[i].start-shift, 0)
+	}
+}
+
+// Start returns when the load started, or when the collector was created if
+// Begin was not called.
+func (c *Collector) Start() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.start
+}
+
 // Report prints a summary report to stdout
 func (c *Collector) Report() {
 	c.FprintReport(os.Stdout)
@@ -81,62 +629,105 @@ func (c *Collector) Report() {
 
 // FprintReport generates a summary report to the given writer
 func (c *Collector) FprintReport(w io.Writer) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	snapshot := c.Snapshot()
 
-	total := len(c.results)
-	if total == 0 {
+	if snapshot.TotalRequests == 0 {
 		fmt.Fprintln(w, "No requests made")
 		return
 	}
 
-	var totalDuration time.Duration
-	var errors int
-	statusCodes := make(map[int]int)
-	durations := make([]time.Duration, 0, total)
+	fmt.Fprintf(w, "\n=== Load Test Results ===\n")
+	fmt.Fprintf(w, "Total Requests: %d\n", snapshot.TotalRequests)
+	fmt.Fprintf(w, "Total Duration: %v\n", snapshot.Elapsed)
+	fmt.Fprintf(w, "Requests/sec:   %.2f\n", snapshot.RPS)
+	if snapshot.TargetRPS > 0 {
+		fmt.Fprintf(w, "Target Rate:    %.2f/s (%d planned)\n", snapshot.TargetRPS, snapshot.Planned)
+		fmt.Fprintf(w, "Achieved Rate:  %.2f/s (%.1f%% of target)\n", snapshot.RPS, snapshot.RPS/snapshot.TargetRPS*100)
+		fmt.Fprintf(w, "Dropped:        %d iterations\n", snapshot.Dropped)
+	}
+	fmt.Fprintf(w, "Errors:         %d\n", snapshot.Errors)
+	if snapshot.FailedChecks > 0 {
+		fmt.Fprintf(w, "Failed Checks:  %d (%.2f%%)\n", snapshot.FailedChecks,
+			float64(snapshot.FailedChecks)/float64(snapshot.TotalRequests)*100)
+	}
 
-	for _, r := range c.results {
-		if r.Error != nil {
-			errors++
+	if snapshot.TotalRequests > snapshot.Errors {
+		if snapshot.Planned > 0 {
+			fmt.Fprintf(w, "\nLatency (from intended send time):\n")
 		} else {
-			statusCodes[r.StatusCode]++
-			durations = append(durations, r.Duration)
-			totalDuration += r.Duration
+			fmt.Fprintf(w, "\nLatency:\n")
 		}
+		fmt.Fprintf(w, "  p50: %v\n", snapshot.Latency.P50)
+		fmt.Fprintf(w, "  p90: %v\n", snapshot.Latency.P90)
+		fmt.Fprintf(w, "  p95: %v\n", snapshot.Latency.P95)
+		fmt.Fprintf(w, "  p99: %v\n", snapshot.Latency.P99)
+		fmt.Fprintf(w, "  p99.9: %v\n", snapshot.Latency.P999)
+		fmt.Fprintf(w, "  Max: %v\n", snapshot.Latency.Max)
 	}
 
-	elapsed := time.Since(c.start)
-	rps := float64(total) / elapsed.Seconds()
-
-	fmt.Fprintf(w, "\n=== Load Test Results ===\n")
-	fmt.Fprintf(w, "Total Requests: %d\n", total)
-	fmt.Fprintf(w, "Total Duration: %v\n", elapsed)
-	fmt.Fprintf(w, "Requests/sec:   %.2f\n", rps)
-	fmt.Fprintf(w, "Errors:         %d\n", errors)
-
-	if len(durations) > 0 {
-		sort.Slice(durations, func(i, j int) bool {
-			return durations[i] < durations[j]
-		})
-
-		count := len(durations)
-		fmt.Fprintf(w, "\nLatency:\n")
-		fmt.Fprintf(w, "  p50: %v\n", durations[count/2])
-		fmt.Fprintf(w, "  p95: %v\n", durations[int(float64(count)*0.95)])
-		fmt.Fprintf(w, "  p99: %v\n", durations[int(float64(count)*0.99)])
-		fmt.Fprintf(w, "  Max: %v\n", durations[count-1])
+	if snapshot.Timing.Measured > 0 {
+		fprintTiming(w, snapshot.Timing)
 	}
 
-	if len(statusCodes) > 0 {
+	if len(snapshot.StatusCodes) > 0 {
 		fmt.Fprintf(w, "\nStatus Codes:\n")
 		// Sort status codes for deterministic output
-		keys := make([]int, 0, len(statusCodes))
-		for k := range statusCodes {
+		keys := make([]int, 0, len(snapshot.StatusCodes))
+		for k := range snapshot.StatusCodes {
 			keys = append(keys, k)
 		}
 		sort.Ints(keys)
 		for _, code := range keys {
-			fmt.Fprintf(w, "  [%d]: %d\n", code, statusCodes[code])
+			fmt.Fprintf(w, "  [%s]: %d\n", c.StatusLabel(code), snapshot.StatusCodes[code])
+		}
+	}
+
+	if len(snapshot.CheckFailures) > 0 {
+		fmt.Fprintf(w, "\nCheck Failures:\n")
+		names := make([]string, 0, len(snapshot.CheckFailures))
+		for name := range snapshot.CheckFailures {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "  %s: %d\n", name, snapshot.CheckFailures[name])
+		}
+	}
+
+	if len(snapshot.Steps) > 0 {
+		fmt.Fprintf(w, "\nSteps:\n")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  NAME\tREQUESTS\tERRORS\tFAILED CHECKS\tP50\tP95\tP99\tMAX")
+		for _, step := range snapshot.Steps {
+			fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t%v\t%v\t%v\t%v\n",
+				step.Name, step.Requests, step.Errors, step.FailedChecks,
+				step.Latency.P50, step.Latency.P95, step.Latency.P99, step.Latency.Max)
+		}
+		tw.Flush()
+	}
+}
+
+// fprintTiming writes the timing breakdown. Connect and TLS handshake only
+// cover the requests that opened a new connection.
+func fprintTiming(w io.Writer, timing TimingStats) {
+	fmt.Fprintf(w, "\nTiming Breakdown:\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  PHASE\tCOUNT\tP50\tP95\tP99\tMAX")
+	phases := []struct {
+		name  string
+		count int
+		stats LatencyStats
+	}{
+		{"Connect", timing.Connections, timing.Connect},
+		{"TLS Handshake", timing.Handshakes, timing.TLSHandshake},
+		{"TTFB", timing.Measured, timing.TTFB},
+	}
+	for _, phase := range phases {
+		if phase.count == 0 {
+			continue
 		}
+		fmt.Fprintf(tw, "  %s\t%d\t%v\t%v\t%v\t%v\n", phase.name, phase.count,
+			phase.stats.P50, phase.stats.P95, phase.stats.P99, phase.stats.Max)
 	}
+	tw.Flush()
 }
@@ -112,3 +112,211 @@ func TestCollector_Percentiles(t *testing.T) {
 		}
 	}
 }
+
+func TestCollector_ReportsTargetAndDropped(t *testing.T) {
+	c := NewCollector()
+	c.SetSchedule(100, 10*time.Second)
+	for i := 0; i < 90; i++ {
+		c.Add(Result{StatusCode: 200, Duration: time.Millisecond})
+	}
+	for i := 0; i < 10; i++ {
+		c.AddDropped()
+	}
+
+	snapshot := c.Snapshot()
+	if snapshot.TargetRPS != 10 {
+		t.Fatalf("expected target rate 10/s, got %.2f", snapshot.TargetRPS)
+	}
+	if snapshot.Dropped != 10 {
+		t.Fatalf("expected 10 dropped iterations, got %d", snapshot.Dropped)
+	}
+
+	var buf bytes.Buffer
+	c.FprintReport(&buf)
+	output := buf.String()
+	for _, s := range []string{
+		"Target Rate:    10.00/s (100 planned)",
+		"Achieved Rate:",
+		"Dropped:        10 iterations",
+		"Latency (from intended send time):",
+	} {
+		if !strings.Contains(output, s) {
+			t.Errorf("expected output to contain %q, got:\n%s", s, output)
+		}
+	}
+}
+
+func TestCollector_ClosedModelOmitsTarget(t *testing.T) {
+	c := NewCollector()
+	c.Add(Result{StatusCode: 200, Duration: time.Millisecond})
+
+	var buf bytes.Buffer
+	c.FprintReport(&buf)
+	if strings.Contains(buf.String(), "Target Rate") || strings.Contains(buf.String(), "Dropped") {
+		t.Fatalf("expected no open-model lines for a closed-model run, got:\n%s", buf.String())
+	}
+}
+
+func TestCollector_RecordersAreMerged(t *testing.T) {
+	c := NewCollector()
+	first, second := c.NewRecorder(), c.NewRecorder()
+	first.Add(Result{StatusCode: 200, Duration: 10 * time.Millisecond})
+	second.Add(Result{StatusCode: 500, Duration: 30 * time.Millisecond})
+	second.Add(Result{Error: errors.New("timeout")})
+	c.Add(Result{StatusCode: 200, Duration: 20 * time.Millisecond})
+
+	snapshot := c.Snapshot()
+	if snapshot.TotalRequests != 4 || snapshot.Errors != 1 {
+		t.Fatalf("expected 4 requests and 1 error, got %d and %d", snapshot.TotalRequests, snapshot.Errors)
+	}
+	if snapshot.StatusCodes[200] != 2 || snapshot.StatusCodes[500] != 1 {
+		t.Fatalf("unexpected status codes: %v", snapshot.StatusCodes)
+	}
+	if snapshot.Latency.P50 != 20*time.Millisecond || snapshot.Latency.Max != 30*time.Millisecond {
+		t.Fatalf("unexpected latency: %+v", snapshot.Latency)
+	}
+}
+
+func TestCollector_Roll(t *testing.T) {
+	c := NewCollector()
+	c.Add(Result{StatusCode: 200, Duration: 10 * time.Millisecond})
+	c.Add(Result{StatusCode: 500, Duration: 30 * time.Millisecond, FailedChecks: []string{"status in [200]"}})
+	c.Add(Result{Error: errors.New("timeout")})
+
+	first := c.Roll()
+	if first.Offset != 0 || first.Requests != 3 || first.Errors != 1 || first.FailedChecks != 1 {
+		t.Fatalf("unexpected interval: %+v", first)
+	}
+	if first.StatusCodes[200] != 1 || first.StatusCodes[500] != 1 {
+		t.Fatalf("unexpected interval status codes: %v", first.StatusCodes)
+	}
+	if first.Latency.Max != 30*time.Millisecond || first.Latency.Mean != 20*time.Millisecond {
+		t.Fatalf("unexpected interval latency: %+v", first.Latency)
+	}
+
+	c.Add(Result{StatusCode: 200, Duration: 50 * time.Millisecond})
+	second := c.Roll()
+	if second.Requests != 1 || second.Latency.Max != 50*time.Millisecond {
+		t.Fatalf("expected only results since the last roll, got %+v", second)
+	}
+	if second.Offset != first.Offset+first.Duration {
+		t.Fatalf("expected intervals to be contiguous, got offsets %v and %v", first.Offset, second.Offset)
+	}
+
+	series := c.Series()
+	if len(series) != 2 {
+		t.Fatalf("expected 2 intervals, got %d", len(series))
+	}
+	if got := c.Snapshot().TotalRequests; got != 4 {
+		t.Fatalf("expected rolling to keep the totals, got %d requests", got)
+	}
+}
+
+func TestInterval_RPS(t *testing.T) {
+	interval := Interval{Duration: 500 * time.Millisecond, Requests: 50}
+	if got := interval.RPS(); got != 100 {
+		t.Fatalf("expected 100 rps, got %v", got)
+	}
+	if got := (Interval{}).RPS(); got != 0 {
+		t.Fatalf("expected 0 rps for an empty interval, got %v", got)
+	}
+}
+
+func TestCollector_ReportsTimingBreakdown(t *testing.T) {
+	c := NewCollector()
+	c.Add(Result{StatusCode: 200, Duration: 30 * time.Millisecond, Timing: Timing{
+		Connect: 2 * time.Millisecond, TLSHandshake: 8 * time.Millisecond, TTFB: 25 * time.Millisecond,
+	}})
+	c.Add(Result{StatusCode: 200, Duration: 10 * time.Millisecond, Timing: Timing{TTFB: 9 * time.Millisecond}})
+
+	timing := c.Snapshot().Timing
+	if timing.Connections != 1 || timing.Handshakes != 1 || timing.Measured != 2 {
+		t.Fatalf("unexpected timing counts: %+v", timing)
+	}
+
+	var buf bytes.Buffer
+	c.FprintReport(&buf)
+	output := buf.String()
+	for _, s := range []string{"Timing Breakdown:", "Connect", "TLS Handshake", "TTFB"} {
+		if !strings.Contains(output, s) {
+			t.Errorf("expected output to contain %q. Output:\n%s", s, output)
+		}
+	}
+}
+
+func TestCollector_OmitsTimingWithoutMeasurements(t *testing.T) {
+	c := NewCollector()
+	c.Add(Result{StatusCode: 0, Duration: 5 * time.Millisecond})
+
+	var buf bytes.Buffer
+	c.FprintReport(&buf)
+	if strings.Contains(buf.String(), "Timing Breakdown:") {
+		t.Fatalf("expected no timing breakdown. Output:\n%s", buf.String())
+	}
+}
+
+func TestCollector_Phases(t *testing.T) {
+	c := NewCollector()
+	c.Add(Result{StatusCode: 200, Duration: 10 * time.Millisecond}) // before any phase
+
+	c.SetPhase("baseline")
+	time.Sleep(5 * time.Millisecond) // the phase covers most of the interval
+	recorder := c.NewRecorder()
+	recorder.Add(Result{StatusCode: 200, Duration: 10 * time.Millisecond})
+	recorder.Add(Result{StatusCode: 200, Duration: 20 * time.Millisecond})
+	first := c.Roll()
+
+	c.SetPhase("fault: partition")
+	time.Sleep(5 * time.Millisecond)
+	recorder.Add(Result{Error: errors.New("connection refused")})
+	recorder.Add(Result{StatusCode: 200, Duration: 90 * time.Millisecond})
+	second := c.Roll()
+
+	if first.Phase != "baseline" {
+		t.Fatalf("expected the first interval in the baseline, got %q", first.Phase)
+	}
+	if second.Phase != "fault: partition" {
+		t.Fatalf("expected the second interval in the fault, got %q", second.Phase)
+	}
+
+	snapshot := c.Snapshot()
+	if len(snapshot.Phases) != 2 {
+		t.Fatalf("expected 2 phases, got %+v", snapshot.Phases)
+	}
+	baseline, fault := snapshot.Phases[0], snapshot.Phases[1]
+	if baseline.Name != "baseline" || baseline.Requests != 2 || baseline.Latency.Max != 20*time.Millisecond {
+		t.Fatalf("unexpected baseline: %+v", baseline)
+	}
+	if baseline.End != fault.Start || fault.End != snapshot.Elapsed {
+		t.Fatalf("expected contiguous phases, got %v-%v and %v-%v", baseline.Start, baseline.End, fault.Start, fault.End)
+	}
+	if fault.Requests != 2 || fault.Errors != 1 || fault.ErrorRate() != 0.5 {
+		t.Fatalf("unexpected fault phase: %+v", fault)
+	}
+}
+
+func TestCollector_BeginExcludesSetup(t *testing.T) {
+	c := NewCollector()
+	c.SetPhase("baseline")
+	time.Sleep(50 * time.Millisecond) // setup before the load
+
+	c.Begin()
+	start := c.Start()
+	time.Sleep(5 * time.Millisecond)
+	c.Begin() // only the first call counts
+	if !c.Start().Equal(start) {
+		t.Fatal("expected a second Begin to keep the start of the load")
+	}
+
+	interval := c.Roll()
+	snapshot := c.Snapshot()
+	if interval.Offset != 0 || interval.Duration >= 50*time.Millisecond {
+		t.Fatalf("expected the first interval to start with the load, got %v+%v", interval.Offset, interval.Duration)
+	}
+	if snapshot.Elapsed >= 50*time.Millisecond {
+		t.Fatalf("expected the setup not to count, elapsed %v", snapshot.Elapsed)
+	}
+	if len(snapshot.Phases) != 1 || snapshot.Phases[0].Start != 0 {
+		t.Fatalf("expected the baseline to start with the load, got %+v", snapshot.Phases)
+	}
+}
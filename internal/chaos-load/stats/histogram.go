@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"math/bits"
+	"time"
+)
+
+const (
+	// histogramUnit is the resolution of recorded latencies.
+	histogramUnit = time.Microsecond
+
+	// subBucketBits gives 2048 linear sub-buckets per power of two, so every
+	// recorded value keeps three significant digits.
+	subBucketBits  = 11
+	subBucketCount = 1 << subBucketBits
+	subBucketHalf  = subBucketCount / 2
+)
+
+// Histogram is a log-linear latency histogram in the style of HdrHistogram.
+// Values up to 2048µs are counted exactly; above that every power of two is
+// split into 1024 buckets, so quantiles are accurate to about 0.1% while
+// memory depends only on the largest value recorded, not on the count.
+type Histogram struct {
+	counts []int64
+	total  int64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+}
+
+// Record adds a latency to the histogram.
+func (h *Histogram) Record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	index := bucketIndex(int64(d / histogramUnit))
+	if index >= len(h.counts) {
+		grown := make([]int64, index+1, max(index+1, 2*len(h.counts)))
+		copy(grown, h.counts)
+		h.counts = grown
+	}
+	h.counts[index]++
+
+	if h.total == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.total++
+	h.sum += d
+}
+
+// Merge adds every value recorded in other to the histogram.
+func (h *Histogram) Merge(other *Histogram) {
+	if other.total == 0 {
+		return
+	}
+	if len(other.counts) > len(h.counts) {
+		grown := make([]int64, len(other.counts))
+		copy(grown, h.counts)
+		h.counts = grown
+	}
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+
+	if h.total == 0 || other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+	h.total += other.total
+	h.sum += other.sum
+}
+
+// Count returns the number of recorded values.
+func (h *Histogram) Count() int64 {
+	return h.total
+}
+
+// Max returns the largest recorded value.
+func (h *Histogram) Max() time.Duration {
+	return h.max
+}
+
+// Mean returns the average of the recorded values.
+func (h *Histogram) Mean() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.total)
+}
+
+// Quantile returns the value below which a fraction q of the recorded values
+// fall, rounded to the three significant digits the histogram keeps.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	rank := int64(q*float64(h.total)) + 1
+	if rank >= h.total {
+		return h.max
+	}
+
+	var seen int64
+	for i, count := range h.counts {
+		seen += count
+		if seen >= rank {
+			value := roundSignificant(time.Duration(bucketValue(i)) * histogramUnit)
+			return min(max(value, h.min), h.max)
+		}
+	}
+	return h.max
+}
+
+// bucketIndex maps a value to its bucket: values below subBucketCount get a
+// bucket each, larger ones share a bucket with the values that agree in their
+// top subBucketBits bits.
+func bucketIndex(value int64) int {
+	if value < subBucketCount {
+		return int(value)
+	}
+	shift := bits.Len64(uint64(value)) - subBucketBits
+	return subBucketCount + (shift-1)*subBucketHalf + int(value>>shift) - subBucketHalf
+}
+
+// bucketValue returns the midpoint of the values counted in bucket index.
+func bucketValue(index int) int64 {
+	if index < subBucketCount {
+		return int64(index)
+	}
+	offset := index - subBucketCount
+	shift := offset/subBucketHalf + 1
+	lowest := int64(offset%subBucketHalf+subBucketHalf) << shift
+	return lowest + int64(1)<<shift/2
+}
+
+// roundSignificant rounds d to three significant digits.
+func roundSignificant(d time.Duration) time.Duration {
+	unit := time.Duration(1)
+	for d/unit >= 1000 {
+		unit *= 10
+	}
+	return d.Round(unit)
+}
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogram_QuantilesWithinPrecision(t *testing.T) {
+	var h Histogram
+	for i := 1; i <= 100000; i++ {
+		h.Record(time.Duration(i) * 10 * time.Microsecond)
+	}
+
+	cases := []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0.5, 500 * time.Millisecond},
+		{0.9, 900 * time.Millisecond},
+		{0.99, 990 * time.Millisecond},
+		{0.999, 999 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		got := h.Quantile(tc.q)
+		diff := got - tc.want
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tc.want/1000 {
+			t.Errorf("Quantile(%v) = %v, want %v within 0.1%%", tc.q, got, tc.want)
+		}
+	}
+
+	if h.Count() != 100000 {
+		t.Fatalf("expected 100000 values, got %d", h.Count())
+	}
+	if h.Max() != time.Second {
+		t.Fatalf("expected exact max 1s, got %v", h.Max())
+	}
+}
+
+func TestHistogram_Merge(t *testing.T) {
+	var a, b Histogram
+	for i := 1; i <= 50; i++ {
+		a.Record(time.Duration(i) * time.Millisecond)
+		b.Record(time.Duration(i+50) * time.Millisecond)
+	}
+
+	var merged Histogram
+	merged.Merge(&a)
+	merged.Merge(&b)
+
+	if merged.Count() != 100 {
+		t.Fatalf("expected 100 values, got %d", merged.Count())
+	}
+	if got := merged.Quantile(0.5); got != 51*time.Millisecond {
+		t.Fatalf("expected p50 51ms, got %v", got)
+	}
+	if got := merged.Max(); got != 100*time.Millisecond {
+		t.Fatalf("expected max 100ms, got %v", got)
+	}
+	if got := merged.Mean(); got != 50500*time.Microsecond {
+		t.Fatalf("expected mean 50.5ms, got %v", got)
+	}
+}
+
+func TestHistogram_MemoryIsBoundedByRange(t *testing.T) {
+	var h Histogram
+	for i := 0; i < 1000000; i++ {
+		h.Record(time.Duration(i%1000) * time.Millisecond)
+	}
+	// One second at three significant digits needs about 11k buckets,
+	// however many values are recorded.
+	if len(h.counts) > 12000 {
+		t.Fatalf("expected at most 12000 buckets, got %d", len(h.counts))
+	}
+}
+
+func TestHistogram_BucketRoundTrip(t *testing.T) {
+	for _, value := range []int64{0, 1, 2047, 2048, 4095, 4096, 123456, 1 << 30} {
+		index := bucketIndex(value)
+		mid := bucketValue(index)
+		if bucketIndex(mid) != index {
+			t.Errorf("bucketValue(%d) = %d falls outside bucket %d", index, mid, index)
+		}
+		if diff := mid - value; diff > value/1000+1 || -diff > value/1000+1 {
+			t.Errorf("bucket midpoint %d too far from %d", mid, value)
+		}
+	}
+}
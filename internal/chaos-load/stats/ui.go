@@ -58,6 +58,11 @@ func (u *UI) render() {
 	fmt.Printf("Requests:     %d\n", snapshot.TotalRequests)
 	fmt.Printf("Errors:       %d\n", snapshot.Errors)
 	fmt.Printf("RPS:          %.2f req/s\n", snapshot.RPS)
+	fmt.Printf("Latency:      p50 %v  p99 %v  max %v\n", snapshot.Latency.P50, snapshot.Latency.P99, snapshot.Latency.Max)
+	if snapshot.TargetRPS > 0 {
+		fmt.Printf("Target:       %.2f req/s\n", snapshot.TargetRPS)
+		fmt.Printf("Dropped:      %d\n", snapshot.Dropped)
+	}
 
 	fmt.Println("\nStatus Codes:")
 	if len(snapshot.StatusCodes) == 0 {
@@ -70,7 +75,7 @@ func (u *UI) render() {
 		}
 		sort.Ints(keys)
 		for _, k := range keys {
-			fmt.Printf("  [%d]: %d\n", k, snapshot.StatusCodes[k])
+			fmt.Printf("  [%s]: %d\n", u.collector.StatusLabel(k), snapshot.StatusCodes[k])
 		}
 	}
 	// Print a few empty lines to clear out any residual lines if the list shrinks (unlikely)
@@ -150,3 +150,95 @@ func (c *Client) ListAlerts(ctx context.Context, filter []string) ([]Alert, erro
 
 	return alerts, nil
 }
+
+// Status represents the subset of the Alertmanager status response used by the toolkit.
+type Status struct {
+	Config struct {
+		Original string `json:"original"`
+	} `json:"config"`
+}
+
+// GetStatus fetches the Alertmanager status, including the loaded configuration.
+func (c *Client) GetStatus(ctx context.Context) (*Status, error) {
+	statusURL := c.baseURL.JoinPath("api/v2/status")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", statusURL.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if c.config.Username != "" && c.config.Password != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.client.Do(req) //nolint:gosec // URL is provided by operator configuration, SSRF is acceptable
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var status Status
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &status, nil
+}
+
+// SilenceMatcher is a label matcher of an Alertmanager silence.
+type SilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	// IsEqual is false for negative matchers; older Alertmanager versions omit it.
+	IsEqual *bool `json:"isEqual,omitempty"`
+}
+
+// Silence represents an Alertmanager silence
+type Silence struct {
+	ID        string           `json:"id"`
+	Matchers  []SilenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+	Status    struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// ListSilences fetches active, pending and expired silences. Alertmanager keeps
+// expired silences for its data retention period (120h by default).
+func (c *Client) ListSilences(ctx context.Context) ([]Silence, error) {
+	u := c.baseURL.JoinPath("api/v2/silences")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if c.config.Username != "" && c.config.Password != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.client.Do(req) //nolint:gosec // URL is provided by operator configuration, SSRF is acceptable
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var silences []Silence
+	if err := json.NewDecoder(resp.Body).Decode(&silences); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return silences, nil
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AlertAnalyzerDashboardUID is the uid of the generated alert-analyzer dashboard.
+const AlertAnalyzerDashboardUID = "sre-toolkit-alert-analyzer-metrics"
+
+// AlertAnalyzerDashboard renders the Grafana dashboard for the alert-analyzer
+// gauges, with one panel per entry of AlertAnalyzerMetricDefinitions and
+// cluster and namespace template variables.
+func AlertAnalyzerDashboard() ([]byte, error) {
+	panels := make([]interface{}, 0, len(AlertAnalyzerMetricDefinitions))
+	x, y, rowHeight := 0, 0, 0
+	for i, definition := range AlertAnalyzerMetricDefinitions {
+		width, height := 12, 8
+		if definition.Panel == PanelStat {
+			height = 4
+		}
+		if x+width > 24 {
+			x, y = 0, y+rowHeight
+			rowHeight = 0
+		}
+		panels = append(panels, dashboardPanel(i+1, definition, x, y, width, height))
+		x += width
+		rowHeight = max(rowHeight, height)
+	}
+
+	dashboard := map[string]interface{}{
+		"annotations":   map[string]interface{}{"list": []interface{}{}},
+		"editable":      true,
+		"panels":        panels,
+		"refresh":       "1m",
+		"schemaVersion": 39,
+		"tags":          []string{"sre-toolkit", "alert-analyzer"},
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":  "datasource",
+					"label": "Data source",
+					"type":  "datasource",
+					"query": "prometheus",
+				},
+				dashboardScopeVariable("cluster", "label_values("+alertAnalyzerFiringsMetric.Name+", cluster)"),
+				dashboardScopeVariable("namespace", "label_values("+alertAnalyzerFiringsMetric.Name+`{cluster=~"$cluster"}, namespace)`),
+			},
+		},
+		"time":     map[string]interface{}{"from": "now-7d", "to": "now"},
+		"timezone": "browser",
+		"title":    "Alert Analyzer Metrics",
+		"uid":      AlertAnalyzerDashboardUID,
+		"version":  1,
+	}
+
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dashboard: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+func dashboardPanel(id int, definition MetricDefinition, x, y, width, height int) map[string]interface{} {
+	target := map[string]interface{}{
+		"datasource": map[string]interface{}{"type": "prometheus", "uid": "${datasource}"},
+		"expr":       definition.query(),
+		"refId":      "A",
+	}
+	if definition.Panel == PanelTable {
+		target["format"] = "table"
+		target["instant"] = true
+	} else if len(definition.Labels) > 0 {
+		legend := make([]string, 0, len(definition.Labels))
+		for _, label := range definition.Labels {
+			legend = append(legend, "{{"+label+"}}")
+		}
+		target["legendFormat"] = strings.Join(legend, " ")
+	}
+
+	defaults := map[string]interface{}{}
+	if definition.Unit != "" {
+		defaults["unit"] = definition.Unit
+	}
+
+	return map[string]interface{}{
+		"id":          id,
+		"type":        definition.Panel,
+		"title":       definition.Title,
+		"description": definition.Help,
+		"datasource":  map[string]interface{}{"type": "prometheus", "uid": "${datasource}"},
+		"fieldConfig": map[string]interface{}{"defaults": defaults, "overrides": []interface{}{}},
+		"gridPos":     map[string]interface{}{"h": height, "w": width, "x": x, "y": y},
+		"targets":     []interface{}{target},
+	}
+}
+
+func dashboardScopeVariable(name, query string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       name,
+		"label":      strings.ToUpper(name[:1]) + name[1:],
+		"type":       "query",
+		"datasource": map[string]interface{}{"type": "prometheus", "uid": "${datasource}"},
+		"query":      query,
+		"refresh":    2,
+		"includeAll": true,
+		"multi":      true,
+		"allValue":   ".*",
+		"current":    map[string]interface{}{"text": "All", "value": "$__all"},
+		"sort":       1,
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+const shippedDashboard = "../../deployments/docker/alert-analyzer/grafana/dashboards/alert-analyzer-metrics.json"
+
+func TestAlertAnalyzerDashboard(t *testing.T) {
+	data, err := AlertAnalyzerDashboard()
+	if err != nil {
+		t.Fatalf("AlertAnalyzerDashboard() failed: %v", err)
+	}
+
+	var dashboard struct {
+		UID    string `json:"uid"`
+		Panels []struct {
+			Type    string `json:"type"`
+			Targets []struct {
+				Expr string `json:"expr"`
+			} `json:"targets"`
+		} `json:"panels"`
+		Templating struct {
+			List []struct {
+				Name string `json:"name"`
+			} `json:"list"`
+		} `json:"templating"`
+	}
+	if err := json.Unmarshal(data, &dashboard); err != nil {
+		t.Fatalf("dashboard is not valid JSON: %v", err)
+	}
+
+	if dashboard.UID != AlertAnalyzerDashboardUID {
+		t.Errorf("expected uid %q, got %q", AlertAnalyzerDashboardUID, dashboard.UID)
+	}
+	if len(dashboard.Panels) != len(AlertAnalyzerMetricDefinitions) {
+		t.Fatalf("expected one panel per metric definition, got %d panels", len(dashboard.Panels))
+	}
+	for i, definition := range AlertAnalyzerMetricDefinitions {
+		panel := dashboard.Panels[i]
+		if panel.Type != definition.Panel {
+			t.Errorf("%s: expected panel type %q, got %q", definition.Name, definition.Panel, panel.Type)
+		}
+		if len(panel.Targets) != 1 || !bytes.Contains([]byte(panel.Targets[0].Expr), []byte(definition.Name)) {
+			t.Errorf("%s: panel query does not use the metric: %+v", definition.Name, panel.Targets)
+		}
+	}
+
+	variables := make([]string, 0, len(dashboard.Templating.List))
+	for _, variable := range dashboard.Templating.List {
+		variables = append(variables, variable.Name)
+	}
+	if len(variables) != 3 || variables[1] != "cluster" || variables[2] != "namespace" {
+		t.Errorf("expected datasource, cluster and namespace variables, got %v", variables)
+	}
+}
+
+func TestAlertAnalyzerDashboard_ShippedFileUpToDate(t *testing.T) {
+	generated, err := AlertAnalyzerDashboard()
+	if err != nil {
+		t.Fatalf("AlertAnalyzerDashboard() failed: %v", err)
+	}
+
+	shipped, err := os.ReadFile(shippedDashboard)
+	if err != nil {
+		t.Fatalf("failed to read shipped dashboard: %v", err)
+	}
+
+	if !bytes.Equal(generated, shipped) {
+		t.Errorf("%s is out of date; regenerate it with 'alert-analyzer dashboard --output-file' from the repository root", shippedDashboard)
+	}
+}
@@ -0,0 +1,205 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Grafana panel types used by the generated alert-analyzer dashboard.
+const (
+	PanelStat       = "stat"
+	PanelTimeSeries = "timeseries"
+	PanelTable      = "table"
+)
+
+// scopeSelector filters the per-cluster and per-namespace series by the
+// dashboard template variables.
+const scopeSelector = `{cluster=~"$cluster",namespace=~"$namespace"}`
+
+// MetricDefinition describes an alert-analyzer gauge. The gauges and the
+// shipped Grafana dashboard are both built from these definitions.
+type MetricDefinition struct {
+	Name   string
+	Help   string
+	Labels []string
+	// Title, Panel and Unit describe the dashboard panel.
+	Title string
+	Panel string
+	Unit  string
+	// Query is the panel expression; defaults to the metric name.
+	Query string
+}
+
+func (d MetricDefinition) gaugeOpts() prometheus.GaugeOpts {
+	return prometheus.GaugeOpts{Name: d.Name, Help: d.Help}
+}
+
+// query returns the PromQL expression shown on the dashboard panel.
+func (d MetricDefinition) query() string {
+	if d.Query != "" {
+		return d.Query
+	}
+	return d.Name
+}
+
+var (
+	alertAnalyzerLastRunMetric = MetricDefinition{
+		Name:  "sre_toolkit_alert_analyzer_last_run_timestamp_seconds",
+		Help:  "Unix timestamp of the last completed alert-analyzer analysis run",
+		Title: "Last Analysis Run",
+		Panel: PanelStat,
+		Unit:  "dateTimeFromNow",
+		Query: "sre_toolkit_alert_analyzer_last_run_timestamp_seconds * 1000",
+	}
+
+	alertAnalyzerSummaryMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_summary",
+		Help:   "Summary metrics from the latest alert-analyzer run",
+		Labels: []string{"metric"},
+		Title:  "Summary",
+		Panel:  PanelStat,
+		Query:  `sre_toolkit_alert_analyzer_summary{metric=~"total_alerts|unique_alerts|total_firings"}`,
+	}
+
+	alertAnalyzerFiringsMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_firings",
+		Help:   "Firing counts per cluster, namespace and alert from the latest alert-analyzer run",
+		Labels: []string{"cluster", "namespace", "alert_name", "severity"},
+		Title:  "Firings by Cluster and Namespace",
+		Panel:  PanelTimeSeries,
+		Query:  "topk(10, sre_toolkit_alert_analyzer_firings" + scopeSelector + ")",
+	}
+
+	alertAnalyzerFiringTimeMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_firing_time_seconds",
+		Help:   "Total firing time per cluster, namespace and alert from the latest alert-analyzer run",
+		Labels: []string{"cluster", "namespace", "alert_name", "severity"},
+		Title:  "Firing Time by Cluster and Namespace",
+		Panel:  PanelTimeSeries,
+		Unit:   "s",
+		Query:  "topk(10, sre_toolkit_alert_analyzer_firing_time_seconds" + scopeSelector + ")",
+	}
+
+	alertAnalyzerTopAlertFiringsMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_top_alert_firings",
+		Help:   "Top alert firing counts from the latest alert-analyzer run",
+		Labels: []string{"alert_name", "severity"},
+		Title:  "Top Alerts by Firings",
+		Panel:  PanelTimeSeries,
+		Query:  "topk(10, sre_toolkit_alert_analyzer_top_alert_firings)",
+	}
+
+	alertAnalyzerFlappingScoreMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_flapping_score",
+		Help:   "Flapping scores from the latest alert-analyzer run",
+		Labels: []string{"alert_name", "severity", "is_flapping"},
+		Title:  "Flapping Scores",
+		Panel:  PanelTimeSeries,
+		Query:  "topk(10, sre_toolkit_alert_analyzer_flapping_score)",
+	}
+
+	alertAnalyzerCorrelationScoreMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_correlation_score",
+		Help:   "Correlation scores from the latest alert-analyzer run",
+		Labels: []string{"alert_a", "alert_b"},
+		Title:  "Top Correlated Alert Pairs",
+		Panel:  PanelTable,
+		Query:  "topk(10, sre_toolkit_alert_analyzer_correlation_score)",
+	}
+
+	alertAnalyzerTemporalBusinessHoursRatioMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_temporal_business_hours_ratio",
+		Help:   "Business-hours firing ratio from the latest alert-analyzer run",
+		Labels: []string{"alert_name", "severity", "peak_weekday", "peak_hour"},
+		Title:  "Temporal Patterns",
+		Panel:  PanelTable,
+		Unit:   "percentunit",
+		Query:  "topk(10, sre_toolkit_alert_analyzer_temporal_business_hours_ratio)",
+	}
+
+	alertAnalyzerRecommendationTotalMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_recommendations_total",
+		Help:   "Recommendation counts from the latest alert-analyzer run",
+		Labels: []string{"category", "priority"},
+		Title:  "Recommendations by Category",
+		Panel:  PanelTable,
+		Query:  "sum by (category, priority) (sre_toolkit_alert_analyzer_recommendations_total)",
+	}
+
+	alertAnalyzerTrendFiringsDeltaMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_trend_firings_delta",
+		Help:   "Change in firing count versus the comparison window from the latest alert-analyzer run",
+		Labels: []string{"alert_name", "severity", "status"},
+		Title:  "Firing Count Change",
+		Panel:  PanelTimeSeries,
+		Query:  "topk(10, abs(sre_toolkit_alert_analyzer_trend_firings_delta))",
+	}
+
+	alertAnalyzerTrendFiringTimeDeltaMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_trend_firing_time_delta_seconds",
+		Help:   "Change in total firing time versus the comparison window from the latest alert-analyzer run",
+		Labels: []string{"alert_name", "severity", "status"},
+		Title:  "Firing Time Change",
+		Panel:  PanelTimeSeries,
+		Unit:   "s",
+		Query:  "topk(10, abs(sre_toolkit_alert_analyzer_trend_firing_time_delta_seconds))",
+	}
+
+	alertAnalyzerTrendFlappingScoreDeltaMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_trend_flapping_score_delta",
+		Help:   "Change in flapping score versus the comparison window from the latest alert-analyzer run",
+		Labels: []string{"alert_name", "severity", "status"},
+		Title:  "Flapping Score Change",
+		Panel:  PanelTimeSeries,
+		Query:  "topk(10, abs(sre_toolkit_alert_analyzer_trend_flapping_score_delta))",
+	}
+
+	alertAnalyzerTrendSummaryMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_trend_summary",
+		Help:   "Period-over-period totals from the latest alert-analyzer run",
+		Labels: []string{"metric"},
+		Title:  "Period-over-Period Totals",
+		Panel:  PanelTimeSeries,
+	}
+
+	alertAnalyzerAnomalyScoreMetric = MetricDefinition{
+		Name:  "sre_toolkit_alert_analyzer_anomaly_score",
+		Help:  "Robust z-score of the alert volume of the latest complete hour against the same hour of the week",
+		Title: "Alert Volume Anomaly Score",
+		Panel: PanelTimeSeries,
+	}
+
+	alertAnalyzerAnomalyWindowsMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_anomaly_windows",
+		Help:   "Anomalous alert volume windows in the lookback of the latest alert-analyzer run",
+		Labels: []string{"direction"},
+		Title:  "Anomalous Windows",
+		Panel:  PanelStat,
+	}
+
+	alertAnalyzerAnomalyContributorExcessMetric = MetricDefinition{
+		Name:   "sre_toolkit_alert_analyzer_anomaly_contributor_excess",
+		Help:   "Firings above (or below) the seasonal baseline per alert, summed over the anomalous windows of the latest run",
+		Labels: []string{"alert_name", "severity", "direction"},
+		Title:  "Anomaly Contributors",
+		Panel:  PanelTable,
+		Query:  "topk(10, abs(sre_toolkit_alert_analyzer_anomaly_contributor_excess))",
+	}
+)
+
+// AlertAnalyzerMetricDefinitions lists every alert-analyzer gauge in dashboard order.
+var AlertAnalyzerMetricDefinitions = []MetricDefinition{
+	alertAnalyzerLastRunMetric,
+	alertAnalyzerSummaryMetric,
+	alertAnalyzerFiringsMetric,
+	alertAnalyzerFiringTimeMetric,
+	alertAnalyzerTopAlertFiringsMetric,
+	alertAnalyzerFlappingScoreMetric,
+	alertAnalyzerCorrelationScoreMetric,
+	alertAnalyzerTemporalBusinessHoursRatioMetric,
+	alertAnalyzerRecommendationTotalMetric,
+	alertAnalyzerTrendFiringsDeltaMetric,
+	alertAnalyzerTrendFiringTimeDeltaMetric,
+	alertAnalyzerTrendFlappingScoreDeltaMetric,
+	alertAnalyzerTrendSummaryMetric,
+	alertAnalyzerAnomalyScoreMetric,
+	alertAnalyzerAnomalyWindowsMetric,
+	alertAnalyzerAnomalyContributorExcessMetric,
+}
@@ -3,9 +3,12 @@ package metrics
 
 import (
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
 	"github.com/neogan/sre-toolkit/internal/cert-monitor/scanner"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -51,66 +54,52 @@ var (
 	)
 
 	// AlertAnalyzerLastRun tracks the last run time of the alert analyzer.
-	AlertAnalyzerLastRun = promauto.NewGauge(
-		prometheus.GaugeOpts{
-			Name: "sre_toolkit_alert_analyzer_last_run_timestamp_seconds",
-			Help: "Unix timestamp of the last completed alert-analyzer analysis run",
-		},
-	)
+	AlertAnalyzerLastRun = promauto.NewGauge(alertAnalyzerLastRunMetric.gaugeOpts())
 
 	// AlertAnalyzerSummary tracks summary metrics from the latest alert-analyzer run.
-	AlertAnalyzerSummary = promauto.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "sre_toolkit_alert_analyzer_summary",
-			Help: "Summary metrics from the latest alert-analyzer run",
-		},
-		[]string{"metric"},
-	)
+	AlertAnalyzerSummary = newAlertAnalyzerGaugeVec(alertAnalyzerSummaryMetric)
+
+	// AlertAnalyzerFirings tracks firing counts per cluster, namespace and alert from the latest run.
+	AlertAnalyzerFirings = newAlertAnalyzerGaugeVec(alertAnalyzerFiringsMetric)
+
+	// AlertAnalyzerFiringTime tracks total firing time per cluster, namespace and alert from the latest run.
+	AlertAnalyzerFiringTime = newAlertAnalyzerGaugeVec(alertAnalyzerFiringTimeMetric)
 
 	// AlertAnalyzerTopAlertFirings tracks the top alert firing counts from the latest run.
-	AlertAnalyzerTopAlertFirings = promauto.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "sre_toolkit_alert_analyzer_top_alert_firings",
-			Help: "Top alert firing counts from the latest alert-analyzer run",
-		},
-		[]string{"alert_name", "severity"},
-	)
+	AlertAnalyzerTopAlertFirings = newAlertAnalyzerGaugeVec(alertAnalyzerTopAlertFiringsMetric)
 
 	// AlertAnalyzerFlappingScore tracks the flapping scores from the latest alert-analyzer run.
-	AlertAnalyzerFlappingScore = promauto.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "sre_toolkit_alert_analyzer_flapping_score",
-			Help: "Flapping scores from the latest alert-analyzer run",
-		},
-		[]string{"alert_name", "severity", "is_flapping"},
-	)
+	AlertAnalyzerFlappingScore = newAlertAnalyzerGaugeVec(alertAnalyzerFlappingScoreMetric)
 
 	// AlertAnalyzerCorrelationScore tracks the correlation scores from the latest alert-analyzer run.
-	AlertAnalyzerCorrelationScore = promauto.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "sre_toolkit_alert_analyzer_correlation_score",
-			Help: "Correlation scores from the latest alert-analyzer run",
-		},
-		[]string{"alert_a", "alert_b"},
-	)
+	AlertAnalyzerCorrelationScore = newAlertAnalyzerGaugeVec(alertAnalyzerCorrelationScoreMetric)
 
 	// AlertAnalyzerTemporalBusinessHoursRatio tracks the business-hours firing ratio from the latest run.
-	AlertAnalyzerTemporalBusinessHoursRatio = promauto.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "sre_toolkit_alert_analyzer_temporal_business_hours_ratio",
-			Help: "Business-hours firing ratio from the latest alert-analyzer run",
-		},
-		[]string{"alert_name", "severity", "peak_weekday", "peak_hour"},
-	)
+	AlertAnalyzerTemporalBusinessHoursRatio = newAlertAnalyzerGaugeVec(alertAnalyzerTemporalBusinessHoursRatioMetric)
 
 	// AlertAnalyzerRecommendationTotal tracks recommendation counts from the latest alert-analyzer run.
-	AlertAnalyzerRecommendationTotal = promauto.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "sre_toolkit_alert_analyzer_recommendations_total",
-			Help: "Recommendation counts from the latest alert-analyzer run",
-		},
-		[]string{"category", "priority"},
-	)
+	AlertAnalyzerRecommendationTotal = newAlertAnalyzerGaugeVec(alertAnalyzerRecommendationTotalMetric)
+
+	// AlertAnalyzerTrendFiringsDelta tracks per-alert firing count changes against the comparison window.
+	AlertAnalyzerTrendFiringsDelta = newAlertAnalyzerGaugeVec(alertAnalyzerTrendFiringsDeltaMetric)
+
+	// AlertAnalyzerTrendFiringTimeDelta tracks per-alert firing time changes against the comparison window.
+	AlertAnalyzerTrendFiringTimeDelta = newAlertAnalyzerGaugeVec(alertAnalyzerTrendFiringTimeDeltaMetric)
+
+	// AlertAnalyzerTrendFlappingScoreDelta tracks per-alert flapping score changes against the comparison window.
+	AlertAnalyzerTrendFlappingScoreDelta = newAlertAnalyzerGaugeVec(alertAnalyzerTrendFlappingScoreDeltaMetric)
+
+	// AlertAnalyzerTrendSummary tracks period-over-period totals from the latest alert-analyzer run.
+	AlertAnalyzerTrendSummary = newAlertAnalyzerGaugeVec(alertAnalyzerTrendSummaryMetric)
+
+	// AlertAnalyzerAnomalyScore tracks the alert volume anomaly score of the latest complete hour.
+	AlertAnalyzerAnomalyScore = promauto.NewGauge(alertAnalyzerAnomalyScoreMetric.gaugeOpts())
+
+	// AlertAnalyzerAnomalyWindows tracks the anomalous volume windows per direction from the latest run.
+	AlertAnalyzerAnomalyWindows = newAlertAnalyzerGaugeVec(alertAnalyzerAnomalyWindowsMetric)
+
+	// AlertAnalyzerAnomalyContributorExcess tracks per-alert deviations within anomalous windows.
+	AlertAnalyzerAnomalyContributorExcess = newAlertAnalyzerGaugeVec(alertAnalyzerAnomalyContributorExcessMetric)
 
 	// cert-monitor metrics
 
@@ -216,6 +205,27 @@ func (s *Server) Stop() error {
 	return s.server.Close()
 }
 
+// Series of the per-run alert-analyzer gauges. They are updated in place and
+// only series missing from the latest run are deleted, so a scrape or remote
+// write between two runs never sees a partially rebuilt vector.
+var (
+	firingsSeries                 = newGaugeSeries(AlertAnalyzerFirings)
+	firingTimeSeries              = newGaugeSeries(AlertAnalyzerFiringTime)
+	topAlertFiringsSeries         = newGaugeSeries(AlertAnalyzerTopAlertFirings)
+	flappingScoreSeries           = newGaugeSeries(AlertAnalyzerFlappingScore)
+	correlationScoreSeries        = newGaugeSeries(AlertAnalyzerCorrelationScore)
+	temporalSeries                = newGaugeSeries(AlertAnalyzerTemporalBusinessHoursRatio)
+	recommendationTotalSeries     = newGaugeSeries(AlertAnalyzerRecommendationTotal)
+	trendFiringsDeltaSeries       = newGaugeSeries(AlertAnalyzerTrendFiringsDelta)
+	trendFiringTimeDeltaSeries    = newGaugeSeries(AlertAnalyzerTrendFiringTimeDelta)
+	trendFlappingScoreDeltaSeries = newGaugeSeries(AlertAnalyzerTrendFlappingScoreDelta)
+	anomalyContributorSeries      = newGaugeSeries(AlertAnalyzerAnomalyContributorExcess)
+)
+
+func newAlertAnalyzerGaugeVec(definition MetricDefinition) *prometheus.GaugeVec {
+	return promauto.NewGaugeVec(definition.gaugeOpts(), definition.Labels)
+}
+
 // SetAlertAnalyzerMetrics updates the latest alert-analyzer analysis gauges.
 func SetAlertAnalyzerMetrics(
 	stats analyzer.SummaryStats,
@@ -233,36 +243,36 @@ func SetAlertAnalyzerMetrics(
 	AlertAnalyzerSummary.WithLabelValues("avg_duration_seconds").Set(stats.AvgDuration.Seconds())
 	AlertAnalyzerSummary.WithLabelValues("total_firing_time_seconds").Set(stats.TotalFiringTime.Seconds())
 
-	AlertAnalyzerTopAlertFirings.Reset()
 	for _, result := range frequency {
-		AlertAnalyzerTopAlertFirings.WithLabelValues(result.AlertName, result.Severity).Set(float64(result.FiringCount))
+		topAlertFiringsSeries.set(float64(result.FiringCount), result.AlertName, result.Severity)
 	}
+	topAlertFiringsSeries.flush()
 
-	AlertAnalyzerFlappingScore.Reset()
 	for _, result := range flapping {
 		isFlapping := "false"
 		if result.IsFlapping {
 			isFlapping = "true"
 		}
-		AlertAnalyzerFlappingScore.WithLabelValues(result.AlertName, result.Severity, isFlapping).Set(result.FlappingScore)
+		flappingScoreSeries.set(result.FlappingScore, result.AlertName, result.Severity, isFlapping)
 	}
+	flappingScoreSeries.flush()
 
-	AlertAnalyzerCorrelationScore.Reset()
 	for _, result := range correlation {
-		AlertAnalyzerCorrelationScore.WithLabelValues(result.AlertA, result.AlertB).Set(result.CorrelationScore)
+		correlationScoreSeries.set(result.CorrelationScore, result.AlertA, result.AlertB)
 	}
+	correlationScoreSeries.flush()
 
-	AlertAnalyzerTemporalBusinessHoursRatio.Reset()
 	for _, result := range temporal {
-		AlertAnalyzerTemporalBusinessHoursRatio.WithLabelValues(
+		temporalSeries.set(
+			result.BusinessHoursRatio,
 			result.AlertName,
 			result.Severity,
 			result.PeakWeekday,
 			formatMetricHour(result.PeakHour),
-		).Set(result.BusinessHoursRatio)
+		)
 	}
+	temporalSeries.flush()
 
-	AlertAnalyzerRecommendationTotal.Reset()
 	seen := make(map[string]float64)
 	for _, result := range recommendations {
 		key := result.Category + "\x00" + result.Priority
@@ -270,8 +280,82 @@ func SetAlertAnalyzerMetrics(
 	}
 	for key, count := range seen {
 		parts := splitMetricKey(key)
-		AlertAnalyzerRecommendationTotal.WithLabelValues(parts[0], parts[1]).Set(count)
+		recommendationTotalSeries.set(count, parts[0], parts[1])
 	}
+	recommendationTotalSeries.flush()
+}
+
+// SetAlertAnalyzerBreakdownMetrics updates the per-cluster and per-namespace
+// firing gauges from the analyzed alert history. Alerts without a cluster or
+// namespace are exported with an empty label value.
+func SetAlertAnalyzerBreakdownMetrics(history *collector.AlertHistory) {
+	type scopeKey struct {
+		cluster, namespace, alertName, severity string
+	}
+
+	firings := make(map[scopeKey]float64)
+	firingTime := make(map[scopeKey]float64)
+	if history != nil {
+		for _, alert := range history.Alerts {
+			cluster := alert.Cluster
+			if cluster == "" {
+				cluster = alert.Labels["cluster"]
+			}
+			key := scopeKey{cluster, alert.GetNamespace(), alert.Name, alert.GetSeverity()}
+			firings[key]++
+			firingTime[key] += alert.Duration().Seconds()
+		}
+	}
+
+	for key, count := range firings {
+		firingsSeries.set(count, key.cluster, key.namespace, key.alertName, key.severity)
+		firingTimeSeries.set(firingTime[key], key.cluster, key.namespace, key.alertName, key.severity)
+	}
+	firingsSeries.flush()
+	firingTimeSeries.flush()
+}
+
+// SetAlertAnalyzerTrendMetrics updates the period-over-period delta gauges.
+// results may be a subset of trends.Alerts, e.g. limited to the largest changes.
+func SetAlertAnalyzerTrendMetrics(trends analyzer.TrendReport, results []analyzer.TrendResult) {
+	AlertAnalyzerTrendSummary.WithLabelValues("firings_delta").Set(float64(trends.FiringDelta))
+	AlertAnalyzerTrendSummary.WithLabelValues("firing_time_delta_seconds").Set(trends.FiringTimeDelta.Seconds())
+	AlertAnalyzerTrendSummary.WithLabelValues("new_alerts").Set(float64(len(trends.New)))
+	AlertAnalyzerTrendSummary.WithLabelValues("disappeared_alerts").Set(float64(len(trends.Disappeared)))
+
+	for _, result := range results {
+		trendFiringsDeltaSeries.set(float64(result.FiringDelta), result.AlertName, result.Severity, result.Status)
+		trendFiringTimeDeltaSeries.set(result.TotalTimeDelta.Seconds(), result.AlertName, result.Severity, result.Status)
+		trendFlappingScoreDeltaSeries.set(result.FlappingScoreDelta, result.AlertName, result.Severity, result.Status)
+	}
+	trendFiringsDeltaSeries.flush()
+	trendFiringTimeDeltaSeries.flush()
+	trendFlappingScoreDeltaSeries.flush()
+}
+
+// SetAlertAnalyzerAnomalyMetrics updates the alert volume anomaly gauges.
+func SetAlertAnalyzerAnomalyMetrics(report analyzer.AnomalyReport) {
+	AlertAnalyzerAnomalyScore.Set(report.LatestScore)
+
+	windows := map[string]float64{analyzer.AnomalySpike: 0, analyzer.AnomalyDrop: 0}
+	type contributorKey struct {
+		alertName, severity, direction string
+	}
+	excess := make(map[contributorKey]float64)
+	for _, window := range report.Windows {
+		windows[window.Direction]++
+		for _, contributor := range window.Contributors {
+			excess[contributorKey{contributor.AlertName, contributor.Severity, window.Direction}] += contributor.Excess
+		}
+	}
+
+	for direction, count := range windows {
+		AlertAnalyzerAnomalyWindows.WithLabelValues(direction).Set(count)
+	}
+	for key, value := range excess {
+		anomalyContributorSeries.set(value, key.alertName, key.severity, key.direction)
+	}
+	anomalyContributorSeries.flush()
 }
 
 // SetCertMonitorMetrics updates cert-monitor Prometheus gauges after a scan.
@@ -339,3 +423,43 @@ func splitMetricKey(key string) []string {
 	}
 	return []string{key, ""}
 }
+
+// gaugeSeries tracks the label sets set on a GaugeVec since the last flush.
+type gaugeSeries struct {
+	mu       sync.Mutex
+	vec      *prometheus.GaugeVec
+	current  map[string][]string
+	previous map[string][]string
+}
+
+func newGaugeSeries(vec *prometheus.GaugeVec) *gaugeSeries {
+	return &gaugeSeries{
+		vec:      vec,
+		current:  make(map[string][]string),
+		previous: make(map[string][]string),
+	}
+}
+
+// set updates the series in place and marks it as present in this update.
+func (s *gaugeSeries) set(value float64, labelValues ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.vec.WithLabelValues(labelValues...).Set(value)
+	s.current[strings.Join(labelValues, "\x00")] = labelValues
+}
+
+// flush deletes the series that were set in the previous update but not in
+// this one and starts the next update.
+func (s *gaugeSeries) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, labelValues := range s.previous {
+		if _, ok := s.current[key]; !ok {
+			s.vec.DeleteLabelValues(labelValues...)
+		}
+	}
+	s.previous = s.current
+	s.current = make(map[string][]string)
+}
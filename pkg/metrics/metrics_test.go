@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/neogan/sre-toolkit/internal/alert-analyzer/analyzer"
+	"github.com/neogan/sre-toolkit/internal/alert-analyzer/collector"
 	"github.com/neogan/sre-toolkit/internal/cert-monitor/scanner"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
@@ -104,6 +105,123 @@ func TestSetAlertAnalyzerMetrics(t *testing.T) {
 	}
 }
 
+func TestSetAlertAnalyzerMetrics_StableSeries(t *testing.T) {
+	run := func(frequency []analyzer.FrequencyResult) {
+		SetAlertAnalyzerMetrics(analyzer.SummaryStats{}, frequency, nil, nil, nil, nil)
+	}
+
+	run([]analyzer.FrequencyResult{
+		{AlertName: "StableAlert", Severity: "warning", FiringCount: 3},
+		{AlertName: "GoneAlert", Severity: "warning", FiringCount: 1},
+	})
+	stable := AlertAnalyzerTopAlertFirings.WithLabelValues("StableAlert", "warning")
+
+	run([]analyzer.FrequencyResult{
+		{AlertName: "StableAlert", Severity: "warning", FiringCount: 4},
+	})
+
+	// The series is updated in place rather than recreated.
+	if got := testutil.ToFloat64(stable); got != 4 {
+		t.Fatalf("expected the existing series to be updated to 4, got %v", got)
+	}
+	if AlertAnalyzerTopAlertFirings.DeleteLabelValues("GoneAlert", "warning") {
+		t.Fatal("expected the series missing from the latest run to be deleted")
+	}
+}
+
+func TestSetAlertAnalyzerBreakdownMetrics(t *testing.T) {
+	firedAt := time.Now().Add(-time.Hour)
+	resolvedAt := firedAt.Add(10 * time.Minute)
+	history := &collector.AlertHistory{Alerts: []collector.Alert{
+		{Name: "HighCPU", Cluster: "prod", Labels: map[string]string{"namespace": "api", "severity": "critical"}, FiredAt: firedAt, ResolvedAt: &resolvedAt},
+		{Name: "HighCPU", Cluster: "prod", Labels: map[string]string{"namespace": "api", "severity": "critical"}, FiredAt: firedAt, ResolvedAt: &resolvedAt},
+		{Name: "HighCPU", Labels: map[string]string{"cluster": "dev", "severity": "critical"}, FiredAt: firedAt, ResolvedAt: &resolvedAt},
+	}}
+
+	SetAlertAnalyzerBreakdownMetrics(history)
+
+	if got := testutil.ToFloat64(AlertAnalyzerFirings.WithLabelValues("prod", "api", "HighCPU", "critical")); got != 2 {
+		t.Fatalf("expected 2 firings in prod/api, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerFiringTime.WithLabelValues("prod", "api", "HighCPU", "critical")); got != 1200 {
+		t.Fatalf("expected 1200s firing time in prod/api, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerFirings.WithLabelValues("dev", "", "HighCPU", "critical")); got != 1 {
+		t.Fatalf("expected the cluster label to be used when the alert has no cluster, got %v", got)
+	}
+
+	SetAlertAnalyzerBreakdownMetrics(&collector.AlertHistory{})
+	if got := testutil.CollectAndCount(AlertAnalyzerFirings); got != 0 {
+		t.Fatalf("expected all breakdown series to be deleted, got %d", got)
+	}
+}
+
+func TestSetAlertAnalyzerTrendMetrics(t *testing.T) {
+	trends := analyzer.TrendReport{
+		FiringDelta:     3,
+		FiringTimeDelta: -10 * time.Minute,
+		Alerts: []analyzer.TrendResult{
+			{AlertName: "HighCPU", Severity: "critical", Status: analyzer.TrendStatusExisting, FiringDelta: 5, TotalTimeDelta: 20 * time.Minute, FlappingScoreDelta: 1.5},
+			{AlertName: "OldAlert", Severity: "warning", Status: analyzer.TrendStatusDisappeared, FiringDelta: -2, TotalTimeDelta: -30 * time.Minute},
+		},
+		New:         []string{},
+		Disappeared: []string{"OldAlert"},
+	}
+
+	SetAlertAnalyzerTrendMetrics(trends, trends.Alerts)
+
+	if got := testutil.ToFloat64(AlertAnalyzerTrendSummary.WithLabelValues("firings_delta")); got != 3 {
+		t.Fatalf("expected firings delta 3, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerTrendSummary.WithLabelValues("disappeared_alerts")); got != 1 {
+		t.Fatalf("expected 1 disappeared alert, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerTrendFiringsDelta.WithLabelValues("OldAlert", "warning", "disappeared")); got != -2 {
+		t.Fatalf("expected firings delta -2, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerTrendFiringTimeDelta.WithLabelValues("HighCPU", "critical", "existing")); got != 1200 {
+		t.Fatalf("expected firing time delta 1200s, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerTrendFlappingScoreDelta.WithLabelValues("HighCPU", "critical", "existing")); got != 1.5 {
+		t.Fatalf("expected flapping score delta 1.5, got %v", got)
+	}
+}
+
+func TestSetAlertAnalyzerAnomalyMetrics(t *testing.T) {
+	start := time.Date(2026, 3, 25, 10, 0, 0, 0, time.UTC)
+	report := analyzer.AnomalyReport{
+		LatestScore: 4.5,
+		Windows: []analyzer.AnomalyWindow{
+			{Start: start, End: start.Add(time.Hour), Direction: analyzer.AnomalySpike, Contributors: []analyzer.AnomalyContributor{
+				{AlertName: "NodeDown", Severity: "critical", Excess: 30},
+			}},
+			{Start: start.Add(24 * time.Hour), End: start.Add(25 * time.Hour), Direction: analyzer.AnomalySpike, Contributors: []analyzer.AnomalyContributor{
+				{AlertName: "NodeDown", Severity: "critical", Excess: 12},
+			}},
+		},
+	}
+
+	SetAlertAnalyzerAnomalyMetrics(report)
+
+	if got := testutil.ToFloat64(AlertAnalyzerAnomalyScore); got != 4.5 {
+		t.Fatalf("expected anomaly score 4.5, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerAnomalyWindows.WithLabelValues("spike")); got != 2 {
+		t.Fatalf("expected 2 spike windows, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerAnomalyWindows.WithLabelValues("drop")); got != 0 {
+		t.Fatalf("expected 0 drop windows, got %v", got)
+	}
+	if got := testutil.ToFloat64(AlertAnalyzerAnomalyContributorExcess.WithLabelValues("NodeDown", "critical", "spike")); got != 42 {
+		t.Fatalf("expected contributor excess 42, got %v", got)
+	}
+
+	SetAlertAnalyzerAnomalyMetrics(analyzer.AnomalyReport{})
+	if got := testutil.CollectAndCount(AlertAnalyzerAnomalyContributorExcess); got != 0 {
+		t.Fatalf("expected contributors of the previous run to be removed, got %d series", got)
+	}
+}
+
 func TestSetCertMonitorMetrics(t *testing.T) {
 	results := []*scanner.CertInfo{
 		{
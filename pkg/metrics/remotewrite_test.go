@@ -0,0 +1,327 @@
+package metrics
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type receivedSample struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+	exemplars []receivedSample
+}
+
+// remoteWriteReceiver is a minimal remote-write endpoint that records the
+// decoded samples and request headers.
+type remoteWriteReceiver struct {
+	t       *testing.T
+	samples []receivedSample
+	headers http.Header
+}
+
+func (r *remoteWriteReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		r.t.Errorf("failed to read body: %v", err)
+		return
+	}
+	r.headers = req.Header.Clone()
+
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		r.t.Errorf("invalid snappy body: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.samples = append(r.samples, decodeWriteRequest(r.t, decoded)...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeWriteRequest(t *testing.T, data []byte) []receivedSample {
+	var samples []receivedSample
+	forEachField(t, data, func(num protowire.Number, value []byte) {
+		if num != 1 {
+			return
+		}
+		sample := receivedSample{labels: make(map[string]string)}
+		forEachField(t, value, func(num protowire.Number, value []byte) {
+			switch num {
+			case 1:
+				decodeLabel(t, value, sample.labels)
+			case 2:
+				forEachField(t, value, func(num protowire.Number, value []byte) {
+					if num == 1 {
+						sample.value = decodeDouble(value)
+					} else {
+						sample.timestamp = decodeInt64(value)
+					}
+				})
+			case 3:
+				exemplar := receivedSample{labels: make(map[string]string)}
+				forEachField(t, value, func(num protowire.Number, value []byte) {
+					switch num {
+					case 1:
+						decodeLabel(t, value, exemplar.labels)
+					case 2:
+						exemplar.value = decodeDouble(value)
+					case 3:
+						exemplar.timestamp = decodeInt64(value)
+					}
+				})
+				sample.exemplars = append(sample.exemplars, exemplar)
+			}
+		})
+		samples = append(samples, sample)
+	})
+	return samples
+}
+
+func decodeLabel(t *testing.T, data []byte, labels map[string]string) {
+	var name, value string
+	forEachField(t, data, func(num protowire.Number, field []byte) {
+		if num == 1 {
+			name = string(field)
+		} else {
+			value = string(field)
+		}
+	})
+	labels[name] = value
+}
+
+func decodeDouble(data []byte) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(data))
+}
+
+func decodeInt64(data []byte) int64 {
+	value, _ := protowire.ConsumeVarint(data)
+	return int64(value) //nolint:gosec // decoding an int64 varint
+}
+
+// forEachField calls fn with the raw value of every field of a protobuf
+// message: the payload for length-delimited fields, the encoded bytes otherwise.
+func forEachField(t *testing.T, data []byte, fn func(protowire.Number, []byte)) {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			t.Fatalf("invalid tag: %v", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		var value []byte
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			value = data[:max(n, 0)]
+		}
+		if n < 0 {
+			t.Fatalf("invalid field %d: %v", num, protowire.ParseError(n))
+		}
+		fn(num, value)
+		data = data[n:]
+	}
+}
+
+func findSample(samples []receivedSample, labels map[string]string) *receivedSample {
+	for i, sample := range samples {
+		matches := true
+		for name, value := range labels {
+			if sample.labels[name] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return &samples[i]
+		}
+	}
+	return nil
+}
+
+func TestRemoteWriter_Write(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "sre_toolkit_test_firings", Help: "test"}, []string{"cluster", "namespace"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "sre_toolkit_test_duration_seconds", Help: "test", Buckets: []float64{1, 5}})
+	other := prometheus.NewGauge(prometheus.GaugeOpts{Name: "go_test_other", Help: "test"})
+	registry.MustRegister(gauge, histogram, other)
+
+	gauge.WithLabelValues("prod", "api").Set(7)
+	gauge.WithLabelValues("dev", "").Set(2)
+	histogram.Observe(3)
+	other.Set(1)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	receiver := &remoteWriteReceiver{t: t}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	writer, err := NewRemoteWriter(&RemoteWriteConfig{
+		URL:             server.URL + "/api/v1/push",
+		BearerTokenFile: tokenFile,
+		Headers:         map[string]string{"X-Scope-OrgID": "sre"},
+		ExternalLabels:  map[string]string{"instance": "alert-analyzer", "cluster": "ignored"},
+		MetricPrefix:    "sre_toolkit_",
+	}, registry)
+	if err != nil {
+		t.Fatalf("NewRemoteWriter() failed: %v", err)
+	}
+
+	now := time.UnixMilli(1700000000123)
+	if err := writer.Write(context.Background(), now); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	if got := receiver.headers.Get("Content-Encoding"); got != "snappy" {
+		t.Errorf("expected snappy content encoding, got %q", got)
+	}
+	if got := receiver.headers.Get("X-Prometheus-Remote-Write-Version"); got != "0.1.0" {
+		t.Errorf("expected remote write version header, got %q", got)
+	}
+	if got := receiver.headers.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("expected bearer token from file, got %q", got)
+	}
+	if got := receiver.headers.Get("X-Scope-OrgID"); got != "sre" {
+		t.Errorf("expected tenant header, got %q", got)
+	}
+
+	// 2 gauge series + 3 buckets, sum and count; go_test_other is filtered out.
+	if len(receiver.samples) != 7 {
+		t.Fatalf("expected 7 samples, got %d: %+v", len(receiver.samples), receiver.samples)
+	}
+
+	prod := findSample(receiver.samples, map[string]string{"__name__": "sre_toolkit_test_firings", "cluster": "prod"})
+	if prod == nil {
+		t.Fatal("missing prod series")
+	}
+	if prod.value != 7 || prod.timestamp != now.UnixMilli() {
+		t.Errorf("unexpected prod sample: %+v", prod)
+	}
+	if prod.labels["namespace"] != "api" || prod.labels["instance"] != "alert-analyzer" {
+		t.Errorf("expected namespace and external labels, got %v", prod.labels)
+	}
+
+	bucket := findSample(receiver.samples, map[string]string{"__name__": "sre_toolkit_test_duration_seconds_bucket", "le": "+Inf"})
+	if bucket == nil || bucket.value != 1 {
+		t.Errorf("expected +Inf bucket with 1 observation, got %+v", bucket)
+	}
+	if findSample(receiver.samples, map[string]string{"__name__": "sre_toolkit_test_duration_seconds_bucket", "le": "5"}) == nil {
+		t.Error("missing le=5 bucket")
+	}
+
+	// A second write sends the same series again.
+	gauge.WithLabelValues("prod", "api").Set(8)
+	if err := writer.Write(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("second Write() failed: %v", err)
+	}
+	if len(receiver.samples) != 14 {
+		t.Fatalf("expected 14 samples after two writes, got %d", len(receiver.samples))
+	}
+	latest := findSample(receiver.samples[7:], map[string]string{"__name__": "sre_toolkit_test_firings", "cluster": "prod"})
+	if latest == nil || latest.value != 8 {
+		t.Errorf("expected updated prod sample, got %+v", latest)
+	}
+}
+
+func TestRemoteWriter_WriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "sre_toolkit_test_gauge", Help: "test"})
+	registry.MustRegister(gauge)
+
+	writer, err := NewRemoteWriter(&RemoteWriteConfig{URL: server.URL}, registry)
+	if err != nil {
+		t.Fatalf("NewRemoteWriter() failed: %v", err)
+	}
+
+	err = writer.Write(context.Background(), time.Now())
+	if err == nil || !strings.Contains(err.Error(), "HTTP 400: out of order sample") {
+		t.Fatalf("expected HTTP 400 error, got %v", err)
+	}
+}
+
+func TestNewRemoteWriter_InvalidURL(t *testing.T) {
+	if _, err := NewRemoteWriter(&RemoteWriteConfig{}, nil); err == nil {
+		t.Error("expected error for missing URL")
+	}
+	if _, err := NewRemoteWriter(&RemoteWriteConfig{URL: "not a url"}, nil); err == nil {
+		t.Error("expected error for invalid URL")
+	}
+}
+
+func TestRemoteWriter_WriteExemplars(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "sre_toolkit_test_pages_total", Help: "test"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "sre_toolkit_test_duration_seconds", Help: "test", Buckets: []float64{1, 5}})
+	registry.MustRegister(counter, histogram)
+
+	counter.(prometheus.ExemplarAdder).AddWithExemplar(1, prometheus.Labels{"alertname": "HighLatency"})
+	histogram.(prometheus.ExemplarObserver).ObserveWithExemplar(3, prometheus.Labels{"trace_id": "abc123"})
+	histogram.(prometheus.ExemplarObserver).ObserveWithExemplar(9, prometheus.Labels{"trace_id": "def456"})
+
+	receiver := &remoteWriteReceiver{t: t}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	writer, err := NewRemoteWriter(&RemoteWriteConfig{URL: server.URL}, registry)
+	if err != nil {
+		t.Fatalf("NewRemoteWriter() failed: %v", err)
+	}
+	if err := writer.Write(context.Background(), time.Now()); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	tests := []struct {
+		series   map[string]string
+		label    string
+		value    float64
+		exemplar string
+	}{
+		{map[string]string{"__name__": "sre_toolkit_test_pages_total"}, "alertname", 1, "HighLatency"},
+		{map[string]string{"__name__": "sre_toolkit_test_duration_seconds_bucket", "le": "5"}, "trace_id", 3, "abc123"},
+		{map[string]string{"__name__": "sre_toolkit_test_duration_seconds_bucket", "le": "+Inf"}, "trace_id", 9, "def456"},
+	}
+	for _, tt := range tests {
+		sample := findSample(receiver.samples, tt.series)
+		if sample == nil {
+			t.Fatalf("missing series %v", tt.series)
+		}
+		if len(sample.exemplars) != 1 {
+			t.Fatalf("%v: expected one exemplar, got %+v", tt.series, sample.exemplars)
+		}
+		exemplar := sample.exemplars[0]
+		if exemplar.labels[tt.label] != tt.exemplar || exemplar.value != tt.value || exemplar.timestamp == 0 {
+			t.Errorf("%v: unexpected exemplar %+v", tt.series, exemplar)
+		}
+	}
+
+	if sample := findSample(receiver.samples, map[string]string{"__name__": "sre_toolkit_test_duration_seconds_bucket", "le": "1"}); sample == nil || len(sample.exemplars) != 0 {
+		t.Errorf("expected le=1 bucket without exemplars, got %+v", sample)
+	}
+	// Series keep their value: the +Inf bucket counts both observations.
+	if sample := findSample(receiver.samples, map[string]string{"__name__": "sre_toolkit_test_duration_seconds_bucket", "le": "+Inf"}); sample.value != 2 {
+		t.Errorf("expected 2 observations in the +Inf bucket, got %v", sample.value)
+	}
+}
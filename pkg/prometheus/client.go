@@ -4,8 +4,12 @@ package prometheus
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/api"
@@ -16,13 +20,25 @@ import (
 
 // Config holds the configuration for the Prometheus client
 type Config struct {
-	URL      string        // Prometheus server URL
+	URL      string        // Prometheus server URL, including any API path prefix
 	Username string        // Basic auth username (optional)
 	Password string        // Basic auth password (optional) //nolint:gosec // Password is a configuration field for Prometheus basic auth
 	Timeout  time.Duration // Request timeout
 	Insecure bool          // Skip TLS verification
+
+	TenantID        string            // Tenant sent as X-Scope-OrgID for Mimir and Cortex (optional)
+	BearerToken     string            // Static bearer token (optional) //nolint:gosec // BearerToken is a configuration field for Prometheus authentication
+	BearerTokenFile string            // File with a bearer token, re-read on every request (optional)
+	Headers         map[string]string // Extra request headers (optional)
+	CAFile          string            // CA bundle used to verify the server (optional)
+	CertFile        string            // Client certificate for mTLS (optional)
+	KeyFile         string            // Client key for mTLS (optional)
+	PartialResponse *bool             // Thanos partial_response query parameter; nil leaves the server default
 }
 
+// TenantHeader is the HTTP header carrying the tenant for multi-tenant backends.
+const TenantHeader = "X-Scope-OrgID"
+
 // Client wraps the Prometheus API client
 type Client struct {
 	api    v1.API
@@ -41,12 +57,15 @@ func NewClient(cfg *Config, logger *zerolog.Logger) (*Client, error) {
 		cfg.Timeout = 30 * time.Second
 	}
 
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create HTTP client with custom transport
 	httpClient := &http.Client{
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: cfg.Insecure, // #nosec G402
-			},
+			TLSClientConfig: tlsConfig,
 		},
 	}
 
@@ -60,6 +79,17 @@ func NewClient(cfg *Config, logger *zerolog.Logger) (*Client, error) {
 		}
 	}
 
+	if cfg.TenantID != "" || cfg.BearerToken != "" || cfg.BearerTokenFile != "" || len(cfg.Headers) > 0 || cfg.PartialResponse != nil {
+		roundTripper = &requestRoundTripper{
+			tenantID:        cfg.TenantID,
+			bearerToken:     cfg.BearerToken,
+			bearerTokenFile: cfg.BearerTokenFile,
+			headers:         cfg.Headers,
+			partialResponse: cfg.PartialResponse,
+			next:            roundTripper,
+		}
+	}
+
 	// Create Prometheus API client
 	apiClient, err := api.NewClient(api.Config{
 		Address:      cfg.URL,
@@ -184,3 +214,86 @@ func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, e
 	req.SetBasicAuth(rt.username, rt.password)
 	return rt.next.RoundTrip(req)
 }
+
+// newTLSConfig builds the TLS settings, loading the CA bundle and the client
+// certificate for mTLS when configured.
+func newTLSConfig(cfg *Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.Insecure, // #nosec G402
+	}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile) //nolint:gosec // path is provided by the operator
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("both a client certificate and key are required for mTLS")
+		}
+		// Fail fast on a broken pair, then reload on every handshake so
+		// rotated certificates are picked up without a restart.
+		if _, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile); err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		certFile, keyFile := cfg.CertFile, cfg.KeyFile
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			return &cert, nil
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// requestRoundTripper adds tenant, bearer token and custom headers, and the
+// Thanos partial_response parameter to HTTP requests
+type requestRoundTripper struct {
+	tenantID        string
+	bearerToken     string
+	bearerTokenFile string
+	headers         map[string]string
+	partialResponse *bool
+	next            http.RoundTripper
+}
+
+func (rt *requestRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	for name, value := range rt.headers {
+		req.Header.Set(name, value)
+	}
+	if rt.tenantID != "" {
+		req.Header.Set(TenantHeader, rt.tenantID)
+	}
+
+	token := rt.bearerToken
+	if rt.bearerTokenFile != "" {
+		data, err := os.ReadFile(rt.bearerTokenFile) //nolint:gosec // path is provided by the operator
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bearer token file: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if rt.partialResponse != nil {
+		query := req.URL.Query()
+		query.Set("partial_response", strconv.FormatBool(*rt.partialResponse))
+		req.URL.RawQuery = query.Encode()
+	}
+
+	return rt.next.RoundTrip(req)
+}
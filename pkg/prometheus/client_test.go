@@ -2,8 +2,20 @@ package prometheus
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,6 +24,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockAPI is a mock for v1.API
@@ -338,3 +351,106 @@ func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	args := m.mock.Called(req)
 	return args.Get(0).(*http.Response), args.Error(1)
 }
+
+func TestNewClient_RequestOptions(t *testing.T) {
+	var captured *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		captured = r
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first\n"), 0o600))
+
+	partial := false
+	logger := zerolog.Nop()
+	client, err := NewClient(&Config{
+		URL:             server.URL + "/prometheus",
+		TenantID:        "team-a",
+		BearerTokenFile: tokenFile,
+		Headers:         map[string]string{"X-Custom": "value"},
+		PartialResponse: &partial,
+	}, &logger)
+	require.NoError(t, err)
+
+	_, err = client.Query(context.Background(), "up", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "/prometheus/api/v1/query", captured.URL.Path)
+	assert.Equal(t, "team-a", captured.Header.Get(TenantHeader))
+	assert.Equal(t, "Bearer first", captured.Header.Get("Authorization"))
+	assert.Equal(t, "value", captured.Header.Get("X-Custom"))
+	assert.Equal(t, "false", captured.Form.Get("partial_response"))
+	assert.Equal(t, "up", captured.Form.Get("query"))
+
+	// Rotated tokens are picked up without recreating the client.
+	require.NoError(t, os.WriteFile(tokenFile, []byte("second"), 0o600))
+	_, err = client.Query(context.Background(), "up", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer second", captured.Header.Get("Authorization"))
+}
+
+func TestNewClient_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir, "client")
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Len(t, r.TLS.PeerCertificates, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	logger := zerolog.Nop()
+	client, err := NewClient(&Config{URL: server.URL, CAFile: caFile, CertFile: certFile, KeyFile: keyFile}, &logger)
+	require.NoError(t, err)
+	_, err = client.Query(context.Background(), "up", time.Now())
+	assert.NoError(t, err)
+
+	t.Run("without client certificate", func(t *testing.T) {
+		client, err := NewClient(&Config{URL: server.URL, CAFile: caFile}, &logger)
+		require.NoError(t, err)
+		_, err = client.Query(context.Background(), "up", time.Now())
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewClient(&Config{URL: server.URL, CertFile: certFile}, &logger)
+		assert.ErrorContains(t, err, "both a client certificate and key are required")
+
+		_, err = NewClient(&Config{URL: server.URL, CAFile: keyFile}, &logger)
+		assert.ErrorContains(t, err, "no certificates found in CA file")
+	})
+}
+
+// writeTestCertificate writes a self-signed certificate and key to dir.
+func writeTestCertificate(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
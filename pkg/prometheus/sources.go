@@ -0,0 +1,169 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Backend flavors with their own API path and tenancy conventions.
+const (
+	FlavorPrometheus      = "prometheus"
+	FlavorThanos          = "thanos"
+	FlavorMimir           = "mimir"
+	FlavorVictoriaMetrics = "victoriametrics"
+)
+
+// Source describes a named Prometheus-compatible backend and how to authenticate to it.
+type Source struct {
+	Name            string            `yaml:"name"`
+	URL             string            `yaml:"url"`
+	Flavor          string            `yaml:"flavor,omitempty"`
+	TenantID        string            `yaml:"tenant_id,omitempty"`
+	Username        string            `yaml:"username,omitempty"`
+	Password        string            `yaml:"password,omitempty"` //nolint:gosec // Password is a configuration field for Prometheus basic auth
+	PasswordFile    string            `yaml:"password_file,omitempty"`
+	BearerToken     string            `yaml:"bearer_token,omitempty"` //nolint:gosec // BearerToken is a configuration field for Prometheus authentication
+	BearerTokenFile string            `yaml:"bearer_token_file,omitempty"`
+	Headers         map[string]string `yaml:"headers,omitempty"`
+	TLS             SourceTLS         `yaml:"tls,omitempty"`
+	PartialResponse *bool             `yaml:"partial_response,omitempty"`
+	Timeout         string            `yaml:"timeout,omitempty"`
+}
+
+// SourceTLS holds the TLS settings of a source.
+type SourceTLS struct {
+	CAFile             string `yaml:"ca_file,omitempty"`
+	CertFile           string `yaml:"cert_file,omitempty"`
+	KeyFile            string `yaml:"key_file,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+}
+
+type sourcesFile struct {
+	Sources []Source `yaml:"sources"`
+}
+
+// LoadSources reads and validates source definitions from a YAML file.
+func LoadSources(path string) ([]Source, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources file: %w", err)
+	}
+	return ParseSources(data)
+}
+
+// ParseSources parses and validates source definitions from a YAML document.
+func ParseSources(data []byte) ([]Source, error) {
+	var f sourcesFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse sources file: %w", err)
+	}
+
+	seen := make(map[string]bool, len(f.Sources))
+	for i, source := range f.Sources {
+		if source.Name == "" {
+			return nil, fmt.Errorf("source %d: name is required", i+1)
+		}
+		if seen[source.Name] {
+			return nil, fmt.Errorf("duplicate source name: %s", source.Name)
+		}
+		seen[source.Name] = true
+
+		switch source.Flavor {
+		case "", FlavorPrometheus, FlavorThanos, FlavorMimir, FlavorVictoriaMetrics:
+		default:
+			return nil, fmt.Errorf("source %s: unknown flavor %q", source.Name, source.Flavor)
+		}
+		if source.Password != "" && source.PasswordFile != "" {
+			return nil, fmt.Errorf("source %s: password and password_file are mutually exclusive", source.Name)
+		}
+		if source.BearerToken != "" && source.BearerTokenFile != "" {
+			return nil, fmt.Errorf("source %s: bearer_token and bearer_token_file are mutually exclusive", source.Name)
+		}
+		if source.Timeout != "" {
+			if _, err := time.ParseDuration(source.Timeout); err != nil {
+				return nil, fmt.Errorf("source %s: invalid timeout: %w", source.Name, err)
+			}
+		}
+	}
+	return f.Sources, nil
+}
+
+// Config converts the source to a client configuration. The defaults are used
+// for the timeout and TLS verification when the source does not set them.
+func (s Source) Config(defaults Config) (*Config, error) {
+	if s.URL == "" {
+		return nil, fmt.Errorf("source %s: url is required", s.Name)
+	}
+
+	address, err := s.apiURL()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		URL:             address,
+		Username:        s.Username,
+		Password:        s.Password,
+		Timeout:         defaults.Timeout,
+		Insecure:        defaults.Insecure || s.TLS.InsecureSkipVerify,
+		TenantID:        s.TenantID,
+		BearerToken:     s.BearerToken,
+		BearerTokenFile: s.BearerTokenFile,
+		Headers:         s.Headers,
+		CAFile:          s.TLS.CAFile,
+		CertFile:        s.TLS.CertFile,
+		KeyFile:         s.TLS.KeyFile,
+		PartialResponse: s.PartialResponse,
+	}
+
+	if s.Timeout != "" {
+		timeout, err := time.ParseDuration(s.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("source %s: invalid timeout: %w", s.Name, err)
+		}
+		cfg.Timeout = timeout
+	}
+
+	if s.PasswordFile != "" {
+		password, err := os.ReadFile(s.PasswordFile) //nolint:gosec // path is provided by the operator
+		if err != nil {
+			return nil, fmt.Errorf("source %s: failed to read password file: %w", s.Name, err)
+		}
+		cfg.Password = strings.TrimSpace(string(password))
+	}
+
+	// VictoriaMetrics cluster selects the tenant by path, not by header.
+	if s.Flavor == FlavorVictoriaMetrics {
+		cfg.TenantID = ""
+	}
+
+	return cfg, nil
+}
+
+// apiURL adds the default query path of the flavor when the URL has none:
+// /prometheus for Mimir and /select/<tenant>/prometheus for a VictoriaMetrics
+// cluster. Thanos and single-node VictoriaMetrics serve the API at the root.
+func (s Source) apiURL() (string, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return "", fmt.Errorf("source %s: invalid url: %w", s.Name, err)
+	}
+	if strings.Trim(u.Path, "/") != "" {
+		return s.URL, nil
+	}
+
+	switch {
+	case s.Flavor == FlavorMimir:
+		u.Path = "/prometheus"
+	case s.Flavor == FlavorVictoriaMetrics && s.TenantID != "":
+		u.Path = "/select/" + url.PathEscape(s.TenantID) + "/prometheus"
+	default:
+		return s.URL, nil
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,108 @@
+package prometheus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSources = `
+sources:
+  - name: mimir-prod
+    url: https://mimir.example.com
+    flavor: mimir
+    tenant_id: team-a
+    bearer_token_file: /var/run/secrets/token
+    headers:
+      X-Source: alert-analyzer
+  - name: thanos
+    url: https://thanos.example.com
+    flavor: thanos
+    partial_response: false
+    timeout: 1m
+    tls:
+      ca_file: /etc/ssl/ca.pem
+      cert_file: /etc/ssl/client.crt
+      key_file: /etc/ssl/client.key
+  - name: vm
+    url: http://vmselect:8481
+    flavor: victoriametrics
+    tenant_id: "42"
+`
+
+func TestParseSources(t *testing.T) {
+	sources, err := ParseSources([]byte(testSources))
+	require.NoError(t, err)
+	require.Len(t, sources, 3)
+
+	defaults := Config{Timeout: 30 * time.Second}
+
+	mimir, err := sources[0].Config(defaults)
+	require.NoError(t, err)
+	assert.Equal(t, "https://mimir.example.com/prometheus", mimir.URL)
+	assert.Equal(t, "team-a", mimir.TenantID)
+	assert.Equal(t, "/var/run/secrets/token", mimir.BearerTokenFile)
+	assert.Equal(t, map[string]string{"X-Source": "alert-analyzer"}, mimir.Headers)
+	assert.Equal(t, 30*time.Second, mimir.Timeout)
+
+	thanos, err := sources[1].Config(defaults)
+	require.NoError(t, err)
+	assert.Equal(t, "https://thanos.example.com", thanos.URL)
+	require.NotNil(t, thanos.PartialResponse)
+	assert.False(t, *thanos.PartialResponse)
+	assert.Equal(t, time.Minute, thanos.Timeout)
+	assert.Equal(t, "/etc/ssl/client.crt", thanos.CertFile)
+
+	vm, err := sources[2].Config(defaults)
+	require.NoError(t, err)
+	assert.Equal(t, "http://vmselect:8481/select/42/prometheus", vm.URL)
+	assert.Empty(t, vm.TenantID, "VictoriaMetrics selects the tenant by path")
+}
+
+func TestSourceConfig(t *testing.T) {
+	t.Run("explicit path is kept", func(t *testing.T) {
+		cfg, err := Source{Name: "mimir", URL: "https://gateway.example.com/api/prom", Flavor: FlavorMimir}.Config(Config{})
+		require.NoError(t, err)
+		assert.Equal(t, "https://gateway.example.com/api/prom", cfg.URL)
+	})
+
+	t.Run("password file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+		require.NoError(t, os.WriteFile(path, []byte("s3cret\n"), 0o600))
+
+		cfg, err := Source{Name: "prom", URL: "http://prom:9090", Username: "admin", PasswordFile: path}.Config(Config{Insecure: true})
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", cfg.Password)
+		assert.True(t, cfg.Insecure)
+	})
+
+	t.Run("url is required", func(t *testing.T) {
+		_, err := Source{Name: "prom"}.Config(Config{})
+		assert.ErrorContains(t, err, "url is required")
+	})
+}
+
+func TestParseSources_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "missing name", yaml: "sources:\n  - url: http://prom:9090\n", wantErr: "name is required"},
+		{name: "duplicate", yaml: "sources:\n  - name: a\n  - name: a\n", wantErr: "duplicate source name: a"},
+		{name: "flavor", yaml: "sources:\n  - name: a\n    flavor: cortex\n", wantErr: `unknown flavor "cortex"`},
+		{name: "token", yaml: "sources:\n  - name: a\n    bearer_token: x\n    bearer_token_file: /t\n", wantErr: "mutually exclusive"},
+		{name: "timeout", yaml: "sources:\n  - name: a\n    timeout: soon\n", wantErr: "invalid timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSources([]byte(tt.yaml))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}